@@ -4,13 +4,19 @@ import (
 	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/di"
 	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"log/slog"
+	"os"
 )
 
 func main() {
 	cfg := config.LoadConfig()         // getting config from .env
 	log := logger.New(logger.EnvLocal) // creating pretty logger
 
-	if server, err := di.InitializeAPI(cfg, log); err == nil {
-		server.Start(cfg, log)
+	server, err := di.InitializeAPI(cfg, log)
+	if err != nil {
+		log.Error("failed to initialize the API", slog.String("error", err.Error()))
+		os.Exit(1)
 	}
+
+	server.Start(cfg, log)
 }
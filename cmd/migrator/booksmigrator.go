@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	_ "github.com/lib/pq"
 	"log"
 	"os"
@@ -12,10 +13,10 @@ import (
 )
 
 type Book struct {
-	Title  string  `json:"title"`
-	Author string  `json:"author"`
-	Price  float64 `json:"price"`
-	Stock  int     `json:"stock"`
+	Title  string      `json:"title"`
+	Author string      `json:"author"`
+	Price  money.Money `json:"price"`
+	Stock  int         `json:"stock"`
 }
 
 func main() {
@@ -53,18 +54,34 @@ func main() {
 
 	stmt, err := db.Prepare(`
     INSERT INTO books (title, author, price, stock, created_at) 
-    VALUES ($1, $2, $3, $4, $5);
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id;
   `)
 	if err != nil {
 		log.Fatal(fmt.Errorf("error preparing query: %v", err))
 	}
 	defer stmt.Close()
 
+	stmtMovement, err := db.Prepare(`
+    INSERT INTO stock_movements (book_id, reason, on_hand_delta, note) 
+    VALUES ($1, 'restock', $2, 'initial import');
+  `)
+	if err != nil {
+		log.Fatal(fmt.Errorf("error preparing query: %v", err))
+	}
+	defer stmtMovement.Close()
+
 	for _, book := range books {
-		_, err := stmt.Exec(book.Title, book.Author, book.Price, book.Stock, time.Now())
+		var bookID string
+		err := stmt.QueryRow(book.Title, book.Author, book.Price, book.Stock, time.Now()).Scan(&bookID)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error inserting data: %v", err))
 		}
+
+		_, err = stmtMovement.Exec(bookID, book.Stock)
+		if err != nil {
+			log.Fatal(fmt.Errorf("error inserting stock movement: %v", err))
+		}
 	}
 
 	fmt.Println("Book migrations have been done successfully!")
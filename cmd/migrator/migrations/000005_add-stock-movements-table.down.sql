@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS book_inventory;
+DROP TABLE IF EXISTS stock_movements;
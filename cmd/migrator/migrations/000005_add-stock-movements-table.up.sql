@@ -0,0 +1,44 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sale', 'reservation', 'release', 'restock', 'adjustment', 'return')),
+    on_hand_delta INT NOT NULL DEFAULT 0,
+    reserved_delta INT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_book_id ON stock_movements(book_id, created_at);
+
+-- books.stock holds what is still sellable, so the opening on-hand balance
+-- adds back whatever is sitting in draft carts.
+INSERT INTO stock_movements (book_id, reason, on_hand_delta, note)
+SELECT b.id, 'adjustment', COALESCE(b.stock, 0) + COALESCE(r.quantity, 0), 'opening balance'
+FROM books b
+LEFT JOIN (
+    SELECT oi.book_id, SUM(oi.quantity) AS quantity
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.status = 'draft'
+    GROUP BY oi.book_id
+) r ON r.book_id = b.id;
+
+INSERT INTO stock_movements (book_id, order_id, reason, reserved_delta, note)
+SELECT oi.book_id, o.id, 'reservation', oi.quantity, 'opening balance'
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+WHERE o.status = 'draft';
+
+CREATE OR REPLACE VIEW book_inventory AS
+SELECT
+    b.id AS book_id,
+    COALESCE(SUM(m.on_hand_delta), 0) AS on_hand,
+    COALESCE(SUM(m.reserved_delta), 0) AS reserved,
+    COALESCE(SUM(m.on_hand_delta - m.reserved_delta), 0) AS available
+FROM books b
+LEFT JOIN stock_movements m ON m.book_id = b.id
+GROUP BY b.id;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_items_reserved_until;
+ALTER TABLE order_items DROP COLUMN IF EXISTS reserved_until;
@@ -0,0 +1,8 @@
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS reserved_until TIMESTAMP;
+
+UPDATE order_items oi
+SET reserved_until = CURRENT_TIMESTAMP + INTERVAL '15 minutes'
+FROM orders o
+WHERE o.id = oi.order_id AND o.status = 'draft';
+
+CREATE INDEX IF NOT EXISTS idx_order_items_reserved_until ON order_items(reserved_until) WHERE reserved_until IS NOT NULL;
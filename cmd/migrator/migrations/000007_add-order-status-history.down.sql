@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_status_history;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ALTER COLUMN status DROP NOT NULL;
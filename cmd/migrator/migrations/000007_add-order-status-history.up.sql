@@ -0,0 +1,19 @@
+UPDATE orders SET status = 'draft' WHERE status IS NULL;
+
+ALTER TABLE orders ALTER COLUMN status SET NOT NULL;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('draft', 'pending_payment', 'paid', 'fulfilled', 'shipped', 'delivered', 'cancelled', 'refunded')
+);
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_kind VARCHAR(20) NOT NULL CHECK (actor_kind IN ('customer', 'admin', 'system')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id, created_at);
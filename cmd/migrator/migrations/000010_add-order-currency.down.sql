@@ -0,0 +1,5 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,9 @@
+-- Orders stay priced in the catalogue currency; currency and exchange_rate
+-- record what the customer was quoted at checkout, and the payment is taken
+-- in that currency.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(18, 8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0);
+
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS order_promotions;
+DROP TABLE IF EXISTS promotions;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_total,
+    DROP COLUMN IF EXISTS subtotal;
+
+ALTER TABLE books DROP COLUMN IF EXISTS category;
@@ -0,0 +1,57 @@
+ALTER TABLE books ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '';
+
+-- subtotal is what the lines add up to; total_price is what is charged after
+-- discount_total is taken off.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_total NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (discount_total >= 0);
+
+UPDATE orders SET subtotal = total_price;
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('percentage', 'fixed', 'buy_x_get_y')),
+    percent INT NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+    amount NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    buy_quantity INT NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+    get_quantity INT NOT NULL DEFAULT 0 CHECK (get_quantity >= 0),
+    scope VARCHAR(20) NOT NULL DEFAULT 'order' CHECK (scope IN ('order', 'category', 'author')),
+    scope_value VARCHAR(255) NOT NULL DEFAULT '',
+    min_order_value NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (min_order_value >= 0),
+    usage_limit INT NOT NULL DEFAULT 0 CHECK (usage_limit >= 0),
+    per_user_limit INT NOT NULL DEFAULT 0 CHECK (per_user_limit >= 0),
+    times_used INT NOT NULL DEFAULT 0 CHECK (times_used >= 0),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_code ON promotions(UPPER(code));
+
+-- The codes a customer applied to an order, with what each took off at the
+-- last recalculation.
+CREATE TABLE IF NOT EXISTS order_promotions (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    discount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id, promotion_id)
+);
+
+-- A redemption is taken when an order carrying a promotion goes to payment
+-- and given back if the order returns to the cart or is cancelled. Usage
+-- limits count these rows.
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_user ON promotion_redemptions(promotion_id, user_id);
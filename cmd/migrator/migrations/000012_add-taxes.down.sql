@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS order_item_taxes;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_region,
+    DROP COLUMN IF EXISTS tax_country,
+    DROP COLUMN IF EXISTS shipping_total,
+    DROP COLUMN IF EXISTS tax_included,
+    DROP COLUMN IF EXISTS tax_total;
+
+ALTER TABLE books DROP COLUMN IF EXISTS tax_class;
@@ -0,0 +1,27 @@
+ALTER TABLE books ADD COLUMN IF NOT EXISTS tax_class VARCHAR(20) NOT NULL DEFAULT 'standard'
+    CHECK (tax_class IN ('standard', 'reduced', 'zero'));
+
+-- total_price is the grand total: subtotal, less discount_total, plus the part
+-- of tax_total not already in the prices (tax_total - tax_included), plus
+-- shipping_total. tax_country and tax_region are the jurisdiction the order
+-- was last taxed in.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS tax_total NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (tax_total >= 0),
+    ADD COLUMN IF NOT EXISTS tax_included NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (tax_included >= 0 AND tax_included <= tax_total),
+    ADD COLUMN IF NOT EXISTS shipping_total NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (shipping_total >= 0),
+    ADD COLUMN IF NOT EXISTS tax_country CHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tax_region VARCHAR(3) NOT NULL DEFAULT '';
+
+-- The tax charged on each order line, written whenever a draft order is
+-- priced and frozen with it at checkout.
+CREATE TABLE IF NOT EXISTS order_item_taxes (
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    jurisdiction VARCHAR(10) NOT NULL,
+    tax_class VARCHAR(20) NOT NULL,
+    rate NUMERIC(7, 4) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    taxable NUMERIC(10, 2) NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (order_item_id, name)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS delivery_method,
+    DROP COLUMN IF EXISTS billing_address,
+    DROP COLUMN IF EXISTS shipping_address;
+
+DROP TABLE IF EXISTS addresses;
+
+ALTER TABLE books DROP COLUMN IF EXISTS weight_grams;
@@ -0,0 +1,28 @@
+ALTER TABLE books ADD COLUMN IF NOT EXISTS weight_grams INT NOT NULL DEFAULT 0 CHECK (weight_grams >= 0);
+
+CREATE TABLE IF NOT EXISTS addresses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    label VARCHAR(50) NOT NULL DEFAULT '',
+    full_name VARCHAR(100) NOT NULL,
+    line1 VARCHAR(200) NOT NULL,
+    line2 VARCHAR(200) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    region VARCHAR(3) NOT NULL DEFAULT '',
+    postal_code VARCHAR(20) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL,
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_addresses_user ON addresses(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_user_default ON addresses(user_id) WHERE is_default;
+
+-- Addresses are copied onto the order when chosen, so editing or deleting an
+-- address book entry never changes where an order goes.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipping_address JSONB,
+    ADD COLUMN IF NOT EXISTS billing_address JSONB,
+    ADD COLUMN IF NOT EXISTS delivery_method VARCHAR(32) NOT NULL DEFAULT '';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS shipments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'shipped', 'delivered', 'cancelled')),
+    carrier VARCHAR(50) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(100) NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments(order_id, created_at);
+
+CREATE TABLE IF NOT EXISTS shipment_items (
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(id),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (shipment_id, book_id)
+);
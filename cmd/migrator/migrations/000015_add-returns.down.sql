@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS return_items;
+
+DROP TABLE IF EXISTS returns;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_total;
+
+UPDATE orders SET status = 'refunded' WHERE status = 'partially_refunded';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('draft', 'pending_payment', 'paid', 'fulfilled', 'shipped', 'delivered', 'cancelled', 'refunded')
+);
@@ -0,0 +1,32 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('draft', 'pending_payment', 'paid', 'fulfilled', 'shipped', 'delivered', 'cancelled', 'partially_refunded', 'refunded')
+);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS refunded_total NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (refunded_total >= 0);
+
+CREATE TABLE IF NOT EXISTS returns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested' CHECK (status IN ('requested', 'approved', 'rejected', 'received')),
+    note TEXT NOT NULL DEFAULT '',
+    resolution_note TEXT NOT NULL DEFAULT '',
+    refund_amount NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (refund_amount >= 0),
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    received_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON returns(order_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_returns_status ON returns(status, created_at);
+
+CREATE TABLE IF NOT EXISTS return_items (
+    return_id UUID NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(id),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'wrong_item', 'not_as_described', 'changed_mind', 'other')),
+    PRIMARY KEY (return_id, book_id)
+);
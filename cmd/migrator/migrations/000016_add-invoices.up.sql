@@ -0,0 +1,24 @@
+-- A single counter row. Taking a number locks the row until the payment
+-- transaction commits, and a rolled back payment gives its number back, so
+-- invoice numbers have no gaps.
+CREATE TABLE IF NOT EXISTS invoice_sequence (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_number BIGINT NOT NULL DEFAULT 0 CHECK (last_number >= 0)
+);
+
+INSERT INTO invoice_sequence (id, last_number) VALUES (TRUE, 0) ON CONFLICT (id) DO NOTHING;
+
+-- Invoices are never updated once written, except to store the PDF the first
+-- time it is rendered.
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    number BIGINT NOT NULL UNIQUE CHECK (number > 0),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE RESTRICT,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    details JSONB NOT NULL,
+    pdf BYTEA,
+    rendered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices(user_id, issued_at);
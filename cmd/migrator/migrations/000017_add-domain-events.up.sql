@@ -0,0 +1,21 @@
+-- The outbox. Events are written in the same transaction as the change they
+-- describe and handed to subscribers afterwards by the dispatcher, so an
+-- event exists if and only if its change was committed. handled_by lists the
+-- subscribers that already took the event, so a retry only goes to the rest.
+CREATE TABLE IF NOT EXISTS domain_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    type VARCHAR(64) NOT NULL,
+    aggregate_type VARCHAR(32) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error TEXT NOT NULL DEFAULT '',
+    handled_by TEXT[] NOT NULL DEFAULT '{}',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_events_due ON domain_events(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_domain_events_aggregate ON domain_events(aggregate_type, aggregate_id, created_at);
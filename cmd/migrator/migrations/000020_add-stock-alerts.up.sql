@@ -0,0 +1,19 @@
+-- A customer watching a book for it to come back in stock or drop under a
+-- price. pending is set when the alert fires and cleared once the customer
+-- has been emailed.
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('back_in_stock', 'price_below')),
+    threshold NUMERIC(10, 2) CHECK ((kind = 'price_below') = (threshold IS NOT NULL)),
+    token VARCHAR(64) NOT NULL UNIQUE,
+    pending BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered_at TIMESTAMP,
+    notified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, book_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_book ON stock_alerts(book_id, kind);
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_pending ON stock_alerts(triggered_at) WHERE pending;
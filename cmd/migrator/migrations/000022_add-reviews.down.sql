@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS review_votes;
+DROP TABLE IF EXISTS reviews;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_average;
@@ -0,0 +1,34 @@
+-- The rating of a book over its approved reviews, kept up to date whenever a
+-- review is moderated, rewritten or deleted so the catalogue can sort by it.
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS rating_average NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
+
+-- One review per customer and book. Only customers with a paid order for the
+-- book may write one, and it shows once staff approve it.
+CREATE TABLE IF NOT EXISTS reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(120) NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    moderation_note TEXT NOT NULL DEFAULT '',
+    moderated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP,
+    helpful_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (book_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_book ON reviews(book_id, status);
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews(status, created_at);
+
+CREATE TABLE IF NOT EXISTS review_votes (
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
@@ -0,0 +1,13 @@
+-- The books most often bought together with each book, rebuilt periodically
+-- from paid orders. score is the cosine similarity of the two books' buyers,
+-- so a pair of bestsellers does not drown out a pair of niche titles.
+CREATE TABLE IF NOT EXISTS book_recommendations (
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    recommended_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    co_purchases INT NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (book_id, recommended_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_recommendations_score ON book_recommendations(book_id, score DESC);
@@ -0,0 +1,4 @@
+-- Sales reports find orders by the day they were paid and carts by the day
+-- they were started.
+CREATE INDEX IF NOT EXISTS idx_order_status_history_to_status ON order_status_history(to_status, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at);
@@ -0,0 +1,13 @@
+-- Internal notes staff leave on an order. Customers never see them.
+CREATE TABLE IF NOT EXISTS order_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL CHECK (body <> ''),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_notes_order ON order_notes(order_id, created_at);
+
+-- The admin order list filters by status and shows the newest orders first.
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders(status, created_at);
@@ -0,0 +1,7 @@
+DELETE FROM users WHERE is_guest;
+
+DROP INDEX IF EXISTS idx_users_guest_created_at;
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_guest;
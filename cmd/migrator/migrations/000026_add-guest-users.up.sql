@@ -0,0 +1,10 @@
+-- Anonymous visitors get a guest user to hang their cart on. Guests have no
+-- password and no email until they check out, and several of them may check
+-- out with the same address, so emails are only unique among real accounts.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_guest BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email) WHERE NOT is_guest;
+
+-- The purge job looks for old guests.
+CREATE INDEX IF NOT EXISTS idx_users_guest_created_at ON users(created_at) WHERE is_guest;
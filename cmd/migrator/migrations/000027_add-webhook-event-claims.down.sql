@@ -0,0 +1 @@
+ALTER TABLE payment_webhook_events DROP COLUMN IF EXISTS claimed_until;
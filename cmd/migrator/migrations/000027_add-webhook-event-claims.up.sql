@@ -0,0 +1 @@
+ALTER TABLE payment_webhook_events ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
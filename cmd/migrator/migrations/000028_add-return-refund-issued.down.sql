@@ -0,0 +1 @@
+ALTER TABLE returns DROP COLUMN IF EXISTS refund_issued_at;
@@ -0,0 +1 @@
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS refund_issued_at TIMESTAMP;
@@ -0,0 +1,22 @@
+-- Every refund owed to a customer is recorded in the transaction that makes
+-- it owed, then sent to the provider. One that fails is retried with backoff;
+-- one that cannot go through the provider waits as manual for staff.
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+    currency CHAR(3) NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'owed' CHECK (status IN ('owed', 'issued', 'manual')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    issued_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    issued_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_due ON refunds(next_attempt_at) WHERE status = 'owed';
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
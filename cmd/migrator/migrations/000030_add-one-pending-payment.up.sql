@@ -0,0 +1,3 @@
+-- An order has at most one payment in flight, so paying again while a charge
+-- is still being settled cannot take the money twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_one_pending ON payments(order_id) WHERE status = 'pending';
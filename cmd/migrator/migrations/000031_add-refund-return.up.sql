@@ -0,0 +1,3 @@
+-- A refund for returned books points at its return, so issuing the refund is
+-- what stamps the return's refund_issued_at.
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS return_id UUID REFERENCES returns(id) ON DELETE SET NULL;
@@ -0,0 +1,272 @@
+package address
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.AddressService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewAddressHandler(r chi.Router) {
+	r.Route("/me/addresses", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+
+		r.Get("/", h.GetAddresses)
+		r.Post("/", h.CreateAddress)
+		r.Get("/{addressId}", h.GetAddress)
+		r.Put("/{addressId}", h.UpdateAddress)
+		r.Delete("/{addressId}", h.DeleteAddress)
+	})
+}
+
+// GetAddresses
+//
+// @Summary List my addresses
+// @Description Lists the current user's address book, default address first
+// @Tags addresses
+// @Produce json
+// @Success 200 {array} model.Address "Addresses"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/addresses [get]
+func (h *Handler) GetAddresses(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.address.GetAddresses"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	addresses, err := h.Svc.GetAddresses(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("error getting addresses", slog.String("error", err.Error()))
+		writeAddressError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, addresses)
+}
+
+// GetAddress
+//
+// @Summary Get one of my addresses
+// @Tags addresses
+// @Produce json
+// @Param addressId path string true "Address ID"
+// @Success 200 {object} model.Address "Address"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Address not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/addresses/{addressId} [get]
+func (h *Handler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.address.GetAddress"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "addressId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	address, err := h.Svc.GetAddress(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error getting address", slog.String("error", err.Error()))
+		writeAddressError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, address)
+}
+
+// CreateAddress
+//
+// @Summary Add an address
+// @Description Adds an address to the current user's address book. The first address, or one marked is_default, becomes the default. Invalid fields are reported together as a field to message map.
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Param request body model.Request true "Address"
+// @Success 201 {string} string "Address ID"
+// @Failure 400 {object} response.ResponseError "Invalid address"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/addresses [post]
+func (h *Handler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.address.CreateAddress"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.Svc.CreateAddress(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error creating address", slog.String("error", err.Error()))
+		writeAddressError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, id.String())
+}
+
+// UpdateAddress
+//
+// @Summary Update an address
+// @Description Replaces one of the current user's addresses. Orders already sent to it keep the address they were sent to.
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Param addressId path string true "Address ID"
+// @Param request body model.Request true "Address"
+// @Success 200 {string} string "Address updated"
+// @Failure 400 {object} response.ResponseError "Invalid address"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Address not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/addresses/{addressId} [put]
+func (h *Handler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.address.UpdateAddress"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "addressId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.UpdateAddress(r.Context(), userID, id, req)
+	if err != nil {
+		h.Log.Error("error updating address", slog.String("error", err.Error()))
+		writeAddressError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Address updated")
+}
+
+// DeleteAddress
+//
+// @Summary Delete an address
+// @Description Removes one of the current user's addresses. Deleting the default makes the most recent remaining address the default.
+// @Tags addresses
+// @Produce json
+// @Param addressId path string true "Address ID"
+// @Success 200 {string} string "Address deleted"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Address not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/addresses/{addressId} [delete]
+func (h *Handler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.address.DeleteAddress"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "addressId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeleteAddress(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error deleting address", slog.String("error", err.Error()))
+		writeAddressError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Address deleted")
+}
+
+// writeAddressError reports invalid addresses field by field, so forms can
+// show each message next to its input.
+func writeAddressError(w http.ResponseWriter, r *http.Request, err error) {
+	var invalid model.ValidationErrors
+	switch {
+	case errors.As(err, &invalid):
+		response.WriteError(w, r, http.StatusBadRequest, map[string]string(invalid))
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,180 @@
+package address
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_NewAddressHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewAddressHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/me/addresses/", http.NoBody)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetAddresses", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_GetAddresses(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetAddresses)
+
+	t.Run("it should list the user's addresses", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", http.NoBody)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		addresses := []model.Address{{Label: "Home", City: "London", Country: "GB", IsDefault: true}}
+		svc.On("GetAddresses", mock.Anything, "123").Return(addresses, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"label":"Home"`)
+		assert.Contains(t, r.Body.String(), `"is_default":true`)
+	})
+}
+
+func TestHandler_CreateAddress(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success", wantStatus: http.StatusCreated, wantBody: id.String()},
+		{
+			name:       "invalid fields are reported one by one",
+			svcErr:     fmt.Errorf("test: %w: %w", model.ValidationErrors{"postal_code": "is not a valid postal code for US"}, service.ErrValid),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"postal_code":"is not a valid postal code for US"`,
+		},
+		{name: "address book full", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.CreateAddress)
+
+			payload := `{"full_name":"Ada Lovelace","line1":"1 Main St","city":"Albany","region":"NY","postal_code":"12207","country":"US"}`
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			matches := mock.MatchedBy(func(req model.Request) bool {
+				return req.FullName == "Ada Lovelace" && req.Region == "NY" && req.Country == "US"
+			})
+			svc.On("CreateAddress", mock.Anything, "123", matches).Return(id, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantBody != "" {
+				assert.Contains(t, r.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateAddress(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", path: "/" + id.String(), wantStatus: http.StatusOK},
+		{name: "someone else's address", path: "/" + id.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "/123", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/{addressId}", hdl.UpdateAddress)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, tt.path, strings.NewReader(`{"full_name":"Ada Lovelace","country":"GB"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			svc.On("UpdateAddress", mock.Anything, "123", id, mock.AnythingOfType("address.Request")).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteAddress(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/{addressId}", hdl.DeleteAddress)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/"+id.String(), http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			svc.On("DeleteAddress", mock.Anything, "123", id).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.AddressService) {
+	svc := &mocks.AddressService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
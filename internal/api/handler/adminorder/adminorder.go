@@ -0,0 +1,254 @@
+package adminorder
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.AdminOrderService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewAdminOrderHandler(r chi.Router) {
+	r.Route("/admin/orders", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.SearchOrders)
+		r.Get("/{orderId}", h.GetOrder)
+		r.Post("/{orderId}/status", h.UpdateStatus)
+		r.Post("/{orderId}/notes", h.AddNote)
+		r.Post("/{orderId}/resend-confirmation", h.ResendConfirmation)
+	})
+}
+
+// SearchOrders
+//
+// @Summary Search orders
+// @Description Lists every customer's orders, newest first. Open carts (drafts) are left out unless asked for by status.
+// @Tags admin-orders
+// @Produce json
+// @Param status query string false "Order status"
+// @Param from query string false "Created on or after this day, YYYY-MM-DD"
+// @Param to query string false "Created on or before this day, YYYY-MM-DD"
+// @Param email query string false "Part of the customer's email"
+// @Param min_total query string false "Smallest grand total, in the catalogue currency"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Orders to skip"
+// @Success 200 {object} model.Page "Orders"
+// @Failure 400 {object} response.ResponseError "Invalid filter"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/orders [get]
+func (h *Handler) SearchOrders(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.adminorder.SearchOrders"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter, err := model.ParseFilter(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse filter", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.Svc.SearchOrders(r.Context(), filter)
+	if err != nil {
+		h.Log.Error("error searching orders", slog.String("error", err.Error()))
+		writeAdminOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, page)
+}
+
+// GetOrder
+//
+// @Summary Get an order
+// @Description Returns an order with its customer, items, payments, shipments, status history and internal notes.
+// @Tags admin-orders
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Success 200 {object} model.Detail "Order"
+// @Failure 400 {object} response.ResponseError "Invalid order ID"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/orders/{orderId} [get]
+func (h *Handler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.adminorder.GetOrder"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	detail, err := h.Svc.GetOrder(r.Context(), chi.URLParam(r, "orderId"))
+	if err != nil {
+		h.Log.Error("error getting order", slog.String("error", err.Error()))
+		writeAdminOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, detail)
+}
+
+// UpdateStatus
+//
+// @Summary Change an order's status
+// @Description Cancels an order, or confirms a payment taken outside the provider for an order awaiting one. Cancelling puts the books back in stock and refunds a paid order. Other statuses follow checkout, shipments, cancellations and returns and cannot be set by hand.
+// @Tags admin-orders
+// @Accept json
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Param request body model.StatusRequest true "New status and reason"
+// @Success 200 {object} model.Detail "Updated order"
+// @Failure 400 {object} response.ResponseError "Invalid order ID or status"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "The order cannot move to that status"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/orders/{orderId}/status [post]
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.adminorder.UpdateStatus"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.StatusRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	detail, err := h.Svc.UpdateStatus(r.Context(), userID, chi.URLParam(r, "orderId"), req)
+	if err != nil {
+		h.Log.Error("error updating order status", slog.String("error", err.Error()))
+		writeAdminOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, detail)
+}
+
+// AddNote
+//
+// @Summary Add an internal note
+// @Description Leaves a note on an order for other staff. Customers never see notes.
+// @Tags admin-orders
+// @Accept json
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Param request body model.NoteRequest true "Note"
+// @Success 201 {object} model.Note "Note"
+// @Failure 400 {object} response.ResponseError "Invalid order ID or empty note"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/orders/{orderId}/notes [post]
+func (h *Handler) AddNote(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.adminorder.AddNote"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.NoteRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	note, err := h.Svc.AddNote(r.Context(), userID, chi.URLParam(r, "orderId"), req)
+	if err != nil {
+		h.Log.Error("error adding order note", slog.String("error", err.Error()))
+		writeAdminOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, note)
+}
+
+// ResendConfirmation
+//
+// @Summary Resend the order confirmation
+// @Description Emails the customer their order confirmation again. Only paid orders have one.
+// @Tags admin-orders
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Success 202 {string} string "Confirmation queued"
+// @Failure 400 {object} response.ResponseError "Invalid order ID or order not paid"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/orders/{orderId}/resend-confirmation [post]
+func (h *Handler) ResendConfirmation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.adminorder.ResendConfirmation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	err := h.Svc.ResendConfirmation(r.Context(), chi.URLParam(r, "orderId"))
+	if err != nil {
+		h.Log.Error("error resending order confirmation", slog.String("error", err.Error()))
+		writeAdminOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusAccepted, "Confirmation queued")
+}
+
+func writeAdminOrderError(w http.ResponseWriter, r *http.Request, err error) {
+	var transitionErr *orderModel.TransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		response.WriteError(w, r, http.StatusConflict, transitionErr)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
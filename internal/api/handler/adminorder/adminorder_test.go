@@ -0,0 +1,264 @@
+package adminorder
+
+import (
+	"bytes"
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const orderID = "5b0e7a4c-3f1d-4c2a-9e8b-7d6f5a4b3c21"
+
+func TestHandler_NewAdminOrderHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewAdminOrderHandler(router)
+
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/orders"},
+		{http.MethodGet, "/admin/orders/" + orderID},
+		{http.MethodPost, "/admin/orders/" + orderID + "/status"},
+		{http.MethodPost, "/admin/orders/" + orderID + "/notes"},
+		{http.MethodPost, "/admin/orders/" + orderID + "/resend-confirmation"},
+	} {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+	}
+	svc.AssertNotCalled(t, "SearchOrders", mock.Anything, mock.Anything)
+}
+
+func TestHandler_SearchOrders(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		filter     model.Filter
+		svcErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "success",
+			query:      "?status=paid&email=ann&min_total=20.00",
+			filter:     model.Filter{Status: orderModel.StatusPaid, Email: "ann", MinTotal: money.MustParse("20.00", money.DefaultCurrency), Limit: model.DefaultLimit},
+			wantStatus: http.StatusOK,
+			wantBody:   `"total":1`,
+		},
+		{name: "unknown status", query: "?status=lost", wantStatus: http.StatusBadRequest},
+		{name: "bad min total", query: "?min_total=lots", wantStatus: http.StatusBadRequest},
+		{name: "internal error", filter: model.Filter{Limit: model.DefaultLimit}, svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/", hdl.SearchOrders)
+
+			var page *model.Page
+			if tt.svcErr == nil {
+				page = &model.Page{
+					Orders: []model.Summary{{ID: uuid.Must(uuid.FromString(orderID)), Email: "ann@example.com", Status: orderModel.StatusPaid}},
+					Total:  1,
+					Limit:  model.DefaultLimit,
+				}
+			}
+			svc.On("SearchOrders", mock.Anything, tt.filter).Return(page, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantBody != "" {
+				assert.Contains(t, r.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				svc.AssertNotCalled(t, "SearchOrders", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_GetOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "invalid id", svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "not found", svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/{orderId}", hdl.GetOrder)
+
+			var detail *model.Detail
+			if tt.svcErr == nil {
+				detail = &model.Detail{Order: &orderModel.Model{Status: orderModel.StatusPaid}}
+			}
+			svc.On("GetOrder", mock.Anything, orderID).Return(detail, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+orderID, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_UpdateStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", body: `{"status":"cancelled"}`, wantStatus: http.StatusOK},
+		{name: "illegal transition", body: `{"status":"cancelled"}`,
+			svcErr:     &orderModel.TransitionError{From: orderModel.StatusDelivered, To: orderModel.StatusCancelled},
+			wantStatus: http.StatusConflict},
+		{name: "refund", body: `{"status":"refunded"}`, svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "not found", body: `{"status":"cancelled"}`, svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "bad body", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{orderId}/status", hdl.UpdateStatus)
+
+			var detail *model.Detail
+			if tt.svcErr == nil {
+				detail = &model.Detail{Order: &orderModel.Model{Status: orderModel.StatusCancelled}}
+			}
+			svc.On("UpdateStatus", mock.Anything, "123", orderID, mock.AnythingOfType("adminorder.StatusRequest")).Return(detail, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+orderID+"/status", bytes.NewBufferString(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_UpdateStatus_NotLoggedIn(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{orderId}/status", hdl.UpdateStatus)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/"+orderID+"/status", bytes.NewBufferString(`{"status":"cancelled"}`))
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusUnauthorized, r.Code)
+	svc.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_AddNote(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", body: `{"body":"called the customer"}`, wantStatus: http.StatusCreated},
+		{name: "empty note", body: `{"body":""}`, svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "not found", body: `{"body":"called"}`, svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "bad body", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{orderId}/notes", hdl.AddNote)
+
+			var note *model.Note
+			if tt.svcErr == nil {
+				note = &model.Note{Body: "called the customer"}
+			}
+			svc.On("AddNote", mock.Anything, "123", orderID, mock.AnythingOfType("adminorder.NoteRequest")).Return(note, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+orderID+"/notes", bytes.NewBufferString(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ResendConfirmation(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusAccepted},
+		{name: "not paid", svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "not found", svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{orderId}/resend-confirmation", hdl.ResendConfirmation)
+
+			svc.On("ResendConfirmation", mock.Anything, orderID).Return(tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+orderID+"/resend-confirmation", nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.AdminOrderService) {
+	svc := &mocks.AdminOrderService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/auth"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
 	"github.com/TeslaMode1X/DockerWireAPI/packages/jsonReader"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/gofrs/uuid"
 	"log/slog"
 	"net/http"
 	"time"
@@ -21,12 +23,17 @@ import (
 
 type Handler struct {
 	Svc interfaces.AuthService
-	Log *slog.Logger
+	// Guests and Guest move an anonymous visitor's cart into the account
+	// they log in or register with.
+	Guests interfaces.GuestService
+	Guest  *middle.Guest
+	Log    *slog.Logger
 }
 
 func (h *Handler) NewAuthHandler(r chi.Router) {
 	r.Post("/registration", h.Register)
 	r.Post("/login", h.Login)
+	r.With(middle.WithAuth).Put("/password", h.ChangePassword)
 }
 
 // Register
@@ -74,6 +81,8 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.mergeGuestCart(w, r, userCreated)
+
 	response.WriteJson(w, r, http.StatusCreated, userCreated)
 }
 
@@ -148,5 +157,76 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 	http.SetCookie(w, cookie)
 
+	h.mergeGuestCart(w, r, userID)
+
 	response.WriteJson(w, r, http.StatusOK, userID.String())
 }
+
+// ChangePassword
+//
+// @Summary Change my password
+// @Description Replaces the current user's password after checking the current one. The user is emailed about the change.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.PasswordChange true "Current and new password"
+// @Success 200 {string} string "Password changed"
+// @Failure 400 {object} response.ResponseError "Wrong current password, or the new one is too weak"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "User not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/password [put]
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.ChangePassword"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.PasswordChange
+	if err := jsonReader.ReadJSON(w, r, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, errors.New("failed to decode request body"))
+		return
+	}
+
+	err := h.Svc.ChangePassword(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error changing password", slog.String("error", err.Error()))
+		switch {
+		case errors.Is(err, service.ErrValid):
+			response.WriteError(w, r, http.StatusBadRequest, err)
+		case errors.Is(err, service.ErrNotFound):
+			response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+		default:
+			response.WriteError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Password changed")
+}
+
+// mergeGuestCart moves the cart the visitor filled in before logging in or
+// registering into their account. A failed merge is logged and does not fail
+// the login; the guest cart cookie is cleared either way.
+func (h *Handler) mergeGuestCart(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	guestID, ok := h.Guest.Take(w, r)
+	if !ok || h.Guests == nil {
+		return
+	}
+
+	if err := h.Guests.MergeCart(r.Context(), guestID, userID); err != nil {
+		h.Log.Error("failed to merge guest cart",
+			slog.String("guest_id", guestID.String()),
+			slog.String("error", err.Error()))
+	}
+}
@@ -1,9 +1,13 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/auth"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
 	"github.com/go-chi/chi"
@@ -17,15 +21,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_NewAuthHandler(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 
 	t.Run("should be no errors", func(t *testing.T) {
@@ -34,12 +34,7 @@ func TestHandler_NewAuthHandler(t *testing.T) {
 }
 
 func TestHandler_Registration_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/registration", hdl.Register)
@@ -68,12 +63,7 @@ func TestHandler_Registration_Success(t *testing.T) {
 }
 
 func TestHandler_Login_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -94,12 +84,7 @@ func TestHandler_Login_Success(t *testing.T) {
 }
 
 func TestHandler_Login_Model_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -120,12 +105,7 @@ func TestHandler_Login_Model_Error(t *testing.T) {
 }
 
 func TestHandler_Login_SVC_ErrNotFound(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -146,12 +126,7 @@ func TestHandler_Login_SVC_ErrNotFound(t *testing.T) {
 }
 
 func TestHandler_Login_SVC_ErrValid(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -172,12 +147,7 @@ func TestHandler_Login_SVC_ErrValid(t *testing.T) {
 }
 
 func TestHandler_Login_SVC_Err(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -198,12 +168,7 @@ func TestHandler_Login_SVC_Err(t *testing.T) {
 }
 
 func TestHandler_Registration_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/registration", hdl.Register)
@@ -279,12 +244,7 @@ func TestHandler_Registration_Error(t *testing.T) {
 }
 
 func TestHandler_Registration_Svc_Error_User_Already_Exists(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/registration", hdl.Register)
@@ -315,12 +275,7 @@ func TestHandler_Registration_Svc_Error_User_Already_Exists(t *testing.T) {
 }
 
 func TestHandler_Registration_Svc_Error_Not_Found(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/registration", hdl.Register)
@@ -349,12 +304,7 @@ func TestHandler_Registration_Svc_Error_Not_Found(t *testing.T) {
 }
 
 func TestHandler_Login_AlreadyLoggedIn(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.AuthService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/login", hdl.Login)
@@ -376,3 +326,98 @@ func TestHandler_Login_AlreadyLoggedIn(t *testing.T) {
 		assert.Contains(t, r.Body.String(), "already logged in")
 	})
 }
+
+func TestHandler_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "wrong current password", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "user gone", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/password", hdl.ChangePassword)
+
+			r := httptest.NewRecorder()
+			payload := `{"current_password": "password123", "new_password": "correct horse battery"}`
+			req, err := http.NewRequest(http.MethodPut, "/password", strings.NewReader(payload))
+			require.NoError(t, err)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			want := model.PasswordChange{CurrentPassword: "password123", NewPassword: "correct horse battery"}
+			svc.On("ChangePassword", mock.Anything, "123", want).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ChangePassword_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewAuthHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPut, "/password", strings.NewReader(`{}`))
+		require.NoError(t, err)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_Login_MergesGuestCart(t *testing.T) {
+	hdl, svc := newTestHandler()
+	guests := &mocks.GuestService{}
+	secret := []byte("guest-secret")
+	hdl.Guests = guests
+	hdl.Guest = &middle.Guest{Secret: secret, TTL: time.Hour}
+
+	router := chi.NewRouter()
+	router.Post("/login", hdl.Login)
+
+	t.Run("it should log in and clear the guest cookie even when the merge fails", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"email": "testuser@example.com", "password": "password123"}`))
+		require.NoError(t, err)
+
+		guestID, _ := uuid.NewV4()
+		userID, _ := uuid.NewV4()
+		req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now(), secret)})
+
+		svc.On("Login", mock.Anything, mock.Anything).Return(userID, 0, nil)
+		guests.On("MergeCart", mock.Anything, guestID, userID).Return(errors.New("error"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		guests.AssertExpectations(t)
+
+		var cleared bool
+		for _, cookie := range r.Result().Cookies() {
+			if cookie.Name == guest.CookieName {
+				cleared = cookie.MaxAge < 0
+			}
+		}
+		assert.True(t, cleared)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.AuthService) {
+	svc := &mocks.AuthService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,99 @@
+package event
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.EventService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewEventHandler(r chi.Router) {
+	r.Route("/admin/events", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/stuck", h.GetStuckEvents)
+		r.Post("/{eventId}/retry", h.RetryEvent)
+	})
+}
+
+// GetStuckEvents
+//
+// @Summary List stuck domain events
+// @Description Lists events that were dead-lettered after running out of attempts, and events still waiting for delivery long after they were raised, oldest first
+// @Tags events
+// @Produce json
+// @Success 200 {array} model.Event "Stuck events"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/events/stuck [get]
+func (h *Handler) GetStuckEvents(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.event.GetStuckEvents"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	events, err := h.Svc.GetStuckEvents(r.Context())
+	if err != nil {
+		h.Log.Error("error getting stuck events", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, events)
+}
+
+// RetryEvent
+//
+// @Summary Retry a domain event
+// @Description Makes an undelivered event due now with a fresh set of attempts. Subscribers that already handled it are skipped.
+// @Tags events
+// @Produce json
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} model.Event "Event"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "No undelivered event with this ID"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/events/{eventId}/retry [post]
+func (h *Handler) RetryEvent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.event.RetryEvent"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "eventId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	e, err := h.Svc.RetryEvent(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error retrying event", slog.String("error", err.Error()))
+		if errors.Is(err, service.ErrNotFound) {
+			response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+			return
+		}
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, e)
+}
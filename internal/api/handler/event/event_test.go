@@ -0,0 +1,134 @@
+package event
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewEventHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewEventHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/events/stuck", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetStuckEvents", mock.Anything)
+	})
+}
+
+func TestHandler_GetStuckEvents_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/stuck", hdl.GetStuckEvents)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return the stuck events", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/stuck", nil)
+
+		events := []model.Event{
+			{ID: id, Type: model.TypeBookOutOfStock, Status: model.StatusDead, Attempts: 8, Payload: []byte(`{}`)},
+		}
+		svc.On("GetStuckEvents", mock.Anything).Return(events, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"status":"dead"`)
+		assert.Contains(t, r.Body.String(), `"type":"book.out_of_stock"`)
+	})
+}
+
+func TestHandler_GetStuckEvents_Error(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/stuck", hdl.GetStuckEvents)
+
+	t.Run("it should return 500", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/stuck", nil)
+
+		svc.On("GetStuckEvents", mock.Anything).Return(nil, errors.New("error"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestHandler_RetryEvent(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{eventId}/retry", hdl.RetryEvent)
+
+			var e *model.Event
+			if tt.svcErr == nil {
+				e = &model.Event{ID: id, Status: model.StatusPending, Payload: []byte(`{}`)}
+			}
+			svc.On("RetryEvent", mock.Anything, id).Return(e, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/retry", nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_RetryEvent_UUID_Error(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{eventId}/retry", hdl.RetryEvent)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/123/retry", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.EventService) {
+	svc := &mocks.EventService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
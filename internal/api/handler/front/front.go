@@ -5,45 +5,79 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/mainPageParams"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
 	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Handler struct {
-	Svc     interfaces.FrontService
-	SvcUser interfaces.UserService
-	Log     *slog.Logger
+	Svc         interfaces.FrontService
+	SvcUser     interfaces.UserService
+	Idempotency *middle.Idempotency
+	Currency    *middle.Currency
+	Guest       *middle.Guest
+	// Guests moves an anonymous visitor's cart into the account they log in
+	// or register with.
+	Guests interfaces.GuestService
+	Log    *slog.Logger
 }
 
 func (h *Handler) NewFrontEndHandler(r chi.Router) {
 	r.Route("/", func(r chi.Router) {
 		r.Use(middle.WithOptionalAuth)
+		r.Use(h.Currency.Handler)
 		r.Get("/", h.MainPage)
 
 		r.Get("/login", h.LoginPage)
 		r.Get("/register", h.RegistrationPage)
 
 		r.Get("/history", h.HistoryPage)
+		r.Get("/addresses", h.AddressesPage)
+		r.Get("/wishlists/{token}", h.WishlistPage)
+		r.Get("/books/{id}", h.BookPage)
 
 		r.Post("/register/front", h.RegistrationFront)
 		r.Post("/login/front", h.LoginFront)
 
 		r.Route("/cart", func(r chi.Router) {
-			r.Use(middle.WithAuth)
-			r.Get("/add", h.AddCartItems)
-			r.Get("/items", h.GetCartItems)
-			r.Post("/remove", h.RemoveCartItem)
-			r.Get("/success", h.CartCheckout)
+			// Adding to the cart is a link, so it needs a guest even on GET.
+			r.With(h.Guest.Writer, h.Idempotency.Handler).Get("/add", h.AddCartItems)
+
+			r.Group(func(r chi.Router) {
+				r.Use(h.Guest.Handler)
+				r.Get("/items", h.GetCartItems)
+				r.Post("/remove", h.RemoveCartItem)
+				r.Post("/quantity", h.SetCartItemQuantity)
+				r.Get("/summary", h.CartSummary)
+				r.Post("/promotions", h.ApplyCartPromotion)
+				r.Post("/promotions/remove", h.RemoveCartPromotion)
+				r.Get("/shipping/options", h.CartShippingOptions)
+				r.Post("/shipping", h.SetCartShipping)
+				r.With(h.Idempotency.Handler).Get("/success", h.CartCheckout)
+			})
 		})
 
 		r.Route("/admin", func(r chi.Router) {
@@ -53,6 +87,10 @@ func (h *Handler) NewFrontEndHandler(r chi.Router) {
 
 			r.Post("/edit/{id}", h.EditBookFront)
 			r.Post("/delete/{id}", h.DeleteBookFront)
+			r.Get("/shipments/{id}/picklist", h.PickListPage)
+			r.Get("/reports", h.ReportsPage)
+			r.Get("/orders", h.OrdersPage)
+			r.Get("/orders/{id}", h.OrdersPage)
 		})
 	})
 }
@@ -105,6 +143,7 @@ func (h *Handler) MainPage(w http.ResponseWriter, r *http.Request) {
 		SortBy:         r.URL.Query().Get("sort"),
 		UserName:       userName,
 		Role:           role,
+		Currency:       middle.CurrencyFromContext(r.Context()),
 	}
 
 	mainPageHTML, err := h.Svc.MainPage(r.Context(), params)
@@ -161,12 +200,14 @@ func (h *Handler) LoginFront(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.ProcessLogin(r.Context(), w, r, r.Form)
+	userID, err := h.Svc.ProcessLogin(r.Context(), w, r, r.Form)
 	if err != nil {
 		http.Redirect(w, r, "/login?error="+err.Error(), http.StatusSeeOther)
 		return
 	}
 
+	h.mergeGuestCart(w, r, userID)
+
 	http.Redirect(w, r, "/?success=logged_in", http.StatusSeeOther)
 }
 
@@ -212,12 +253,14 @@ func (h *Handler) RegistrationFront(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.ProcessRegistration(r.Context(), r.Form)
+	userID, err := h.Svc.ProcessRegistration(r.Context(), r.Form)
 	if err != nil {
 		http.Redirect(w, r, "/register?error="+err.Error(), http.StatusSeeOther)
 		return
 	}
 
+	h.mergeGuestCart(w, r, userID)
+
 	http.Redirect(w, r, "/login?success=registered", http.StatusSeeOther)
 }
 
@@ -249,6 +292,98 @@ func (h *Handler) AdminPage(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(adminPageHTML))
 }
 
+func (h *Handler) PickListPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.PickListPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	shipmentID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid shipment ID", http.StatusBadRequest)
+		return
+	}
+
+	pickListPage, err := h.Svc.PickListPage(r.Context(), shipmentID)
+	if err != nil {
+		h.Log.Error("Error in pick list page", "error", err)
+		if errors.Is(err, service.ErrNotFound) {
+			http.Error(w, "Shipment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(pickListPage))
+}
+
+func (h *Handler) ReportsPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.ReportsPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, err := report.ParseRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	interval, err := report.ParseInterval(r.URL.Query().Get("interval"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reportsPage, err := h.Svc.ReportsPage(r.Context(), rng, interval)
+	if err != nil {
+		h.Log.Error("Error in reports page", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(reportsPage))
+}
+
+func (h *Handler) OrdersPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.OrdersPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter, err := adminorder.ParseFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ordersPage, err := h.Svc.OrdersPage(r.Context(), filter, chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("Error in orders page", "error", err)
+		if errors.Is(err, service.ErrNotFound) || errors.Is(err, service.ErrValid) {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(ordersPage))
+}
+
 func (h *Handler) HistoryPage(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.front.HistoryPage"
 
@@ -276,6 +411,95 @@ func (h *Handler) HistoryPage(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(historyPage))
 }
 
+func (h *Handler) AddressesPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.AddressesPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	if _, ok := r.Context().Value("user_id").(string); !ok {
+		http.Redirect(w, r, "/login?error=user_not_logged_in", http.StatusSeeOther)
+		return
+	}
+
+	addressesPage, err := h.Svc.AddressesPage(r.Context())
+	if err != nil {
+		h.Log.Error("Error in addresses page", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(addressesPage))
+}
+
+func (h *Handler) WishlistPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.WishlistPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	_, loggedIn := r.Context().Value("user_id").(string)
+
+	wishlistPage, err := h.Svc.WishlistPage(r.Context(), chi.URLParam(r, "token"), loggedIn, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("Error in wishlist page", "error", err)
+		if errors.Is(err, service.ErrNotFound) {
+			http.Error(w, "Wishlist not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(wishlistPage))
+}
+
+func (h *Handler) BookPage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.BookPage"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		http.Error(w, "Book not found", http.StatusNotFound)
+		return
+	}
+
+	sort, err := review.ParseSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		sort = review.SortHelpful
+	}
+
+	userID, _ := r.Context().Value("user_id").(string)
+
+	bookPage, err := h.Svc.BookPage(r.Context(), userID, bookID, sort, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("Error in book page", "error", err)
+		if errors.Is(err, service.ErrNotFound) {
+			http.Error(w, "Book not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(bookPage))
+}
+
 func (h *Handler) EditBookFront(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.front.EditBookFront"
 
@@ -291,16 +515,23 @@ func (h *Handler) EditBookFront(w http.ResponseWriter, r *http.Request) {
 	}
 
 	book := model.Book{
-		Title:  r.Form.Get("title"),
-		Author: r.Form.Get("author"),
+		Title:    r.Form.Get("title"),
+		Author:   r.Form.Get("author"),
+		Category: r.Form.Get("category"),
 	}
 
-	if price, err := strconv.ParseFloat(r.Form.Get("price"), 64); err == nil {
+	if class, err := tax.ParseClass(r.Form.Get("tax_class")); err == nil && r.Form.Has("tax_class") {
+		book.TaxClass = class
+	}
+	if price, err := money.Parse(r.Form.Get("price"), money.DefaultCurrency); err == nil {
 		book.Price = price
 	}
 	if stock, err := strconv.Atoi(r.Form.Get("stock")); err == nil {
 		book.Stock = stock
 	}
+	if weight, err := strconv.Atoi(r.Form.Get("weight_grams")); err == nil {
+		book.WeightGrams = weight
+	}
 
 	err := h.Svc.EditBook(r.Context(), bookID, &book)
 	if err != nil {
@@ -339,6 +570,7 @@ func (h *Handler) DeleteBookFront(w http.ResponseWriter, r *http.Request) {
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param currency query string false "Currency to price the items in, otherwise taken from the currency cookie or Accept-Language"
 // @Success 200 {array} orderItem.OrderItem "List of cart items"
 // @Failure 401 {object} response.ResponseError "User not logged in"
 // @Failure 500 {object} response.ResponseError "Internal server error"
@@ -358,7 +590,7 @@ func (h *Handler) GetCartItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cartItems, err := h.Svc.GetCartItems(r.Context(), userID)
+	cartItems, err := h.Svc.GetCartItems(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
 	if err != nil {
 		h.Log.Error("failed to fetch cart items", "error", err)
 		http.Error(w, "Failed to load cart", http.StatusInternalServerError)
@@ -494,6 +726,345 @@ func (h *Handler) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/?success=removed_from_cart", http.StatusSeeOther)
 }
 
+// SetCartItemQuantity
+//
+// @Summary Set how many copies of a book the cart holds
+// @Description Sets the quantity of a cart line to the given number. Zero takes the book out of the cart.
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param id query string true "Book ID"
+// @Param request body cart.QuantityRequest true "Quantity"
+// @Success 200 {string} string "Quantity updated"
+// @Failure 400 {object} response.ResponseError "Invalid book ID or quantity"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 409 {object} response.ResponseError "Not enough stock"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /cart/quantity [post]
+func (h *Handler) SetCartItemQuantity(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.SetCartItemQuantity"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, err := uuid.FromString(r.URL.Query().Get("id"))
+	if err != nil {
+		h.Log.Error("invalid book ID format", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, errors.New("invalid book ID format"))
+		return
+	}
+
+	var req cart.QuantityRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.SetCartItemQuantity(r.Context(), userID, bookID, req.Quantity)
+	if err != nil {
+		h.Log.Error("failed to set cart item quantity", "error", err)
+		writeCartError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Quantity updated")
+}
+
+// CartSummary
+//
+// @Summary Get the price breakdown of the user's cart
+// @Description Returns the cart's subtotal, the discount of every applied promotion, or why it no longer applies, and the total
+// @Tags cart
+// @Produce json
+// @Param currency query string false "Currency to price the cart in, otherwise taken from the currency cookie or Accept-Language"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/summary [get]
+func (h *Handler) CartSummary(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.CartSummary"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	breakdown, err := h.Svc.CartSummary(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to price cart", "error", err)
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// ApplyCartPromotion
+//
+// @Summary Apply a promotion code to the cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param request body promotion.ApplyRequest true "Promotion code"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 400 {object} response.ResponseError "Missing code"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown code"
+// @Failure 422 {object} response.ResponseError "Code does not apply to the cart"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/promotions [post]
+func (h *Handler) ApplyCartPromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.ApplyCartPromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req promotion.ApplyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	breakdown, err := h.Svc.ApplyCartPromotion(r.Context(), userID, req.Code, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to apply promotion", "error", err)
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// RemoveCartPromotion
+//
+// @Summary Remove a promotion code from the cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param request body promotion.ApplyRequest true "Promotion code"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 400 {object} response.ResponseError "Missing code"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Code not applied to the cart"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/promotions/remove [post]
+func (h *Handler) RemoveCartPromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.RemoveCartPromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req promotion.ApplyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	breakdown, err := h.Svc.RemoveCartPromotion(r.Context(), userID, req.Code, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to remove promotion", "error", err)
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// CartShippingOptions
+//
+// @Summary Quote delivery for the cart
+// @Description Lists the delivery methods that take the cart to one of the user's addresses, priced in the display currency, cheapest first
+// @Tags cart
+// @Produce json
+// @Param address_id query string true "Address ID"
+// @Success 200 {array} shipping.Quote "Delivery options"
+// @Failure 400 {object} response.ResponseError "Invalid address ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown address"
+// @Failure 422 {object} response.ResponseError "No delivery to this address"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /cart/shipping/options [get]
+func (h *Handler) CartShippingOptions(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.CartShippingOptions"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	addressID, err := uuid.FromString(r.URL.Query().Get("address_id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, errors.New("invalid address ID"))
+		return
+	}
+
+	quotes, err := h.Svc.CartShippingOptions(r.Context(), userID, addressID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to quote shipping", "error", err)
+		writeShippingError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, quotes)
+}
+
+// SetCartShipping
+//
+// @Summary Choose addresses and delivery for the cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param request body orderModel.ShippingRequest true "Addresses and delivery method"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 400 {object} response.ResponseError "Missing delivery method"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown address"
+// @Failure 422 {object} response.ResponseError "Delivery method does not go to this address"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /cart/shipping [post]
+func (h *Handler) SetCartShipping(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.front.SetCartShipping"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req orderModel.ShippingRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	breakdown, err := h.Svc.SetCartShipping(r.Context(), userID, req, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to set shipping", "error", err)
+		writeShippingError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// mergeGuestCart moves the cart the visitor filled in before logging in or
+// registering into their account. A failed merge is logged and does not fail
+// the login; the guest cart cookie is cleared either way.
+func (h *Handler) mergeGuestCart(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	guestID, ok := h.Guest.Take(w, r)
+	if !ok || h.Guests == nil {
+		return
+	}
+
+	if err := h.Guests.MergeCart(r.Context(), guestID, userID); err != nil {
+		h.Log.Error("failed to merge guest cart",
+			slog.String("guest_id", guestID.String()),
+			slog.String("error", err.Error()))
+	}
+}
+
+func writeCartError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrInsufficientStock):
+		response.WriteError(w, r, http.StatusConflict, errors.New("not enough copies in stock"))
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, fmt.Errorf("quantity must be between 0 and %d", cart.MaxQuantity))
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, errors.New("book not found"))
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, errors.New("failed to update cart"))
+	}
+}
+
+func writeShippingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, shipping.ErrUnavailable):
+		response.WriteError(w, r, http.StatusUnprocessableEntity, errors.New("we cannot deliver this cart to that address with that method"))
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, errors.New("choose a delivery method"))
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, errors.New("address not found"))
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, errors.New("failed to update cart"))
+	}
+}
+
+func writePromotionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, promotion.ErrIneligible):
+		response.WriteError(w, r, http.StatusUnprocessableEntity, promotionReason(err))
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, service.ErrValid)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, errors.New("promotion code not found"))
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, errors.New("failed to update cart"))
+	}
+}
+
+// promotionReason keeps the customer-facing part of an ineligibility error,
+// dropping the layers it was wrapped in on the way up.
+func promotionReason(err error) string {
+	text := err.Error()
+	if i := strings.Index(text, promotion.ErrIneligible.Error()); i >= 0 {
+		return text[i:]
+	}
+	return text
+}
+
 func (h *Handler) CartCheckout(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.front.CartCheckout"
 	h.Log = h.Log.With(
@@ -508,7 +1079,7 @@ func (h *Handler) CartCheckout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.Svc.CartCheckout(r.Context(), userID)
+	err := h.Svc.CartCheckout(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
 	if err != nil {
 		h.Log.Error("failed to checkout cart item", "error", err)
 		http.Redirect(w, r, "/cart?error=checkout_failed", http.StatusSeeOther)
@@ -4,11 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/mainPageParams"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/go-chi/chi"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
@@ -18,15 +31,11 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_NewFrontEndHandler(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 
 	t.Run("should be no errors", func(t *testing.T) {
@@ -35,12 +44,7 @@ func TestHandler_NewFrontEndHandler(t *testing.T) {
 }
 
 func TestHandler_MainPage_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/", hdl.MainPage)
 
@@ -49,7 +53,8 @@ func TestHandler_MainPage_Success(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodGet, "/", nil)
 
 		params := mainPageParams.Model{
-			Page: "main",
+			Page:     "main",
+			Currency: money.USD,
 		}
 		svc.On("MainPage", mock.Anything, params).Return("<html>Test</html>", nil)
 
@@ -61,12 +66,7 @@ func TestHandler_MainPage_Success(t *testing.T) {
 }
 
 func TestHandler_MainPage_InternalServerError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/", hdl.MainPage)
 
@@ -75,7 +75,8 @@ func TestHandler_MainPage_InternalServerError(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodGet, "/", nil)
 
 		params := mainPageParams.Model{
-			Page: "main",
+			Page:     "main",
+			Currency: money.USD,
 		}
 		svc.On("MainPage", mock.Anything, params).Return("", errors.New("internal error"))
 
@@ -86,12 +87,7 @@ func TestHandler_MainPage_InternalServerError(t *testing.T) {
 }
 
 func TestHandler_AddCartItems_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/cart/add", hdl.AddCartItems)
 
@@ -118,12 +114,7 @@ func TestHandler_AddCartItems_Success(t *testing.T) {
 }
 
 func TestHandler_AddCartItems_InvalidBookID(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/cart/add", hdl.AddCartItems)
 
@@ -141,12 +132,7 @@ func TestHandler_AddCartItems_InvalidBookID(t *testing.T) {
 }
 
 func TestHandler_LoginFront_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/login/front", hdl.LoginFront)
 
@@ -158,7 +144,7 @@ func TestHandler_LoginFront_Success(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodPost, "/login/front", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-		svc.On("ProcessLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		svc.On("ProcessLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, nil)
 
 		router.ServeHTTP(r, req)
 
@@ -167,13 +153,80 @@ func TestHandler_LoginFront_Success(t *testing.T) {
 	})
 }
 
+func TestHandler_LoginFront_MergesGuestCart(t *testing.T) {
+	hdl, svc := newTestHandler()
+	guests := &mocks.GuestService{}
+	secret := []byte("guest-secret")
+	hdl.Guests = guests
+	hdl.Guest = &middle.Guest{Secret: secret, TTL: time.Hour}
+	router := chi.NewRouter()
+	router.Post("/login/front", hdl.LoginFront)
+
+	t.Run("it should move the guest cart into the account and clear the guest cookie", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		form := url.Values{}
+		form.Set("email", "test@example.com")
+		form.Set("password", "test_password")
+		req, _ := http.NewRequest(http.MethodPost, "/login/front", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		guestID, _ := uuid.NewV4()
+		userID, _ := uuid.NewV4()
+		req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now(), secret)})
+
+		svc.On("ProcessLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(userID, nil)
+		guests.On("MergeCart", mock.Anything, guestID, userID).Return(nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusSeeOther, r.Code)
+		assert.Contains(t, r.Header().Get("Location"), "/?success=logged_in")
+		guests.AssertExpectations(t)
+
+		var cleared bool
+		for _, cookie := range r.Result().Cookies() {
+			if cookie.Name == guest.CookieName {
+				cleared = cookie.MaxAge < 0
+			}
+		}
+		assert.True(t, cleared)
+	})
+}
+
+func TestHandler_RegistrationFront_MergesGuestCart(t *testing.T) {
+	hdl, svc := newTestHandler()
+	guests := &mocks.GuestService{}
+	secret := []byte("guest-secret")
+	hdl.Guests = guests
+	hdl.Guest = &middle.Guest{Secret: secret, TTL: time.Hour}
+	router := chi.NewRouter()
+	router.Post("/register/front", hdl.RegistrationFront)
+
+	t.Run("it should register even when the merge fails", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		form := url.Values{}
+		form.Set("email", "test@example.com")
+		form.Set("password", "test_password")
+		req, _ := http.NewRequest(http.MethodPost, "/register/front", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		guestID, _ := uuid.NewV4()
+		userID, _ := uuid.NewV4()
+		req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now(), secret)})
+
+		svc.On("ProcessRegistration", mock.Anything, mock.Anything).Return(userID, nil)
+		guests.On("MergeCart", mock.Anything, guestID, userID).Return(errors.New("error"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusSeeOther, r.Code)
+		assert.Contains(t, r.Header().Get("Location"), "/login?success=registered")
+		guests.AssertExpectations(t)
+	})
+}
+
 func TestHandler_LoginFront_FormError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/login/front", hdl.LoginFront)
 
@@ -189,12 +242,7 @@ func TestHandler_LoginFront_FormError(t *testing.T) {
 }
 
 func TestHandler_LoginPage_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/login", hdl.LoginPage)
 
@@ -212,12 +260,7 @@ func TestHandler_LoginPage_Success(t *testing.T) {
 }
 
 func TestHandler_LoginPage_InternalServerError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/login", hdl.LoginPage)
 
@@ -234,12 +277,7 @@ func TestHandler_LoginPage_InternalServerError(t *testing.T) {
 }
 
 func TestHandler_RegistrationPage_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/register", hdl.RegistrationPage)
 
@@ -257,12 +295,7 @@ func TestHandler_RegistrationPage_Success(t *testing.T) {
 }
 
 func TestHandler_RegistrationPage_InternalServerError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/register", hdl.RegistrationPage)
 
@@ -279,12 +312,7 @@ func TestHandler_RegistrationPage_InternalServerError(t *testing.T) {
 }
 
 func TestHandler_RegistrationFront_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/register/front", hdl.RegistrationFront)
 
@@ -296,7 +324,7 @@ func TestHandler_RegistrationFront_Success(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodPost, "/register/front", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-		svc.On("ProcessRegistration", mock.Anything, mock.Anything).Return(nil)
+		svc.On("ProcessRegistration", mock.Anything, mock.Anything).Return(uuid.Nil, nil)
 
 		router.ServeHTTP(r, req)
 
@@ -306,12 +334,7 @@ func TestHandler_RegistrationFront_Success(t *testing.T) {
 }
 
 func TestHandler_RegistrationFront_FormError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/register/front", hdl.RegistrationFront)
 
@@ -326,12 +349,7 @@ func TestHandler_RegistrationFront_FormError(t *testing.T) {
 }
 
 func TestHandler_AdminPage_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/admin", hdl.AdminPage)
 
@@ -354,12 +372,7 @@ func TestHandler_AdminPage_Success(t *testing.T) {
 }
 
 func TestHandler_AdminPage_InternalServerError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/admin", hdl.AdminPage)
 
@@ -379,12 +392,7 @@ func TestHandler_AdminPage_InternalServerError(t *testing.T) {
 }
 
 func TestHandler_HistoryPage_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/history", hdl.HistoryPage)
 
@@ -405,12 +413,7 @@ func TestHandler_HistoryPage_Success(t *testing.T) {
 }
 
 func TestHandler_HistoryPage_BadRequestError(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/history", hdl.HistoryPage)
 
@@ -425,12 +428,7 @@ func TestHandler_HistoryPage_BadRequestError(t *testing.T) {
 }
 
 func TestHandler_EditBookFront_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/admin/edit/{id}", hdl.EditBookFront)
 
@@ -447,7 +445,7 @@ func TestHandler_EditBookFront_Success(t *testing.T) {
 		book := model.Book{
 			Title:  "New Title",
 			Author: "New Author",
-			Price:  19.99,
+			Price:  money.MustParse("19.99", money.USD),
 			Stock:  10,
 		}
 		svc.On("EditBook", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", &book).Return(nil)
@@ -459,13 +457,40 @@ func TestHandler_EditBookFront_Success(t *testing.T) {
 	})
 }
 
+func TestHandler_EditBookFront_TaxClass(t *testing.T) {
+	hdl, svc := newTestHandler()
+	router := chi.NewRouter()
+	router.Post("/admin/edit/{id}", hdl.EditBookFront)
+
+	t.Run("it should pass the tax class on", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		form := url.Values{}
+		form.Set("title", "New Title")
+		form.Set("author", "New Author")
+		form.Set("tax_class", "zero")
+		form.Set("price", "19.99")
+		form.Set("stock", "10")
+		req, _ := http.NewRequest(http.MethodPost, "/admin/edit/123e4567-e89b-12d3-a456-426614174000", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		book := model.Book{
+			Title:    "New Title",
+			Author:   "New Author",
+			TaxClass: tax.ClassZero,
+			Price:    money.MustParse("19.99", money.USD),
+			Stock:    10,
+		}
+		svc.On("EditBook", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", &book).Return(nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusSeeOther, r.Code)
+		assert.Contains(t, r.Header().Get("Location"), "/admin?success=book_updated")
+	})
+}
+
 func TestHandler_DeleteBookFront_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/admin/delete/{id}", hdl.DeleteBookFront)
 
@@ -483,12 +508,7 @@ func TestHandler_DeleteBookFront_Success(t *testing.T) {
 }
 
 func TestHandler_GetCartItems_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/api/v1/cart/items", hdl.GetCartItems)
 
@@ -502,7 +522,7 @@ func TestHandler_GetCartItems_Success(t *testing.T) {
 		cartItems := []orderItem.OrderItemFull{
 			{BookID: uuid.Must(uuid.NewV4()), Quantity: 2},
 		}
-		svc.On("GetCartItems", mock.Anything, "test_user_id").Return(&cartItems, nil)
+		svc.On("GetCartItems", mock.Anything, "test_user_id", money.USD).Return(&cartItems, nil)
 
 		router.ServeHTTP(r, req)
 
@@ -516,12 +536,7 @@ func TestHandler_GetCartItems_Success(t *testing.T) {
 }
 
 func TestHandler_GetCartItems_Unauthorized(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/api/v1/cart/items", hdl.GetCartItems)
 
@@ -536,12 +551,7 @@ func TestHandler_GetCartItems_Unauthorized(t *testing.T) {
 }
 
 func TestHandler_RemoveCartItem_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/api/v1/cart/remove", hdl.RemoveCartItem)
 
@@ -563,12 +573,7 @@ func TestHandler_RemoveCartItem_Success(t *testing.T) {
 }
 
 func TestHandler_RemoveCartItem_InvalidBookID(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Post("/api/v1/cart/remove", hdl.RemoveCartItem)
 
@@ -585,13 +590,41 @@ func TestHandler_RemoveCartItem_InvalidBookID(t *testing.T) {
 	})
 }
 
-func TestHandler_CartCheckout_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
+func TestHandler_SetCartItemQuantity(t *testing.T) {
+	bookID := uuid.Must(uuid.FromString("123e4567-e89b-12d3-a456-426614174000"))
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not enough stock", svcErr: fmt.Errorf("test: %w", repository.ErrInsufficientStock), wantStatus: http.StatusConflict},
+		{name: "quantity out of range", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+			router := chi.NewRouter()
+			router.Post("/cart/quantity", hdl.SetCartItemQuantity)
+
+			svc.On("SetCartItemQuantity", mock.Anything, "test_user_id", bookID, 3).Return(tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/cart/quantity?id="+bookID.String(), strings.NewReader(`{"quantity":3}`))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "test_user_id"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			svc.AssertExpectations(t)
+		})
 	}
+}
+
+func TestHandler_CartCheckout_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/cart/success", hdl.CartCheckout)
 
@@ -602,7 +635,7 @@ func TestHandler_CartCheckout_Success(t *testing.T) {
 		ctx := context.WithValue(req.Context(), "user_id", "test_user_id")
 		req = req.WithContext(ctx)
 
-		svc.On("CartCheckout", mock.Anything, "test_user_id").Return(nil)
+		svc.On("CartCheckout", mock.Anything, "test_user_id", money.USD).Return(nil)
 
 		router.ServeHTTP(r, req)
 
@@ -612,12 +645,7 @@ func TestHandler_CartCheckout_Success(t *testing.T) {
 }
 
 func TestHandler_CartCheckout_UserNotLoggedIn(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.FrontService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 	router := chi.NewRouter()
 	router.Get("/cart/success", hdl.CartCheckout)
 
@@ -631,3 +659,212 @@ func TestHandler_CartCheckout_UserNotLoggedIn(t *testing.T) {
 		assert.Contains(t, r.Header().Get("Location"), "/login?error=user_not_logged_in")
 	})
 }
+
+func TestHandler_SetCartShipping_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
+	router := chi.NewRouter()
+	router.Post("/cart/shipping", hdl.SetCartShipping)
+
+	t.Run("it should return the repriced cart", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		addressID := uuid.Must(uuid.NewV4())
+		body := `{"shipping_address_id":"` + addressID.String() + `","delivery_method":"express"}`
+		req, _ := http.NewRequest(http.MethodPost, "/cart/shipping", strings.NewReader(body))
+
+		ctx := context.WithValue(req.Context(), "user_id", "test_user_id")
+		req = req.WithContext(ctx)
+
+		shippingReq := orderModel.ShippingRequest{ShippingAddressID: addressID, DeliveryMethod: "express"}
+		breakdown := &promotion.Breakdown{DeliveryMethod: "express", ShippingTotal: money.MustParse("12.99", money.USD)}
+		svc.On("SetCartShipping", mock.Anything, "test_user_id", shippingReq, money.USD).Return(breakdown, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"delivery_method":"express"`)
+	})
+}
+
+func TestHandler_CartShippingOptions_Unavailable(t *testing.T) {
+	hdl, svc := newTestHandler()
+	router := chi.NewRouter()
+	router.Get("/cart/shipping/options", hdl.CartShippingOptions)
+
+	t.Run("it should return 422 when nothing goes to the address", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		addressID := uuid.Must(uuid.NewV4())
+		req, _ := http.NewRequest(http.MethodGet, "/cart/shipping/options?address_id="+addressID.String(), nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "test_user_id")
+		req = req.WithContext(ctx)
+
+		svc.On("CartShippingOptions", mock.Anything, "test_user_id", addressID, money.USD).
+			Return(nil, fmt.Errorf("service.front.CartShippingOptions: %w", shipping.ErrUnavailable))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+	})
+
+	t.Run("it should return 400 for a bad address ID", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cart/shipping/options?address_id=nope", nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "test_user_id")
+		req = req.WithContext(ctx)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestHandler_WishlistPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "shared", wantStatus: http.StatusOK},
+		{name: "private or unknown", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+			router := chi.NewRouter()
+			router.Get("/wishlists/{token}", hdl.WishlistPage)
+
+			svc.On("WishlistPage", mock.Anything, "abc", false, money.USD).Return("<html>Birthday</html>", tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/wishlists/abc", nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_BookPage(t *testing.T) {
+	bookID := uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+
+	tests := []struct {
+		name       string
+		path       string
+		sort       review.Sort
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "most helpful first", path: "/books/" + bookID.String(), sort: review.SortHelpful, wantStatus: http.StatusOK},
+		{name: "newest first", path: "/books/" + bookID.String() + "?sort=recent", sort: review.SortRecent, wantStatus: http.StatusOK},
+		{name: "unknown sort falls back", path: "/books/" + bookID.String() + "?sort=random", sort: review.SortHelpful, wantStatus: http.StatusOK},
+		{name: "book not found", path: "/books/" + bookID.String(), sort: review.SortHelpful, svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "/books/not-a-uuid", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+			router := chi.NewRouter()
+			router.Get("/books/{id}", hdl.BookPage)
+
+			svc.On("BookPage", mock.Anything, "", bookID, tt.sort, money.USD).Return("<html>Dune</html>", tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ReportsPage(t *testing.T) {
+	march := report.Range{
+		From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		interval   report.Interval
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "daily", query: "?from=2025-03-01&to=2025-03-31", interval: report.IntervalDay, wantStatus: http.StatusOK},
+		{name: "monthly", query: "?from=2025-03-01&to=2025-03-31&interval=month", interval: report.IntervalMonth, wantStatus: http.StatusOK},
+		{name: "bad range", query: "?from=2025-04-01&to=2025-03-31", wantStatus: http.StatusBadRequest},
+		{name: "bad interval", query: "?interval=hour", wantStatus: http.StatusBadRequest},
+		{name: "internal error", query: "?from=2025-03-01&to=2025-03-31", interval: report.IntervalDay, svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+			router := chi.NewRouter()
+			router.Get("/admin/reports", hdl.ReportsPage)
+
+			svc.On("ReportsPage", mock.Anything, march, tt.interval).Return("<html>Sales Reports</html>", tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/reports"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				svc.AssertNotCalled(t, "ReportsPage", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_OrdersPage(t *testing.T) {
+	const orderID = "5b0e7a4c-3f1d-4c2a-9e8b-7d6f5a4b3c21"
+
+	tests := []struct {
+		name       string
+		path       string
+		filter     adminorder.Filter
+		orderID    string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "list", path: "/admin/orders?status=paid", filter: adminorder.Filter{Status: orderModel.StatusPaid, Limit: adminorder.DefaultLimit}, wantStatus: http.StatusOK},
+		{name: "detail", path: "/admin/orders/" + orderID, filter: adminorder.Filter{Limit: adminorder.DefaultLimit}, orderID: orderID, wantStatus: http.StatusOK},
+		{name: "bad filter", path: "/admin/orders?min_total=lots", wantStatus: http.StatusBadRequest},
+		{name: "order not found", path: "/admin/orders/" + orderID, filter: adminorder.Filter{Limit: adminorder.DefaultLimit}, orderID: orderID, svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "internal error", path: "/admin/orders", filter: adminorder.Filter{Limit: adminorder.DefaultLimit}, svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+			router := chi.NewRouter()
+			router.Get("/admin/orders", hdl.OrdersPage)
+			router.Get("/admin/orders/{id}", hdl.OrdersPage)
+
+			svc.On("OrdersPage", mock.Anything, tt.filter, tt.orderID).Return("<html>Orders</html>", tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				svc.AssertNotCalled(t, "OrdersPage", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.FrontService) {
+	svc := &mocks.FrontService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,107 @@
+package guest
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc         interfaces.GuestService
+	Guest       *middle.Guest
+	Idempotency *middle.Idempotency
+	Currency    *middle.Currency
+	Log         *slog.Logger
+}
+
+func (h *Handler) NewGuestHandler(r chi.Router) {
+	r.Route("/guest", func(r chi.Router) {
+		r.Use(middle.WithOptionalAuth)
+		r.Use(h.Guest.Handler)
+		r.Use(h.Currency.Handler)
+
+		r.With(h.Idempotency.Handler).Post("/checkout", h.Checkout)
+	})
+}
+
+// Checkout
+//
+// @Summary Check out as a guest
+// @Description Pays for the visitor's anonymous cart without an account. The order is shipped to the given address with the given delivery method, or the cheapest one that reaches it, and the order emails go to the given address. The cart is found by the guest cart cookie.
+// @Tags guests
+// @Accept json
+// @Produce json
+// @Param request body model.CheckoutRequest true "Email, shipping address and delivery method"
+// @Success 201 {object} orderModel.Model "Order placed"
+// @Failure 400 {object} response.ResponseError "Invalid email or address, or the visitor is logged in"
+// @Failure 402 {object} response.ResponseError "Payment declined"
+// @Failure 404 {object} response.ResponseError "Cart is empty"
+// @Failure 409 {object} response.ResponseError "Not enough stock or an earlier payment is still being processed"
+// @Failure 422 {object} response.ResponseError "No delivery to the address"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/guest/checkout [post]
+func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.guest.Checkout"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	guestID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.CheckoutRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, errors.New("failed to decode request body"))
+		return
+	}
+
+	order, err := h.Svc.Checkout(r.Context(), guestID, req, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("guest checkout failed", slog.String("error", err.Error()))
+		writeGuestError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, order)
+}
+
+func writeGuestError(w http.ResponseWriter, r *http.Request, err error) {
+	var transitionErr *orderModel.TransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		response.WriteError(w, r, http.StatusConflict, transitionErr)
+	case errors.Is(err, shipping.ErrUnavailable):
+		response.WriteError(w, r, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, repository.ErrInsufficientStock):
+		response.WriteError(w, r, http.StatusConflict, repository.ErrInsufficientStock)
+	case errors.Is(err, service.ErrPaymentDeclined):
+		response.WriteError(w, r, http.StatusPaymentRequired, err)
+	case errors.Is(err, service.ErrPaymentPending):
+		response.WriteError(w, r, http.StatusConflict, service.ErrPaymentPending)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
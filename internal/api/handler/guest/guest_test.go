@@ -0,0 +1,85 @@
+package guest
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const guestID = "0c3f7e1a-8d2b-4f6e-9a1c-5b4d3e2f1a09"
+
+func TestHandler_Checkout(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", body: `{"email": "ada@example.com", "delivery_method": "standard"}`, wantStatus: http.StatusCreated},
+		{name: "bad body", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "invalid email", body: `{"email": "ada"}`, svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "empty cart", body: `{"email": "ada@example.com"}`, svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "no delivery", body: `{"email": "ada@example.com"}`, svcErr: shipping.ErrUnavailable, wantStatus: http.StatusUnprocessableEntity},
+		{name: "declined", body: `{"email": "ada@example.com"}`, svcErr: service.ErrPaymentDeclined, wantStatus: http.StatusPaymentRequired},
+		{name: "payment in flight", body: `{"email": "ada@example.com"}`, svcErr: service.ErrPaymentPending, wantStatus: http.StatusConflict},
+		{name: "internal error", body: `{"email": "ada@example.com"}`, svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/guest/checkout", hdl.Checkout)
+
+			order := &orderModel.Model{ID: uuid.Must(uuid.NewV4()), Status: orderModel.StatusPaid}
+			svc.On("Checkout", mock.Anything, guestID, mock.MatchedBy(func(req model.CheckoutRequest) bool {
+				return req.Email != ""
+			}), mock.Anything).Return(order, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/guest/checkout", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", guestID))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusCreated {
+				assert.Contains(t, r.Body.String(), order.ID.String())
+			}
+		})
+	}
+}
+
+func TestHandler_NewGuestHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewGuestHandler(router)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/guest/checkout", strings.NewReader(`{}`))
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusUnauthorized, r.Code)
+	svc.AssertNotCalled(t, "Checkout", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func newTestHandler() (*Handler, *mocks.GuestService) {
+	svc := &mocks.GuestService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
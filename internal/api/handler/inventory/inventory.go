@@ -0,0 +1,174 @@
+package inventory
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.InventoryService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewInventoryHandler(r chi.Router) {
+	r.Route("/admin/inventory", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/{bookId}", h.GetLevel)
+		r.Get("/{bookId}/movements", h.GetMovements)
+		r.Post("/{bookId}/adjustments", h.AdjustStock)
+	})
+}
+
+// GetLevel
+//
+// @Summary Get stock level of a book
+// @Description Returns on-hand, reserved and available quantities derived from the stock movement ledger
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Success 200 {object} model.Level "Stock level"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/inventory/{bookId} [get]
+func (h *Handler) GetLevel(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.inventory.GetLevel"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	level, err := h.Svc.GetLevel(context.Background(), bookID)
+	if err != nil {
+		h.Log.Error("error getting stock level", slog.String("error", err.Error()))
+		if errors.Is(err, repository.ErrBookNotFound) {
+			response.WriteError(w, r, http.StatusNotFound, repository.ErrBookNotFound)
+			return
+		}
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, level)
+}
+
+// GetMovements
+//
+// @Summary Get stock movement history of a book
+// @Description Lists every recorded stock movement of a book, newest first
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Success 200 {array} model.Movement "Stock movements"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/inventory/{bookId}/movements [get]
+func (h *Handler) GetMovements(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.inventory.GetMovements"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	movements, err := h.Svc.GetMovements(context.Background(), bookID)
+	if err != nil {
+		h.Log.Error("error getting stock movements", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, movements)
+}
+
+// AdjustStock
+//
+// @Summary Post a manual stock movement
+// @Description Records a restock, return or signed adjustment for a book. Adjustments require a note.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Param request body model.AdjustmentRequest true "Stock adjustment"
+// @Success 201 {string} string "Stock adjusted"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 409 {object} response.ResponseError "Insufficient stock"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/inventory/{bookId}/adjustments [post]
+func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.inventory.AdjustStock"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.AdjustmentRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	err = h.Svc.AdjustStock(r.Context(), bookID, actorID, req)
+	if err != nil {
+		h.Log.Error("error adjusting stock", slog.String("error", err.Error()))
+		switch {
+		case errors.Is(err, service.ErrValid):
+			response.WriteError(w, r, http.StatusBadRequest, err)
+		case errors.Is(err, repository.ErrBookNotFound):
+			response.WriteError(w, r, http.StatusNotFound, repository.ErrBookNotFound)
+		case errors.Is(err, repository.ErrInsufficientStock):
+			response.WriteError(w, r, http.StatusConflict, repository.ErrInsufficientStock)
+		default:
+			response.WriteError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, "Stock adjusted")
+}
@@ -0,0 +1,220 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewInventoryHandler(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+
+	t.Run("it should return no errors", func(t *testing.T) {
+		hdl.NewInventoryHandler(router)
+	})
+}
+
+func TestHandler_NewInventoryHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewInventoryHandler(router)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/inventory/"+id.String(), nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetLevel", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_GetLevel_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{bookId}", hdl.GetLevel)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return the stock level", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/"+id.String(), nil)
+		require.NoError(t, err)
+
+		level := &model.Level{BookID: id, OnHand: 10, Reserved: 3, Available: 7}
+		svc.On("GetLevel", mock.Anything, id).Return(level, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"available":7`)
+	})
+}
+
+func TestHandler_GetLevel_NotFound(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{bookId}", hdl.GetLevel)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return 404", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String(), nil)
+
+		svc.On("GetLevel", mock.Anything, id).Return(nil, errors.Wrap(repository.ErrBookNotFound, "test"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestHandler_GetLevel_UUID_Error(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{bookId}", hdl.GetLevel)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/123", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestHandler_GetMovements_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{bookId}/movements", hdl.GetMovements)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return the movement history", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/movements", nil)
+
+		movements := []model.Movement{
+			model.NewMovement(id, model.ReasonRestock, 5),
+			model.NewMovement(id, model.ReasonReservation, 2),
+		}
+		svc.On("GetMovements", mock.Anything, id).Return(movements, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"reason":"restock"`)
+		assert.Contains(t, r.Body.String(), `"reason":"reservation"`)
+	})
+}
+
+func TestHandler_GetMovements_Error(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{bookId}/movements", hdl.GetMovements)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return 500", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/movements", nil)
+
+		svc.On("GetMovements", mock.Anything, id).Return(nil, errors.New("error"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestHandler_AdjustStock(t *testing.T) {
+	id, _ := uuid.NewV4()
+	adminID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusCreated},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "book not found", svcErr: errors.Wrap(repository.ErrBookNotFound, "test"), wantStatus: http.StatusNotFound},
+		{name: "insufficient stock", svcErr: errors.Wrap(repository.ErrInsufficientStock, "test"), wantStatus: http.StatusConflict},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{bookId}/adjustments", hdl.AdjustStock)
+
+			adjustment := model.AdjustmentRequest{Reason: model.ReasonAdjustment, Quantity: -2, Note: "damaged in storage"}
+			payload, err := json.Marshal(adjustment)
+			require.NoError(t, err)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/adjustments", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+			svc.On("AdjustStock", mock.Anything, id, adminID.String(), adjustment).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_AdjustStock_BadBody(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{bookId}/adjustments", hdl.AdjustStock)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/adjustments", bytes.NewReader([]byte("{")))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.InventoryService) {
+	svc := &mocks.InventoryService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.NotificationService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewNotificationHandler(r chi.Router) {
+	r.Route("/me/notifications", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+
+		r.Get("/", h.GetPreferences)
+		r.Put("/", h.UpdatePreferences)
+	})
+}
+
+// GetPreferences
+//
+// @Summary Get my email preferences
+// @Description Lists every kind of email with whether the current user gets it. Required kinds, such as security notices, cannot be turned off.
+// @Tags notifications
+// @Produce json
+// @Success 200 {array} model.Preference "Preferences"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/notifications [get]
+func (h *Handler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.notification.GetPreferences"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	prefs, err := h.Svc.GetPreferences(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("error getting notification preferences", slog.String("error", err.Error()))
+		writeNotificationError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, prefs)
+}
+
+// UpdatePreferences
+//
+// @Summary Update my email preferences
+// @Description Turns kinds of email on or off, e.g. {"order_shipped": false}. Kinds left out keep their setting.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body model.PreferencesRequest true "Kind to enabled"
+// @Success 200 {array} model.Preference "Preferences"
+// @Failure 400 {object} response.ResponseError "Unknown kind, or a required kind turned off"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/notifications [put]
+func (h *Handler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.notification.UpdatePreferences"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.PreferencesRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	prefs, err := h.Svc.UpdatePreferences(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error updating notification preferences", slog.String("error", err.Error()))
+		writeNotificationError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, prefs)
+}
+
+func writeNotificationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewNotificationHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewNotificationHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/me/notifications/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetPreferences", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_GetPreferences(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetPreferences)
+
+	t.Run("it should list every kind with whether it is sent", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		svc.On("GetPreferences", mock.Anything, "123").Return(model.Preferences([]model.Kind{model.KindOrderShipped}), nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `{"kind":"order_shipped","enabled":false,"required":false}`)
+		assert.Contains(t, r.Body.String(), `{"kind":"password_changed","enabled":true,"required":true}`)
+	})
+
+	t.Run("it should return 401 without a user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestHandler_UpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "required kind turned off", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/", hdl.UpdatePreferences)
+
+			payload := []byte(`{"order_shipped":false,"order_refunded":true}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			want := model.PreferencesRequest{model.KindOrderShipped: false, model.KindOrderRefunded: true}
+			var prefs []model.Preference
+			if tt.svcErr == nil {
+				prefs = model.Preferences([]model.Kind{model.KindOrderShipped})
+			}
+			svc.On("UpdatePreferences", mock.Anything, "123", want).Return(prefs, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_UpdatePreferences_BadBody(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Put("/", hdl.UpdatePreferences)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/", bytes.NewReader([]byte(`["order_shipped"]`)))
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		svc.AssertNotCalled(t, "UpdatePreferences", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.NotificationService) {
+	svc := &mocks.NotificationService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -2,34 +2,97 @@ package order
 
 import (
 	"context"
+	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
-	_ "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	_ "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 )
 
 type Handler struct {
-	Log *slog.Logger
-	Svc interfaces.OrderService
+	Log         *slog.Logger
+	Svc         interfaces.OrderService
+	Shipments   interfaces.ShipmentService
+	Invoices    interfaces.InvoiceService
+	Idempotency *middle.Idempotency
+	Currency    *middle.Currency
+	Guest       *middle.Guest
 }
 
 func (h *Handler) NewOrderHandler(r chi.Router) {
 	r.Route("/order", func(r chi.Router) {
-		r.Get("/", h.GetUsersOrder)
+		r.Use(h.Currency.Handler)
 
-		r.Get("/{orderId}", h.GetUserOrderByUserID)
+		// The cart works without an account: anonymous visitors shop as guests.
+		r.Group(func(r chi.Router) {
+			r.Use(middle.WithOptionalAuth)
+			r.Use(h.Guest.Handler)
 
-		r.Post("/", h.CreateUserOrder)
+			r.Get("/", h.GetUsersOrder)
 
-		r.Post("/order", h.AddOrderItemIntoOrder)
+			r.With(h.Idempotency.Handler).Post("/", h.CreateUserOrder)
 
-		r.Put("/{orderId}", h.AlterUserOrder)
+			r.With(h.Idempotency.Handler).Post("/order", h.AddOrderItemIntoOrder)
+
+			r.Get("/promotions", h.GetPriceBreakdown)
+
+			r.Post("/promotions", h.ApplyPromotion)
+
+			r.Delete("/promotions/{code}", h.RemovePromotion)
+
+			r.Get("/shipping/options", h.GetShippingOptions)
+
+			r.Put("/shipping", h.SetShipping)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middle.WithAuth)
+
+			r.Get("/{orderId}", h.GetUserOrderByUserID)
+
+			r.With(h.Idempotency.Handler).Put("/{orderId}", h.AlterUserOrder)
+
+			r.Get("/{orderId}/history", h.GetStatusHistory)
+
+			r.Get("/{orderId}/shipments", h.GetShipments)
+
+			r.Get("/{orderId}/invoice.pdf", h.GetInvoice)
+
+			r.Post("/{orderId}/cancel", h.CancelOrder)
+		})
+	})
+
+	r.Route("/cart", func(r chi.Router) {
+		r.Use(h.Currency.Handler)
+		r.Use(middle.WithOptionalAuth)
+		r.Use(h.Guest.Handler)
+
+		r.Get("/", h.GetCart)
+
+		r.Get("/items", h.GetCartItems)
+
+		r.Get("/items/{bookId}", h.GetCartItem)
+
+		r.Put("/items/{bookId}", h.SetCartItemQuantity)
+
+		r.Delete("/items/{bookId}", h.RemoveCartItem)
 	})
 }
 
@@ -140,14 +203,18 @@ func (h *Handler) CreateUserOrder(w http.ResponseWriter, r *http.Request) {
 // AlterUserOrder
 //
 // @Summary Update user's order
-// @Description Updates an existing order by its ID and the user ID in the context
+// @Description Pays for one of the current user's orders through the configured payment provider
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param orderId path string true "Order ID"
+// @Param currency query string false "Currency to pay in, otherwise taken from the currency cookie or Accept-Language"
 // @Success 200 {string} string "Order paid successfully"
 // @Failure 400 {object} response.ResponseError "Missing or invalid order ID"
 // @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 402 {object} response.ResponseError "Payment declined"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "Order cannot be paid in its current status, stock ran out or an earlier payment is still being processed"
 // @Failure 500 {object} response.ResponseError "Internal server error"
 // @Router /api/v1/order/{orderId} [put]
 func (h *Handler) AlterUserOrder(w http.ResponseWriter, r *http.Request) {
@@ -171,10 +238,10 @@ func (h *Handler) AlterUserOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.Svc.AlterUserOrderByID(r.Context(), userID, orderID)
+	err := h.Svc.AlterUserOrderByID(r.Context(), userID, orderID, middle.CurrencyFromContext(r.Context()))
 	if err != nil {
 		h.Log.Error("failed to alter order", "error", err)
-		response.WriteError(w, r, http.StatusInternalServerError, err)
+		writeOrderError(w, r, err)
 		return
 	}
 
@@ -225,3 +292,658 @@ func (h *Handler) AddOrderItemIntoOrder(w http.ResponseWriter, r *http.Request)
 
 	response.WriteJson(w, r, http.StatusCreated, "Order Items Added")
 }
+
+// GetStatusHistory
+//
+// @Summary Get order status history
+// @Description Returns every status change of one of the current user's orders, oldest first
+// @Tags orders
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Success 200 {array} order.StatusHistoryEntry "Status history"
+// @Failure 400 {object} response.ResponseError "Invalid order ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/{orderId}/history [get]
+func (h *Handler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetStatusHistory"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	orderID := chi.URLParam(r, "orderId")
+
+	history, err := h.Svc.GetStatusHistory(r.Context(), userID, orderID)
+	if err != nil {
+		h.Log.Error("failed to get order status history", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, history)
+}
+
+// GetShipments
+//
+// @Summary Get the parcels of an order
+// @Description Lists the shipments of one of the current user's orders with their status, carrier and tracking link
+// @Tags orders
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Success 200 {array} shipment.Shipment "Shipments"
+// @Failure 400 {object} response.ResponseError "Invalid order ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/{orderId}/shipments [get]
+func (h *Handler) GetShipments(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetShipments"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	orderID := chi.URLParam(r, "orderId")
+
+	shipments, err := h.Shipments.GetCustomerShipments(r.Context(), userID, orderID)
+	if err != nil {
+		h.Log.Error("failed to get order shipments", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipments)
+}
+
+// GetInvoice
+//
+// @Summary Download the invoice of an order
+// @Description Returns the PDF invoice of a paid order. Invoices are numbered when the order is paid and never change; customers can download their own, admins any.
+// @Tags orders
+// @Produce application/pdf
+// @Param orderId path string true "Order ID"
+// @Success 200 {file} file "Invoice PDF"
+// @Failure 400 {object} response.ResponseError "Invalid order ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "Order has not been paid"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/{orderId}/invoice.pdf [get]
+func (h *Handler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetInvoice"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+	role, _ := r.Context().Value("role").(float64)
+
+	orderID := chi.URLParam(r, "orderId")
+
+	file, err := h.Invoices.GetInvoicePDF(r.Context(), userID, orderID, role == 1)
+	if err != nil {
+		h.Log.Error("failed to get invoice", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Reference+".pdf"))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Content); err != nil {
+		h.Log.Error("failed to write invoice", "error", err)
+	}
+}
+
+// CancelOrder
+//
+// @Summary Cancel an order
+// @Description Cancels an order, puts its books back in stock and refunds it if it was paid. Customers can cancel their own orders while they await payment or are paid but not yet shipped; admins can also cancel carts.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param orderId path string true "Order ID"
+// @Param request body order.CancelOrderRequest false "Cancellation reason"
+// @Success 200 {string} string "Order cancelled"
+// @Failure 400 {object} response.ResponseError "Invalid order ID or body"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "Order can no longer be cancelled"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/{orderId}/cancel [post]
+func (h *Handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.CancelOrder"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	role, _ := r.Context().Value("role").(float64)
+
+	orderID := chi.URLParam(r, "orderId")
+
+	var req orderModel.CancelOrderRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err := h.Svc.Cancel(r.Context(), userID, orderID, role == 1, req.Reason)
+	if err != nil {
+		h.Log.Error("failed to cancel order", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Order cancelled")
+}
+
+// GetPriceBreakdown
+//
+// @Summary Get the price breakdown of the user's cart
+// @Description Prices the current user's draft order: subtotal, what each applied promotion takes off, or why it no longer applies, and the total. Amounts are in the catalogue currency.
+// @Tags orders
+// @Produce json
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "No draft order"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/promotions [get]
+func (h *Handler) GetPriceBreakdown(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetPriceBreakdown"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	breakdown, err := h.Svc.GetPriceBreakdown(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("failed to price order", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// ApplyPromotion
+//
+// @Summary Apply a promotion code to the user's cart
+// @Description Applies a coupon or promotion code to the current user's draft order and returns the new price breakdown
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param request body promotion.ApplyRequest true "Promotion code"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 400 {object} response.ResponseError "Missing code"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown code or no draft order"
+// @Failure 422 {object} response.ResponseError "Code does not apply to this order"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/promotions [post]
+func (h *Handler) ApplyPromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.ApplyPromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req promotion.ApplyRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	breakdown, err := h.Svc.ApplyPromotion(r.Context(), userID, req.Code)
+	if err != nil {
+		h.Log.Error("failed to apply promotion", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// RemovePromotion
+//
+// @Summary Remove a promotion code from the user's cart
+// @Tags orders
+// @Produce json
+// @Param code path string true "Promotion code"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Code not applied or no draft order"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/promotions/{code} [delete]
+func (h *Handler) RemovePromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.RemovePromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	breakdown, err := h.Svc.RemovePromotion(r.Context(), userID, chi.URLParam(r, "code"))
+	if err != nil {
+		h.Log.Error("failed to remove promotion", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// GetShippingOptions
+//
+// @Summary Quote delivery for the user's cart
+// @Description Lists the delivery methods that take the current user's draft order to one of their addresses, with what each costs for the cart's weight, cheapest first. Prices are in the catalogue currency.
+// @Tags orders
+// @Produce json
+// @Param address_id query string true "Address ID"
+// @Success 200 {array} shipping.Quote "Delivery options"
+// @Failure 400 {object} response.ResponseError "Invalid address ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown address or no draft order"
+// @Failure 422 {object} response.ResponseError "No delivery to this address"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/shipping/options [get]
+func (h *Handler) GetShippingOptions(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetShippingOptions"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	addressID, err := uuid.FromString(r.URL.Query().Get("address_id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	quotes, err := h.Svc.GetShippingOptions(r.Context(), userID, addressID)
+	if err != nil {
+		h.Log.Error("failed to quote shipping", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, quotes)
+}
+
+// SetShipping
+//
+// @Summary Choose addresses and delivery for the user's cart
+// @Description Copies a shipping address, and a billing address which defaults to it, from the address book onto the current user's draft order, picks a delivery method and returns the new price breakdown. The order is taxed where it is shipped.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param request body order.ShippingRequest true "Addresses and delivery method"
+// @Success 200 {object} promotion.Breakdown "Price breakdown"
+// @Failure 400 {object} response.ResponseError "Missing delivery method"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown address or no draft order"
+// @Failure 422 {object} response.ResponseError "Delivery method does not go to this address"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/order/shipping [put]
+func (h *Handler) SetShipping(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.SetShipping"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req orderModel.ShippingRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	breakdown, err := h.Svc.SetShipping(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("failed to set shipping", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, breakdown)
+}
+
+// GetCart
+//
+// @Summary Get the user's cart
+// @Description Lists the lines of the current user's cart with the number of copies and a price breakdown, applied discounts included. Prices are in the requested currency. A user without a cart gets an empty one.
+// @Tags cart
+// @Produce json
+// @Param currency query string false "Currency to show prices in"
+// @Success 200 {object} cart.Cart "Cart"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart [get]
+func (h *Handler) GetCart(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetCart"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	c, err := h.Svc.GetCart(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to get cart", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, c)
+}
+
+// GetCartItems
+//
+// @Summary List the lines of the user's cart
+// @Tags cart
+// @Produce json
+// @Param currency query string false "Currency to show prices in"
+// @Success 200 {array} orderItem.OrderItemFull "Cart lines"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/items [get]
+func (h *Handler) GetCartItems(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetCartItems"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	c, err := h.Svc.GetCart(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to get cart", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, c.Items)
+}
+
+// GetCartItem
+//
+// @Summary Get one line of the user's cart
+// @Tags cart
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Param currency query string false "Currency to show prices in"
+// @Success 200 {object} orderItem.OrderItemFull "Cart line"
+// @Failure 400 {object} response.ResponseError "Invalid book ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Book is not in the cart"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/items/{bookId} [get]
+func (h *Handler) GetCartItem(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.GetCartItem"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	c, err := h.Svc.GetCart(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to get cart", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	item, ok := c.Item(bookID)
+	if !ok {
+		response.WriteError(w, r, http.StatusNotFound, repository.ErrCartItemNotFound)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, item)
+}
+
+// SetCartItemQuantity
+//
+// @Summary Set how many copies of a book the user's cart holds
+// @Description Sets the quantity of a cart line to the given number, adding the book if it is not in the cart yet, and returns the updated cart. Stock is reserved or released by the difference. Zero takes the book out of the cart.
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Param currency query string false "Currency to show prices in"
+// @Param request body cart.QuantityRequest true "Quantity"
+// @Success 200 {object} cart.Cart "Updated cart"
+// @Failure 400 {object} response.ResponseError "Invalid book ID or quantity"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Unknown book, or removing a book that is not in the cart"
+// @Failure 409 {object} response.ResponseError "Not enough stock"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/items/{bookId} [put]
+func (h *Handler) SetCartItemQuantity(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.SetCartItemQuantity"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req cart.QuantityRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.Svc.SetCartItemQuantity(r.Context(), userID, bookID, req.Quantity); err != nil {
+		h.Log.Error("failed to set cart item quantity", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	c, err := h.Svc.GetCart(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to get cart", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, c)
+}
+
+// RemoveCartItem
+//
+// @Summary Remove a book from the user's cart
+// @Description Takes a line out of the cart, releasing its reserved stock, and returns the updated cart.
+// @Tags cart
+// @Produce json
+// @Param bookId path string true "Book ID"
+// @Param currency query string false "Currency to show prices in"
+// @Success 200 {object} cart.Cart "Updated cart"
+// @Failure 400 {object} response.ResponseError "Invalid book ID"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Book is not in the cart"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/cart/items/{bookId} [delete]
+func (h *Handler) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.order.RemoveCartItem"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.Svc.RemoveCartItem(r.Context(), userID, bookID); err != nil {
+		h.Log.Error("failed to remove cart item", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	c, err := h.Svc.GetCart(r.Context(), userID, middle.CurrencyFromContext(r.Context()))
+	if err != nil {
+		h.Log.Error("failed to get cart", "error", err)
+		writeOrderError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, c)
+}
+
+// writeOrderError maps service errors onto status codes. An illegal status
+// move is a conflict with the order's current state rather than a bad request.
+func writeOrderError(w http.ResponseWriter, r *http.Request, err error) {
+	var transitionErr *orderModel.TransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		response.WriteError(w, r, http.StatusConflict, transitionErr)
+	case errors.Is(err, promotion.ErrIneligible), errors.Is(err, shipping.ErrUnavailable):
+		response.WriteError(w, r, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, invoice.ErrNotInvoiced):
+		response.WriteError(w, r, http.StatusConflict, invoice.ErrNotInvoiced)
+	case errors.Is(err, repository.ErrInsufficientStock):
+		response.WriteError(w, r, http.StatusConflict, repository.ErrInsufficientStock)
+	case errors.Is(err, service.ErrPaymentDeclined):
+		response.WriteError(w, r, http.StatusPaymentRequired, err)
+	case errors.Is(err, service.ErrPaymentPending):
+		response.WriteError(w, r, http.StatusConflict, service.ErrPaymentPending)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -3,11 +3,23 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/go-chi/chi"
 	"github.com/gofrs/uuid"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"net/http"
@@ -17,13 +29,7 @@ import (
 )
 
 func TestHandler_NewOrderHandler(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, _ := newTestHandler()
 
 	router := chi.NewRouter()
 
@@ -33,12 +39,7 @@ func TestHandler_NewOrderHandler(t *testing.T) {
 }
 
 func TestHandler_GetUsersOrder_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Get("/orders", hdl.GetUsersOrder)
@@ -72,12 +73,7 @@ func TestHandler_GetUsersOrder_Success(t *testing.T) {
 }
 
 func TestHandler_GetUsersOrder_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Get("/orders", hdl.GetUsersOrder)
@@ -111,12 +107,7 @@ func TestHandler_GetUsersOrder_Error(t *testing.T) {
 }
 
 func TestHandler_GetUsersOrderByID_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Get("/{orderId}", hdl.GetUserOrderByUserID)
@@ -141,12 +132,7 @@ func TestHandler_GetUsersOrderByID_Success(t *testing.T) {
 }
 
 func TestHandler_GetUsersOrderByID_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Get("/{orderId}", hdl.GetUserOrderByUserID)
@@ -169,12 +155,7 @@ func TestHandler_GetUsersOrderByID_Error(t *testing.T) {
 }
 
 func TestHandler_CreateOrder_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 
@@ -197,12 +178,7 @@ func TestHandler_CreateOrder_Success(t *testing.T) {
 }
 
 func TestHandler_CreateOrder_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/", hdl.CreateUserOrder)
@@ -224,12 +200,7 @@ func TestHandler_CreateOrder_Error(t *testing.T) {
 }
 
 func TestHandler_CreateOrder_User_Id_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 
@@ -249,12 +220,7 @@ func TestHandler_CreateOrder_User_Id_Error(t *testing.T) {
 }
 
 func TestHandler_AlterUserOrder_User_Id_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 
@@ -273,12 +239,7 @@ func TestHandler_AlterUserOrder_User_Id_Error(t *testing.T) {
 }
 
 func TestHandler_AlterUserOrder_Object_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 
@@ -302,12 +263,7 @@ func TestHandler_AlterUserOrder_Object_Error(t *testing.T) {
 }
 
 func TestHandler_AlterUserOrder_Svc_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/{orderId}", hdl.AlterUserOrder)
@@ -322,7 +278,7 @@ func TestHandler_AlterUserOrder_Svc_Success(t *testing.T) {
 		ctx := context.WithValue(req.Context(), "user_id", "123")
 		req = req.WithContext(ctx)
 
-		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String()).Return(nil)
+		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String(), money.USD).Return(nil)
 
 		router.ServeHTTP(r, req)
 
@@ -330,13 +286,29 @@ func TestHandler_AlterUserOrder_Svc_Success(t *testing.T) {
 	})
 }
 
+func TestHandler_AlterUserOrder_ChargesInChosenCurrency(t *testing.T) {
+	hdl, svc := newTestHandler()
+	hdl.Currency = &middle.Currency{Supported: []money.Currency{money.USD, money.EUR}}
+
+	router := chi.NewRouter()
+	router.With(hdl.Currency.Handler).Post("/{orderId}", hdl.AlterUserOrder)
+
+	id, _ := uuid.NewV4()
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"?currency=EUR", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+	svc.On("AlterUserOrderByID", mock.Anything, "123", id.String(), money.EUR).Return(nil)
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	svc.AssertExpectations(t)
+}
+
 func TestHandler_AlterUserOrder_Svc_Error(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/{orderId}", hdl.AlterUserOrder)
@@ -351,7 +323,7 @@ func TestHandler_AlterUserOrder_Svc_Error(t *testing.T) {
 		ctx := context.WithValue(req.Context(), "user_id", "123")
 		req = req.WithContext(ctx)
 
-		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String()).Return(errors.New("error"))
+		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String(), money.USD).Return(errors.New("error"))
 
 		router.ServeHTTP(r, req)
 
@@ -360,12 +332,7 @@ func TestHandler_AlterUserOrder_Svc_Error(t *testing.T) {
 }
 
 func TestHandler_AddOrderItemIntoOrder_Success(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/", hdl.AddOrderItemIntoOrder)
@@ -387,12 +354,7 @@ func TestHandler_AddOrderItemIntoOrder_Success(t *testing.T) {
 }
 
 func TestHandler_AddOrderItemIntoOrder_NoUserID(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/", hdl.AddOrderItemIntoOrder)
@@ -411,12 +373,7 @@ func TestHandler_AddOrderItemIntoOrder_NoUserID(t *testing.T) {
 }
 
 func TestHandler_AddOrderItemIntoOrder_NoPayload(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/", hdl.AddOrderItemIntoOrder)
@@ -438,12 +395,7 @@ func TestHandler_AddOrderItemIntoOrder_NoPayload(t *testing.T) {
 }
 
 func TestHandler_AddOrderItemIntoOrder_MissingItems(t *testing.T) {
-	log := logger.New(logger.EnvLocal)
-	svc := mocks.OrderService{}
-	hdl := Handler{
-		Svc: &svc,
-		Log: log,
-	}
+	hdl, svc := newTestHandler()
 
 	router := chi.NewRouter()
 	router.Post("/", hdl.AddOrderItemIntoOrder)
@@ -463,3 +415,526 @@ func TestHandler_AddOrderItemIntoOrder_MissingItems(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
 }
+
+func TestHandler_AlterUserOrder_Illegal_Transition(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{orderId}", hdl.AlterUserOrder)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("should return conflict when the order cannot be paid", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String(), nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		transitionErr := &model.TransitionError{From: model.StatusCancelled, To: model.StatusPaid}
+		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String(), money.USD).Return(pkgerrors.Wrap(transitionErr, "service.order.Transition"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestHandler_AlterUserOrder_Not_Found(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{orderId}", hdl.AlterUserOrder)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("should return not found for someone else's order", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String(), nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		svc.On("AlterUserOrderByID", mock.Anything, "123", id.String(), money.USD).Return(fmt.Errorf("op: %w", service.ErrNotFound))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestHandler_GetStatusHistory(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{orderId}/history", hdl.GetStatusHistory)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("error - no user ID in context", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/history", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("success - should return status history", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/history", nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		history := []model.StatusHistoryEntry{
+			{OrderID: id, FromStatus: model.StatusDraft, ToStatus: model.StatusPendingPayment, ActorKind: model.ActorCustomer},
+		}
+		svc.On("GetStatusHistory", mock.Anything, "123", id.String()).Return(history, nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "pending_payment")
+	})
+
+	t.Run("error - invalid order ID", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/not-a-uuid/history", nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		svc.On("GetStatusHistory", mock.Anything, "123", "not-a-uuid").Return(nil, fmt.Errorf("op: %w", service.ErrValid)).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestHandler_CancelOrder(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{orderId}/cancel", hdl.CancelOrder)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("error - no user ID in context", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/cancel", http.NoBody)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("success - customer cancels without a reason", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/cancel", http.NoBody)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		svc.On("Cancel", mock.Anything, "123", id.String(), false, "").Return(nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("success - admin cancels with a reason", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/cancel", strings.NewReader(`{"reason":"out of print"}`))
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		ctx = context.WithValue(ctx, "role", float64(1))
+		req = req.WithContext(ctx)
+
+		svc.On("Cancel", mock.Anything, "123", id.String(), true, "out of print").Return(nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("error - malformed body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/cancel", strings.NewReader(`{"reason":`))
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("error - order already shipped", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/cancel", http.NoBody)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		transitionErr := &model.TransitionError{From: model.StatusShipped, To: model.StatusCancelled}
+		svc.On("Cancel", mock.Anything, "123", id.String(), false, "").Return(transitionErr).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestHandler_ApplyPromotion(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "usage limit reached", svcErr: fmt.Errorf("test: %w", promotion.ErrUsageLimit), wantStatus: http.StatusUnprocessableEntity},
+		{name: "not stackable", svcErr: fmt.Errorf("test: %w", promotion.ErrNotStackable), wantStatus: http.StatusUnprocessableEntity},
+		{name: "unknown code", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/promotions", hdl.ApplyPromotion)
+
+			var breakdown *promotion.Breakdown
+			if tt.svcErr == nil {
+				breakdown = &promotion.Breakdown{
+					Subtotal:      money.New(2000, money.USD),
+					DiscountTotal: money.New(200, money.USD),
+					Total:         money.New(1800, money.USD),
+				}
+			}
+			svc.On("ApplyPromotion", mock.Anything, "123", "spring10").Return(breakdown, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/promotions", strings.NewReader(`{"code":"spring10"}`))
+
+			ctx := context.WithValue(req.Context(), "user_id", "123")
+			req = req.WithContext(ctx)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_GetShippingOptions(t *testing.T) {
+	addressID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		query      string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", query: "?address_id=" + addressID.String(), wantStatus: http.StatusOK},
+		{name: "nothing goes there", query: "?address_id=" + addressID.String(), svcErr: fmt.Errorf("test: %w", shipping.ErrUnavailable), wantStatus: http.StatusUnprocessableEntity},
+		{name: "someone else's address", query: "?address_id=" + addressID.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "missing address", query: "", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/shipping/options", hdl.GetShippingOptions)
+
+			var quotes []shipping.Quote
+			if tt.svcErr == nil {
+				quotes = []shipping.Quote{{Method: "standard", Name: "Standard", Price: money.New(399, money.USD), MinDays: 3, MaxDays: 7}}
+			}
+			svc.On("GetShippingOptions", mock.Anything, "123", addressID).Return(quotes, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/shipping/options"+tt.query, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, r.Body.String(), `"method":"standard"`)
+			}
+		})
+	}
+}
+
+func TestHandler_SetShipping(t *testing.T) {
+	addressID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "method does not go there", svcErr: fmt.Errorf("test: %w", shipping.ErrUnavailable), wantStatus: http.StatusUnprocessableEntity},
+		{name: "no delivery method", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "unknown address", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/shipping", hdl.SetShipping)
+
+			var breakdown *promotion.Breakdown
+			if tt.svcErr == nil {
+				breakdown = &promotion.Breakdown{
+					Subtotal:       money.New(2000, money.USD),
+					ShippingTotal:  money.New(399, money.USD),
+					DeliveryMethod: "standard",
+					Total:          money.New(2399, money.USD),
+				}
+			}
+			want := model.ShippingRequest{ShippingAddressID: addressID, DeliveryMethod: "standard"}
+			svc.On("SetShipping", mock.Anything, "123", want).Return(breakdown, tt.svcErr)
+
+			payload := fmt.Sprintf(`{"shipping_address_id":%q,"delivery_method":"standard"}`, addressID)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/shipping", strings.NewReader(payload))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_GetShipments(t *testing.T) {
+	hdl, _ := newTestHandler()
+	shipments := &mocks.ShipmentService{}
+	hdl.Shipments = shipments
+
+	router := chi.NewRouter()
+	router.Get("/{orderId}/shipments", hdl.GetShipments)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("success - should return parcels with tracking", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/shipments", nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "123")
+		req = req.WithContext(ctx)
+
+		parcels := []shipment.Shipment{
+			{OrderID: id, Status: shipment.StatusShipped, Carrier: "ups", TrackingNumber: "1Z999", TrackingURL: shipment.TrackingURL("ups", "1Z999")},
+		}
+		shipments.On("GetCustomerShipments", mock.Anything, "123", id.String()).Return(parcels, nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"tracking_url":"https://www.ups.com/track?tracknum=1Z999"`)
+	})
+
+	t.Run("error - someone else's order", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/shipments", nil)
+
+		ctx := context.WithValue(req.Context(), "user_id", "456")
+		req = req.WithContext(ctx)
+
+		shipments.On("GetCustomerShipments", mock.Anything, "456", id.String()).
+			Return(nil, fmt.Errorf("service.shipment.GetCustomerShipments: %w", service.ErrNotFound)).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestHandler_GetInvoice(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		role       float64
+		file       *invoice.File
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "owner downloads the pdf", file: &invoice.File{Reference: "INV-000042", Content: []byte("%PDF-1.4")}, wantStatus: http.StatusOK},
+		{name: "admin downloads any invoice", role: 1, file: &invoice.File{Reference: "INV-000043", Content: []byte("%PDF-1.4")}, wantStatus: http.StatusOK},
+		{name: "someone else's order", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "not paid yet", svcErr: pkgerrors.Wrap(invoice.ErrNotInvoiced, "test"), wantStatus: http.StatusConflict},
+		{name: "invalid order id", svcErr: fmt.Errorf("invalid orderID format: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, _ := newTestHandler()
+			invoices := &mocks.InvoiceService{}
+			hdl.Invoices = invoices
+
+			router := chi.NewRouter()
+			router.Get("/{orderId}/invoice.pdf", hdl.GetInvoice)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/invoice.pdf", nil)
+
+			ctx := context.WithValue(req.Context(), "user_id", "123")
+			if tt.role != 0 {
+				ctx = context.WithValue(ctx, "role", tt.role)
+			}
+			req = req.WithContext(ctx)
+
+			invoices.On("GetInvoicePDF", mock.Anything, "123", id.String(), tt.role == 1).Return(tt.file, tt.svcErr).Once()
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			invoices.AssertExpectations(t)
+			if tt.file != nil {
+				assert.Equal(t, "application/pdf", r.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="`+tt.file.Reference+`.pdf"`, r.Header().Get("Content-Disposition"))
+				assert.Equal(t, string(tt.file.Content), r.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_GetCartItem(t *testing.T) {
+	bookID, _ := uuid.NewV4()
+	otherID, _ := uuid.NewV4()
+	orderID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		bookID     string
+		wantStatus int
+	}{
+		{name: "book in the cart", bookID: bookID.String(), wantStatus: http.StatusOK},
+		{name: "book not in the cart", bookID: otherID.String(), wantStatus: http.StatusNotFound},
+		{name: "invalid book id", bookID: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/cart/items/{bookId}", hdl.GetCartItem)
+
+			c := cart.New(orderID, []orderItem.OrderItemFull{
+				{BookID: bookID, Name: "Dune", Quantity: 2, Price: money.New(1000, money.USD)},
+			}, promotion.Breakdown{Subtotal: money.New(2000, money.USD), Total: money.New(2000, money.USD)})
+			svc.On("GetCart", mock.Anything, "123", money.DefaultCurrency).Return(&c, nil)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cart/items/"+tt.bookID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, r.Body.String(), `"quantity":2`)
+			}
+		})
+	}
+}
+
+func TestHandler_SetCartItemQuantity(t *testing.T) {
+	bookID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		bookID     string
+		payload    string
+		quantity   int
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "lower the quantity", bookID: bookID.String(), payload: `{"quantity":2}`, quantity: 2, wantStatus: http.StatusOK},
+		{name: "zero removes the line", bookID: bookID.String(), payload: `{"quantity":0}`, quantity: 0, wantStatus: http.StatusOK},
+		{name: "not enough stock", bookID: bookID.String(), payload: `{"quantity":50}`, quantity: 50, svcErr: pkgerrors.Wrap(repository.ErrInsufficientStock, "test"), wantStatus: http.StatusConflict},
+		{name: "quantity out of range", bookID: bookID.String(), payload: `{"quantity":500}`, quantity: 500, svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "unknown book", bookID: bookID.String(), payload: `{"quantity":1}`, quantity: 1, svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid book id", bookID: "not-a-uuid", payload: `{"quantity":1}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body", bookID: bookID.String(), payload: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/cart/items/{bookId}", hdl.SetCartItemQuantity)
+
+			c := cart.Empty()
+			svc.On("SetCartItemQuantity", mock.Anything, "123", bookID, tt.quantity).Return(tt.svcErr)
+			svc.On("GetCart", mock.Anything, "123", money.DefaultCurrency).Return(&c, nil)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/cart/items/"+tt.bookID, strings.NewReader(tt.payload))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				svc.AssertCalled(t, "SetCartItemQuantity", mock.Anything, "123", bookID, tt.quantity)
+				assert.Contains(t, r.Body.String(), `"summary"`)
+			}
+		})
+	}
+}
+
+func TestHandler_RemoveCartItem(t *testing.T) {
+	bookID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "book not in the cart", svcErr: fmt.Errorf("test: %w: %w", repository.ErrCartItemNotFound, service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/cart/items/{bookId}", hdl.RemoveCartItem)
+
+			c := cart.Empty()
+			svc.On("RemoveCartItem", mock.Anything, "123", bookID).Return(tt.svcErr)
+			svc.On("GetCart", mock.Anything, "123", money.DefaultCurrency).Return(&c, nil)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/cart/items/"+bookID.String(), nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.OrderService) {
+	svc := &mocks.OrderService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,175 @@
+package payment
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	_ "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// SignatureHeader carries the provider's signature of the raw webhook body.
+const SignatureHeader = "X-Payment-Signature"
+
+const maxWebhookBody = 1 << 20
+
+type Handler struct {
+	Svc interfaces.PaymentService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewPaymentHandler(r chi.Router) {
+	r.Route("/payments", func(r chi.Router) {
+		r.Post("/webhook", h.Webhook)
+	})
+
+	r.Route("/admin/refunds", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetRefunds)
+		r.Post("/{refundId}/issued", h.ResolveRefund)
+	})
+}
+
+// Webhook
+//
+// @Summary Receive a payment provider webhook
+// @Description Verifies the signature of a provider notification and applies it to the payment and its order. Redeliveries of an event that was already applied are acknowledged without effect.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "Signature of the raw request body"
+// @Success 200 {string} string "Webhook processed"
+// @Failure 400 {object} response.ResponseError "Unreadable body"
+// @Failure 401 {object} response.ResponseError "Invalid signature"
+// @Failure 409 {object} response.ResponseError "Another delivery of the event is being applied, retry later"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/payments/webhook [post]
+func (h *Handler) Webhook(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.payment.Webhook"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		h.Log.Error("failed to read webhook body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.HandleWebhook(r.Context(), payload, r.Header.Get(SignatureHeader))
+	if err != nil {
+		h.Log.Error("failed to handle webhook", slog.String("error", err.Error()))
+		if errors.Is(err, paymentModel.ErrInvalidSignature) {
+			response.WriteError(w, r, http.StatusUnauthorized, paymentModel.ErrInvalidSignature)
+			return
+		}
+		if errors.Is(err, repository.ErrWebhookEventBusy) {
+			response.WriteError(w, r, http.StatusConflict, repository.ErrWebhookEventBusy)
+			return
+		}
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Webhook processed")
+}
+
+// GetRefunds
+//
+// @Summary List refunds
+// @Description Lists refunds oldest first. Without a status it lists every refund that has not gone out yet: owed ones the retrier still sends, and manual ones staff have to issue by hand.
+// @Tags payments
+// @Produce json
+// @Param status query string false "owed, manual or issued"
+// @Success 200 {array} refund.Refund "Refunds"
+// @Failure 400 {object} response.ResponseError "Unknown status"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/refunds [get]
+func (h *Handler) GetRefunds(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.payment.GetRefunds"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	refunds, err := h.Svc.GetRefunds(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		h.Log.Error("error getting refunds", slog.String("error", err.Error()))
+		writeRefundError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, refunds)
+}
+
+// ResolveRefund
+//
+// @Summary Record a refund issued by hand
+// @Description Marks a refund as issued after staff paid it back outside the payment provider.
+// @Tags payments
+// @Produce json
+// @Param refundId path string true "Refund ID"
+// @Success 200 {object} refund.Refund "Refund"
+// @Failure 400 {object} response.ResponseError "Invalid refund ID"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 404 {object} response.ResponseError "Refund not found"
+// @Failure 409 {object} response.ResponseError "Refund already issued"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/refunds/{refundId}/issued [post]
+func (h *Handler) ResolveRefund(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.payment.ResolveRefund"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "refundId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	refund, err := h.Svc.ResolveRefund(r.Context(), actorID, id)
+	if err != nil {
+		h.Log.Error("error resolving refund", slog.String("error", err.Error()))
+		writeRefundError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, refund)
+}
+
+func writeRefundError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	case errors.Is(err, repository.ErrRefundIssued):
+		response.WriteError(w, r, http.StatusConflict, repository.ErrRefundIssued)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,204 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_NewPaymentHandler(t *testing.T) {
+	log := logger.New(logger.EnvLocal)
+	svc := mocks.PaymentService{}
+
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	t.Run("it should return no errors", func(t *testing.T) {
+		hdl.NewPaymentHandler(router)
+	})
+}
+
+func TestHandler_Webhook(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/webhook", hdl.Webhook)
+
+	payload := `{"id":"evt_1","type":"payment.succeeded","provider_ref":"fake_pi_1"}`
+
+	t.Run("success - signed event is handed to the service", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		req.Header.Set(SignatureHeader, "good")
+
+		svc.On("HandleWebhook", mock.Anything, []byte(payload), "good").Return(nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		req.Header.Set(SignatureHeader, "bad")
+
+		svc.On("HandleWebhook", mock.Anything, []byte(payload), "bad").
+			Return(pkgerrors.Wrap(paymentModel.ErrInvalidSignature, "service.payment.HandleWebhook")).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("error - another delivery is applying the event", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		req.Header.Set(SignatureHeader, "good")
+
+		svc.On("HandleWebhook", mock.Anything, []byte(payload), "good").
+			Return(pkgerrors.Wrap(repository.ErrWebhookEventBusy, "service.payment.HandleWebhook")).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("error - service failure", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		req.Header.Set(SignatureHeader, "good")
+
+		svc.On("HandleWebhook", mock.Anything, []byte(payload), "good").Return(errors.New("error")).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestHandler_NewPaymentHandler_RefundsRequireAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewPaymentHandler(router)
+
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/refunds"},
+		{http.MethodPost, "/admin/refunds/" + uuid.Must(uuid.NewV4()).String() + "/issued"},
+	} {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+	}
+	svc.AssertNotCalled(t, "GetRefunds", mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetRefunds(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		status     string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success - refunds not issued yet", wantStatus: http.StatusOK},
+		{name: "success - by status", query: "?status=manual", status: "manual", wantStatus: http.StatusOK},
+		{name: "unknown status", query: "?status=lost", status: "lost", svcErr: service.ErrValid, wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/", hdl.GetRefunds)
+
+			var refunds []refundModel.Refund
+			if tt.svcErr == nil {
+				refunds = []refundModel.Refund{{Status: refundModel.StatusManual}}
+			}
+			svc.On("GetRefunds", mock.Anything, tt.status).Return(refunds, tt.svcErr).Once()
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ResolveRefund(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4()).String()
+	refundID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", id: refundID.String(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "not found", id: refundID.String(), svcErr: service.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "already issued", id: refundID.String(), svcErr: pkgerrors.Wrap(repository.ErrRefundIssued, "service.payment.ResolveRefund"), wantStatus: http.StatusConflict},
+		{name: "internal error", id: refundID.String(), svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{refundId}/issued", hdl.ResolveRefund)
+
+			var refund *refundModel.Refund
+			if tt.svcErr == nil {
+				refund = &refundModel.Refund{ID: refundID, Status: refundModel.StatusIssued}
+			}
+			svc.On("ResolveRefund", mock.Anything, adminID, refundID).Return(refund, tt.svcErr).Once()
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+tt.id+"/issued", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				svc.AssertNotCalled(t, "ResolveRefund", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.PaymentService) {
+	svc := &mocks.PaymentService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
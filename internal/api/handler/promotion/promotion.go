@@ -0,0 +1,233 @@
+package promotion
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.PromotionService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewPromotionHandler(r chi.Router) {
+	r.Route("/admin/promotions", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetPromotions)
+		r.Post("/", h.CreatePromotion)
+		r.Get("/{promotionId}", h.GetPromotionByID)
+		r.Put("/{promotionId}", h.UpdatePromotion)
+		r.Delete("/{promotionId}", h.DeactivatePromotion)
+	})
+}
+
+// GetPromotions
+//
+// @Summary List promotions
+// @Description Lists every promotion, newest first, with how often each has been redeemed
+// @Tags promotions
+// @Produce json
+// @Success 200 {array} model.Promotion "Promotions"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/promotions [get]
+func (h *Handler) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.promotion.GetPromotions"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	promotions, err := h.Svc.GetPromotions(r.Context())
+	if err != nil {
+		h.Log.Error("error getting promotions", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, promotions)
+}
+
+// GetPromotionByID
+//
+// @Summary Get a promotion
+// @Tags promotions
+// @Produce json
+// @Param promotionId path string true "Promotion ID"
+// @Success 200 {object} model.Promotion "Promotion"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Promotion not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/promotions/{promotionId} [get]
+func (h *Handler) GetPromotionByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.promotion.GetPromotionByID"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "promotionId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	promotion, err := h.Svc.GetPromotionByID(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting promotion", slog.String("error", err.Error()))
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, promotion)
+}
+
+// CreatePromotion
+//
+// @Summary Create a promotion
+// @Description Creates a percentage, fixed-amount or buy-X-get-Y promotion, optionally limited to a category or author, a minimum order value, a validity window and usage limits. Amounts are in the catalogue currency.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param request body model.Request true "Promotion"
+// @Success 201 {string} string "Promotion ID"
+// @Failure 400 {object} response.ResponseError "Invalid input or code already in use"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/promotions [post]
+func (h *Handler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.promotion.CreatePromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.Svc.CreatePromotion(r.Context(), req)
+	if err != nil {
+		h.Log.Error("error creating promotion", slog.String("error", err.Error()))
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, id.String())
+}
+
+// UpdatePromotion
+//
+// @Summary Update a promotion
+// @Description Replaces a promotion's terms. Orders already paid keep the discount they were given.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotionId path string true "Promotion ID"
+// @Param request body model.Request true "Promotion"
+// @Success 200 {string} string "Promotion updated"
+// @Failure 400 {object} response.ResponseError "Invalid input or code already in use"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Promotion not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/promotions/{promotionId} [put]
+func (h *Handler) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.promotion.UpdatePromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "promotionId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.UpdatePromotion(r.Context(), id, req)
+	if err != nil {
+		h.Log.Error("error updating promotion", slog.String("error", err.Error()))
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Promotion updated")
+}
+
+// DeactivatePromotion
+//
+// @Summary Deactivate a promotion
+// @Description Switches a promotion off. It is kept for the orders that used it; carts holding the code stop getting the discount.
+// @Tags promotions
+// @Produce json
+// @Param promotionId path string true "Promotion ID"
+// @Success 200 {string} string "Promotion deactivated"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Promotion not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/promotions/{promotionId} [delete]
+func (h *Handler) DeactivatePromotion(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.promotion.DeactivatePromotion"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "promotionId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeactivatePromotion(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error deactivating promotion", slog.String("error", err.Error()))
+		writePromotionError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Promotion deactivated")
+}
+
+func writePromotionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,233 @@
+package promotion
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewPromotionHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewPromotionHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/promotions/", http.NoBody)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetPromotions", mock.Anything)
+	})
+}
+
+func TestHandler_GetPromotions_Success(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetPromotions)
+
+	t.Run("it should list promotions", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", http.NoBody)
+
+		promotions := []model.Promotion{{
+			Code:    "SPRING10",
+			Kind:    model.KindPercentage,
+			Percent: 10,
+			Scope:   model.ScopeOrder,
+			Amount:  money.Zero(money.USD),
+		}}
+		svc.On("GetPromotions", mock.Anything).Return(promotions, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"code":"SPRING10"`)
+		assert.Contains(t, r.Body.String(), `"kind":"percentage"`)
+	})
+}
+
+func TestHandler_GetPromotionByID(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", path: "/" + id.String(), wantStatus: http.StatusOK},
+		{name: "not found", path: "/" + id.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "/123", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/{promotionId}", hdl.GetPromotionByID)
+
+			var promotion *model.Promotion
+			if tt.svcErr == nil {
+				promotion = &model.Promotion{ID: id, Code: "SPRING10"}
+			}
+			svc.On("GetPromotionByID", mock.Anything, id).Return(promotion, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, http.NoBody)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_CreatePromotion(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusCreated},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.CreatePromotion)
+
+			payload := []byte(`{"code":"orwell5","kind":"fixed","amount":"5.00","scope":"author","scope_value":"Orwell","per_user_limit":1,"active":true}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+
+			matches := mock.MatchedBy(func(req model.Request) bool {
+				return req.Code == "orwell5" && req.Kind == model.KindFixed &&
+					req.Amount.Amount() == 500 && req.ScopeValue == "Orwell" && req.PerUserLimit == 1
+			})
+			svc.On("CreatePromotion", mock.Anything, matches).Return(id, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.svcErr == nil {
+				assert.Contains(t, r.Body.String(), id.String())
+			}
+		})
+	}
+}
+
+func TestHandler_CreatePromotion_BadBody(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/", hdl.CreatePromotion)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{")))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		svc.AssertNotCalled(t, "CreatePromotion", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_UpdatePromotion(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "code taken", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/{promotionId}", hdl.UpdatePromotion)
+
+			payload := []byte(`{"code":"3FOR2","kind":"buy_x_get_y","buy_quantity":2,"get_quantity":1,"stackable":true,"active":true}`)
+
+			r := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPut, "/"+id.String(), bytes.NewReader(payload))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			svc.On("UpdatePromotion", mock.Anything, id, mock.AnythingOfType("promotion.Request")).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeactivatePromotion(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/{promotionId}", hdl.DeactivatePromotion)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/"+id.String(), http.NoBody)
+
+			svc.On("DeactivatePromotion", mock.Anything, id).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.PromotionService) {
+	svc := &mocks.PromotionService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
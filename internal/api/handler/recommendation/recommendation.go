@@ -0,0 +1,126 @@
+package recommendation
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc   interfaces.RecommendationService
+	Guest *middle.Guest
+	Log   *slog.Logger
+}
+
+func (h *Handler) NewRecommendationHandler(r chi.Router) {
+	r.Get("/book/{id}/recommendations", h.GetRecommendations)
+	r.With(middle.WithOptionalAuth, h.Guest.Handler).Get("/me/cart/recommendations", h.GetCartRecommendations)
+}
+
+// GetRecommendations
+//
+// @Summary Customers also bought
+// @Description Returns books in stock that customers bought together with this one. Books with little purchase history get suggestions by the same author or in the same category instead.
+// @Tags recommendations
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param limit query int false "Number of suggestions, 6 by default and 20 at most"
+// @Success 200 {array} model.Recommendation "Suggested books"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or limit"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/book/{id}/recommendations [get]
+func (h *Handler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recommendation.GetRecommendations"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, err := model.ParseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.Log.Error("failed to parse limit", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	recs, err := h.Svc.GetRecommendations(r.Context(), bookID, limit)
+	if err != nil {
+		h.Log.Error("error getting recommendations", slog.String("error", err.Error()))
+		writeRecommendationError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, recs)
+}
+
+// GetCartRecommendations
+//
+// @Summary You may also like
+// @Description Returns books in stock that go with the current user's cart. An empty cart gets no suggestions.
+// @Tags recommendations
+// @Produce json
+// @Param limit query int false "Number of suggestions, 6 by default and 20 at most"
+// @Success 200 {array} model.Recommendation "Suggested books"
+// @Failure 400 {object} response.ResponseError "Invalid limit"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/cart/recommendations [get]
+func (h *Handler) GetCartRecommendations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recommendation.GetCartRecommendations"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	limit, err := model.ParseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.Log.Error("failed to parse limit", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	recs, err := h.Svc.GetCartRecommendations(r.Context(), userID, limit)
+	if err != nil {
+		h.Log.Error("error getting cart recommendations", slog.String("error", err.Error()))
+		writeRecommendationError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, recs)
+}
+
+func writeRecommendationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,124 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var (
+	bookID      = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+	recommended = uuid.Must(uuid.FromString("5b2e8c41-7d3a-4f6e-8a1b-2c9d0e4f7a63"))
+)
+
+func TestHandler_GetRecommendations(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		limit      int
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", path: "/book/" + bookID.String() + "/recommendations", limit: model.DefaultLimit, wantStatus: http.StatusOK},
+		{name: "given limit", path: "/book/" + bookID.String() + "/recommendations?limit=3", limit: 3, wantStatus: http.StatusOK},
+		{name: "invalid UUID", path: "/book/nope/recommendations", wantStatus: http.StatusBadRequest},
+		{name: "invalid limit", path: "/book/" + bookID.String() + "/recommendations?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "book not found", path: "/book/" + bookID.String() + "/recommendations", limit: model.DefaultLimit,
+			svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", path: "/book/" + bookID.String() + "/recommendations", limit: model.DefaultLimit,
+			svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			hdl.NewRecommendationHandler(router)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+
+			recs := []model.Recommendation{{Book: books.Book{ID: recommended, Title: "Homage to Catalonia"}, Reason: model.ReasonAlsoBought, Score: 0.5}}
+			if tt.svcErr != nil {
+				recs = nil
+			}
+			svc.On("GetRecommendations", mock.Anything, bookID, tt.limit).Return(recs, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, r.Body.String(), `"title":"Homage to Catalonia"`)
+				assert.Contains(t, r.Body.String(), `"reason":"also_bought"`)
+			}
+			if tt.limit == 0 {
+				svc.AssertNotCalled(t, "GetRecommendations", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_GetCartRecommendations(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewRecommendationHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/me/cart/recommendations", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetCartRecommendations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	direct := chi.NewRouter()
+	direct.Get("/", hdl.GetCartRecommendations)
+
+	t.Run("it should suggest books for the cart", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/?limit=4", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		svc.On("GetCartRecommendations", mock.Anything, "123", 4).Return([]model.Recommendation{
+			{Book: books.Book{ID: recommended, Title: "Burmese Days"}, Reason: model.ReasonSameAuthor},
+		}, nil)
+
+		direct.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"reason":"same_author"`)
+	})
+
+	t.Run("it should return 500 when the service fails", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "456"))
+
+		svc.On("GetCartRecommendations", mock.Anything, "456", model.DefaultLimit).Return(nil, errors.New("error"))
+
+		direct.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.RecommendationService) {
+	svc := &mocks.RecommendationService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
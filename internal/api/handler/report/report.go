@@ -0,0 +1,336 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+type Handler struct {
+	Svc interfaces.ReportService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewReportHandler(r chi.Router) {
+	r.Route("/admin/reports", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/summary", h.GetSummary)
+		r.Get("/revenue", h.GetRevenue)
+		r.Get("/top-sellers", h.GetTopSellers)
+		r.Get("/turnover", h.GetTurnover)
+		r.Get("/low-stock", h.GetLowStock)
+	})
+}
+
+// GetSummary
+//
+// @Summary Sales summary
+// @Description Returns the orders paid in a date range with their revenue, refunds, average order value and units sold, and how many of the carts started in the range were paid.
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "First day, YYYY-MM-DD. Defaults to 30 days before to"
+// @Param to query string false "Last day, YYYY-MM-DD. Defaults to today"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} model.Summary "Summary"
+// @Failure 400 {object} response.ResponseError "Invalid date range or format"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reports/summary [get]
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.report.GetSummary"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, format, err := reportParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse report parameters", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	summary, err := h.Svc.GetSummary(r.Context(), rng)
+	if err != nil {
+		h.Log.Error("error getting sales summary", slog.String("error", err.Error()))
+		writeReportError(w, r, err)
+		return
+	}
+
+	h.writeReport(w, r, format, "summary", rng, summary)
+}
+
+// GetRevenue
+//
+// @Summary Revenue over time
+// @Description Returns the orders paid and their revenue per day, week or month of a date range. Periods without orders are listed with zeros.
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "First day, YYYY-MM-DD. Defaults to 30 days before to"
+// @Param to query string false "Last day, YYYY-MM-DD. Defaults to today"
+// @Param interval query string false "day (default), week or month"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} model.Revenue "Revenue per period"
+// @Failure 400 {object} response.ResponseError "Invalid date range, interval or format"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reports/revenue [get]
+func (h *Handler) GetRevenue(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.report.GetRevenue"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, format, err := reportParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse report parameters", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	interval, err := model.ParseInterval(r.URL.Query().Get("interval"))
+	if err != nil {
+		h.Log.Error("failed to parse interval", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	revenue, err := h.Svc.GetRevenue(r.Context(), rng, interval)
+	if err != nil {
+		h.Log.Error("error getting revenue", slog.String("error", err.Error()))
+		writeReportError(w, r, err)
+		return
+	}
+
+	h.writeReport(w, r, format, "revenue-"+string(interval), rng, revenue)
+}
+
+// GetTopSellers
+//
+// @Summary Top sellers
+// @Description Returns the books that sold the most copies in orders paid in a date range.
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "First day, YYYY-MM-DD. Defaults to 30 days before to"
+// @Param to query string false "Last day, YYYY-MM-DD. Defaults to today"
+// @Param limit query int false "Number of books, 10 by default and 100 at most"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} model.TopSellers "Top sellers"
+// @Failure 400 {object} response.ResponseError "Invalid date range, limit or format"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reports/top-sellers [get]
+func (h *Handler) GetTopSellers(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.report.GetTopSellers"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, format, err := reportParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse report parameters", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, err := model.ParseCount("limit", r.URL.Query().Get("limit"), model.DefaultLimit, model.MaxLimit)
+	if err != nil {
+		h.Log.Error("failed to parse limit", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	sellers, err := h.Svc.GetTopSellers(r.Context(), rng, limit)
+	if err != nil {
+		h.Log.Error("error getting top sellers", slog.String("error", err.Error()))
+		writeReportError(w, r, err)
+		return
+	}
+
+	h.writeReport(w, r, format, "top-sellers", rng, sellers)
+}
+
+// GetTurnover
+//
+// @Summary Stock turnover
+// @Description Returns books by the share of their copies that sold in a date range, with how many days the remaining stock lasts at that rate.
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "First day, YYYY-MM-DD. Defaults to 30 days before to"
+// @Param to query string false "Last day, YYYY-MM-DD. Defaults to today"
+// @Param limit query int false "Number of books, 10 by default and 100 at most"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} model.StockReport "Stock turnover"
+// @Failure 400 {object} response.ResponseError "Invalid date range, limit or format"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reports/turnover [get]
+func (h *Handler) GetTurnover(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.report.GetTurnover"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, format, err := reportParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse report parameters", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, err := model.ParseCount("limit", r.URL.Query().Get("limit"), model.DefaultLimit, model.MaxLimit)
+	if err != nil {
+		h.Log.Error("failed to parse limit", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	turnover, err := h.Svc.GetTurnover(r.Context(), rng, limit)
+	if err != nil {
+		h.Log.Error("error getting stock turnover", slog.String("error", err.Error()))
+		writeReportError(w, r, err)
+		return
+	}
+
+	h.writeReport(w, r, format, "turnover", rng, turnover)
+}
+
+// GetLowStock
+//
+// @Summary Low stock
+// @Description Returns books with few copies left, the ones that will run out first at the top, with their sales in a date range.
+// @Tags reports
+// @Produce json,text/csv
+// @Param from query string false "First day, YYYY-MM-DD. Defaults to 30 days before to"
+// @Param to query string false "Last day, YYYY-MM-DD. Defaults to today"
+// @Param threshold query int false "Stock at or below which a book is listed, 5 by default"
+// @Param limit query int false "Number of books, 10 by default and 100 at most"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} model.StockReport "Low stock"
+// @Failure 400 {object} response.ResponseError "Invalid date range, threshold, limit or format"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Forbidden"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reports/low-stock [get]
+func (h *Handler) GetLowStock(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.report.GetLowStock"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rng, format, err := reportParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse report parameters", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	threshold, err := model.ParseThreshold(r.URL.Query().Get("threshold"))
+	if err != nil {
+		h.Log.Error("failed to parse threshold", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, err := model.ParseCount("limit", r.URL.Query().Get("limit"), model.DefaultLimit, model.MaxLimit)
+	if err != nil {
+		h.Log.Error("failed to parse limit", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	lowStock, err := h.Svc.GetLowStock(r.Context(), rng, threshold, limit)
+	if err != nil {
+		h.Log.Error("error getting low stock", slog.String("error", err.Error()))
+		writeReportError(w, r, err)
+		return
+	}
+
+	h.writeReport(w, r, format, "low-stock", rng, lowStock)
+}
+
+// reportParams reads the date range and output format every report takes.
+func reportParams(r *http.Request) (model.Range, string, error) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	switch format {
+	case "":
+		format = formatJSON
+	case formatJSON, formatCSV:
+	default:
+		return model.Range{}, "", fmt.Errorf("unknown format %q", format)
+	}
+
+	rng, err := model.ParseRange(query.Get("from"), query.Get("to"), time.Now())
+	if err != nil {
+		return model.Range{}, "", err
+	}
+
+	return rng, format, nil
+}
+
+type tabular interface {
+	Table() model.Table
+}
+
+// writeReport sends the report as JSON, or as a CSV download named after
+// the report and its range.
+func (h *Handler) writeReport(w http.ResponseWriter, r *http.Request, format, name string, rng model.Range, report tabular) {
+	if format != formatCSV {
+		response.WriteJson(w, r, http.StatusOK, report)
+		return
+	}
+
+	table := report.Table()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"_"+rng.String()+".csv"))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	out.Write(table.Header)
+	out.WriteAll(table.Rows)
+	if err := out.Error(); err != nil {
+		h.Log.Error("failed to write report", "error", err)
+	}
+}
+
+func writeReportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
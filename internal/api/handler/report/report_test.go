@@ -0,0 +1,254 @@
+package report
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var (
+	bookID = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+	march  = model.Range{
+		From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestHandler_NewReportHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewReportHandler(router)
+
+	for _, path := range []string{"/admin/reports/summary", "/admin/reports/revenue", "/admin/reports/low-stock"} {
+		t.Run(path, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+	}
+	svc.AssertNotCalled(t, "GetSummary", mock.Anything, mock.Anything)
+}
+
+func TestHandler_GetSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		svcErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "json", query: "?from=2025-03-01&to=2025-03-31", wantStatus: http.StatusOK, wantBody: `"average_order_value":"30.00"`},
+		{name: "csv", query: "?from=2025-03-01&to=2025-03-31&format=csv", wantStatus: http.StatusOK,
+			wantBody: "from,to,orders,revenue,refunded,net_revenue,average_order_value,units_sold,carts,converted,conversion_rate\n" +
+				"2025-03-01,2025-03-31,2,60.00,0.00,60.00,30.00,3,8,2,25.00\n"},
+		{name: "from after to", query: "?from=2025-03-31&to=2025-03-01", wantStatus: http.StatusBadRequest},
+		{name: "bad date", query: "?from=March", wantStatus: http.StatusBadRequest},
+		{name: "unknown format", query: "?format=xml", wantStatus: http.StatusBadRequest},
+		{name: "internal error", query: "?from=2025-03-01&to=2025-03-31", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/", hdl.GetSummary)
+
+			summary := &model.Summary{
+				Range:     march,
+				Orders:    2,
+				Revenue:   money.MustParse("60.00", money.DefaultCurrency),
+				Refunded:  money.Zero(money.DefaultCurrency),
+				UnitsSold: 3,
+				Carts:     8,
+				Converted: 2,
+			}
+			summary.Finish()
+			if tt.svcErr != nil {
+				summary = nil
+			}
+			svc.On("GetSummary", mock.Anything, march).Return(summary, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantBody != "" {
+				assert.Contains(t, r.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				svc.AssertNotCalled(t, "GetSummary", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_GetSummary_CSVDownload(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetSummary)
+
+	svc.On("GetSummary", mock.Anything, march).Return(&model.Summary{Range: march}, nil)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/?from=2025-03-01&to=2025-03-31&format=csv", nil)
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, "text/csv; charset=utf-8", r.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="summary_2025-03-01_2025-03-31.csv"`, r.Header().Get("Content-Disposition"))
+}
+
+func TestHandler_GetRevenue(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		interval   model.Interval
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "daily by default", query: "?from=2025-03-01&to=2025-03-31", interval: model.IntervalDay, wantStatus: http.StatusOK},
+		{name: "weekly", query: "?from=2025-03-01&to=2025-03-31&interval=week", interval: model.IntervalWeek, wantStatus: http.StatusOK},
+		{name: "unknown interval", query: "?interval=year", wantStatus: http.StatusBadRequest},
+		{name: "invalid", query: "?from=2025-03-01&to=2025-03-31", interval: model.IntervalDay,
+			svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/", hdl.GetRevenue)
+
+			revenue := &model.Revenue{Range: march, Interval: tt.interval, Points: []model.Point{
+				{Period: march.From, Orders: 1, Revenue: money.MustParse("20.00", money.DefaultCurrency)},
+			}}
+			if tt.svcErr != nil {
+				revenue = nil
+			}
+			svc.On("GetRevenue", mock.Anything, march, tt.interval).Return(revenue, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, r.Body.String(), `"revenue":"20.00"`)
+			}
+		})
+	}
+}
+
+func TestHandler_GetTopSellers(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetTopSellers)
+
+	t.Run("it should list the top sellers", func(t *testing.T) {
+		svc.On("GetTopSellers", mock.Anything, march, 5).Return(&model.TopSellers{Range: march, Books: []model.TopSeller{
+			{BookID: bookID, Title: "Dune", Author: "Frank Herbert", Units: 4, Revenue: money.MustParse("39.96", money.DefaultCurrency)},
+		}}, nil)
+
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/?from=2025-03-01&to=2025-03-31&limit=5&format=csv", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), bookID.String()+",Dune,Frank Herbert,4,39.96")
+	})
+
+	t.Run("it should reject a bad limit", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/?limit=none", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestHandler_GetTurnover(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetTurnover)
+
+	cover := 18.0
+	svc.On("GetTurnover", mock.Anything, march, model.DefaultLimit).Return(&model.StockReport{Range: march, Books: []model.Stock{
+		{BookID: bookID, Title: "Dune", Stock: 6, UnitsSold: 10, SellThrough: 62.5, DaysOfCover: &cover},
+	}}, nil)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/?from=2025-03-01&to=2025-03-31", nil)
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Contains(t, r.Body.String(), `"sell_through":62.5`)
+	assert.Contains(t, r.Body.String(), `"days_of_cover":18`)
+}
+
+func TestHandler_GetLowStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		threshold  int
+		wantStatus int
+	}{
+		{name: "default threshold", query: "?from=2025-03-01&to=2025-03-31", threshold: model.DefaultThreshold, wantStatus: http.StatusOK},
+		{name: "out of stock only", query: "?from=2025-03-01&to=2025-03-31&threshold=0", threshold: 0, wantStatus: http.StatusOK},
+		{name: "negative threshold", query: "?threshold=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/", hdl.GetLowStock)
+
+			svc.On("GetLowStock", mock.Anything, march, tt.threshold, model.DefaultLimit).Return(&model.StockReport{
+				Range: march, Threshold: tt.threshold, Books: []model.Stock{{BookID: bookID, Title: "Dune", Stock: tt.threshold}},
+			}, nil)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, r.Body.String(), `"title":"Dune"`)
+			}
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.ReportService) {
+	svc := &mocks.ReportService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
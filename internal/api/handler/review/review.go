@@ -0,0 +1,408 @@
+package review
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.ReviewService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewReviewHandler(r chi.Router) {
+	r.Route("/book/{id}/reviews", func(r chi.Router) {
+		r.With(middle.WithOptionalAuth).Get("/", h.GetReviews)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middle.WithAuth)
+
+			r.Post("/", h.SaveReview)
+			r.Delete("/{reviewId}", h.DeleteReview)
+			r.Post("/{reviewId}/helpful", h.Vote)
+		})
+	})
+
+	r.Route("/admin/reviews", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetQueue)
+		r.Post("/{reviewId}/approve", h.ApproveReview)
+		r.Post("/{reviewId}/reject", h.RejectReview)
+	})
+}
+
+// GetReviews
+//
+// @Summary Reviews of a book
+// @Description Returns the rating of a book and its approved reviews. A logged-in customer also gets their own review, whatever its moderation status.
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param sort query string false "helpful (default), recent, highest or lowest"
+// @Success 200 {object} model.Reviews "Rating and reviews"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or sort"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/book/{id}/reviews [get]
+func (h *Handler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.GetReviews"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	sort, err := model.ParseSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		h.Log.Error("failed to parse sort", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, _ := r.Context().Value("user_id").(string)
+
+	reviews, err := h.Svc.GetReviews(r.Context(), userID, bookID, sort)
+	if err != nil {
+		h.Log.Error("error getting reviews", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, reviews)
+}
+
+// SaveReview
+//
+// @Summary Review a book
+// @Description Writes the current user's review of a book they paid for, replacing the one they wrote before. The review is shown once staff approve it.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param request body model.Request true "Review"
+// @Success 201 {object} model.Review "Review, pending moderation"
+// @Failure 400 {object} response.ResponseError "Invalid review"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 403 {object} response.ResponseError "The user never paid for the book"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/book/{id}/reviews [post]
+func (h *Handler) SaveReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.SaveReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	rv, err := h.Svc.SaveReview(r.Context(), userID, bookID, req)
+	if err != nil {
+		h.Log.Error("error saving review", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, rv)
+}
+
+// DeleteReview
+//
+// @Summary Delete my review
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param reviewId path string true "Review ID"
+// @Success 200 {string} string "Review deleted"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Review not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/book/{id}/reviews/{reviewId} [delete]
+func (h *Handler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.DeleteReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, id, err := reviewParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeleteReview(r.Context(), userID, bookID, id)
+	if err != nil {
+		h.Log.Error("error deleting review", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Review deleted")
+}
+
+// Vote
+//
+// @Summary Mark a review as helpful
+// @Description Counts the current user as finding an approved review helpful. Voting again changes nothing, and authors cannot vote for their own reviews.
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param reviewId path string true "Review ID"
+// @Success 200 {object} model.Review "Review"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Review not found"
+// @Failure 409 {object} response.ResponseError "The review is the user's own"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/book/{id}/reviews/{reviewId}/helpful [post]
+func (h *Handler) Vote(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.Vote"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	bookID, id, err := reviewParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	rv, err := h.Svc.Vote(r.Context(), userID, bookID, id)
+	if err != nil {
+		h.Log.Error("error voting for review", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, rv)
+}
+
+// GetQueue
+//
+// @Summary Review moderation queue
+// @Description Lists reviews across all books in a status, oldest first
+// @Tags reviews
+// @Produce json
+// @Param status query string false "pending (default), approved or rejected"
+// @Success 200 {array} model.Review "Reviews"
+// @Failure 400 {object} response.ResponseError "Unknown status"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reviews [get]
+func (h *Handler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.GetQueue"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	status := model.Status(r.URL.Query().Get("status"))
+	if status == "" {
+		status = model.StatusPending
+	}
+
+	reviews, err := h.Svc.GetQueue(r.Context(), status)
+	if err != nil {
+		h.Log.Error("error getting review queue", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, reviews)
+}
+
+// ApproveReview
+//
+// @Summary Approve a review
+// @Description Publishes a pending or rejected review and counts it towards the book's rating
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param reviewId path string true "Review ID"
+// @Param request body model.ModerateRequest false "Note"
+// @Success 200 {object} model.Review "Review"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or note"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Review not found"
+// @Failure 409 {object} response.ResponseError "Review already approved"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reviews/{reviewId}/approve [post]
+func (h *Handler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.ApproveReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "reviewId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ModerateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	rv, err := h.Svc.ApproveReview(r.Context(), actorID, id, req)
+	if err != nil {
+		h.Log.Error("error approving review", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, rv)
+}
+
+// RejectReview
+//
+// @Summary Reject a review
+// @Description Keeps a pending review off the book page, or takes down an approved one. The note tells the author why.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param reviewId path string true "Review ID"
+// @Param request body model.ModerateRequest true "Note"
+// @Success 200 {object} model.Review "Review"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or missing note"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Review not found"
+// @Failure 409 {object} response.ResponseError "Review already rejected"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/reviews/{reviewId}/reject [post]
+func (h *Handler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.review.RejectReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "reviewId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ModerateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	rv, err := h.Svc.RejectReview(r.Context(), actorID, id, req)
+	if err != nil {
+		h.Log.Error("error rejecting review", slog.String("error", err.Error()))
+		writeReviewError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, rv)
+}
+
+// reviewParams reads the book and review IDs from the path.
+func reviewParams(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	bookID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reviewId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return bookID, id, nil
+}
+
+func writeReviewError(w http.ResponseWriter, r *http.Request, err error) {
+	var transitionErr *model.TransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		response.WriteError(w, r, http.StatusConflict, transitionErr)
+	case errors.Is(err, model.ErrNotVerified):
+		response.WriteError(w, r, http.StatusForbidden, model.ErrNotVerified)
+	case errors.Is(err, model.ErrOwnReview):
+		response.WriteError(w, r, http.StatusConflict, model.ErrOwnReview)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,320 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var (
+	bookID   = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+	reviewID = uuid.Must(uuid.FromString("3e8b2f14-6d0a-4c59-8a7e-5b1c9d2f0a63"))
+)
+
+func TestHandler_NewReviewHandler_Auth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewReviewHandler(router)
+
+	t.Run("reading reviews needs no login", func(t *testing.T) {
+		svc.On("GetReviews", mock.Anything, "", bookID, model.SortHelpful).Return(&model.Reviews{
+			BookID:  bookID,
+			Rating:  model.NewRating(map[int]int{5: 1}),
+			Reviews: []model.Review{{ID: reviewID, BookID: bookID, Rating: 5, Author: "ada"}},
+		}, nil)
+
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/book/"+bookID.String()+"/reviews", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"author":"ada"`)
+		assert.Contains(t, r.Body.String(), `"average":5`)
+	})
+
+	t.Run("writing a review needs a login", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/book/"+bookID.String()+"/reviews", bytes.NewReader([]byte(`{"rating":5}`)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "SaveReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("the moderation queue needs a login", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/reviews", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetQueue", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_GetReviews(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		sort       model.Sort
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "most recent first", query: "?sort=recent", sort: model.SortRecent, wantStatus: http.StatusOK},
+		{name: "unknown sort", query: "?sort=random", wantStatus: http.StatusBadRequest},
+		{name: "book not found", sort: model.SortHelpful, svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/book/{id}/reviews", hdl.GetReviews)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/book/"+bookID.String()+"/reviews"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var reviews *model.Reviews
+			if tt.svcErr == nil {
+				reviews = &model.Reviews{BookID: bookID, Reviews: []model.Review{}}
+			}
+			svc.On("GetReviews", mock.Anything, "123", bookID, tt.sort).Return(reviews, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_SaveReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusCreated},
+		{name: "never bought the book", svcErr: fmt.Errorf("test: %w", model.ErrNotVerified), wantStatus: http.StatusForbidden},
+		{name: "invalid rating", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/book/{id}/reviews", hdl.SaveReview)
+
+			payload := []byte(`{"rating":4,"title":"Gripping","body":"Read it in one sitting."}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/book/"+bookID.String()+"/reviews", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var rv *model.Review
+			if tt.svcErr == nil {
+				rv = &model.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: model.StatusPending}
+			}
+			svc.On("SaveReview", mock.Anything, "123", bookID, model.Request{Rating: 4, Title: "Gripping", Body: "Read it in one sitting."}).Return(rv, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_Vote(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "own review", svcErr: fmt.Errorf("test: %w", model.ErrOwnReview), wantStatus: http.StatusConflict},
+		{name: "not approved", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/book/{id}/reviews/{reviewId}/helpful", hdl.Vote)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/book/"+bookID.String()+"/reviews/"+reviewID.String()+"/helpful", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var rv *model.Review
+			if tt.svcErr == nil {
+				rv = &model.Review{ID: reviewID, BookID: bookID, HelpfulCount: 3}
+			}
+			svc.On("Vote", mock.Anything, "123", bookID, reviewID).Return(rv, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteReview(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Delete("/book/{id}/reviews/{reviewId}", hdl.DeleteReview)
+
+	t.Run("it should delete the review", func(t *testing.T) {
+		svc.On("DeleteReview", mock.Anything, "123", bookID, reviewID).Return(nil)
+
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/book/"+bookID.String()+"/reviews/"+reviewID.String(), nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("it should return 400 for an invalid review ID", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/book/"+bookID.String()+"/reviews/not-a-uuid", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestHandler_GetQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		status     model.Status
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "pending by default", status: model.StatusPending, wantStatus: http.StatusOK},
+		{name: "rejected", query: "?status=rejected", status: model.StatusRejected, wantStatus: http.StatusOK},
+		{name: "unknown status", query: "?status=lost", status: "lost", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Get("/admin/reviews", hdl.GetQueue)
+
+			svc.On("GetQueue", mock.Anything, tt.status).Return([]model.Review{}, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/reviews"+tt.query, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ApproveReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "without a note", wantStatus: http.StatusOK},
+		{name: "with a note", body: `{"note":"thanks"}`, wantStatus: http.StatusOK},
+		{name: "already approved", svcErr: &model.TransitionError{From: model.StatusApproved, To: model.StatusApproved}, wantStatus: http.StatusConflict},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/admin/reviews/{reviewId}/approve", hdl.ApproveReview)
+
+			var rv *model.Review
+			if tt.svcErr == nil {
+				rv = &model.Review{ID: reviewID, Status: model.StatusApproved}
+			}
+			svc.On("ApproveReview", mock.Anything, "123", reviewID, mock.AnythingOfType("review.ModerateRequest")).Return(rv, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/reviews/"+reviewID.String()+"/approve", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_RejectReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", body: `{"note":"spoilers"}`, wantStatus: http.StatusOK},
+		{name: "no note", body: `{"note":""}`, svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "no body", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/admin/reviews/{reviewId}/reject", hdl.RejectReview)
+
+			var rv *model.Review
+			if tt.svcErr == nil {
+				rv = &model.Review{ID: reviewID, Status: model.StatusRejected}
+			}
+			svc.On("RejectReview", mock.Anything, "123", reviewID, mock.AnythingOfType("review.ModerateRequest")).Return(rv, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/reviews/"+reviewID.String()+"/reject", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.ReviewService) {
+	svc := &mocks.ReviewService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,403 @@
+package rma
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.ReturnService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewReturnHandler(r chi.Router) {
+	r.Route("/returns", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+
+		r.Get("/", h.GetMyReturns)
+		r.Post("/", h.RequestReturn)
+		r.Get("/{returnId}", h.GetMyReturn)
+	})
+
+	r.Route("/admin/returns", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetReturns)
+		r.Get("/{returnId}", h.GetReturn)
+		r.Post("/{returnId}/approve", h.ApproveReturn)
+		r.Post("/{returnId}/reject", h.RejectReturn)
+		r.Post("/{returnId}/receive", h.ReceiveReturn)
+	})
+}
+
+// RequestReturn
+//
+// @Summary Ask to return books
+// @Description Opens a return for some of the books of a delivered order. Each book needs a quantity and a reason; a book cannot be returned more times than it was bought.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param request body model.CreateRequest true "Order, items and reasons"
+// @Success 201 {object} model.Return "Return"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "Order cannot be returned"
+// @Failure 422 {object} response.ResponseError "Items cannot be returned"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/returns [post]
+func (h *Handler) RequestReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.RequestReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ret, err := h.Svc.RequestReturn(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error requesting return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, ret)
+}
+
+// GetMyReturns
+//
+// @Summary List my returns
+// @Tags returns
+// @Produce json
+// @Success 200 {array} model.Return "Returns"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/returns [get]
+func (h *Handler) GetMyReturns(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.GetMyReturns"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	returns, err := h.Svc.GetCustomerReturns(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("error getting returns", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, returns)
+}
+
+// GetMyReturn
+//
+// @Summary Get one of my returns
+// @Tags returns
+// @Produce json
+// @Param returnId path string true "Return ID"
+// @Success 200 {object} model.Return "Return"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Return not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/returns/{returnId} [get]
+func (h *Handler) GetMyReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.GetMyReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "returnId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ret, err := h.Svc.GetCustomerReturn(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error getting return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ret)
+}
+
+// GetReturns
+//
+// @Summary List returns
+// @Description Lists every return, newest first, optionally only those in one status.
+// @Tags returns
+// @Produce json
+// @Param status query string false "requested, approved, rejected or received"
+// @Success 200 {array} model.Return "Returns"
+// @Failure 400 {object} response.ResponseError "Unknown status"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/returns [get]
+func (h *Handler) GetReturns(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.GetReturns"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	returns, err := h.Svc.GetReturns(r.Context(), model.Status(r.URL.Query().Get("status")))
+	if err != nil {
+		h.Log.Error("error getting returns", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, returns)
+}
+
+// GetReturn
+//
+// @Summary Get a return
+// @Tags returns
+// @Produce json
+// @Param returnId path string true "Return ID"
+// @Success 200 {object} model.Return "Return"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Return not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/returns/{returnId} [get]
+func (h *Handler) GetReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.GetReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "returnId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ret, err := h.Svc.GetReturn(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ret)
+}
+
+// ApproveReturn
+//
+// @Summary Approve a return
+// @Description Lets the customer send the books back. The note is shown to the customer.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param returnId path string true "Return ID"
+// @Param request body model.ResolveRequest false "Note to the customer"
+// @Success 200 {object} model.Return "Return"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Return not found"
+// @Failure 409 {object} response.ResponseError "Return already resolved"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/returns/{returnId}/approve [post]
+func (h *Handler) ApproveReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.ApproveReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "returnId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ResolveRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	ret, err := h.Svc.ApproveReturn(r.Context(), actorID, id, req)
+	if err != nil {
+		h.Log.Error("error approving return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ret)
+}
+
+// RejectReturn
+//
+// @Summary Reject a return
+// @Description Turns a return down before or after the books arrive. A note telling the customer why is required.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param returnId path string true "Return ID"
+// @Param request body model.ResolveRequest true "Why the return was rejected"
+// @Success 200 {object} model.Return "Return"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Return not found"
+// @Failure 409 {object} response.ResponseError "Return already resolved"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/returns/{returnId}/reject [post]
+func (h *Handler) RejectReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.RejectReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "returnId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ResolveRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	ret, err := h.Svc.RejectReturn(r.Context(), actorID, id, req)
+	if err != nil {
+		h.Log.Error("error rejecting return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ret)
+}
+
+// ReceiveReturn
+//
+// @Summary Receive returned books
+// @Description Books the copies of an approved return back into stock and refunds what they cost, less their share of any discount. The order is marked partially refunded, or refunded once every book is back.
+// @Tags returns
+// @Produce json
+// @Param returnId path string true "Return ID"
+// @Success 200 {object} model.Return "Return with the refunded amount"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Return not found"
+// @Failure 409 {object} response.ResponseError "Return not approved"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/returns/{returnId}/receive [post]
+func (h *Handler) ReceiveReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.rma.ReceiveReturn"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "returnId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	ret, err := h.Svc.ReceiveReturn(r.Context(), actorID, id)
+	if err != nil {
+		h.Log.Error("error receiving return", slog.String("error", err.Error()))
+		writeReturnError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ret)
+}
+
+// writeReturnError maps service errors onto status codes. Asking for books
+// the order cannot give back is unprocessable; acting on a return or order in
+// the wrong state is a conflict.
+func writeReturnError(w http.ResponseWriter, r *http.Request, err error) {
+	var returnErr *model.TransitionError
+	var orderErr *orderModel.TransitionError
+
+	switch {
+	case errors.As(err, &returnErr):
+		response.WriteError(w, r, http.StatusConflict, returnErr)
+	case errors.As(err, &orderErr):
+		response.WriteError(w, r, http.StatusConflict, orderErr)
+	case errors.Is(err, model.ErrNotReturnable):
+		response.WriteError(w, r, http.StatusConflict, err)
+	case errors.Is(err, model.ErrItems):
+		response.WriteError(w, r, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,220 @@
+package rma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewReturnHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewReturnHandler(router)
+
+	id, _ := uuid.NewV4()
+
+	for _, path := range []string{"/returns/" + id.String(), "/admin/returns/" + id.String()} {
+		t.Run(path, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+	}
+	svc.AssertNotCalled(t, "GetReturn", mock.Anything, mock.Anything)
+	svc.AssertNotCalled(t, "GetCustomerReturn", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_RequestReturn(t *testing.T) {
+	orderID, _ := uuid.NewV4()
+	bookID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusCreated},
+		{name: "more than was bought", svcErr: errors.Wrap(fmt.Errorf("%w: 1 of \"Dune\" bought, 0 already returned", model.ErrItems), "test"), wantStatus: http.StatusUnprocessableEntity},
+		{name: "not delivered yet", svcErr: errors.Wrap(model.ErrNotReturnable, "test"), wantStatus: http.StatusConflict},
+		{name: "someone else's order", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.RequestReturn)
+
+			create := model.CreateRequest{
+				OrderID: orderID,
+				Items:   []model.ItemRequest{{BookID: bookID, Quantity: 2, Reason: model.ReasonDamaged}},
+			}
+			payload, err := json.Marshal(create)
+			require.NoError(t, err)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", userID.String()))
+
+			var created *model.Return
+			if tt.svcErr == nil {
+				created = &model.Return{OrderID: orderID, Status: model.StatusRequested}
+			}
+			svc.On("RequestReturn", mock.Anything, userID.String(), create).Return(created, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_RequestReturn_NotLoggedIn(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/", hdl.RequestReturn)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusUnauthorized, r.Code)
+	svc.AssertNotCalled(t, "RequestReturn", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_ReceiveReturn(t *testing.T) {
+	id, _ := uuid.NewV4()
+	adminID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusOK},
+		{name: "not approved", svcErr: errors.Wrap(&model.TransitionError{From: model.StatusRequested, To: model.StatusReceived}, "test"), wantStatus: http.StatusConflict},
+		{name: "order moved meanwhile", svcErr: errors.Wrap(&orderModel.TransitionError{From: orderModel.StatusRefunded, To: orderModel.StatusRefunded}, "test"), wantStatus: http.StatusConflict},
+		{name: "return not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "refund failed", svcErr: errors.New("provider unavailable"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{returnId}/receive", hdl.ReceiveReturn)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/receive", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+			var received *model.Return
+			if tt.svcErr == nil {
+				received = &model.Return{ID: id, Status: model.StatusReceived, RefundAmount: money.MustParse("12.99", "USD")}
+			}
+			svc.On("ReceiveReturn", mock.Anything, adminID.String(), id).Return(received, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.svcErr == nil {
+				assert.Contains(t, r.Body.String(), `"refund_amount":"12.99"`)
+			}
+		})
+	}
+}
+
+func TestHandler_RejectReturn(t *testing.T) {
+	id, _ := uuid.NewV4()
+	adminID, _ := uuid.NewV4()
+
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/{returnId}/reject", hdl.RejectReturn)
+
+	t.Run("success", func(t *testing.T) {
+		resolve := model.ResolveRequest{Note: "Outside the 30 day window"}
+		payload, err := json.Marshal(resolve)
+		require.NoError(t, err)
+
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/reject", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+		svc.On("RejectReturn", mock.Anything, adminID.String(), id, resolve).
+			Return(&model.Return{ID: id, Status: model.StatusRejected, ResolutionNote: resolve.Note}, nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"status":"rejected"`)
+	})
+
+	t.Run("already received", func(t *testing.T) {
+		resolve := model.ResolveRequest{Note: "Damaged by the customer"}
+		payload, err := json.Marshal(resolve)
+		require.NoError(t, err)
+
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/reject", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+		svc.On("RejectReturn", mock.Anything, adminID.String(), id, resolve).
+			Return(nil, errors.Wrap(&model.TransitionError{From: model.StatusReceived, To: model.StatusRejected}, "test")).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestHandler_GetMyReturn_UUID_Error(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{returnId}", hdl.GetMyReturn)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/123", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusBadRequest, r.Code)
+}
+
+func newTestHandler() (*Handler, *mocks.ReturnService) {
+	svc := &mocks.ReturnService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
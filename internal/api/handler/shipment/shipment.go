@@ -0,0 +1,342 @@
+package shipment
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.ShipmentService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewShipmentHandler(r chi.Router) {
+	r.Route("/admin/shipments", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetOrderShipments)
+		r.Post("/", h.CreateShipment)
+		r.Get("/{shipmentId}", h.GetShipment)
+		r.Get("/{shipmentId}/picklist", h.GetPickList)
+		r.Post("/{shipmentId}/ship", h.ShipShipment)
+		r.Post("/{shipmentId}/deliver", h.DeliverShipment)
+		r.Post("/{shipmentId}/cancel", h.CancelShipment)
+	})
+}
+
+// CreateShipment
+//
+// @Summary Pack a parcel for a paid order
+// @Description Makes up a shipment from some or, without items, all of what a paid order has left to pack. The order is marked fulfilled once every item is in a parcel.
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Param request body model.CreateRequest true "Order and items"
+// @Success 201 {object} model.Shipment "Shipment"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Order not found"
+// @Failure 409 {object} response.ResponseError "Order not shippable or items already packed"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments [post]
+func (h *Handler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.CreateShipment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req model.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	shipment, err := h.Svc.CreateShipment(r.Context(), actorID, req)
+	if err != nil {
+		h.Log.Error("error creating shipment", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, shipment)
+}
+
+// GetOrderShipments
+//
+// @Summary List the parcels of an order
+// @Tags shipments
+// @Produce json
+// @Param order_id query string true "Order ID"
+// @Success 200 {array} model.Shipment "Shipments"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments [get]
+func (h *Handler) GetOrderShipments(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.GetOrderShipments"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	orderID, err := uuid.FromString(r.URL.Query().Get("order_id"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	shipments, err := h.Svc.GetOrderShipments(r.Context(), orderID)
+	if err != nil {
+		h.Log.Error("error getting shipments", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipments)
+}
+
+// GetShipment
+//
+// @Summary Get a shipment
+// @Tags shipments
+// @Produce json
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {object} model.Shipment "Shipment"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Shipment not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments/{shipmentId} [get]
+func (h *Handler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.GetShipment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "shipmentId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	shipment, err := h.Svc.GetShipment(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting shipment", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipment)
+}
+
+// GetPickList
+//
+// @Summary Get the pick list of a shipment
+// @Description Returns what to pick for a parcel, sorted by title, with the address it goes to. The storefront prints it at /admin/shipments/{shipmentId}/picklist.
+// @Tags shipments
+// @Produce json
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {object} model.PickList "Pick list"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Shipment not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments/{shipmentId}/picklist [get]
+func (h *Handler) GetPickList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.GetPickList"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "shipmentId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	pickList, err := h.Svc.GetPickList(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting pick list", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, pickList)
+}
+
+// ShipShipment
+//
+// @Summary Hand a parcel to a carrier
+// @Description Records the carrier and tracking number of a packed parcel. The order is marked shipped once every parcel has left.
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Param shipmentId path string true "Shipment ID"
+// @Param request body model.ShipRequest true "Carrier and tracking number"
+// @Success 200 {object} model.Shipment "Shipment"
+// @Failure 400 {object} response.ResponseError "Invalid input"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Shipment not found"
+// @Failure 409 {object} response.ResponseError "Shipment already shipped or cancelled"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments/{shipmentId}/ship [post]
+func (h *Handler) ShipShipment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.ShipShipment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "shipmentId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ShipRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	shipment, err := h.Svc.ShipShipment(r.Context(), actorID, id, req)
+	if err != nil {
+		h.Log.Error("error shipping shipment", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipment)
+}
+
+// DeliverShipment
+//
+// @Summary Mark a parcel delivered
+// @Description The order is marked delivered once every parcel has arrived.
+// @Tags shipments
+// @Produce json
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {object} model.Shipment "Shipment"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Shipment not found"
+// @Failure 409 {object} response.ResponseError "Shipment has not shipped"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments/{shipmentId}/deliver [post]
+func (h *Handler) DeliverShipment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.DeliverShipment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "shipmentId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	shipment, err := h.Svc.DeliverShipment(r.Context(), actorID, id)
+	if err != nil {
+		h.Log.Error("error delivering shipment", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipment)
+}
+
+// CancelShipment
+//
+// @Summary Call a parcel back before it ships
+// @Description Puts the parcel's books back among what the order has left to pack. A fulfilled order goes back to paid.
+// @Tags shipments
+// @Produce json
+// @Param shipmentId path string true "Shipment ID"
+// @Success 200 {object} model.Shipment "Shipment"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Shipment not found"
+// @Failure 409 {object} response.ResponseError "Shipment already shipped"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/shipments/{shipmentId}/cancel [post]
+func (h *Handler) CancelShipment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.shipment.CancelShipment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "shipmentId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	actorID, _ := middle.GetUserIDFromContext(r.Context())
+
+	shipment, err := h.Svc.CancelShipment(r.Context(), actorID, id)
+	if err != nil {
+		h.Log.Error("error cancelling shipment", slog.String("error", err.Error()))
+		writeShipmentError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, shipment)
+}
+
+func writeShipmentError(w http.ResponseWriter, r *http.Request, err error) {
+	var shipmentErr *model.TransitionError
+	var orderErr *orderModel.TransitionError
+
+	switch {
+	case errors.As(err, &shipmentErr):
+		response.WriteError(w, r, http.StatusConflict, shipmentErr)
+	case errors.As(err, &orderErr):
+		response.WriteError(w, r, http.StatusConflict, orderErr)
+	case errors.Is(err, model.ErrNotShippable), errors.Is(err, model.ErrAllocation):
+		response.WriteError(w, r, http.StatusConflict, err)
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,157 @@
+package shipment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewShipmentHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewShipmentHandler(router)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/shipments/"+id.String(), nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetShipment", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_CreateShipment(t *testing.T) {
+	orderID, _ := uuid.NewV4()
+	bookID, _ := uuid.NewV4()
+	adminID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusCreated},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "order not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "not shippable", svcErr: errors.Wrap(model.ErrNotShippable, "test"), wantStatus: http.StatusConflict},
+		{name: "already packed", svcErr: fmt.Errorf("test: %w", model.ErrAllocation), wantStatus: http.StatusConflict},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.CreateShipment)
+
+			create := model.CreateRequest{OrderID: orderID, Items: []model.ItemRequest{{BookID: bookID, Quantity: 1}}}
+			payload, err := json.Marshal(create)
+			require.NoError(t, err)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+			var created *model.Shipment
+			if tt.svcErr == nil {
+				created = &model.Shipment{OrderID: orderID, Status: model.StatusPending}
+			}
+			svc.On("CreateShipment", mock.Anything, adminID.String(), create).Return(created, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_ShipShipment(t *testing.T) {
+	id, _ := uuid.NewV4()
+	adminID, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", svcErr: nil, wantStatus: http.StatusOK},
+		{name: "missing tracking number", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "already shipped", svcErr: errors.Wrap(&model.TransitionError{From: model.StatusShipped, To: model.StatusShipped}, "test"), wantStatus: http.StatusConflict},
+		{name: "order cancelled meanwhile", svcErr: errors.Wrap(&orderModel.TransitionError{From: orderModel.StatusCancelled, To: orderModel.StatusShipped}, "test"), wantStatus: http.StatusConflict},
+		{name: "shipment not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{shipmentId}/ship", hdl.ShipShipment)
+
+			ship := model.ShipRequest{Carrier: "ups", TrackingNumber: "1Z999AA10123456784"}
+			payload, err := json.Marshal(ship)
+			require.NoError(t, err)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/ship", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", adminID.String()))
+
+			var shipped *model.Shipment
+			if tt.svcErr == nil {
+				shipped = &model.Shipment{ID: id, Status: model.StatusShipped, Carrier: ship.Carrier, TrackingNumber: ship.TrackingNumber}
+			}
+			svc.On("ShipShipment", mock.Anything, adminID.String(), id, ship).Return(shipped, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.svcErr == nil {
+				assert.Contains(t, r.Body.String(), `"tracking_number":"1Z999AA10123456784"`)
+			}
+		})
+	}
+}
+
+func TestHandler_GetPickList_UUID_Error(t *testing.T) {
+	hdl, _ := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{shipmentId}/picklist", hdl.GetPickList)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/123/picklist", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.ShipmentService) {
+	svc := &mocks.ShipmentService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
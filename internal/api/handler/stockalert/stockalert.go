@@ -0,0 +1,229 @@
+package stockalert
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.StockAlertService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewStockAlertHandler(r chi.Router) {
+	r.Route("/me/alerts", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+
+		r.Get("/", h.GetAlerts)
+		r.Post("/", h.Subscribe)
+		r.Delete("/{alertId}", h.DeleteAlert)
+	})
+
+	r.Get("/alerts/unsubscribe", h.Unsubscribe)
+
+	r.With(middle.WithAuth, middle.AdminMiddleware).Get("/admin/alerts/demand", h.GetDemand)
+}
+
+// GetAlerts
+//
+// @Summary List my stock alerts
+// @Description Lists the back-in-stock and price-drop alerts of the current user, newest first
+// @Tags alerts
+// @Produce json
+// @Success 200 {array} model.Alert "Alerts"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/alerts [get]
+func (h *Handler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stockalert.GetAlerts"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	alerts, err := h.Svc.GetAlerts(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("error getting stock alerts", slog.String("error", err.Error()))
+		writeAlertError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, alerts)
+}
+
+// Subscribe
+//
+// @Summary Watch a book
+// @Description Emails the current user when the book is back in stock, or when its price drops to or under the threshold. Alerts stay in place after firing. Subscribing again to the same book and kind changes the threshold.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param request body model.Request true "Alert"
+// @Success 201 {object} model.Alert "Alert"
+// @Failure 400 {object} response.ResponseError "Invalid alert, or the book is already in stock or under the threshold"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/alerts [post]
+func (h *Handler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stockalert.Subscribe"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	alert, err := h.Svc.Subscribe(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error subscribing to stock alert", slog.String("error", err.Error()))
+		writeAlertError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, alert)
+}
+
+// DeleteAlert
+//
+// @Summary Stop watching a book
+// @Tags alerts
+// @Produce json
+// @Param alertId path string true "Alert ID"
+// @Success 200 {string} string "Alert deleted"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Alert not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/alerts/{alertId} [delete]
+func (h *Handler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stockalert.DeleteAlert"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "alertId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeleteAlert(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error deleting stock alert", slog.String("error", err.Error()))
+		writeAlertError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Alert deleted")
+}
+
+// Unsubscribe
+//
+// @Summary Unsubscribe from an alert email
+// @Description Target of the unsubscribe link in alert emails. The token identifies the alert, so no login is needed.
+// @Tags alerts
+// @Produce json
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {object} model.Alert "The removed alert"
+// @Failure 400 {object} response.ResponseError "Token missing"
+// @Failure 404 {object} response.ResponseError "Alert not found or already removed"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/alerts/unsubscribe [get]
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stockalert.Unsubscribe"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	alert, err := h.Svc.Unsubscribe(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		h.Log.Error("error unsubscribing from stock alert", slog.String("error", err.Error()))
+		writeAlertError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, alert)
+}
+
+// GetDemand
+//
+// @Summary Demand for out-of-stock books
+// @Description Lists out-of-stock books by how many customers wait for them to come back
+// @Tags alerts
+// @Produce json
+// @Success 200 {array} model.Demand "Demand"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/alerts/demand [get]
+func (h *Handler) GetDemand(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stockalert.GetDemand"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	demand, err := h.Svc.GetDemand(r.Context())
+	if err != nil {
+		h.Log.Error("error getting stock alert demand", slog.String("error", err.Error()))
+		writeAlertError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, demand)
+}
+
+func writeAlertError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
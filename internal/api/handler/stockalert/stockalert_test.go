@@ -0,0 +1,212 @@
+package stockalert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var bookID = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+
+func TestHandler_NewStockAlertHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewStockAlertHandler(router)
+
+	for _, path := range []string{"/me/alerts/", "/admin/alerts/demand"} {
+		t.Run(path, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+	}
+
+	svc.AssertNotCalled(t, "GetAlerts", mock.Anything, mock.Anything)
+	svc.AssertNotCalled(t, "GetDemand", mock.Anything)
+}
+
+func TestHandler_GetAlerts(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetAlerts)
+
+	t.Run("it should list the user's alerts without tokens", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		svc.On("GetAlerts", mock.Anything, "123").Return([]model.Alert{
+			{BookID: bookID, Title: "Animal Farm", Kind: model.KindBackInStock, Token: "secret"},
+		}, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"kind":"back_in_stock"`)
+		assert.NotContains(t, r.Body.String(), "secret")
+	})
+
+	t.Run("it should return 401 without a user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestHandler_Subscribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusCreated},
+		{name: "already under the threshold", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "book not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.Subscribe)
+
+			payload := []byte(`{"book_id":"` + bookID.String() + `","kind":"price_below","threshold":"9.99"}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			threshold := money.MustParse("9.99", money.DefaultCurrency)
+			want := mock.MatchedBy(func(req model.Request) bool {
+				return req.BookID == bookID && req.Kind == model.KindPriceBelow &&
+					req.Threshold != nil && req.Threshold.Equal(threshold)
+			})
+			var alert *model.Alert
+			if tt.svcErr == nil {
+				alert = &model.Alert{BookID: bookID, Kind: model.KindPriceBelow, Threshold: &threshold}
+			}
+			svc.On("Subscribe", mock.Anything, "123", want).Return(alert, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteAlert(t *testing.T) {
+	alertID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", id: alertID.String(), wantStatus: http.StatusOK},
+		{name: "someone else's alert", id: alertID.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/{alertId}", hdl.DeleteAlert)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/"+tt.id, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			svc.On("DeleteAlert", mock.Anything, "123", alertID).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_Unsubscribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "already removed", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			hdl.NewStockAlertHandler(router)
+
+			var alert *model.Alert
+			if tt.svcErr == nil {
+				alert = &model.Alert{BookID: bookID, Title: "Animal Farm", Kind: model.KindBackInStock}
+			}
+			svc.On("Unsubscribe", mock.Anything, "abc").Return(alert, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/alerts/unsubscribe?token=abc", nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_GetDemand(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetDemand)
+
+	t.Run("it should list subscriber counts", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		svc.On("GetDemand", mock.Anything).Return([]model.Demand{
+			{BookID: bookID, Title: "Animal Farm", Subscribers: 12},
+		}, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"subscribers":12`)
+	})
+}
+
+func newTestHandler() (*Handler, *mocks.StockAlertService) {
+	svc := &mocks.StockAlertService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,311 @@
+package webhook
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.WebhookService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewWebhookHandler(r chi.Router) {
+	r.Route("/admin/webhooks", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+		r.Use(middle.AdminMiddleware)
+
+		r.Get("/", h.GetEndpoints)
+		r.Post("/", h.CreateEndpoint)
+		r.Get("/{webhookId}", h.GetEndpoint)
+		r.Put("/{webhookId}", h.UpdateEndpoint)
+		r.Delete("/{webhookId}", h.DeleteEndpoint)
+		r.Get("/{webhookId}/deliveries", h.GetDeliveries)
+		r.Post("/{webhookId}/test", h.SendTestEvent)
+	})
+}
+
+// GetEndpoints
+//
+// @Summary List webhook endpoints
+// @Description Lists every webhook endpoint, including ones disabled after failing persistently. Secrets are not returned.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} model.Endpoint "Endpoints"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks [get]
+func (h *Handler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.GetEndpoints"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	endpoints, err := h.Svc.GetEndpoints(r.Context())
+	if err != nil {
+		h.Log.Error("error getting webhook endpoints", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, endpoints)
+}
+
+// CreateEndpoint
+//
+// @Summary Create a webhook endpoint
+// @Description Subscribes a URL to event types. Deliveries are signed with the secret, which is generated when left empty and is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body model.EndpointRequest true "Endpoint"
+// @Success 201 {object} model.Endpoint "Created endpoint with its secret"
+// @Failure 400 {object} response.ResponseError "Invalid endpoint"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks [post]
+func (h *Handler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.CreateEndpoint"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req model.EndpointRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ep, err := h.Svc.CreateEndpoint(r.Context(), req)
+	if err != nil {
+		h.Log.Error("error creating webhook endpoint", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, ep)
+}
+
+// GetEndpoint
+//
+// @Summary Get a webhook endpoint
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Endpoint ID"
+// @Success 200 {object} model.Endpoint "Endpoint"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Endpoint not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks/{webhookId} [get]
+func (h *Handler) GetEndpoint(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.GetEndpoint"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ep, err := h.Svc.GetEndpoint(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting webhook endpoint", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ep)
+}
+
+// UpdateEndpoint
+//
+// @Summary Update a webhook endpoint
+// @Description Replaces the URL and event types. An empty secret keeps the current one. Enabling a disabled endpoint clears its failure count.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "Endpoint ID"
+// @Param request body model.EndpointRequest true "Endpoint"
+// @Success 200 {object} model.Endpoint "Updated endpoint"
+// @Failure 400 {object} response.ResponseError "Invalid endpoint"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Endpoint not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks/{webhookId} [put]
+func (h *Handler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.UpdateEndpoint"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.EndpointRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ep, err := h.Svc.UpdateEndpoint(r.Context(), id, req)
+	if err != nil {
+		h.Log.Error("error updating webhook endpoint", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, ep)
+}
+
+// DeleteEndpoint
+//
+// @Summary Delete a webhook endpoint
+// @Description Deletes the endpoint and its delivery log
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Endpoint ID"
+// @Success 200 {string} string "Webhook endpoint deleted"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Endpoint not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks/{webhookId} [delete]
+func (h *Handler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.DeleteEndpoint"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeleteEndpoint(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error deleting webhook endpoint", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Webhook endpoint deleted")
+}
+
+// GetDeliveries
+//
+// @Summary List webhook deliveries
+// @Description Lists the latest deliveries to the endpoint, newest first, with attempts, response codes and errors
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Endpoint ID"
+// @Success 200 {array} model.Delivery "Deliveries"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Endpoint not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks/{webhookId}/deliveries [get]
+func (h *Handler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.GetDeliveries"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	deliveries, err := h.Svc.GetDeliveries(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error getting webhook deliveries", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, deliveries)
+}
+
+// SendTestEvent
+//
+// @Summary Send a test event
+// @Description Sends a signed webhook.test delivery to the endpoint once, right away, and returns the logged delivery with the response code. It works on disabled endpoints and does not count towards disabling.
+// @Tags webhooks
+// @Produce json
+// @Param webhookId path string true "Endpoint ID"
+// @Success 200 {object} model.Delivery "Test delivery"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "Admin access required"
+// @Failure 404 {object} response.ResponseError "Endpoint not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/admin/webhooks/{webhookId}/test [post]
+func (h *Handler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.webhook.SendTestEvent"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	d, err := h.Svc.SendTestEvent(r.Context(), id)
+	if err != nil {
+		h.Log.Error("error sending test event", slog.String("error", err.Error()))
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, d)
+}
+
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, service.ErrNotFound)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,297 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_NewWebhookHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewWebhookHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/webhooks/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetEndpoints", mock.Anything)
+	})
+}
+
+func TestHandler_GetEndpoints(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetEndpoints)
+
+	id, _ := uuid.NewV4()
+
+	t.Run("it should list endpoints with why they were disabled", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		endpoints := []model.Endpoint{
+			{
+				ID:                  id,
+				URL:                 "https://erp.example.com/hooks",
+				EventTypes:          []event.Type{"order.paid"},
+				DisabledReason:      "disabled after 50 failed deliveries in a row",
+				ConsecutiveFailures: 50,
+			},
+		}
+		svc.On("GetEndpoints", mock.Anything).Return(endpoints, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"enabled":false`)
+		assert.Contains(t, r.Body.String(), `"consecutive_failures":50`)
+		assert.NotContains(t, r.Body.String(), `"secret"`)
+	})
+}
+
+func TestHandler_CreateEndpoint(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusCreated},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.CreateEndpoint)
+
+			payload := []byte(`{"url":"https://erp.example.com/hooks","event_types":["order.paid","book.price_changed"]}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+
+			matches := mock.MatchedBy(func(req model.EndpointRequest) bool {
+				return req.URL == "https://erp.example.com/hooks" && len(req.EventTypes) == 2 &&
+					req.EventTypes[1] == event.TypeBookPriceChanged && req.Enabled == nil
+			})
+			var ep *model.Endpoint
+			if tt.svcErr == nil {
+				ep = &model.Endpoint{ID: id, URL: "https://erp.example.com/hooks", Secret: "whsec_abc", Enabled: true}
+			}
+			svc.On("CreateEndpoint", mock.Anything, matches).Return(ep, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.svcErr == nil {
+				assert.Contains(t, r.Body.String(), `"secret":"whsec_abc"`)
+			}
+		})
+	}
+}
+
+func TestHandler_CreateEndpoint_BadBody(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Post("/", hdl.CreateEndpoint)
+
+	t.Run("it should return 400", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{")))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		svc.AssertNotCalled(t, "CreateEndpoint", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_UpdateEndpoint(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "validation error", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Put("/{webhookId}", hdl.UpdateEndpoint)
+
+			payload := []byte(`{"url":"https://erp.example.com/hooks","event_types":["order.paid"],"enabled":true}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/"+id.String(), bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+
+			matches := mock.MatchedBy(func(req model.EndpointRequest) bool {
+				return req.Enabled != nil && *req.Enabled
+			})
+			var ep *model.Endpoint
+			if tt.svcErr == nil {
+				ep = &model.Endpoint{ID: id, Enabled: true}
+			}
+			svc.On("UpdateEndpoint", mock.Anything, id, matches).Return(ep, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteEndpoint(t *testing.T) {
+	id, _ := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", path: id.String(), wantStatus: http.StatusOK},
+		{name: "not found", path: id.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "nope", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/{webhookId}", hdl.DeleteEndpoint)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/"+tt.path, nil)
+
+			svc.On("DeleteEndpoint", mock.Anything, id).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_GetDeliveries(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/{webhookId}/deliveries", hdl.GetDeliveries)
+
+	id, _ := uuid.NewV4()
+	deliveryID, _ := uuid.NewV4()
+	code := http.StatusServiceUnavailable
+
+	t.Run("it should return the delivery log with response codes", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/"+id.String()+"/deliveries", nil)
+
+		deliveries := []model.Delivery{
+			{
+				ID:           deliveryID,
+				EndpointID:   id,
+				EventType:    "order.paid",
+				Payload:      []byte(`{}`),
+				Status:       model.DeliveryPending,
+				Attempts:     3,
+				ResponseCode: &code,
+				LastError:    "endpoint answered 503",
+			},
+		}
+		svc.On("GetDeliveries", mock.Anything, id).Return(deliveries, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"response_code":503`)
+		assert.Contains(t, r.Body.String(), `"attempts":3`)
+	})
+}
+
+func TestHandler_SendTestEvent(t *testing.T) {
+	id, _ := uuid.NewV4()
+	deliveryID, _ := uuid.NewV4()
+	code := http.StatusNoContent
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{webhookId}/test", hdl.SendTestEvent)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+id.String()+"/test", nil)
+
+			var d *model.Delivery
+			if tt.svcErr == nil {
+				d = &model.Delivery{
+					ID:           deliveryID,
+					EndpointID:   id,
+					EventType:    model.TestEventType,
+					Payload:      []byte(`{}`),
+					Status:       model.DeliverySucceeded,
+					Attempts:     1,
+					ResponseCode: &code,
+				}
+			}
+			svc.On("SendTestEvent", mock.Anything, id).Return(d, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+			if tt.svcErr == nil {
+				assert.Contains(t, r.Body.String(), `"status":"succeeded"`)
+				assert.Contains(t, r.Body.String(), `"event_type":"webhook.test"`)
+			}
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.WebhookService) {
+	svc := &mocks.WebhookService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -0,0 +1,527 @@
+package wishlist
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.WishlistService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewWishlistHandler(r chi.Router) {
+	r.Route("/me/wishlists", func(r chi.Router) {
+		r.Use(middle.WithAuth)
+
+		r.Get("/", h.GetWishlists)
+		r.Post("/", h.CreateWishlist)
+		r.Get("/{wishlistId}", h.GetWishlist)
+		r.Put("/{wishlistId}", h.UpdateWishlist)
+		r.Delete("/{wishlistId}", h.DeleteWishlist)
+		r.Post("/{wishlistId}/items", h.AddItem)
+		r.Delete("/{wishlistId}/items/{bookId}", h.RemoveItem)
+		r.Post("/{wishlistId}/items/{bookId}/cart", h.MoveToCart)
+		r.Post("/{wishlistId}/save-for-later", h.SaveForLater)
+	})
+
+	r.Get("/wishlists/shared/{token}", h.GetSharedWishlist)
+}
+
+// GetWishlists
+//
+// @Summary List my wishlists
+// @Description Lists the current user's wishlists, oldest first, with their books at current prices
+// @Tags wishlists
+// @Produce json
+// @Success 200 {array} model.Wishlist "Wishlists"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists [get]
+func (h *Handler) GetWishlists(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.GetWishlists"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	lists, err := h.Svc.GetWishlists(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("error getting wishlists", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, lists)
+}
+
+// CreateWishlist
+//
+// @Summary Create a wishlist
+// @Description Creates a named wishlist. A shared wishlist comes with a share_token for its link, /wishlists/{share_token}.
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param request body model.Request true "Wishlist"
+// @Success 201 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid name"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists [post]
+func (h *Handler) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.CreateWishlist"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.CreateWishlist(r.Context(), userID, req)
+	if err != nil {
+		h.Log.Error("error creating wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusCreated, list)
+}
+
+// GetWishlist
+//
+// @Summary Get one of my wishlists
+// @Tags wishlists
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId} [get]
+func (h *Handler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.GetWishlist"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.GetWishlist(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error getting wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// UpdateWishlist
+//
+// @Summary Rename or share a wishlist
+// @Description Sets the wishlist's name and whether it is shared. Making it private stops its link from working.
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Param request body model.Request true "Wishlist"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or name"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId} [put]
+func (h *Handler) UpdateWishlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.UpdateWishlist"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.UpdateWishlist(r.Context(), userID, id, req)
+	if err != nil {
+		h.Log.Error("error updating wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// DeleteWishlist
+//
+// @Summary Delete a wishlist
+// @Tags wishlists
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Success 200 {string} string "Wishlist deleted"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId} [delete]
+func (h *Handler) DeleteWishlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.DeleteWishlist"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.Svc.DeleteWishlist(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("error deleting wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, "Wishlist deleted")
+}
+
+// AddItem
+//
+// @Summary Add a book to a wishlist
+// @Description Adding a book that is already on the wishlist changes nothing. No stock is reserved.
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Param request body model.ItemRequest true "Book"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or book"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist or book not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId}/items [post]
+func (h *Handler) AddItem(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.AddItem"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ItemRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.AddItem(r.Context(), userID, id, req)
+	if err != nil {
+		h.Log.Error("error adding to wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// RemoveItem
+//
+// @Summary Remove a book from a wishlist
+// @Tags wishlists
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Param bookId path string true "Book ID"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found, or the book is not on it"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId}/items/{bookId} [delete]
+func (h *Handler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.RemoveItem"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, bookID, err := itemParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.RemoveItem(r.Context(), userID, id, bookID)
+	if err != nil {
+		h.Log.Error("error removing from wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// MoveToCart
+//
+// @Summary Move a wishlist book into the cart
+// @Description Adds copies of the book to the cart, reserving them, and takes it off the wishlist
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Param bookId path string true "Book ID"
+// @Param request body model.MoveToCartRequest false "Quantity, one copy by default"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or quantity"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found, or the book is not on it"
+// @Failure 409 {object} response.ResponseError "Not enough stock"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId}/items/{bookId}/cart [post]
+func (h *Handler) MoveToCart(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.MoveToCart"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, bookID, err := itemParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.MoveToCartRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+			response.WriteError(w, r, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	list, err := h.Svc.MoveToCart(r.Context(), userID, id, bookID, req)
+	if err != nil {
+		h.Log.Error("error moving wishlist book to cart", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// SaveForLater
+//
+// @Summary Save a cart book for later
+// @Description Moves the book out of the cart, releasing its reservation, and onto the wishlist
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param wishlistId path string true "Wishlist ID"
+// @Param request body model.ItemRequest true "Book"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 400 {object} response.ResponseError "Invalid UUID format or book"
+// @Failure 401 {object} response.ResponseError "User not logged in"
+// @Failure 404 {object} response.ResponseError "Wishlist not found, or the book is not in the cart"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/me/wishlists/{wishlistId}/save-for-later [post]
+func (h *Handler) SaveForLater(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.SaveForLater"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		response.WriteError(w, r, http.StatusUnauthorized, errors.New("user not logged in"))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req model.ItemRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		h.Log.Error("failed to decode request body", slog.String("error", err.Error()))
+		response.WriteError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.Svc.SaveForLater(r.Context(), userID, id, req)
+	if err != nil {
+		h.Log.Error("error saving cart book for later", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+// GetSharedWishlist
+//
+// @Summary Get a shared wishlist
+// @Description Anyone with the share link can see a shared wishlist and buy from it. Private wishlists are not found.
+// @Tags wishlists
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} model.Wishlist "Wishlist"
+// @Failure 404 {object} response.ResponseError "Wishlist not found or not shared"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /api/v1/wishlists/shared/{token} [get]
+func (h *Handler) GetSharedWishlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.wishlist.GetSharedWishlist"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	list, err := h.Svc.GetSharedWishlist(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.Log.Error("error getting shared wishlist", slog.String("error", err.Error()))
+		writeWishlistError(w, r, err)
+		return
+	}
+
+	response.WriteJson(w, r, http.StatusOK, list)
+}
+
+func itemParams(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.FromString(chi.URLParam(r, "wishlistId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	bookID, err := uuid.FromString(chi.URLParam(r, "bookId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return id, bookID, nil
+}
+
+func writeWishlistError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		response.WriteError(w, r, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrNotFound):
+		response.WriteError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrInsufficientStock):
+		response.WriteError(w, r, http.StatusConflict, err)
+	default:
+		response.WriteError(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,293 @@
+package wishlist
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var (
+	wishlistID = uuid.Must(uuid.FromString("7d1f0a52-3c4e-4b7a-9f2d-1e6c8b5a4d30"))
+	bookID     = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+)
+
+func TestHandler_NewWishlistHandler_RequiresAuth(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	hdl.NewWishlistHandler(router)
+
+	t.Run("it should return 401 without a token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/me/wishlists/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		svc.AssertNotCalled(t, "GetWishlists", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_GetWishlists(t *testing.T) {
+	hdl, svc := newTestHandler()
+
+	router := chi.NewRouter()
+	router.Get("/", hdl.GetWishlists)
+
+	t.Run("it should list the user's wishlists", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+		svc.On("GetWishlists", mock.Anything, "123").Return([]model.Wishlist{
+			{ID: wishlistID, Name: "Birthday", Items: []model.Item{{BookID: bookID, Title: "Animal Farm"}}},
+		}, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"name":"Birthday"`)
+		assert.Contains(t, r.Body.String(), `"title":"Animal Farm"`)
+	})
+
+	t.Run("it should return 401 without a user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestHandler_CreateWishlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusCreated},
+		{name: "blank name", svcErr: fmt.Errorf("test: %w", service.ErrValid), wantStatus: http.StatusBadRequest},
+		{name: "internal error", svcErr: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/", hdl.CreateWishlist)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"name":"Birthday","shared":true}`)))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var list *model.Wishlist
+			if tt.svcErr == nil {
+				list = &model.Wishlist{ID: wishlistID, Name: "Birthday", Shared: true, ShareToken: "abc"}
+			}
+			svc.On("CreateWishlist", mock.Anything, "123", model.Request{Name: "Birthday", Shared: true}).Return(list, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteWishlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", id: wishlistID.String(), wantStatus: http.StatusOK},
+		{name: "someone else's wishlist", id: wishlistID.String(), svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Delete("/{wishlistId}", hdl.DeleteWishlist)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/"+tt.id, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			svc.On("DeleteWishlist", mock.Anything, "123", wishlistID).Return(tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_AddItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "book not found", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{wishlistId}/items", hdl.AddItem)
+
+			payload := []byte(`{"book_id":"` + bookID.String() + `"}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+wishlistID.String()+"/items", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var list *model.Wishlist
+			if tt.svcErr == nil {
+				list = &model.Wishlist{ID: wishlistID, Items: []model.Item{{BookID: bookID}}}
+			}
+			svc.On("AddItem", mock.Anything, "123", wishlistID, model.ItemRequest{BookID: bookID}).Return(list, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_MoveToCart(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		quantity   int
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "default quantity", wantStatus: http.StatusOK},
+		{name: "two copies", body: `{"quantity":2}`, quantity: 2, wantStatus: http.StatusOK},
+		{name: "not on the wishlist", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "out of stock", svcErr: errors.Wrap(repository.ErrInsufficientStock, "test"), wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{wishlistId}/items/{bookId}/cart", hdl.MoveToCart)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+wishlistID.String()+"/items/"+bookID.String()+"/cart", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var list *model.Wishlist
+			if tt.svcErr == nil {
+				list = &model.Wishlist{ID: wishlistID}
+			}
+			svc.On("MoveToCart", mock.Anything, "123", wishlistID, bookID, model.MoveToCartRequest{Quantity: tt.quantity}).Return(list, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_SaveForLater(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not in the cart", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			router.Post("/{wishlistId}/save-for-later", hdl.SaveForLater)
+
+			payload := []byte(`{"book_id":"` + bookID.String() + `"}`)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/"+wishlistID.String()+"/save-for-later", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			var list *model.Wishlist
+			if tt.svcErr == nil {
+				list = &model.Wishlist{ID: wishlistID, Items: []model.Item{{BookID: bookID}}}
+			}
+			svc.On("SaveForLater", mock.Anything, "123", wishlistID, model.ItemRequest{BookID: bookID}).Return(list, tt.svcErr)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func TestHandler_GetSharedWishlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "shared", wantStatus: http.StatusOK},
+		{name: "private or unknown", svcErr: fmt.Errorf("test: %w", service.ErrNotFound), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdl, svc := newTestHandler()
+
+			router := chi.NewRouter()
+			hdl.NewWishlistHandler(router)
+
+			var list *model.Wishlist
+			if tt.svcErr == nil {
+				list = &model.Wishlist{ID: wishlistID, Name: "Birthday", Owner: "ada", Shared: true}
+			}
+			svc.On("GetSharedWishlist", mock.Anything, "abc").Return(list, tt.svcErr)
+
+			r := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/wishlists/shared/abc", nil)
+
+			router.ServeHTTP(r, req)
+
+			assert.Equal(t, tt.wantStatus, r.Code)
+		})
+	}
+}
+
+func newTestHandler() (*Handler, *mocks.WishlistService) {
+	svc := &mocks.WishlistService{}
+	return &Handler{Svc: svc, Log: logger.New(logger.EnvLocal)}, svc
+}
@@ -1,14 +1,33 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	_ "github.com/TeslaMode1X/DockerWireAPI/docs"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/adminorder"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/auth"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/event"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/front"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/notification"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/stockalert"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/user"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/wishlist"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/jobs"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/rs/cors"
@@ -20,11 +39,20 @@ import (
 
 type ServerHTTP struct {
 	router http.Handler
+	jobs   *jobs.Runner
 }
 
 func NewServeHTTP(cfg *config.Config, authHdl *auth.Handler,
 	userHdl *user.Handler, bookHdl *books.Handler,
-	frontHdl *front.Handler, orderHdl *order.Handler) *ServerHTTP {
+	frontHdl *front.Handler, orderHdl *order.Handler,
+	inventoryHdl *inventory.Handler, paymentHdl *payment.Handler,
+	promotionHdl *promotion.Handler, addressHdl *address.Handler,
+	shipmentHdl *shipment.Handler, returnHdl *rma.Handler,
+	eventHdl *event.Handler, webhookHdl *webhook.Handler,
+	notificationHdl *notification.Handler, stockAlertHdl *stockalert.Handler,
+	wishlistHdl *wishlist.Handler, reviewHdl *review.Handler,
+	recommendationHdl *recommendation.Handler, reportHdl *report.Handler, adminOrderHdl *adminorder.Handler,
+	guestHdl *guest.Handler, runner *jobs.Runner) *ServerHTTP {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -44,6 +72,22 @@ func NewServeHTTP(cfg *config.Config, authHdl *auth.Handler,
 			userHdl.NewUserHandler(r)
 			bookHdl.NewBookHandler(r)
 			orderHdl.NewOrderHandler(r)
+			inventoryHdl.NewInventoryHandler(r)
+			paymentHdl.NewPaymentHandler(r)
+			promotionHdl.NewPromotionHandler(r)
+			addressHdl.NewAddressHandler(r)
+			shipmentHdl.NewShipmentHandler(r)
+			returnHdl.NewReturnHandler(r)
+			eventHdl.NewEventHandler(r)
+			webhookHdl.NewWebhookHandler(r)
+			notificationHdl.NewNotificationHandler(r)
+			stockAlertHdl.NewStockAlertHandler(r)
+			wishlistHdl.NewWishlistHandler(r)
+			reviewHdl.NewReviewHandler(r)
+			recommendationHdl.NewRecommendationHandler(r)
+			reportHdl.NewReportHandler(r)
+			adminOrderHdl.NewAdminOrderHandler(r)
+			guestHdl.NewGuestHandler(r)
 		})
 
 		r.Get("/swagger/*", httpSwagger.Handler())
@@ -52,19 +96,22 @@ func NewServeHTTP(cfg *config.Config, authHdl *auth.Handler,
 	handler := cors.New(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:8080"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", middle.IdempotencyKeyHeader, payment.SignatureHeader},
 		AllowCredentials: true,
 	}).Handler(r)
 
 	handler = cors.AllowAll().Handler(r) // should use if and only if API is open
 
-	return &ServerHTTP{router: handler}
+	return &ServerHTTP{router: handler, jobs: runner}
 }
 
 func (sh *ServerHTTP) Start(cfg *config.Config, log *slog.Logger) {
 	fmt.Print(fmt.Sprintf("Port is %s ", cfg.Server.Port))
 	log.Info(fmt.Sprintf("Starting server on port: %s", cfg.Server.Port))
 	addr := cfg.Server.Addr + ":" + cfg.Server.Port
+
+	sh.jobs.Start(context.Background())
+
 	err := http.ListenAndServe(addr, sh.router)
 	if err != nil {
 		log.Error(err.Error())
@@ -0,0 +1,43 @@
+package cart
+
+import (
+	"os"
+	"time"
+)
+
+type Cart struct {
+	ReservationTTL time.Duration `env-default:"15m"` // How long a cart line holds its stock
+	SweepInterval  time.Duration `env-default:"1m"`  // How often expired reservations are released
+	SweepBatchSize int           `env-default:"100"` // Lines released per sweeper transaction
+
+	GuestSecret         string        // Signs the guest cart cookie
+	GuestTTL            time.Duration `env-default:"720h"` // How long an anonymous cart lives after it last changed
+	GuestPurgeInterval  time.Duration `env-default:"1h"`   // How often abandoned guest carts are deleted
+	GuestPurgeBatchSize int           `env-default:"100"`  // Guests deleted per purge statement
+}
+
+// InitCartConfig Returning new cart structure
+func InitCartConfig() Cart {
+	secret := os.Getenv("CART_GUEST_SECRET")
+	if secret == "" {
+		secret = os.Getenv("SECRET_KEY_AUTH")
+	}
+
+	return Cart{
+		ReservationTTL:      durationFromEnv("CART_RESERVATION_TTL", 15*time.Minute),
+		SweepInterval:       durationFromEnv("CART_SWEEP_INTERVAL", time.Minute),
+		SweepBatchSize:      100,
+		GuestSecret:         secret,
+		GuestTTL:            durationFromEnv("CART_GUEST_TTL", 30*24*time.Hour),
+		GuestPurgeInterval:  durationFromEnv("CART_GUEST_PURGE_INTERVAL", time.Hour),
+		GuestPurgeBatchSize: 100,
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
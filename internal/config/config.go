@@ -1,15 +1,37 @@
 package config
 
 import (
+	configCart "github.com/TeslaMode1X/DockerWireAPI/internal/config/cart"
+	configCurrency "github.com/TeslaMode1X/DockerWireAPI/internal/config/currency"
 	configDB "github.com/TeslaMode1X/DockerWireAPI/internal/config/db"
+	configEvents "github.com/TeslaMode1X/DockerWireAPI/internal/config/events"
+	configIdempotency "github.com/TeslaMode1X/DockerWireAPI/internal/config/idempotency"
+	configInvoice "github.com/TeslaMode1X/DockerWireAPI/internal/config/invoice"
+	configMail "github.com/TeslaMode1X/DockerWireAPI/internal/config/mail"
+	configPayment "github.com/TeslaMode1X/DockerWireAPI/internal/config/payment"
+	configRecommendation "github.com/TeslaMode1X/DockerWireAPI/internal/config/recommendation"
 	configServer "github.com/TeslaMode1X/DockerWireAPI/internal/config/server"
+	configShipping "github.com/TeslaMode1X/DockerWireAPI/internal/config/shipping"
+	configTax "github.com/TeslaMode1X/DockerWireAPI/internal/config/tax"
+	configWebhook "github.com/TeslaMode1X/DockerWireAPI/internal/config/webhook"
 	"github.com/joho/godotenv"
 	"log"
 )
 
 type Config struct {
-	DB     configDB.Database
-	Server configServer.Server
+	DB             configDB.Database
+	Server         configServer.Server
+	Cart           configCart.Cart
+	Payment        configPayment.Payment
+	Idempotency    configIdempotency.Idempotency
+	Currency       configCurrency.Currency
+	Tax            configTax.Tax
+	Shipping       configShipping.Shipping
+	Invoice        configInvoice.Invoice
+	Events         configEvents.Events
+	Webhook        configWebhook.Webhook
+	Mail           configMail.Mail
+	Recommendation configRecommendation.Recommendation
 }
 
 func LoadConfig() *Config {
@@ -22,9 +44,42 @@ func LoadConfig() *Config {
 
 	srv := configServer.InitServerConfig()
 
+	cart := configCart.InitCartConfig()
+
+	payment := configPayment.InitPaymentConfig()
+
+	idempotency := configIdempotency.InitIdempotencyConfig()
+
+	currency := configCurrency.InitCurrencyConfig()
+
+	tax := configTax.InitTaxConfig()
+
+	shipping := configShipping.InitShippingConfig()
+
+	invoice := configInvoice.InitInvoiceConfig()
+
+	events := configEvents.InitEventsConfig()
+
+	webhook := configWebhook.InitWebhookConfig()
+
+	mail := configMail.InitMailConfig()
+
+	recommendation := configRecommendation.InitRecommendationConfig()
+
 	return &Config{
-		DB:     db,
-		Server: srv,
+		DB:             db,
+		Server:         srv,
+		Cart:           cart,
+		Payment:        payment,
+		Idempotency:    idempotency,
+		Currency:       currency,
+		Tax:            tax,
+		Shipping:       shipping,
+		Invoice:        invoice,
+		Events:         events,
+		Webhook:        webhook,
+		Mail:           mail,
+		Recommendation: recommendation,
 	}
 }
 
@@ -0,0 +1,59 @@
+package currency
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"os"
+	"strings"
+	"time"
+)
+
+type Currency struct {
+	Supported     []money.Currency `env-default:"USD"`        // Currencies the storefront offers; the catalogue's own currency is always included
+	RatesProvider string           `env-default:"static"`     // Which ExchangeRateProvider quotes rates: static or http
+	RatesFile     string           `env-default:"rates.json"` // Static provider only: path of the rates table
+	RatesURL      string           // HTTP provider only: endpoint serving the rates table
+	RatesTTL      time.Duration    `env-default:"1h"` // HTTP provider only: how long fetched rates are reused
+}
+
+// InitCurrencyConfig Returning new currency structure
+func InitCurrencyConfig() Currency {
+	provider := os.Getenv("EXCHANGE_RATES_PROVIDER")
+	if provider == "" {
+		provider = "static"
+	}
+
+	file := os.Getenv("EXCHANGE_RATES_FILE")
+	if file == "" {
+		file = "rates.json"
+	}
+
+	return Currency{
+		Supported:     supportedFromEnv("CURRENCY_SUPPORTED"),
+		RatesProvider: provider,
+		RatesFile:     file,
+		RatesURL:      os.Getenv("EXCHANGE_RATES_URL"),
+		RatesTTL:      durationFromEnv("EXCHANGE_RATES_TTL", time.Hour),
+	}
+}
+
+// supportedFromEnv reads a comma separated list of currency codes, skipping
+// unknown ones, and makes sure the catalogue currency comes first.
+func supportedFromEnv(key string) []money.Currency {
+	supported := []money.Currency{money.DefaultCurrency}
+	for _, code := range strings.Split(os.Getenv(key), ",") {
+		currency, err := money.ParseCurrency(code)
+		if err != nil || currency == money.DefaultCurrency {
+			continue
+		}
+		supported = append(supported, currency)
+	}
+	return supported
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
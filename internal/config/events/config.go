@@ -0,0 +1,46 @@
+package events
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type Events struct {
+	DispatchInterval time.Duration `env-default:"5s"`  // How often the outbox is checked for due events
+	BatchSize        int           `env-default:"100"` // Events claimed per dispatcher query
+	Lease            time.Duration `env-default:"1m"`  // How long a claimed event is hidden from other dispatchers
+	MaxAttempts      int           `env-default:"8"`   // Failed deliveries before an event is dead-lettered
+	RetryBaseDelay   time.Duration `env-default:"10s"` // Wait after the first failure, doubled after each one
+	RetryMaxDelay    time.Duration `env-default:"1h"`  // Longest wait between two attempts
+	StuckAfter       time.Duration `env-default:"15m"` // Age after which an undelivered event shows as stuck
+}
+
+// InitEventsConfig Returning new events structure
+func InitEventsConfig() Events {
+	return Events{
+		DispatchInterval: durationFromEnv("EVENTS_DISPATCH_INTERVAL", 5*time.Second),
+		BatchSize:        100,
+		Lease:            time.Minute,
+		MaxAttempts:      intFromEnv("EVENTS_MAX_ATTEMPTS", 8),
+		RetryBaseDelay:   durationFromEnv("EVENTS_RETRY_BASE_DELAY", 10*time.Second),
+		RetryMaxDelay:    durationFromEnv("EVENTS_RETRY_MAX_DELAY", time.Hour),
+		StuckAfter:       durationFromEnv("EVENTS_STUCK_AFTER", 15*time.Minute),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
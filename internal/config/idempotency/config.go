@@ -0,0 +1,29 @@
+package idempotency
+
+import (
+	"os"
+	"time"
+)
+
+type Idempotency struct {
+	TTL            time.Duration `env-default:"24h"` // How long a key and its stored response are kept
+	SweepInterval  time.Duration `env-default:"1h"`  // How often expired keys are deleted
+	SweepBatchSize int           `env-default:"500"` // Keys deleted per sweep
+}
+
+// InitIdempotencyConfig Returning new idempotency structure
+func InitIdempotencyConfig() Idempotency {
+	return Idempotency{
+		TTL:            durationFromEnv("IDEMPOTENCY_TTL", 24*time.Hour),
+		SweepInterval:  durationFromEnv("IDEMPOTENCY_SWEEP_INTERVAL", time.Hour),
+		SweepBatchSize: 500,
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,40 @@
+package invoice
+
+import (
+	"os"
+	"strings"
+)
+
+type Invoice struct {
+	Prefix        string   `env-default:"INV-"`      // Printed in front of invoice numbers
+	SellerName    string   `env-default:"Bookstore"` // Who the invoices are issued by
+	SellerAddress []string // Address lines of the seller, separated by "|" in the environment
+	SellerTaxID   string   // VAT or other tax registration number printed on invoices
+}
+
+// InitInvoiceConfig Returning new invoice structure
+func InitInvoiceConfig() Invoice {
+	prefix, ok := os.LookupEnv("INVOICE_PREFIX")
+	if !ok {
+		prefix = "INV-"
+	}
+
+	name := os.Getenv("INVOICE_SELLER_NAME")
+	if name == "" {
+		name = "Bookstore"
+	}
+
+	var address []string
+	for _, line := range strings.Split(os.Getenv("INVOICE_SELLER_ADDRESS"), "|") {
+		if line = strings.TrimSpace(line); line != "" {
+			address = append(address, line)
+		}
+	}
+
+	return Invoice{
+		Prefix:        prefix,
+		SellerName:    name,
+		SellerAddress: address,
+		SellerTaxID:   os.Getenv("INVOICE_SELLER_TAX_ID"),
+	}
+}
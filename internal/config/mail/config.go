@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type Mail struct {
+	From           string        `env-default:"Bookstore <no-reply@bookstore.local>"` // Sender of every email
+	SinkDir        string        `env-default:"mail"`                                 // Where the development mailer writes .eml files
+	SendInterval   time.Duration `env-default:"10s"`                                  // How often queued emails are sent
+	BatchSize      int           `env-default:"50"`                                   // Emails claimed per query
+	Lease          time.Duration `env-default:"1m"`                                   // How long a claimed email is hidden from other senders
+	MaxAttempts    int           `env-default:"8"`                                    // Attempts before an email is given up
+	RetryBaseDelay time.Duration `env-default:"30s"`                                  // Wait after the first failure, doubled after each one
+	RetryMaxDelay  time.Duration `env-default:"1h"`                                   // Longest wait between two attempts
+	BaseURL        string        `env-default:"http://localhost:8080"`                // Public address of the API, for links in emails
+	AlertInterval  time.Duration `env-default:"15m"`                                  // How often fired stock alerts are batched into emails
+}
+
+// InitMailConfig Returning new mail structure
+func InitMailConfig() Mail {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Bookstore <no-reply@bookstore.local>"
+	}
+
+	dir := os.Getenv("MAIL_SINK_DIR")
+	if dir == "" {
+		dir = "mail"
+	}
+
+	baseURL := os.Getenv("MAIL_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	return Mail{
+		From:           from,
+		SinkDir:        dir,
+		SendInterval:   durationFromEnv("MAIL_SEND_INTERVAL", 10*time.Second),
+		BatchSize:      50,
+		Lease:          time.Minute,
+		MaxAttempts:    intFromEnv("MAIL_MAX_ATTEMPTS", 8),
+		RetryBaseDelay: durationFromEnv("MAIL_RETRY_BASE_DELAY", 30*time.Second),
+		RetryMaxDelay:  durationFromEnv("MAIL_RETRY_MAX_DELAY", time.Hour),
+		BaseURL:        baseURL,
+		AlertInterval:  durationFromEnv("MAIL_ALERT_INTERVAL", 15*time.Minute),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
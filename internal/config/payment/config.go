@@ -0,0 +1,59 @@
+package payment
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"os"
+	"strconv"
+	"time"
+)
+
+type Payment struct {
+	Provider      string      `env-default:"fake"` // Which PaymentProvider checkout charges through
+	WebhookSecret string      // Shared secret used to sign webhook deliveries
+	DeclineOver   money.Money // Fake provider only: amounts above this are declined, 0 disables
+
+	RefundInterval       time.Duration `env-default:"1m"` // How often owed refunds are retried
+	RefundBatchSize      int           `env-default:"50"` // Refunds claimed per query
+	RefundLease          time.Duration `env-default:"1m"` // How long a claimed refund is hidden from other senders
+	RefundMaxAttempts    int           `env-default:"10"` // Attempts before a refund is left to staff
+	RefundRetryBaseDelay time.Duration `env-default:"1m"` // Wait after the first failure, doubled after each one
+	RefundRetryMaxDelay  time.Duration `env-default:"6h"` // Longest wait between two attempts
+}
+
+// InitPaymentConfig Returning new payment structure
+func InitPaymentConfig() Payment {
+	provider := os.Getenv("PAYMENT_PROVIDER")
+	if provider == "" {
+		provider = "fake"
+	}
+
+	declineOver, _ := money.Parse(os.Getenv("PAYMENT_FAKE_DECLINE_OVER"), money.DefaultCurrency)
+
+	return Payment{
+		Provider:             provider,
+		WebhookSecret:        os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		DeclineOver:          declineOver,
+		RefundInterval:       durationFromEnv("PAYMENT_REFUND_INTERVAL", time.Minute),
+		RefundBatchSize:      50,
+		RefundLease:          time.Minute,
+		RefundMaxAttempts:    intFromEnv("PAYMENT_REFUND_MAX_ATTEMPTS", 10),
+		RefundRetryBaseDelay: durationFromEnv("PAYMENT_REFUND_RETRY_BASE_DELAY", time.Minute),
+		RefundRetryMaxDelay:  durationFromEnv("PAYMENT_REFUND_RETRY_MAX_DELAY", 6*time.Hour),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
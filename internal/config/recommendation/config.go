@@ -0,0 +1,36 @@
+package recommendation
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type Recommendation struct {
+	RebuildInterval time.Duration `env-default:"1h"` // How often book similarities are recomputed from paid orders
+	Neighbours      int           `env-default:"20"` // Similar books kept per book
+}
+
+// InitRecommendationConfig Returning new recommendation structure
+func InitRecommendationConfig() Recommendation {
+	return Recommendation{
+		RebuildInterval: durationFromEnv("RECOMMENDATION_REBUILD_INTERVAL", time.Hour),
+		Neighbours:      intFromEnv("RECOMMENDATION_NEIGHBOURS", 20),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,21 @@
+package shipping
+
+import (
+	"os"
+)
+
+type Shipping struct {
+	RatesFile string `env-default:"shipping_rates.json"` // Path of the shipping rates table
+}
+
+// InitShippingConfig Returning new shipping structure
+func InitShippingConfig() Shipping {
+	file := os.Getenv("SHIPPING_RATES_FILE")
+	if file == "" {
+		file = "shipping_rates.json"
+	}
+
+	return Shipping{
+		RatesFile: file,
+	}
+}
@@ -0,0 +1,30 @@
+package tax
+
+import (
+	"os"
+)
+
+type Tax struct {
+	RatesFile string `env-default:"tax_rates.json"` // Path of the tax rules table
+	Country   string `env-default:"US"`             // Where orders are taxed when they have no address of their own
+	Region    string // Subdivision of Country, for countries whose rates differ by region
+}
+
+// InitTaxConfig Returning new tax structure
+func InitTaxConfig() Tax {
+	file := os.Getenv("TAX_RATES_FILE")
+	if file == "" {
+		file = "tax_rates.json"
+	}
+
+	country := os.Getenv("TAX_COUNTRY")
+	if country == "" {
+		country = "US"
+	}
+
+	return Tax{
+		RatesFile: file,
+		Country:   country,
+		Region:    os.Getenv("TAX_REGION"),
+	}
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type Webhook struct {
+	Timeout        time.Duration `env-default:"10s"` // How long an endpoint has to answer
+	SendInterval   time.Duration `env-default:"5s"`  // How often due deliveries are sent
+	BatchSize      int           `env-default:"50"`  // Deliveries claimed per query
+	Lease          time.Duration `env-default:"1m"`  // How long a claimed delivery is hidden from other senders
+	MaxAttempts    int           `env-default:"10"`  // Attempts before a delivery is given up
+	RetryBaseDelay time.Duration `env-default:"30s"` // Wait after the first failure, doubled after each one
+	RetryMaxDelay  time.Duration `env-default:"6h"`  // Longest wait between two attempts
+	DisableAfter   int           `env-default:"50"`  // Failed attempts in a row after which an endpoint is disabled
+}
+
+// InitWebhookConfig Returning new webhook structure
+func InitWebhookConfig() Webhook {
+	return Webhook{
+		Timeout:        durationFromEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+		SendInterval:   durationFromEnv("WEBHOOK_SEND_INTERVAL", 5*time.Second),
+		BatchSize:      50,
+		Lease:          time.Minute,
+		MaxAttempts:    intFromEnv("WEBHOOK_MAX_ATTEMPTS", 10),
+		RetryBaseDelay: durationFromEnv("WEBHOOK_RETRY_BASE_DELAY", 30*time.Second),
+		RetryMaxDelay:  durationFromEnv("WEBHOOK_RETRY_MAX_DELAY", 6*time.Hour),
+		DisableAfter:   intFromEnv("WEBHOOK_DISABLE_AFTER", 50),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -7,11 +7,34 @@ import (
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/db"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/adminorder"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/auth"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/currency"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/event"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/front"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/idempotency"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/invoice"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/jobs"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/notification"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/tax"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/user"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/wishlist"
 	"github.com/google/wire"
 	"log/slog"
 )
@@ -23,6 +46,29 @@ func InitializeAPI(cfg *config.Config, log *slog.Logger) (*api.ServerHTTP, error
 		books.ProviderSet,
 		front.ProviderSet,
 		order.ProviderSet,
+		inventory.ProviderSet,
+		jobs.ProviderSet,
+		payment.ProviderSet,
+		idempotency.ProviderSet,
+		currency.ProviderSet,
+		promotion.ProviderSet,
+		tax.ProviderSet,
+		address.ProviderSet,
+		shipping.ProviderSet,
+		shipment.ProviderSet,
+		rma.ProviderSet,
+		invoice.ProviderSet,
+		event.ProviderSet,
+		webhook.ProviderSet,
+		notification.ProviderSet,
+		stockalert.ProviderSet,
+		wishlist.ProviderSet,
+		review.ProviderSet,
+		recommendation.ProviderSet,
+		report.ProviderSet,
+		adminorder.ProviderSet,
+		guest.ProviderSet,
+		refund.ProviderSet,
 
 		db.ConnectToDB,
 		api.NewServeHTTP,
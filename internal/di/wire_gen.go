@@ -10,11 +10,34 @@ import (
 	"github.com/TeslaMode1X/DockerWireAPI/internal/api"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/db"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/adminorder"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/auth"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/currency"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/event"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/front"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/idempotency"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/invoice"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/jobs"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/notification"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/tax"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/user"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/providers/wishlist"
 	"log/slog"
 )
 
@@ -25,21 +48,112 @@ func InitializeAPI(cfg *config.Config, log *slog.Logger) (*api.ServerHTTP, error
 	if err != nil {
 		return nil, err
 	}
-	repository := auth.ProvideSetRepository(sqlDB)
+	repository := event.ProvideSetRepository(sqlDB)
+	authRepository := auth.ProvideSetRepository(sqlDB, repository)
 	userRepository := user.ProvideUserRepository(sqlDB)
-	service := auth.ProvideSetService(repository, userRepository)
-	handler := auth.ProvideSetHandler(service, log)
+	service := auth.ProvideSetService(authRepository, userRepository)
+	guestRepository := guest.ProvideSetRepository(sqlDB)
+	inventoryRepository := inventory.ProvideSetRepository(sqlDB, repository)
+	invoiceRepository := invoice.ProvideSetRepository(sqlDB)
+	refundRepository := refund.ProvideSetRepository(sqlDB)
+	orderRepository := order.ProvideUserRepository(sqlDB, inventoryRepository, invoiceRepository, repository, refundRepository, cfg)
+	promotionRepository := promotion.ProvideSetRepository(sqlDB)
+	paymentProvider, err := payment.ProvidePaymentProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	paymentRepository := payment.ProvideSetRepository(sqlDB, orderRepository, refundRepository)
+	paymentService := payment.ProvideSetService(paymentProvider, paymentRepository, refundRepository, cfg, log)
+	exchangeRateProvider, err := currency.ProvideExchangeRateProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	currencyService := currency.ProvideSetService(exchangeRateProvider, cfg)
+	taxCalculator, err := tax.ProvideTaxCalculator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	addressRepository := address.ProvideSetRepository(sqlDB)
+	shippingRateProvider, err := shipping.ProvideShippingRateProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	orderService := order.ProvideUserService(orderRepository, promotionRepository, paymentService, currencyService, taxCalculator, addressRepository, shippingRateProvider, cfg, log)
+	addressService := address.ProvideSetService(addressRepository)
+	guestService := guest.ProvideSetService(guestRepository, orderRepository, orderService, addressService)
+	middlewareGuest, err := guest.ProvideMiddleware(guestRepository, cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	handler := auth.ProvideSetHandler(service, guestService, middlewareGuest, log)
 	userService := user.ProvideUserService(userRepository)
 	userHandler := user.ProvideUserHandler(userService, log)
-	booksRepository := books.ProvideSetRepository(sqlDB)
+	booksRepository := books.ProvideSetRepository(sqlDB, inventoryRepository, repository)
 	booksService := books.ProvideSetService(booksRepository)
 	booksHandler := books.ProvideSetHandler(booksService, log)
-	orderRepository := order.ProvideUserRepository(sqlDB)
+	shipmentRepository := shipment.ProvideSetRepository(sqlDB)
+	shipmentService := shipment.ProvideSetService(shipmentRepository, orderRepository)
+	rmaRepository := rma.ProvideSetRepository(sqlDB, inventoryRepository, orderRepository, refundRepository)
+	wishlistRepository := wishlist.ProvideSetRepository(sqlDB)
+	wishlistService := wishlist.ProvideSetService(wishlistRepository, booksRepository, orderRepository, orderService)
+	reviewRepository := review.ProvideSetRepository(sqlDB)
+	reviewService := review.ProvideSetService(reviewRepository, booksRepository)
+	recommendationRepository := recommendation.ProvideSetRepository(sqlDB)
+	recommendationService := recommendation.ProvideSetService(recommendationRepository, booksRepository, orderRepository)
+	reportRepository := report.ProvideSetRepository(sqlDB)
+	reportService := report.ProvideSetService(reportRepository)
+	adminorderRepository := adminorder.ProvideSetRepository(sqlDB)
+	notificationRepository := notification.ProvideSetRepository(sqlDB)
+	notificationService := notification.ProvideSetService(notificationRepository, orderRepository)
+	adminorderService := adminorder.ProvideSetService(adminorderRepository, orderRepository, orderService, paymentRepository, shipmentRepository, notificationService)
 	v := front.ProvideSetTemplates()
-	frontService := front.ProvideSetService(userRepository, repository, booksRepository, orderRepository, v)
-	frontHandler := front.ProvideSetHandler(frontService, userService, log)
-	orderService := order.ProvideUserService(orderRepository)
-	orderHandler := order.ProvideUserHandler(orderService, log)
-	serverHTTP := api.NewServeHTTP(cfg, handler, userHandler, booksHandler, frontHandler, orderHandler)
+	frontService := front.ProvideSetService(userRepository, authRepository, booksRepository, orderRepository, orderService, shipmentRepository, shipmentService, rmaRepository, currencyService, wishlistService, reviewService, recommendationService, reportService, adminorderService, v)
+	idempotencyRepository := idempotency.ProvideSetRepository(sqlDB)
+	middlewareIdempotency := idempotency.ProvideMiddleware(idempotencyRepository, cfg, log)
+	middlewareCurrency := currency.ProvideMiddleware(cfg)
+	frontHandler := front.ProvideSetHandler(frontService, userService, middlewareIdempotency, middlewareCurrency, middlewareGuest, guestService, log)
+	invoiceService := invoice.ProvideSetService(invoiceRepository, orderRepository, cfg)
+	orderHandler := order.ProvideUserHandler(orderService, shipmentService, invoiceService, middlewareIdempotency, middlewareCurrency, middlewareGuest, log)
+	inventoryService := inventory.ProvideSetService(inventoryRepository)
+	inventoryHandler := inventory.ProvideSetHandler(inventoryService, log)
+	paymentHandler := payment.ProvideSetHandler(paymentService, log)
+	promotionService := promotion.ProvideSetService(promotionRepository)
+	promotionHandler := promotion.ProvideSetHandler(promotionService, log)
+	addressHandler := address.ProvideSetHandler(addressService, log)
+	shipmentHandler := shipment.ProvideSetHandler(shipmentService, log)
+	rmaService := rma.ProvideSetService(rmaRepository, paymentService, log)
+	rmaHandler := rma.ProvideSetHandler(rmaService, log)
+	eventService := event.ProvideSetService(repository, cfg)
+	eventHandler := event.ProvideSetHandler(eventService, log)
+	webhookRepository := webhook.ProvideSetRepository(sqlDB)
+	sender := webhook.ProvideSender(cfg)
+	webhookService := webhook.ProvideSetService(webhookRepository, sender)
+	webhookHandler := webhook.ProvideSetHandler(webhookService, log)
+	notificationHandler := notification.ProvideSetHandler(notificationService, log)
+	stockalertRepository := stockalert.ProvideSetRepository(sqlDB)
+	stockalertService := stockalert.ProvideSetService(stockalertRepository, booksRepository)
+	stockalertHandler := stockalert.ProvideSetHandler(stockalertService, log)
+	wishlistHandler := wishlist.ProvideSetHandler(wishlistService, log)
+	reviewHandler := review.ProvideSetHandler(reviewService, log)
+	recommendationHandler := recommendation.ProvideSetHandler(recommendationService, middlewareGuest, log)
+	reportHandler := report.ProvideSetHandler(reportService, log)
+	adminorderHandler := adminorder.ProvideSetHandler(adminorderService, log)
+	guestHandler := guest.ProvideSetHandler(guestService, middlewareGuest, middlewareIdempotency, middlewareCurrency, log)
+	reservationSweeper := order.ProvideReservationSweeper(orderRepository, cfg)
+	keySweeper := idempotency.ProvideKeySweeper(idempotencyRepository, cfg)
+	subscriber := webhook.ProvideSubscriber(webhookRepository)
+	notificationSubscriber := notification.ProvideSubscriber(notificationRepository, orderRepository, shipmentRepository)
+	watcher := stockalert.ProvideWatcher(stockalertRepository)
+	subscribers := event.ProvideSubscribers(log, subscriber, notificationSubscriber, watcher)
+	dispatcher := event.ProvideDispatcher(repository, subscribers, cfg, log)
+	webhookDispatcher := webhook.ProvideDispatcher(webhookRepository, sender, cfg, log)
+	mailer := notification.ProvideMailer(cfg)
+	notificationSender := notification.ProvideSender(notificationRepository, mailer, cfg, log)
+	digest := stockalert.ProvideDigest(stockalertRepository, notificationRepository, cfg)
+	builder := recommendation.ProvideBuilder(recommendationRepository, log, cfg)
+	cartPurger := guest.ProvideCartPurger(guestRepository, cfg)
+	refundRetrier := payment.ProvideRefundRetrier(paymentService, cfg)
+	runner := jobs.ProvideRunner(log, reservationSweeper, keySweeper, dispatcher, webhookDispatcher, notificationSender, digest, builder, cartPurger, refundRetrier)
+	serverHTTP := api.NewServeHTTP(cfg, handler, userHandler, booksHandler, frontHandler, orderHandler, inventoryHandler, paymentHandler, promotionHandler, addressHandler, shipmentHandler, rmaHandler, eventHandler, webhookHandler, notificationHandler, stockalertHandler, wishlistHandler, reviewHandler, recommendationHandler, reportHandler, adminorderHandler, guestHandler, runner)
 	return serverHTTP, nil
 }
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name AddressRepository
+type (
+	AddressRepository interface {
+		CreateAddress(ctx context.Context, a address.Address) (uuid.UUID, error)
+		UpdateAddress(ctx context.Context, a address.Address) error
+		DeleteAddress(ctx context.Context, userID, id uuid.UUID) error
+		GetAddresses(ctx context.Context, userID uuid.UUID) ([]address.Address, error)
+		GetAddress(ctx context.Context, userID, id uuid.UUID) (*address.Address, error)
+	}
+)
+
+//go:generate mockery --name AddressService
+type (
+	AddressService interface {
+		CreateAddress(ctx context.Context, userID string, req address.Request) (uuid.UUID, error)
+		UpdateAddress(ctx context.Context, userID string, id uuid.UUID, req address.Request) error
+		DeleteAddress(ctx context.Context, userID string, id uuid.UUID) error
+		GetAddresses(ctx context.Context, userID string) ([]address.Address, error)
+		GetAddress(ctx context.Context, userID string, id uuid.UUID) (*address.Address, error)
+	}
+)
+
+//go:generate mockery --name AddressHandler
+type (
+	AddressHandler interface {
+		CreateAddress(w http.ResponseWriter, r *http.Request)
+		UpdateAddress(w http.ResponseWriter, r *http.Request)
+		DeleteAddress(w http.ResponseWriter, r *http.Request)
+		GetAddresses(w http.ResponseWriter, r *http.Request)
+		GetAddress(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name AdminOrderRepository
+type (
+	AdminOrderRepository interface {
+		SearchOrders(ctx context.Context, filter adminorder.Filter) (*adminorder.Page, error)
+		GetSummary(ctx context.Context, orderID uuid.UUID) (*adminorder.Summary, error)
+		AddNote(ctx context.Context, note adminorder.Note) (*adminorder.Note, error)
+		GetNotes(ctx context.Context, orderID uuid.UUID) ([]adminorder.Note, error)
+	}
+)
+
+//go:generate mockery --name AdminOrderService
+type (
+	AdminOrderService interface {
+		SearchOrders(ctx context.Context, filter adminorder.Filter) (*adminorder.Page, error)
+		GetOrder(ctx context.Context, orderID string) (*adminorder.Detail, error)
+		UpdateStatus(ctx context.Context, adminID, orderID string, req adminorder.StatusRequest) (*adminorder.Detail, error)
+		AddNote(ctx context.Context, adminID, orderID string, req adminorder.NoteRequest) (*adminorder.Note, error)
+		ResendConfirmation(ctx context.Context, orderID string) error
+	}
+)
+
+//go:generate mockery --name AdminOrderHandler
+type (
+	AdminOrderHandler interface {
+		SearchOrders(w http.ResponseWriter, r *http.Request)
+		GetOrder(w http.ResponseWriter, r *http.Request)
+		UpdateStatus(w http.ResponseWriter, r *http.Request)
+		AddNote(w http.ResponseWriter, r *http.Request)
+		ResendConfirmation(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -12,6 +12,7 @@ type (
 	AuthRepository interface {
 		Register(ctx context.Context, user model.Registration) (uuid.UUID, error)
 		Login(ctx context.Context, user model.Login) (uuid.UUID, int, error)
+		ChangePassword(ctx context.Context, userID uuid.UUID, current, next string) error
 	}
 )
 
@@ -20,6 +21,7 @@ type (
 	AuthService interface {
 		Register(ctx context.Context, user model.Registration) (uuid.UUID, error)
 		Login(ctx context.Context, user model.Login) (uuid.UUID, int, error)
+		ChangePassword(ctx context.Context, userID string, req model.PasswordChange) error
 	}
 )
 
@@ -28,5 +30,6 @@ type (
 	AuthHandler interface {
 		Login(w http.ResponseWriter, r *http.Request)
 		Register(w http.ResponseWriter, r *http.Request)
+		ChangePassword(w http.ResponseWriter, r *http.Request)
 	}
 )
@@ -0,0 +1,54 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name EventRepository
+type (
+	EventRepository interface {
+		Append(ctx context.Context, tx *sql.Tx, events ...event.Event) error
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]event.Event, error)
+		MarkDelivered(ctx context.Context, id uuid.UUID) error
+		MarkFailed(ctx context.Context, id uuid.UUID, failure event.Failure) error
+		GetStuck(ctx context.Context, createdBefore time.Time, limit int) ([]event.Event, error)
+		Requeue(ctx context.Context, id uuid.UUID) (*event.Event, error)
+	}
+)
+
+// EventSubscriber reacts to domain events after the change that raised them
+// has committed. Delivery is at least once, so Handle has to tolerate seeing
+// the same event again.
+//
+//go:generate mockery --name EventSubscriber
+type (
+	EventSubscriber interface {
+		// Name identifies the subscriber in the outbox; it must not change
+		// once events have been delivered to it.
+		Name() string
+		// Topics lists the event types the subscriber wants, or none for all.
+		Topics() []event.Type
+		Handle(ctx context.Context, e event.Event) error
+	}
+)
+
+//go:generate mockery --name EventService
+type (
+	EventService interface {
+		GetStuckEvents(ctx context.Context) ([]event.Event, error)
+		RetryEvent(ctx context.Context, id uuid.UUID) (*event.Event, error)
+	}
+)
+
+//go:generate mockery --name EventHandler
+type (
+	EventHandler interface {
+		GetStuckEvents(w http.ResponseWriter, r *http.Request)
+		RetryEvent(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+)
+
+//go:generate mockery --name ExchangeRateProvider
+type (
+	ExchangeRateProvider interface {
+		Name() string
+		Rate(ctx context.Context, from, to money.Currency) (money.Rate, error)
+	}
+)
+
+//go:generate mockery --name CurrencyService
+type (
+	CurrencyService interface {
+		Supported() []money.Currency
+		Quote(ctx context.Context, to money.Currency) (money.Rate, error)
+		Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error)
+	}
+)
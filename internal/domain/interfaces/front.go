@@ -2,9 +2,16 @@ package interfaces
 
 import (
 	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
 	modelB "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/mainPageParams"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"net/http"
 	"net/url"
@@ -16,16 +23,28 @@ type (
 		MainPage(ctx context.Context, model mainPageParams.Model) (string, error)
 		RegistrationPage(ctx context.Context, page, errorMessage, successMessage string) (string, error)
 		LoginPage(ctx context.Context, page, errorMessage, successMessage string) (string, error)
-		ProcessRegistration(ctx context.Context, form url.Values) error
-		ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) error
+		ProcessRegistration(ctx context.Context, form url.Values) (uuid.UUID, error)
+		ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) (uuid.UUID, error)
 		AdminPage(ctx context.Context, params mainPageParams.Model) (string, error)
 		EditBook(ctx context.Context, bookID string, book *modelB.Book) error
 		DeleteBook(ctx context.Context, bookID string) error
-		GetCartItems(ctx context.Context, userId string) (*[]orderModels.OrderItemFull, error)
+		GetCartItems(ctx context.Context, userId string, currency money.Currency) (*[]orderModels.OrderItemFull, error)
 		AddCartItems(ctx context.Context, userID string, items *[]orderModels.OrderItem) error
 		RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error
-		CartCheckout(ctx context.Context, userID string) error
+		SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error
+		CartSummary(ctx context.Context, userID string, currency money.Currency) (*promotion.Breakdown, error)
+		ApplyCartPromotion(ctx context.Context, userID, code string, currency money.Currency) (*promotion.Breakdown, error)
+		RemoveCartPromotion(ctx context.Context, userID, code string, currency money.Currency) (*promotion.Breakdown, error)
+		CartShippingOptions(ctx context.Context, userID string, addressID uuid.UUID, currency money.Currency) ([]shipping.Quote, error)
+		SetCartShipping(ctx context.Context, userID string, req orderModel.ShippingRequest, currency money.Currency) (*promotion.Breakdown, error)
+		CartCheckout(ctx context.Context, userID string, currency money.Currency) error
 		HistoryPage(ctx context.Context, userID string) (string, error)
+		AddressesPage(ctx context.Context) (string, error)
+		PickListPage(ctx context.Context, shipmentID uuid.UUID) (string, error)
+		WishlistPage(ctx context.Context, token string, loggedIn bool, currency money.Currency) (string, error)
+		BookPage(ctx context.Context, userID string, bookID uuid.UUID, sort review.Sort, currency money.Currency) (string, error)
+		ReportsPage(ctx context.Context, rng report.Range, interval report.Interval) (string, error)
+		OrdersPage(ctx context.Context, filter adminorder.Filter, orderID string) (string, error)
 	}
 )
 
@@ -43,7 +62,19 @@ type (
 		GetCartItems(w http.ResponseWriter, r *http.Request)
 		AddCartItems(w http.ResponseWriter, r *http.Request)
 		RemoveCartItem(w http.ResponseWriter, r *http.Request)
+		SetCartItemQuantity(w http.ResponseWriter, r *http.Request)
+		CartSummary(w http.ResponseWriter, r *http.Request)
+		ApplyCartPromotion(w http.ResponseWriter, r *http.Request)
+		RemoveCartPromotion(w http.ResponseWriter, r *http.Request)
+		CartShippingOptions(w http.ResponseWriter, r *http.Request)
+		SetCartShipping(w http.ResponseWriter, r *http.Request)
 		CartCheckout(w http.ResponseWriter, r *http.Request)
 		HistoryPage(w http.ResponseWriter, r *http.Request)
+		AddressesPage(w http.ResponseWriter, r *http.Request)
+		PickListPage(w http.ResponseWriter, r *http.Request)
+		WishlistPage(w http.ResponseWriter, r *http.Request)
+		BookPage(w http.ResponseWriter, r *http.Request)
+		ReportsPage(w http.ResponseWriter, r *http.Request)
+		OrdersPage(w http.ResponseWriter, r *http.Request)
 	}
 )
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name GuestRepository
+type (
+	GuestRepository interface {
+		CreateGuest(ctx context.Context) (uuid.UUID, error)
+		SetEmail(ctx context.Context, guestID uuid.UUID, email string) error
+		// DeleteGuest removes a guest that has no orders left.
+		DeleteGuest(ctx context.Context, guestID uuid.UUID) error
+		PurgeAbandoned(ctx context.Context, before time.Time, limit int) (int, error)
+	}
+)
+
+//go:generate mockery --name GuestService
+type (
+	GuestService interface {
+		Checkout(ctx context.Context, guestID string, req guest.CheckoutRequest, currency money.Currency) (*orderModel.Model, error)
+		MergeCart(ctx context.Context, guestID, userID uuid.UUID) error
+	}
+)
+
+//go:generate mockery --name GuestHandler
+type (
+	GuestHandler interface {
+		Checkout(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/idempotency"
+	"time"
+)
+
+//go:generate mockery --name IdempotencyRepository
+type (
+	IdempotencyRepository interface {
+		Acquire(ctx context.Context, record *model.Record) (*model.Record, bool, error)
+		Complete(ctx context.Context, record *model.Record) error
+		Release(ctx context.Context, scope, key string) error
+		DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
+	}
+)
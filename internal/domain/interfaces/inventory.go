@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name InventoryRepository
+type (
+	InventoryRepository interface {
+		RecordMovement(ctx context.Context, tx *sql.Tx, movement inventory.Movement) error
+		AdjustStock(ctx context.Context, movement inventory.Movement) error
+		GetMovementsByBookID(ctx context.Context, bookID uuid.UUID) ([]inventory.Movement, error)
+		GetLevelByBookID(ctx context.Context, bookID uuid.UUID) (*inventory.Level, error)
+	}
+)
+
+//go:generate mockery --name InventoryService
+type (
+	InventoryService interface {
+		GetMovements(ctx context.Context, bookID uuid.UUID) ([]inventory.Movement, error)
+		GetLevel(ctx context.Context, bookID uuid.UUID) (*inventory.Level, error)
+		AdjustStock(ctx context.Context, bookID uuid.UUID, actorID string, req inventory.AdjustmentRequest) error
+	}
+)
+
+//go:generate mockery --name InventoryHandler
+type (
+	InventoryHandler interface {
+		GetMovements(w http.ResponseWriter, r *http.Request)
+		GetLevel(w http.ResponseWriter, r *http.Request)
+		AdjustStock(w http.ResponseWriter, r *http.Request)
+	}
+)
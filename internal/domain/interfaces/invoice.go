@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	"github.com/gofrs/uuid"
+)
+
+//go:generate mockery --name InvoiceRepository
+type (
+	InvoiceRepository interface {
+		IssueInvoice(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*invoice.Invoice, error)
+		IssueMissingInvoice(ctx context.Context, orderID uuid.UUID) (*invoice.Invoice, error)
+		GetInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*invoice.Invoice, error)
+		SavePDF(ctx context.Context, id uuid.UUID, pdf []byte) ([]byte, error)
+	}
+)
+
+//go:generate mockery --name InvoiceService
+type (
+	InvoiceService interface {
+		GetInvoicePDF(ctx context.Context, userID, orderID string, asAdmin bool) (*invoice.File, error)
+	}
+)
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AddressHandler is an autogenerated mock type for the AddressHandler type
+type AddressHandler struct {
+	mock.Mock
+}
+
+// CreateAddress provides a mock function with given fields: w, r
+func (_m *AddressHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeleteAddress provides a mock function with given fields: w, r
+func (_m *AddressHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetAddress provides a mock function with given fields: w, r
+func (_m *AddressHandler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetAddresses provides a mock function with given fields: w, r
+func (_m *AddressHandler) GetAddresses(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdateAddress provides a mock function with given fields: w, r
+func (_m *AddressHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewAddressHandler creates a new instance of AddressHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAddressHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AddressHandler {
+	mock := &AddressHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminOrderHandler is an autogenerated mock type for the AdminOrderHandler type
+type AdminOrderHandler struct {
+	mock.Mock
+}
+
+// AddNote provides a mock function with given fields: w, r
+func (_m *AdminOrderHandler) AddNote(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetOrder provides a mock function with given fields: w, r
+func (_m *AdminOrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ResendConfirmation provides a mock function with given fields: w, r
+func (_m *AdminOrderHandler) ResendConfirmation(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SearchOrders provides a mock function with given fields: w, r
+func (_m *AdminOrderHandler) SearchOrders(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdateStatus provides a mock function with given fields: w, r
+func (_m *AdminOrderHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewAdminOrderHandler creates a new instance of AdminOrderHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminOrderHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminOrderHandler {
+	mock := &AdminOrderHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
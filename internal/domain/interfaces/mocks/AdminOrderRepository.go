@@ -0,0 +1,152 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	adminorder "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// AdminOrderRepository is an autogenerated mock type for the AdminOrderRepository type
+type AdminOrderRepository struct {
+	mock.Mock
+}
+
+// AddNote provides a mock function with given fields: ctx, note
+func (_m *AdminOrderRepository) AddNote(ctx context.Context, note adminorder.Note) (*adminorder.Note, error) {
+	ret := _m.Called(ctx, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNote")
+	}
+
+	var r0 *adminorder.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Note) (*adminorder.Note, error)); ok {
+		return rf(ctx, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Note) *adminorder.Note); ok {
+		r0 = rf(ctx, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, adminorder.Note) error); ok {
+		r1 = rf(ctx, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotes provides a mock function with given fields: ctx, orderID
+func (_m *AdminOrderRepository) GetNotes(ctx context.Context, orderID uuid.UUID) ([]adminorder.Note, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotes")
+	}
+
+	var r0 []adminorder.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]adminorder.Note, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []adminorder.Note); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]adminorder.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSummary provides a mock function with given fields: ctx, orderID
+func (_m *AdminOrderRepository) GetSummary(ctx context.Context, orderID uuid.UUID) (*adminorder.Summary, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *adminorder.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*adminorder.Summary, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *adminorder.Summary); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Summary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchOrders provides a mock function with given fields: ctx, filter
+func (_m *AdminOrderRepository) SearchOrders(ctx context.Context, filter adminorder.Filter) (*adminorder.Page, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchOrders")
+	}
+
+	var r0 *adminorder.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter) (*adminorder.Page, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter) *adminorder.Page); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, adminorder.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAdminOrderRepository creates a new instance of AdminOrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminOrderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminOrderRepository {
+	mock := &AdminOrderRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
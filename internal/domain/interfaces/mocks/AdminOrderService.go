@@ -0,0 +1,168 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	adminorder "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminOrderService is an autogenerated mock type for the AdminOrderService type
+type AdminOrderService struct {
+	mock.Mock
+}
+
+// AddNote provides a mock function with given fields: ctx, adminID, orderID, req
+func (_m *AdminOrderService) AddNote(ctx context.Context, adminID string, orderID string, req adminorder.NoteRequest) (*adminorder.Note, error) {
+	ret := _m.Called(ctx, adminID, orderID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNote")
+	}
+
+	var r0 *adminorder.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, adminorder.NoteRequest) (*adminorder.Note, error)); ok {
+		return rf(ctx, adminID, orderID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, adminorder.NoteRequest) *adminorder.Note); ok {
+		r0 = rf(ctx, adminID, orderID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, adminorder.NoteRequest) error); ok {
+		r1 = rf(ctx, adminID, orderID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrder provides a mock function with given fields: ctx, orderID
+func (_m *AdminOrderService) GetOrder(ctx context.Context, orderID string) (*adminorder.Detail, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrder")
+	}
+
+	var r0 *adminorder.Detail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*adminorder.Detail, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *adminorder.Detail); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Detail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResendConfirmation provides a mock function with given fields: ctx, orderID
+func (_m *AdminOrderService) ResendConfirmation(ctx context.Context, orderID string) error {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendConfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchOrders provides a mock function with given fields: ctx, filter
+func (_m *AdminOrderService) SearchOrders(ctx context.Context, filter adminorder.Filter) (*adminorder.Page, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchOrders")
+	}
+
+	var r0 *adminorder.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter) (*adminorder.Page, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter) *adminorder.Page); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, adminorder.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, adminID, orderID, req
+func (_m *AdminOrderService) UpdateStatus(ctx context.Context, adminID string, orderID string, req adminorder.StatusRequest) (*adminorder.Detail, error) {
+	ret := _m.Called(ctx, adminID, orderID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *adminorder.Detail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, adminorder.StatusRequest) (*adminorder.Detail, error)); ok {
+		return rf(ctx, adminID, orderID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, adminorder.StatusRequest) *adminorder.Detail); ok {
+		r0 = rf(ctx, adminID, orderID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adminorder.Detail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, adminorder.StatusRequest) error); ok {
+		r1 = rf(ctx, adminID, orderID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAdminOrderService creates a new instance of AdminOrderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminOrderService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminOrderService {
+	mock := &AdminOrderService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
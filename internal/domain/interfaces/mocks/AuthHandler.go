@@ -13,6 +13,11 @@ type AuthHandler struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: w, r
+func (_m *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // Login provides a mock function with given fields: w, r
 func (_m *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
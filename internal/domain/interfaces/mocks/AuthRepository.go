@@ -17,6 +17,24 @@ type AuthRepository struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, userID, current, next
+func (_m *AuthRepository) ChangePassword(ctx context.Context, userID uuid.UUID, current string, next string) error {
+	ret := _m.Called(ctx, userID, current, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, userID, current, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Login provides a mock function with given fields: ctx, user
 func (_m *AuthRepository) Login(ctx context.Context, user model.Login) (uuid.UUID, int, error) {
 	ret := _m.Called(ctx, user)
@@ -17,6 +17,24 @@ type AuthService struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, userID, req
+func (_m *AuthService) ChangePassword(ctx context.Context, userID string, req model.PasswordChange) error {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.PasswordChange) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Login provides a mock function with given fields: ctx, user
 func (_m *AuthService) Login(ctx context.Context, user model.Login) (uuid.UUID, int, error) {
 	ret := _m.Called(ctx, user)
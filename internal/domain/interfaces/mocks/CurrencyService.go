@@ -0,0 +1,106 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+)
+
+// CurrencyService is an autogenerated mock type for the CurrencyService type
+type CurrencyService struct {
+	mock.Mock
+}
+
+// Convert provides a mock function with given fields: ctx, amount, to
+func (_m *CurrencyService) Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error) {
+	ret := _m.Called(ctx, amount, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, money.Currency) (money.Money, error)); ok {
+		return rf(ctx, amount, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, money.Currency) money.Money); ok {
+		r0 = rf(ctx, amount, to)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Money, money.Currency) error); ok {
+		r1 = rf(ctx, amount, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Quote provides a mock function with given fields: ctx, to
+func (_m *CurrencyService) Quote(ctx context.Context, to money.Currency) (money.Rate, error) {
+	ret := _m.Called(ctx, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) (money.Rate, error)); ok {
+		return rf(ctx, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) money.Rate); ok {
+		r0 = rf(ctx, to)
+	} else {
+		r0 = ret.Get(0).(money.Rate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Currency) error); ok {
+		r1 = rf(ctx, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Supported provides a mock function with no fields
+func (_m *CurrencyService) Supported() []money.Currency {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Supported")
+	}
+
+	var r0 []money.Currency
+	if rf, ok := ret.Get(0).(func() []money.Currency); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]money.Currency)
+		}
+	}
+
+	return r0
+}
+
+// NewCurrencyService creates a new instance of CurrencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyService {
+	mock := &CurrencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
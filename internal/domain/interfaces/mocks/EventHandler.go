@@ -0,0 +1,38 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventHandler is an autogenerated mock type for the EventHandler type
+type EventHandler struct {
+	mock.Mock
+}
+
+// GetStuckEvents provides a mock function with given fields: w, r
+func (_m *EventHandler) GetStuckEvents(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RetryEvent provides a mock function with given fields: w, r
+func (_m *EventHandler) RetryEvent(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewEventHandler creates a new instance of EventHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventHandler {
+	mock := &EventHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	event "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// EventRepository is an autogenerated mock type for the EventRepository type
+type EventRepository struct {
+	mock.Mock
+}
+
+// Append provides a mock function with given fields: ctx, tx, events
+func (_m *EventRepository) Append(ctx context.Context, tx *sql.Tx, events ...event.Event) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, tx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, ...event.Event) error); ok {
+		r0 = rf(ctx, tx, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease, limit
+func (_m *EventRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]event.Event, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []event.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]event.Event, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []event.Event); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]event.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStuck provides a mock function with given fields: ctx, createdBefore, limit
+func (_m *EventRepository) GetStuck(ctx context.Context, createdBefore time.Time, limit int) ([]event.Event, error) {
+	ret := _m.Called(ctx, createdBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStuck")
+	}
+
+	var r0 []event.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]event.Event, error)); ok {
+		return rf(ctx, createdBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []event.Event); ok {
+		r0 = rf(ctx, createdBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]event.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, createdBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDelivered provides a mock function with given fields: ctx, id
+func (_m *EventRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDelivered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, failure
+func (_m *EventRepository) MarkFailed(ctx context.Context, id uuid.UUID, failure event.Failure) error {
+	ret := _m.Called(ctx, id, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, event.Failure) error); ok {
+		r0 = rf(ctx, id, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Requeue provides a mock function with given fields: ctx, id
+func (_m *EventRepository) Requeue(ctx context.Context, id uuid.UUID) (*event.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Requeue")
+	}
+
+	var r0 *event.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*event.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *event.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*event.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventRepository creates a new instance of EventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventRepository {
+	mock := &EventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	event "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventSubscriber is an autogenerated mock type for the EventSubscriber type
+type EventSubscriber struct {
+	mock.Mock
+}
+
+// Handle provides a mock function with given fields: ctx, e
+func (_m *EventSubscriber) Handle(ctx context.Context, e event.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, event.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Name provides a mock function with no fields
+func (_m *EventSubscriber) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Topics provides a mock function with no fields
+func (_m *EventSubscriber) Topics() []event.Type {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Topics")
+	}
+
+	var r0 []event.Type
+	if rf, ok := ret.Get(0).(func() []event.Type); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]event.Type)
+		}
+	}
+
+	return r0
+}
+
+// NewEventSubscriber creates a new instance of EventSubscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventSubscriber(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventSubscriber {
+	mock := &EventSubscriber{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
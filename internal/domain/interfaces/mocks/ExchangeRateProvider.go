@@ -0,0 +1,76 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+)
+
+// ExchangeRateProvider is an autogenerated mock type for the ExchangeRateProvider type
+type ExchangeRateProvider struct {
+	mock.Mock
+}
+
+// Name provides a mock function with no fields
+func (_m *ExchangeRateProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Rate provides a mock function with given fields: ctx, from, to
+func (_m *ExchangeRateProvider) Rate(ctx context.Context, from money.Currency, to money.Currency) (money.Rate, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rate")
+	}
+
+	var r0 money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency, money.Currency) (money.Rate, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency, money.Currency) money.Rate); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(money.Rate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Currency, money.Currency) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewExchangeRateProvider creates a new instance of ExchangeRateProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExchangeRateProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExchangeRateProvider {
+	mock := &ExchangeRateProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
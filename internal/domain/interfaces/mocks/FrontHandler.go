@@ -18,16 +18,41 @@ func (_m *FrontHandler) AddCartItems(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// AddressesPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) AddressesPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // AdminPage provides a mock function with given fields: w, r
 func (_m *FrontHandler) AdminPage(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// ApplyCartPromotion provides a mock function with given fields: w, r
+func (_m *FrontHandler) ApplyCartPromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// BookPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) BookPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // CartCheckout provides a mock function with given fields: w, r
 func (_m *FrontHandler) CartCheckout(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// CartShippingOptions provides a mock function with given fields: w, r
+func (_m *FrontHandler) CartShippingOptions(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// CartSummary provides a mock function with given fields: w, r
+func (_m *FrontHandler) CartSummary(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // DeleteBookFront provides a mock function with given fields: w, r
 func (_m *FrontHandler) DeleteBookFront(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -63,6 +88,16 @@ func (_m *FrontHandler) MainPage(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// OrdersPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) OrdersPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PickListPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) PickListPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // RegistrationFront provides a mock function with given fields: w, r
 func (_m *FrontHandler) RegistrationFront(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -78,6 +113,31 @@ func (_m *FrontHandler) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// RemoveCartPromotion provides a mock function with given fields: w, r
+func (_m *FrontHandler) RemoveCartPromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ReportsPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) ReportsPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SetCartItemQuantity provides a mock function with given fields: w, r
+func (_m *FrontHandler) SetCartItemQuantity(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SetCartShipping provides a mock function with given fields: w, r
+func (_m *FrontHandler) SetCartShipping(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// WishlistPage provides a mock function with given fields: w, r
+func (_m *FrontHandler) WishlistPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // NewFrontHandler creates a new instance of FrontHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFrontHandler(t interface {
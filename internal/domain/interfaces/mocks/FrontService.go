@@ -3,18 +3,31 @@
 package mocks
 
 import (
-	context "context"
-
+	adminorder "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
 	books "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
 
+	context "context"
+
 	http "net/http"
 
 	mainPageParams "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/mainPageParams"
 
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
+	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+
 	orderItem "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
 
+	promotion "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+
+	report "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+
+	review "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+
+	shipping "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+
 	url "net/url"
 
 	uuid "github.com/gofrs/uuid"
@@ -43,6 +56,34 @@ func (_m *FrontService) AddCartItems(ctx context.Context, userID string, items *
 	return r0
 }
 
+// AddressesPage provides a mock function with given fields: ctx
+func (_m *FrontService) AddressesPage(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddressesPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AdminPage provides a mock function with given fields: ctx, params
 func (_m *FrontService) AdminPage(ctx context.Context, params mainPageParams.Model) (string, error) {
 	ret := _m.Called(ctx, params)
@@ -71,17 +112,75 @@ func (_m *FrontService) AdminPage(ctx context.Context, params mainPageParams.Mod
 	return r0, r1
 }
 
-// CartCheckout provides a mock function with given fields: ctx, userID
-func (_m *FrontService) CartCheckout(ctx context.Context, userID string) error {
-	ret := _m.Called(ctx, userID)
+// ApplyCartPromotion provides a mock function with given fields: ctx, userID, code, currency
+func (_m *FrontService) ApplyCartPromotion(ctx context.Context, userID string, code string, currency money.Currency) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, code, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyCartPromotion")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Currency) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, code, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Currency) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, code, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Currency) error); ok {
+		r1 = rf(ctx, userID, code, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookPage provides a mock function with given fields: ctx, userID, bookID, sort, currency
+func (_m *FrontService) BookPage(ctx context.Context, userID string, bookID uuid.UUID, sort review.Sort, currency money.Currency) (string, error) {
+	ret := _m.Called(ctx, userID, bookID, sort, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BookPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Sort, money.Currency) (string, error)); ok {
+		return rf(ctx, userID, bookID, sort, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Sort, money.Currency) string); ok {
+		r0 = rf(ctx, userID, bookID, sort, currency)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, review.Sort, money.Currency) error); ok {
+		r1 = rf(ctx, userID, bookID, sort, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CartCheckout provides a mock function with given fields: ctx, userID, currency
+func (_m *FrontService) CartCheckout(ctx context.Context, userID string, currency money.Currency) error {
+	ret := _m.Called(ctx, userID, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CartCheckout")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) error); ok {
+		r0 = rf(ctx, userID, currency)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,6 +188,66 @@ func (_m *FrontService) CartCheckout(ctx context.Context, userID string) error {
 	return r0
 }
 
+// CartShippingOptions provides a mock function with given fields: ctx, userID, addressID, currency
+func (_m *FrontService) CartShippingOptions(ctx context.Context, userID string, addressID uuid.UUID, currency money.Currency) ([]shipping.Quote, error) {
+	ret := _m.Called(ctx, userID, addressID, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CartShippingOptions")
+	}
+
+	var r0 []shipping.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, money.Currency) ([]shipping.Quote, error)); ok {
+		return rf(ctx, userID, addressID, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, money.Currency) []shipping.Quote); ok {
+		r0 = rf(ctx, userID, addressID, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipping.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, money.Currency) error); ok {
+		r1 = rf(ctx, userID, addressID, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CartSummary provides a mock function with given fields: ctx, userID, currency
+func (_m *FrontService) CartSummary(ctx context.Context, userID string, currency money.Currency) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CartSummary")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Currency) error); ok {
+		r1 = rf(ctx, userID, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteBook provides a mock function with given fields: ctx, bookID
 func (_m *FrontService) DeleteBook(ctx context.Context, bookID string) error {
 	ret := _m.Called(ctx, bookID)
@@ -125,9 +284,9 @@ func (_m *FrontService) EditBook(ctx context.Context, bookID string, book *books
 	return r0
 }
 
-// GetCartItems provides a mock function with given fields: ctx, userId
-func (_m *FrontService) GetCartItems(ctx context.Context, userId string) (*[]orderItem.OrderItemFull, error) {
-	ret := _m.Called(ctx, userId)
+// GetCartItems provides a mock function with given fields: ctx, userId, currency
+func (_m *FrontService) GetCartItems(ctx context.Context, userId string, currency money.Currency) (*[]orderItem.OrderItemFull, error) {
+	ret := _m.Called(ctx, userId, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCartItems")
@@ -135,19 +294,19 @@ func (_m *FrontService) GetCartItems(ctx context.Context, userId string) (*[]ord
 
 	var r0 *[]orderItem.OrderItemFull
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]orderItem.OrderItemFull, error)); ok {
-		return rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) (*[]orderItem.OrderItemFull, error)); ok {
+		return rf(ctx, userId, currency)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *[]orderItem.OrderItemFull); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) *[]orderItem.OrderItemFull); ok {
+		r0 = rf(ctx, userId, currency)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]orderItem.OrderItemFull)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Currency) error); ok {
+		r1 = rf(ctx, userId, currency)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -239,40 +398,120 @@ func (_m *FrontService) MainPage(ctx context.Context, model mainPageParams.Model
 	return r0, r1
 }
 
+// OrdersPage provides a mock function with given fields: ctx, filter, orderID
+func (_m *FrontService) OrdersPage(ctx context.Context, filter adminorder.Filter, orderID string) (string, error) {
+	ret := _m.Called(ctx, filter, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrdersPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter, string) (string, error)); ok {
+		return rf(ctx, filter, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, adminorder.Filter, string) string); ok {
+		r0 = rf(ctx, filter, orderID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, adminorder.Filter, string) error); ok {
+		r1 = rf(ctx, filter, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PickListPage provides a mock function with given fields: ctx, shipmentID
+func (_m *FrontService) PickListPage(ctx context.Context, shipmentID uuid.UUID) (string, error) {
+	ret := _m.Called(ctx, shipmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PickListPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+		return rf(ctx, shipmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+		r0 = rf(ctx, shipmentID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, shipmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProcessLogin provides a mock function with given fields: ctx, w, r, form
-func (_m *FrontService) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) error {
+func (_m *FrontService) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) (uuid.UUID, error) {
 	ret := _m.Called(ctx, w, r, form)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessLogin")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, http.ResponseWriter, *http.Request, url.Values) error); ok {
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, http.ResponseWriter, *http.Request, url.Values) (uuid.UUID, error)); ok {
+		return rf(ctx, w, r, form)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, http.ResponseWriter, *http.Request, url.Values) uuid.UUID); ok {
 		r0 = rf(ctx, w, r, form)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, http.ResponseWriter, *http.Request, url.Values) error); ok {
+		r1 = rf(ctx, w, r, form)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ProcessRegistration provides a mock function with given fields: ctx, form
-func (_m *FrontService) ProcessRegistration(ctx context.Context, form url.Values) error {
+func (_m *FrontService) ProcessRegistration(ctx context.Context, form url.Values) (uuid.UUID, error) {
 	ret := _m.Called(ctx, form)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessRegistration")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, url.Values) error); ok {
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) (uuid.UUID, error)); ok {
+		return rf(ctx, form)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) uuid.UUID); ok {
 		r0 = rf(ctx, form)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, form)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RegistrationPage provides a mock function with given fields: ctx, page, errorMessage, successMessage
@@ -321,6 +560,140 @@ func (_m *FrontService) RemoveCartItem(ctx context.Context, userID string, bookI
 	return r0
 }
 
+// RemoveCartPromotion provides a mock function with given fields: ctx, userID, code, currency
+func (_m *FrontService) RemoveCartPromotion(ctx context.Context, userID string, code string, currency money.Currency) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, code, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCartPromotion")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Currency) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, code, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Currency) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, code, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Currency) error); ok {
+		r1 = rf(ctx, userID, code, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportsPage provides a mock function with given fields: ctx, rng, interval
+func (_m *FrontService) ReportsPage(ctx context.Context, rng report.Range, interval report.Interval) (string, error) {
+	ret := _m.Called(ctx, rng, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportsPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, report.Interval) (string, error)); ok {
+		return rf(ctx, rng, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, report.Interval) string); ok {
+		r0 = rf(ctx, rng, interval)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range, report.Interval) error); ok {
+		r1 = rf(ctx, rng, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCartItemQuantity provides a mock function with given fields: ctx, userID, bookID, quantity
+func (_m *FrontService) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	ret := _m.Called(ctx, userID, bookID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCartItemQuantity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, bookID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCartShipping provides a mock function with given fields: ctx, userID, req, currency
+func (_m *FrontService) SetCartShipping(ctx context.Context, userID string, req order.ShippingRequest, currency money.Currency) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, req, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCartShipping")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, order.ShippingRequest, money.Currency) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, req, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, order.ShippingRequest, money.Currency) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, req, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, order.ShippingRequest, money.Currency) error); ok {
+		r1 = rf(ctx, userID, req, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WishlistPage provides a mock function with given fields: ctx, token, loggedIn, currency
+func (_m *FrontService) WishlistPage(ctx context.Context, token string, loggedIn bool, currency money.Currency) (string, error) {
+	ret := _m.Called(ctx, token, loggedIn, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WishlistPage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, money.Currency) (string, error)); ok {
+		return rf(ctx, token, loggedIn, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, money.Currency) string); ok {
+		r0 = rf(ctx, token, loggedIn, currency)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, money.Currency) error); ok {
+		r1 = rf(ctx, token, loggedIn, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewFrontService creates a new instance of FrontService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFrontService(t interface {
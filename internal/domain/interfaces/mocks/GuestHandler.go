@@ -0,0 +1,33 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GuestHandler is an autogenerated mock type for the GuestHandler type
+type GuestHandler struct {
+	mock.Mock
+}
+
+// Checkout provides a mock function with given fields: w, r
+func (_m *GuestHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewGuestHandler creates a new instance of GuestHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestHandler {
+	mock := &GuestHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// GuestRepository is an autogenerated mock type for the GuestRepository type
+type GuestRepository struct {
+	mock.Mock
+}
+
+// CreateGuest provides a mock function with given fields: ctx
+func (_m *GuestRepository) CreateGuest(ctx context.Context) (uuid.UUID, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuest")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uuid.UUID, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uuid.UUID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGuest provides a mock function with given fields: ctx, guestID
+func (_m *GuestRepository) DeleteGuest(ctx context.Context, guestID uuid.UUID) error {
+	ret := _m.Called(ctx, guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, guestID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeAbandoned provides a mock function with given fields: ctx, before, limit
+func (_m *GuestRepository) PurgeAbandoned(ctx context.Context, before time.Time, limit int) (int, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAbandoned")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetEmail provides a mock function with given fields: ctx, guestID, email
+func (_m *GuestRepository) SetEmail(ctx context.Context, guestID uuid.UUID, email string) error {
+	ret := _m.Called(ctx, guestID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, guestID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGuestRepository creates a new instance of GuestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestRepository {
+	mock := &GuestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
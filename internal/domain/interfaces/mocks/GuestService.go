@@ -0,0 +1,84 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	guest "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
+	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// GuestService is an autogenerated mock type for the GuestService type
+type GuestService struct {
+	mock.Mock
+}
+
+// Checkout provides a mock function with given fields: ctx, guestID, req, currency
+func (_m *GuestService) Checkout(ctx context.Context, guestID string, req guest.CheckoutRequest, currency money.Currency) (*order.Model, error) {
+	ret := _m.Called(ctx, guestID, req, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Checkout")
+	}
+
+	var r0 *order.Model
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, guest.CheckoutRequest, money.Currency) (*order.Model, error)); ok {
+		return rf(ctx, guestID, req, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, guest.CheckoutRequest, money.Currency) *order.Model); ok {
+		r0 = rf(ctx, guestID, req, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Model)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, guest.CheckoutRequest, money.Currency) error); ok {
+		r1 = rf(ctx, guestID, req, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeCart provides a mock function with given fields: ctx, guestID, userID
+func (_m *GuestService) MergeCart(ctx context.Context, guestID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, guestID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeCart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, guestID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGuestService creates a new instance of GuestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestService {
+	mock := &GuestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
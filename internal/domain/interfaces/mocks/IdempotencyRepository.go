@@ -0,0 +1,133 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	idempotency "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/idempotency"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// Acquire provides a mock function with given fields: ctx, record
+func (_m *IdempotencyRepository) Acquire(ctx context.Context, record *idempotency.Record) (*idempotency.Record, bool, error) {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 *idempotency.Record
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *idempotency.Record) (*idempotency.Record, bool, error)); ok {
+		return rf(ctx, record)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *idempotency.Record) *idempotency.Record); ok {
+		r0 = rf(ctx, record)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*idempotency.Record)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *idempotency.Record) bool); ok {
+		r1 = rf(ctx, record)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *idempotency.Record) error); ok {
+		r2 = rf(ctx, record)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Complete provides a mock function with given fields: ctx, record
+func (_m *IdempotencyRepository) Complete(ctx context.Context, record *idempotency.Record) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *idempotency.Record) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, before, limit
+func (_m *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: ctx, scope, key
+func (_m *IdempotencyRepository) Release(ctx context.Context, scope string, key string) error {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InventoryHandler is an autogenerated mock type for the InventoryHandler type
+type InventoryHandler struct {
+	mock.Mock
+}
+
+// AdjustStock provides a mock function with given fields: w, r
+func (_m *InventoryHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetLevel provides a mock function with given fields: w, r
+func (_m *InventoryHandler) GetLevel(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetMovements provides a mock function with given fields: w, r
+func (_m *InventoryHandler) GetMovements(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewInventoryHandler creates a new instance of InventoryHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInventoryHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InventoryHandler {
+	mock := &InventoryHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	inventory "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// InventoryRepository is an autogenerated mock type for the InventoryRepository type
+type InventoryRepository struct {
+	mock.Mock
+}
+
+// AdjustStock provides a mock function with given fields: ctx, movement
+func (_m *InventoryRepository) AdjustStock(ctx context.Context, movement inventory.Movement) error {
+	ret := _m.Called(ctx, movement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, inventory.Movement) error); ok {
+		r0 = rf(ctx, movement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLevelByBookID provides a mock function with given fields: ctx, bookID
+func (_m *InventoryRepository) GetLevelByBookID(ctx context.Context, bookID uuid.UUID) (*inventory.Level, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLevelByBookID")
+	}
+
+	var r0 *inventory.Level
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*inventory.Level, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *inventory.Level); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Level)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovementsByBookID provides a mock function with given fields: ctx, bookID
+func (_m *InventoryRepository) GetMovementsByBookID(ctx context.Context, bookID uuid.UUID) ([]inventory.Movement, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovementsByBookID")
+	}
+
+	var r0 []inventory.Movement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]inventory.Movement, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []inventory.Movement); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Movement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordMovement provides a mock function with given fields: ctx, tx, movement
+func (_m *InventoryRepository) RecordMovement(ctx context.Context, tx *sql.Tx, movement inventory.Movement) error {
+	ret := _m.Called(ctx, tx, movement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMovement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, inventory.Movement) error); ok {
+		r0 = rf(ctx, tx, movement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewInventoryRepository creates a new instance of InventoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInventoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InventoryRepository {
+	mock := &InventoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	inventory "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// InventoryService is an autogenerated mock type for the InventoryService type
+type InventoryService struct {
+	mock.Mock
+}
+
+// AdjustStock provides a mock function with given fields: ctx, bookID, actorID, req
+func (_m *InventoryService) AdjustStock(ctx context.Context, bookID uuid.UUID, actorID string, req inventory.AdjustmentRequest) error {
+	ret := _m.Called(ctx, bookID, actorID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, inventory.AdjustmentRequest) error); ok {
+		r0 = rf(ctx, bookID, actorID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLevel provides a mock function with given fields: ctx, bookID
+func (_m *InventoryService) GetLevel(ctx context.Context, bookID uuid.UUID) (*inventory.Level, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLevel")
+	}
+
+	var r0 *inventory.Level
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*inventory.Level, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *inventory.Level); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Level)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovements provides a mock function with given fields: ctx, bookID
+func (_m *InventoryService) GetMovements(ctx context.Context, bookID uuid.UUID) ([]inventory.Movement, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovements")
+	}
+
+	var r0 []inventory.Movement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]inventory.Movement, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []inventory.Movement); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Movement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInventoryService creates a new instance of InventoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInventoryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InventoryService {
+	mock := &InventoryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
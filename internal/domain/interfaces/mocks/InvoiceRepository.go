@@ -0,0 +1,154 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	invoice "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// InvoiceRepository is an autogenerated mock type for the InvoiceRepository type
+type InvoiceRepository struct {
+	mock.Mock
+}
+
+// GetInvoiceByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *InvoiceRepository) GetInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*invoice.Invoice, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoiceByOrderID")
+	}
+
+	var r0 *invoice.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*invoice.Invoice, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *invoice.Invoice); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invoice.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueInvoice provides a mock function with given fields: ctx, tx, orderID
+func (_m *InvoiceRepository) IssueInvoice(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*invoice.Invoice, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueInvoice")
+	}
+
+	var r0 *invoice.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, uuid.UUID) (*invoice.Invoice, error)); ok {
+		return rf(ctx, tx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, uuid.UUID) *invoice.Invoice); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invoice.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.Tx, uuid.UUID) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueMissingInvoice provides a mock function with given fields: ctx, orderID
+func (_m *InvoiceRepository) IssueMissingInvoice(ctx context.Context, orderID uuid.UUID) (*invoice.Invoice, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueMissingInvoice")
+	}
+
+	var r0 *invoice.Invoice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*invoice.Invoice, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *invoice.Invoice); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invoice.Invoice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SavePDF provides a mock function with given fields: ctx, id, pdf
+func (_m *InvoiceRepository) SavePDF(ctx context.Context, id uuid.UUID, pdf []byte) ([]byte, error) {
+	ret := _m.Called(ctx, id, pdf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePDF")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte) ([]byte, error)); ok {
+		return rf(ctx, id, pdf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte) []byte); ok {
+		r0 = rf(ctx, id, pdf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []byte) error); ok {
+		r1 = rf(ctx, id, pdf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInvoiceRepository creates a new instance of InvoiceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvoiceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvoiceRepository {
+	mock := &InvoiceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	invoice "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvoiceService is an autogenerated mock type for the InvoiceService type
+type InvoiceService struct {
+	mock.Mock
+}
+
+// GetInvoicePDF provides a mock function with given fields: ctx, userID, orderID, asAdmin
+func (_m *InvoiceService) GetInvoicePDF(ctx context.Context, userID string, orderID string, asAdmin bool) (*invoice.File, error) {
+	ret := _m.Called(ctx, userID, orderID, asAdmin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoicePDF")
+	}
+
+	var r0 *invoice.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*invoice.File, error)); ok {
+		return rf(ctx, userID, orderID, asAdmin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *invoice.File); ok {
+		r0 = rf(ctx, userID, orderID, asAdmin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*invoice.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, userID, orderID, asAdmin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInvoiceService creates a new instance of InvoiceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvoiceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvoiceService {
+	mock := &InvoiceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
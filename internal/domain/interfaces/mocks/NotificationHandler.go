@@ -0,0 +1,38 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotificationHandler is an autogenerated mock type for the NotificationHandler type
+type NotificationHandler struct {
+	mock.Mock
+}
+
+// GetPreferences provides a mock function with given fields: w, r
+func (_m *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdatePreferences provides a mock function with given fields: w, r
+func (_m *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewNotificationHandler creates a new instance of NotificationHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationHandler {
+	mock := &NotificationHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,206 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	notification "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// NotificationRepository is an autogenerated mock type for the NotificationRepository type
+type NotificationRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease, limit
+func (_m *NotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]notification.Message, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []notification.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]notification.Message, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []notification.Message); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notification.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enqueue provides a mock function with given fields: ctx, msg
+func (_m *NotificationRepository) Enqueue(ctx context.Context, msg notification.Message) (bool, error) {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, notification.Message) (bool, error)); ok {
+		return rf(ctx, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, notification.Message) bool); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, notification.Message) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOptOuts provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) GetOptOuts(ctx context.Context, userID uuid.UUID) ([]notification.Kind, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOptOuts")
+	}
+
+	var r0 []notification.Kind
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]notification.Kind, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []notification.Kind); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notification.Kind)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipient provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) GetRecipient(ctx context.Context, userID uuid.UUID) (*notification.Recipient, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipient")
+	}
+
+	var r0 *notification.Recipient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*notification.Recipient, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *notification.Recipient); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*notification.Recipient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, failure
+func (_m *NotificationRepository) MarkFailed(ctx context.Context, id uuid.UUID, failure notification.Failure) error {
+	ret := _m.Called(ctx, id, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, notification.Failure) error); ok {
+		r0 = rf(ctx, id, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkSent provides a mock function with given fields: ctx, id
+func (_m *NotificationRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPreferences provides a mock function with given fields: ctx, userID, req
+func (_m *NotificationRepository) SetPreferences(ctx context.Context, userID uuid.UUID, req notification.PreferencesRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, notification.PreferencesRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationRepository {
+	mock := &NotificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	notification "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// NotificationService is an autogenerated mock type for the NotificationService type
+type NotificationService struct {
+	mock.Mock
+}
+
+// GetPreferences provides a mock function with given fields: ctx, userID
+func (_m *NotificationService) GetPreferences(ctx context.Context, userID string) ([]notification.Preference, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 []notification.Preference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]notification.Preference, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []notification.Preference); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notification.Preference)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResendOrderConfirmation provides a mock function with given fields: ctx, orderID
+func (_m *NotificationService) ResendOrderConfirmation(ctx context.Context, orderID uuid.UUID) error {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendOrderConfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePreferences provides a mock function with given fields: ctx, userID, req
+func (_m *NotificationService) UpdatePreferences(ctx context.Context, userID string, req notification.PreferencesRequest) ([]notification.Preference, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreferences")
+	}
+
+	var r0 []notification.Preference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, notification.PreferencesRequest) ([]notification.Preference, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, notification.PreferencesRequest) []notification.Preference); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notification.Preference)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, notification.PreferencesRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNotificationService creates a new instance of NotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationService {
+	mock := &NotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
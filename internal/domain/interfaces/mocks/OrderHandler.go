@@ -23,11 +23,61 @@ func (_m *OrderHandler) AlterUserOrder(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// ApplyPromotion provides a mock function with given fields: w, r
+func (_m *OrderHandler) ApplyPromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// CancelOrder provides a mock function with given fields: w, r
+func (_m *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // CreateUserOrder provides a mock function with given fields: w, r
 func (_m *OrderHandler) CreateUserOrder(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// GetCart provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetCartItem provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetCartItem(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetCartItems provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetCartItems(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetInvoice provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetPriceBreakdown provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetPriceBreakdown(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetShipments provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetShipments(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetShippingOptions provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetShippingOptions(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetStatusHistory provides a mock function with given fields: w, r
+func (_m *OrderHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // GetUserOrderByUserID provides a mock function with given fields: w, r
 func (_m *OrderHandler) GetUserOrderByUserID(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -38,6 +88,26 @@ func (_m *OrderHandler) GetUsersOrder(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// RemoveCartItem provides a mock function with given fields: w, r
+func (_m *OrderHandler) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RemovePromotion provides a mock function with given fields: w, r
+func (_m *OrderHandler) RemovePromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SetCartItemQuantity provides a mock function with given fields: w, r
+func (_m *OrderHandler) SetCartItemQuantity(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SetShipping provides a mock function with given fields: w, r
+func (_m *OrderHandler) SetShipping(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // NewOrderHandler creates a new instance of OrderHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderHandler(t interface {
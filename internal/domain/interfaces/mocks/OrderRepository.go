@@ -5,12 +5,20 @@ package mocks
 import (
 	context "context"
 
+	address "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
 	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 
 	orderItem "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
 
+	promotion "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+
+	sql "database/sql"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -37,58 +45,32 @@ func (_m *OrderRepository) AddOrderItemIntoOrder(ctx context.Context, userID str
 	return r0
 }
 
-// AlterUserOrder provides a mock function with given fields: ctx, userID
-func (_m *OrderRepository) AlterUserOrder(ctx context.Context, userID string) error {
-	ret := _m.Called(ctx, userID)
+// ChangeStatus provides a mock function with given fields: ctx, tx, change
+func (_m *OrderRepository) ChangeStatus(ctx context.Context, tx *sql.Tx, change order.StatusChange) (order.Status, error) {
+	ret := _m.Called(ctx, tx, change)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AlterUserOrder")
+		panic("no return value specified for ChangeStatus")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, userID)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// AlterUserOrderByID provides a mock function with given fields: ctx, userID, orderID
-func (_m *OrderRepository) AlterUserOrderByID(ctx context.Context, userID uuid.UUID, orderID uuid.UUID) error {
-	ret := _m.Called(ctx, userID, orderID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for AlterUserOrderByID")
+	var r0 order.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, order.StatusChange) (order.Status, error)); ok {
+		return rf(ctx, tx, change)
 	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r0 = rf(ctx, userID, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, order.StatusChange) order.Status); ok {
+		r0 = rf(ctx, tx, change)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(order.Status)
 	}
 
-	return r0
-}
-
-// ChangeStatusOfCart provides a mock function with given fields: ctx, userId, orderId
-func (_m *OrderRepository) ChangeStatusOfCart(ctx context.Context, userId string, orderId string) error {
-	ret := _m.Called(ctx, userId, orderId)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ChangeStatusOfCart")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userId, orderId)
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.Tx, order.StatusChange) error); ok {
+		r1 = rf(ctx, tx, change)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // CheckOrderExists provides a mock function with given fields: ctx, userID
@@ -137,6 +119,36 @@ func (_m *OrderRepository) CreateUserOrder(ctx context.Context, userID string) e
 	return r0
 }
 
+// GetOrderByID provides a mock function with given fields: ctx, orderID
+func (_m *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*order.Model, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderByID")
+	}
+
+	var r0 *order.Model
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*order.Model, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *order.Model); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Model)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrderItemsFromOrderID provides a mock function with given fields: ctx, orderID
 func (_m *OrderRepository) GetOrderItemsFromOrderID(ctx context.Context, orderID string) (*[]orderItem.OrderItemFull, error) {
 	ret := _m.Called(ctx, orderID)
@@ -197,6 +209,66 @@ func (_m *OrderRepository) GetOrdersByUserID(ctx context.Context, userID string)
 	return r0, r1
 }
 
+// GetPricingLines provides a mock function with given fields: ctx, orderID
+func (_m *OrderRepository) GetPricingLines(ctx context.Context, orderID uuid.UUID) ([]promotion.Line, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPricingLines")
+	}
+
+	var r0 []promotion.Line
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]promotion.Line, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []promotion.Line); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]promotion.Line)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, orderID
+func (_m *OrderRepository) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]order.StatusHistoryEntry, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatusHistory")
+	}
+
+	var r0 []order.StatusHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]order.StatusHistoryEntry, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []order.StatusHistoryEntry); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.StatusHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserOrderByUserID provides a mock function with given fields: ctx, orderId
 func (_m *OrderRepository) GetUserOrderByUserID(ctx context.Context, orderId string) (*order.Model, error) {
 	ret := _m.Called(ctx, orderId)
@@ -257,6 +329,52 @@ func (_m *OrderRepository) GetUsersOrder(ctx context.Context, userId string) (*o
 	return r0, r1
 }
 
+// MergeCart provides a mock function with given fields: ctx, fromUserID, toUserID
+func (_m *OrderRepository) MergeCart(ctx context.Context, fromUserID string, toUserID string) error {
+	ret := _m.Called(ctx, fromUserID, toUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeCart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fromUserID, toUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: ctx, limit
+func (_m *OrderRepository) ReleaseExpiredReservations(ctx context.Context, limit int) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveCartItem provides a mock function with given fields: ctx, userID, bookID
 func (_m *OrderRepository) RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error {
 	ret := _m.Called(ctx, userID, bookID)
@@ -275,6 +393,106 @@ func (_m *OrderRepository) RemoveCartItem(ctx context.Context, userID string, bo
 	return r0
 }
 
+// SavePricing provides a mock function with given fields: ctx, orderID, breakdown
+func (_m *OrderRepository) SavePricing(ctx context.Context, orderID uuid.UUID, breakdown promotion.Breakdown) error {
+	ret := _m.Called(ctx, orderID, breakdown)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePricing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, promotion.Breakdown) error); ok {
+		r0 = rf(ctx, orderID, breakdown)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCartItemQuantity provides a mock function with given fields: ctx, userID, bookID, quantity
+func (_m *OrderRepository) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	ret := _m.Called(ctx, userID, bookID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCartItemQuantity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, bookID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCheckoutCurrency provides a mock function with given fields: ctx, orderID, currency, rate
+func (_m *OrderRepository) SetCheckoutCurrency(ctx context.Context, orderID uuid.UUID, currency money.Currency, rate money.Rate) error {
+	ret := _m.Called(ctx, orderID, currency, rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCheckoutCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, money.Currency, money.Rate) error); ok {
+		r0 = rf(ctx, orderID, currency, rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetShipping provides a mock function with given fields: ctx, orderID, shipping, billing, method
+func (_m *OrderRepository) SetShipping(ctx context.Context, orderID uuid.UUID, shipping *address.Snapshot, billing *address.Snapshot, method string) error {
+	ret := _m.Called(ctx, orderID, shipping, billing, method)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetShipping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *address.Snapshot, *address.Snapshot, string) error); ok {
+		r0 = rf(ctx, orderID, shipping, billing, method)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, change
+func (_m *OrderRepository) UpdateStatus(ctx context.Context, change order.StatusChange) (order.Status, error) {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 order.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, order.StatusChange) (order.Status, error)); ok {
+		return rf(ctx, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, order.StatusChange) order.Status); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Get(0).(order.Status)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, order.StatusChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewOrderRepository creates a new instance of OrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderRepository(t interface {
@@ -5,11 +5,21 @@ package mocks
 import (
 	context "context"
 
+	cart "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
+
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
 	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 
 	orderItem "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+
+	promotion "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+
+	shipping "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+
+	uuid "github.com/gofrs/uuid"
 )
 
 // OrderService is an autogenerated mock type for the OrderService type
@@ -35,17 +45,17 @@ func (_m *OrderService) AddOrderItemIntoOrder(ctx context.Context, userID string
 	return r0
 }
 
-// AlterUserOrder provides a mock function with given fields: ctx, userID
-func (_m *OrderService) AlterUserOrder(ctx context.Context, userID string) error {
-	ret := _m.Called(ctx, userID)
+// AlterUserOrder provides a mock function with given fields: ctx, userID, currency
+func (_m *OrderService) AlterUserOrder(ctx context.Context, userID string, currency money.Currency) error {
+	ret := _m.Called(ctx, userID, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AlterUserOrder")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) error); ok {
+		r0 = rf(ctx, userID, currency)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -53,17 +63,83 @@ func (_m *OrderService) AlterUserOrder(ctx context.Context, userID string) error
 	return r0
 }
 
-// AlterUserOrderByID provides a mock function with given fields: ctx, userID, orderID
-func (_m *OrderService) AlterUserOrderByID(ctx context.Context, userID string, orderID string) error {
-	ret := _m.Called(ctx, userID, orderID)
+// AlterUserOrderByID provides a mock function with given fields: ctx, userID, orderID, currency
+func (_m *OrderService) AlterUserOrderByID(ctx context.Context, userID string, orderID string, currency money.Currency) error {
+	ret := _m.Called(ctx, userID, orderID, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AlterUserOrderByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Currency) error); ok {
+		r0 = rf(ctx, userID, orderID, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApplyPromotion provides a mock function with given fields: ctx, userID, code
+func (_m *OrderService) ApplyPromotion(ctx context.Context, userID string, code string) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyPromotion")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cancel provides a mock function with given fields: ctx, userID, orderID, asAdmin, reason
+func (_m *OrderService) Cancel(ctx context.Context, userID string, orderID string, asAdmin bool, reason string) error {
+	ret := _m.Called(ctx, userID, orderID, asAdmin, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, string) error); ok {
+		r0 = rf(ctx, userID, orderID, asAdmin, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Checkout provides a mock function with given fields: ctx, userID, currency
+func (_m *OrderService) Checkout(ctx context.Context, userID string, currency money.Currency) error {
+	ret := _m.Called(ctx, userID, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Checkout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) error); ok {
+		r0 = rf(ctx, userID, currency)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,6 +165,126 @@ func (_m *OrderService) CreateUserOrder(ctx context.Context, userID string) erro
 	return r0
 }
 
+// GetCart provides a mock function with given fields: ctx, userID, currency
+func (_m *OrderService) GetCart(ctx context.Context, userID string, currency money.Currency) (*cart.Cart, error) {
+	ret := _m.Called(ctx, userID, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCart")
+	}
+
+	var r0 *cart.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) (*cart.Cart, error)); ok {
+		return rf(ctx, userID, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Currency) *cart.Cart); ok {
+		r0 = rf(ctx, userID, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cart.Cart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Currency) error); ok {
+		r1 = rf(ctx, userID, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceBreakdown provides a mock function with given fields: ctx, userID
+func (_m *OrderService) GetPriceBreakdown(ctx context.Context, userID string) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceBreakdown")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShippingOptions provides a mock function with given fields: ctx, userID, addressID
+func (_m *OrderService) GetShippingOptions(ctx context.Context, userID string, addressID uuid.UUID) ([]shipping.Quote, error) {
+	ret := _m.Called(ctx, userID, addressID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShippingOptions")
+	}
+
+	var r0 []shipping.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) ([]shipping.Quote, error)); ok {
+		return rf(ctx, userID, addressID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) []shipping.Quote); ok {
+		r0 = rf(ctx, userID, addressID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipping.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, addressID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, userID, orderID
+func (_m *OrderService) GetStatusHistory(ctx context.Context, userID string, orderID string) ([]order.StatusHistoryEntry, error) {
+	ret := _m.Called(ctx, userID, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatusHistory")
+	}
+
+	var r0 []order.StatusHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]order.StatusHistoryEntry, error)); ok {
+		return rf(ctx, userID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []order.StatusHistoryEntry); ok {
+		r0 = rf(ctx, userID, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.StatusHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserOrderByUserID provides a mock function with given fields: ctx, orderId
 func (_m *OrderService) GetUserOrderByUserID(ctx context.Context, orderId string) (*order.Model, error) {
 	ret := _m.Called(ctx, orderId)
@@ -149,6 +345,138 @@ func (_m *OrderService) GetUsersOrder(ctx context.Context, userId string) (*orde
 	return r0, r1
 }
 
+// MergeCart provides a mock function with given fields: ctx, fromUserID, toUserID
+func (_m *OrderService) MergeCart(ctx context.Context, fromUserID string, toUserID string) error {
+	ret := _m.Called(ctx, fromUserID, toUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeCart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fromUserID, toUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveCartItem provides a mock function with given fields: ctx, userID, bookID
+func (_m *OrderService) RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCartItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePromotion provides a mock function with given fields: ctx, userID, code
+func (_m *OrderService) RemovePromotion(ctx context.Context, userID string, code string) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePromotion")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCartItemQuantity provides a mock function with given fields: ctx, userID, bookID, quantity
+func (_m *OrderService) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	ret := _m.Called(ctx, userID, bookID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCartItemQuantity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, bookID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetShipping provides a mock function with given fields: ctx, userID, req
+func (_m *OrderService) SetShipping(ctx context.Context, userID string, req order.ShippingRequest) (*promotion.Breakdown, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetShipping")
+	}
+
+	var r0 *promotion.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, order.ShippingRequest) (*promotion.Breakdown, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, order.ShippingRequest) *promotion.Breakdown); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, order.ShippingRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transition provides a mock function with given fields: ctx, orderID, to, actor, reason
+func (_m *OrderService) Transition(ctx context.Context, orderID uuid.UUID, to order.Status, actor order.Actor, reason string) error {
+	ret := _m.Called(ctx, orderID, to, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, order.Status, order.Actor, string) error); ok {
+		r0 = rf(ctx, orderID, to, actor, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewOrderService creates a new instance of OrderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderService(t interface {
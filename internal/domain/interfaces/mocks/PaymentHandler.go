@@ -0,0 +1,43 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentHandler is an autogenerated mock type for the PaymentHandler type
+type PaymentHandler struct {
+	mock.Mock
+}
+
+// GetRefunds provides a mock function with given fields: w, r
+func (_m *PaymentHandler) GetRefunds(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ResolveRefund provides a mock function with given fields: w, r
+func (_m *PaymentHandler) ResolveRefund(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Webhook provides a mock function with given fields: w, r
+func (_m *PaymentHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewPaymentHandler creates a new instance of PaymentHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentHandler {
+	mock := &PaymentHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
+	payment "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+)
+
+// PaymentProvider is an autogenerated mock type for the PaymentProvider type
+type PaymentProvider struct {
+	mock.Mock
+}
+
+// Capture provides a mock function with given fields: ctx, providerRef
+func (_m *PaymentProvider) Capture(ctx context.Context, providerRef string) (*payment.Result, error) {
+	ret := _m.Called(ctx, providerRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capture")
+	}
+
+	var r0 *payment.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*payment.Result, error)); ok {
+		return rf(ctx, providerRef)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *payment.Result); ok {
+		r0 = rf(ctx, providerRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, providerRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIntent provides a mock function with given fields: ctx, req
+func (_m *PaymentProvider) CreateIntent(ctx context.Context, req payment.IntentRequest) (*payment.Result, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIntent")
+	}
+
+	var r0 *payment.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, payment.IntentRequest) (*payment.Result, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, payment.IntentRequest) *payment.Result); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, payment.IntentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with no fields
+func (_m *PaymentProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Refund provides a mock function with given fields: ctx, providerRef, amount
+func (_m *PaymentProvider) Refund(ctx context.Context, providerRef string, amount money.Money) (*payment.Result, error) {
+	ret := _m.Called(ctx, providerRef, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 *payment.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) (*payment.Result, error)); ok {
+		return rf(ctx, providerRef, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Money) *payment.Result); ok {
+		r0 = rf(ctx, providerRef, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, money.Money) error); ok {
+		r1 = rf(ctx, providerRef, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyWebhook provides a mock function with given fields: payload, signature
+func (_m *PaymentProvider) VerifyWebhook(payload []byte, signature string) (*payment.WebhookEvent, error) {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyWebhook")
+	}
+
+	var r0 *payment.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, string) (*payment.WebhookEvent, error)); ok {
+		return rf(payload, signature)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string) *payment.WebhookEvent); ok {
+		r0 = rf(payload, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(payload, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentProvider creates a new instance of PaymentProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentProvider {
+	mock := &PaymentProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
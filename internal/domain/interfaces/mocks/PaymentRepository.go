@@ -0,0 +1,309 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
+	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+
+	payment "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PaymentRepository is an autogenerated mock type for the PaymentRepository type
+type PaymentRepository struct {
+	mock.Mock
+}
+
+// AddRefund provides a mock function with given fields: ctx, paymentID, amount
+func (_m *PaymentRepository) AddRefund(ctx context.Context, paymentID uuid.UUID, amount money.Money) error {
+	ret := _m.Called(ctx, paymentID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, money.Money) error); ok {
+		r0 = rf(ctx, paymentID, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePayment provides a mock function with given fields: ctx, _a1
+func (_m *PaymentRepository) CreatePayment(ctx context.Context, _a1 *payment.Payment) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *payment.Payment) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPayment provides a mock function with given fields: ctx, id
+func (_m *PaymentRepository) GetPayment(ctx context.Context, id uuid.UUID) (*payment.Payment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayment")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*payment.Payment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *payment.Payment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentByProviderRef provides a mock function with given fields: ctx, provider, providerRef
+func (_m *PaymentRepository) GetPaymentByProviderRef(ctx context.Context, provider string, providerRef string) (*payment.Payment, error) {
+	ret := _m.Called(ctx, provider, providerRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentByProviderRef")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*payment.Payment, error)); ok {
+		return rf(ctx, provider, providerRef)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *payment.Payment); ok {
+		r0 = rf(ctx, provider, providerRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, providerRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *PaymentRepository) GetPaymentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]payment.Payment, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentsByOrderID")
+	}
+
+	var r0 []payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]payment.Payment, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []payment.Payment); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettledPaymentByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *PaymentRepository) GetSettledPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*payment.Payment, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettledPaymentByOrderID")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*payment.Payment, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *payment.Payment); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkWebhookEventProcessed provides a mock function with given fields: ctx, provider, eventID
+func (_m *PaymentRepository) MarkWebhookEventProcessed(ctx context.Context, provider string, eventID string) error {
+	ret := _m.Called(ctx, provider, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWebhookEventProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, provider, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordWebhookEvent provides a mock function with given fields: ctx, provider, event, payload
+func (_m *PaymentRepository) RecordWebhookEvent(ctx context.Context, provider string, event *payment.WebhookEvent, payload []byte) (bool, error) {
+	ret := _m.Called(ctx, provider, event, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordWebhookEvent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *payment.WebhookEvent, []byte) (bool, error)); ok {
+		return rf(ctx, provider, event, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *payment.WebhookEvent, []byte) bool); ok {
+		r0 = rf(ctx, provider, event, payload)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *payment.WebhookEvent, []byte) error); ok {
+		r1 = rf(ctx, provider, event, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseWebhookEvent provides a mock function with given fields: ctx, provider, eventID
+func (_m *PaymentRepository) ReleaseWebhookEvent(ctx context.Context, provider string, eventID string) error {
+	ret := _m.Called(ctx, provider, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseWebhookEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, provider, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetProviderRef provides a mock function with given fields: ctx, paymentID, providerRef
+func (_m *PaymentRepository) SetProviderRef(ctx context.Context, paymentID uuid.UUID, providerRef string) error {
+	ret := _m.Called(ctx, paymentID, providerRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProviderRef")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, paymentID, providerRef)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettlePayment provides a mock function with given fields: ctx, paymentID, status, failureReason, change
+func (_m *PaymentRepository) SettlePayment(ctx context.Context, paymentID uuid.UUID, status payment.Status, failureReason string, change order.StatusChange) (bool, order.Status, error) {
+	ret := _m.Called(ctx, paymentID, status, failureReason, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettlePayment")
+	}
+
+	var r0 bool
+	var r1 order.Status
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, payment.Status, string, order.StatusChange) (bool, order.Status, error)); ok {
+		return rf(ctx, paymentID, status, failureReason, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, payment.Status, string, order.StatusChange) bool); ok {
+		r0 = rf(ctx, paymentID, status, failureReason, change)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, payment.Status, string, order.StatusChange) order.Status); ok {
+		r1 = rf(ctx, paymentID, status, failureReason, change)
+	} else {
+		r1 = ret.Get(1).(order.Status)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, payment.Status, string, order.StatusChange) error); ok {
+		r2 = rf(ctx, paymentID, status, failureReason, change)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRepository {
+	mock := &PaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,162 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+
+	payment "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+
+	refund "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PaymentService is an autogenerated mock type for the PaymentService type
+type PaymentService struct {
+	mock.Mock
+}
+
+// Charge provides a mock function with given fields: ctx, _a1, actor
+func (_m *PaymentService) Charge(ctx context.Context, _a1 *order.Model, actor order.Actor) (*payment.Payment, error) {
+	ret := _m.Called(ctx, _a1, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Charge")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *order.Model, order.Actor) (*payment.Payment, error)); ok {
+		return rf(ctx, _a1, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *order.Model, order.Actor) *payment.Payment); ok {
+		r0 = rf(ctx, _a1, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *order.Model, order.Actor) error); ok {
+		r1 = rf(ctx, _a1, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefunds provides a mock function with given fields: ctx, status
+func (_m *PaymentService) GetRefunds(ctx context.Context, status string) ([]refund.Refund, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefunds")
+	}
+
+	var r0 []refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]refund.Refund, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []refund.Refund); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleWebhook provides a mock function with given fields: ctx, payload, signature
+func (_m *PaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	ret := _m.Called(ctx, payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string) error); ok {
+		r0 = rf(ctx, payload, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IssueRefunds provides a mock function with given fields: ctx, orderID
+func (_m *PaymentService) IssueRefunds(ctx context.Context, orderID uuid.UUID) error {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueRefunds")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveRefund provides a mock function with given fields: ctx, actorID, id
+func (_m *PaymentService) ResolveRefund(ctx context.Context, actorID string, id uuid.UUID) (*refund.Refund, error) {
+	ret := _m.Called(ctx, actorID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRefund")
+	}
+
+	var r0 *refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*refund.Refund, error)); ok {
+		return rf(ctx, actorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *refund.Refund); ok {
+		r0 = rf(ctx, actorID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, actorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentService creates a new instance of PaymentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentService {
+	mock := &PaymentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
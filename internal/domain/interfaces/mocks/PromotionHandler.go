@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PromotionHandler is an autogenerated mock type for the PromotionHandler type
+type PromotionHandler struct {
+	mock.Mock
+}
+
+// CreatePromotion provides a mock function with given fields: w, r
+func (_m *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeactivatePromotion provides a mock function with given fields: w, r
+func (_m *PromotionHandler) DeactivatePromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetPromotionByID provides a mock function with given fields: w, r
+func (_m *PromotionHandler) GetPromotionByID(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetPromotions provides a mock function with given fields: w, r
+func (_m *PromotionHandler) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdatePromotion provides a mock function with given fields: w, r
+func (_m *PromotionHandler) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewPromotionHandler creates a new instance of PromotionHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromotionHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromotionHandler {
+	mock := &PromotionHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,282 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	promotion "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PromotionRepository is an autogenerated mock type for the PromotionRepository type
+type PromotionRepository struct {
+	mock.Mock
+}
+
+// AttachToOrder provides a mock function with given fields: ctx, orderID, promotionID
+func (_m *PromotionRepository) AttachToOrder(ctx context.Context, orderID uuid.UUID, promotionID uuid.UUID) error {
+	ret := _m.Called(ctx, orderID, promotionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachToOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID, promotionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountUserRedemptions provides a mock function with given fields: ctx, promotionID, userID
+func (_m *PromotionRepository) CountUserRedemptions(ctx context.Context, promotionID uuid.UUID, userID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, promotionID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUserRedemptions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (int, error)); ok {
+		return rf(ctx, promotionID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) int); ok {
+		r0 = rf(ctx, promotionID, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, promotionID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePromotion provides a mock function with given fields: ctx, p
+func (_m *PromotionRepository) CreatePromotion(ctx context.Context, p promotion.Promotion) (uuid.UUID, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePromotion")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, promotion.Promotion) (uuid.UUID, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, promotion.Promotion) uuid.UUID); ok {
+		r0 = rf(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, promotion.Promotion) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivatePromotion provides a mock function with given fields: ctx, id
+func (_m *PromotionRepository) DeactivatePromotion(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivatePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DetachFromOrder provides a mock function with given fields: ctx, orderID, promotionID
+func (_m *PromotionRepository) DetachFromOrder(ctx context.Context, orderID uuid.UUID, promotionID uuid.UUID) error {
+	ret := _m.Called(ctx, orderID, promotionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetachFromOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID, promotionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOrderPromotions provides a mock function with given fields: ctx, orderID
+func (_m *PromotionRepository) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]promotion.Promotion, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderPromotions")
+	}
+
+	var r0 []promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]promotion.Promotion, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []promotion.Promotion); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromotionByCode provides a mock function with given fields: ctx, code
+func (_m *PromotionRepository) GetPromotionByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromotionByCode")
+	}
+
+	var r0 *promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*promotion.Promotion, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *promotion.Promotion); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromotionByID provides a mock function with given fields: ctx, id
+func (_m *PromotionRepository) GetPromotionByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromotionByID")
+	}
+
+	var r0 *promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*promotion.Promotion, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *promotion.Promotion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromotions provides a mock function with given fields: ctx
+func (_m *PromotionRepository) GetPromotions(ctx context.Context) ([]promotion.Promotion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromotions")
+	}
+
+	var r0 []promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]promotion.Promotion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []promotion.Promotion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePromotion provides a mock function with given fields: ctx, p
+func (_m *PromotionRepository) UpdatePromotion(ctx context.Context, p promotion.Promotion) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, promotion.Promotion) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPromotionRepository creates a new instance of PromotionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromotionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromotionRepository {
+	mock := &PromotionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
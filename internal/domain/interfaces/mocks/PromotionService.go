@@ -0,0 +1,158 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	promotion "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PromotionService is an autogenerated mock type for the PromotionService type
+type PromotionService struct {
+	mock.Mock
+}
+
+// CreatePromotion provides a mock function with given fields: ctx, req
+func (_m *PromotionService) CreatePromotion(ctx context.Context, req promotion.Request) (uuid.UUID, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePromotion")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, promotion.Request) (uuid.UUID, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, promotion.Request) uuid.UUID); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, promotion.Request) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivatePromotion provides a mock function with given fields: ctx, id
+func (_m *PromotionService) DeactivatePromotion(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivatePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPromotionByID provides a mock function with given fields: ctx, id
+func (_m *PromotionService) GetPromotionByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromotionByID")
+	}
+
+	var r0 *promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*promotion.Promotion, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *promotion.Promotion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPromotions provides a mock function with given fields: ctx
+func (_m *PromotionService) GetPromotions(ctx context.Context) ([]promotion.Promotion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromotions")
+	}
+
+	var r0 []promotion.Promotion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]promotion.Promotion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []promotion.Promotion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]promotion.Promotion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePromotion provides a mock function with given fields: ctx, id, req
+func (_m *PromotionService) UpdatePromotion(ctx context.Context, id uuid.UUID, req promotion.Request) error {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePromotion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, promotion.Request) error); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPromotionService creates a new instance of PromotionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromotionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromotionService {
+	mock := &PromotionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
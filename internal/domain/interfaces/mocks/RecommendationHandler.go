@@ -0,0 +1,38 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RecommendationHandler is an autogenerated mock type for the RecommendationHandler type
+type RecommendationHandler struct {
+	mock.Mock
+}
+
+// GetCartRecommendations provides a mock function with given fields: w, r
+func (_m *RecommendationHandler) GetCartRecommendations(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetRecommendations provides a mock function with given fields: w, r
+func (_m *RecommendationHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewRecommendationHandler creates a new instance of RecommendationHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecommendationHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecommendationHandler {
+	mock := &RecommendationHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
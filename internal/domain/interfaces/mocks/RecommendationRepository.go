@@ -0,0 +1,120 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	recommendation "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// RecommendationRepository is an autogenerated mock type for the RecommendationRepository type
+type RecommendationRepository struct {
+	mock.Mock
+}
+
+// GetAlsoBought provides a mock function with given fields: ctx, bookIDs, limit
+func (_m *RecommendationRepository) GetAlsoBought(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]recommendation.Recommendation, error) {
+	ret := _m.Called(ctx, bookIDs, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlsoBought")
+	}
+
+	var r0 []recommendation.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, int) ([]recommendation.Recommendation, error)); ok {
+		return rf(ctx, bookIDs, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, int) []recommendation.Recommendation); ok {
+		r0 = rf(ctx, bookIDs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]recommendation.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, int) error); ok {
+		r1 = rf(ctx, bookIDs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSimilar provides a mock function with given fields: ctx, bookIDs, limit
+func (_m *RecommendationRepository) GetSimilar(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]recommendation.Recommendation, error) {
+	ret := _m.Called(ctx, bookIDs, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilar")
+	}
+
+	var r0 []recommendation.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, int) ([]recommendation.Recommendation, error)); ok {
+		return rf(ctx, bookIDs, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, int) []recommendation.Recommendation); ok {
+		r0 = rf(ctx, bookIDs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]recommendation.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, int) error); ok {
+		r1 = rf(ctx, bookIDs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rebuild provides a mock function with given fields: ctx, perBook
+func (_m *RecommendationRepository) Rebuild(ctx context.Context, perBook int) (int64, error) {
+	ret := _m.Called(ctx, perBook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rebuild")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, perBook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, perBook)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, perBook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRecommendationRepository creates a new instance of RecommendationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecommendationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecommendationRepository {
+	mock := &RecommendationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	recommendation "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// RecommendationService is an autogenerated mock type for the RecommendationService type
+type RecommendationService struct {
+	mock.Mock
+}
+
+// GetCartRecommendations provides a mock function with given fields: ctx, userID, limit
+func (_m *RecommendationService) GetCartRecommendations(ctx context.Context, userID string, limit int) ([]recommendation.Recommendation, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCartRecommendations")
+	}
+
+	var r0 []recommendation.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]recommendation.Recommendation, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []recommendation.Recommendation); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]recommendation.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecommendations provides a mock function with given fields: ctx, bookID, limit
+func (_m *RecommendationService) GetRecommendations(ctx context.Context, bookID uuid.UUID, limit int) ([]recommendation.Recommendation, error) {
+	ret := _m.Called(ctx, bookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecommendations")
+	}
+
+	var r0 []recommendation.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]recommendation.Recommendation, error)); ok {
+		return rf(ctx, bookID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []recommendation.Recommendation); ok {
+		r0 = rf(ctx, bookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]recommendation.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, bookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRecommendationService creates a new instance of RecommendationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecommendationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecommendationService {
+	mock := &RecommendationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,240 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	refund "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+
+	sql "database/sql"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// RefundRepository is an autogenerated mock type for the RefundRepository type
+type RefundRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease, limit
+func (_m *RefundRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]refund.Refund, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]refund.Refund, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []refund.Refund); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimOrderRefunds provides a mock function with given fields: ctx, orderID, now, lease
+func (_m *RefundRepository) ClaimOrderRefunds(ctx context.Context, orderID uuid.UUID, now time.Time, lease time.Duration) ([]refund.Refund, error) {
+	ret := _m.Called(ctx, orderID, now, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimOrderRefunds")
+	}
+
+	var r0 []refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Duration) ([]refund.Refund, error)); ok {
+		return rf(ctx, orderID, now, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Duration) []refund.Refund); ok {
+		r0 = rf(ctx, orderID, now, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, orderID, now, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRefund provides a mock function with given fields: ctx, tx, _a2
+func (_m *RefundRepository) CreateRefund(ctx context.Context, tx *sql.Tx, _a2 *refund.Refund) error {
+	ret := _m.Called(ctx, tx, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sql.Tx, *refund.Refund) error); ok {
+		r0 = rf(ctx, tx, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRefund provides a mock function with given fields: ctx, id
+func (_m *RefundRepository) GetRefund(ctx context.Context, id uuid.UUID) (*refund.Refund, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefund")
+	}
+
+	var r0 *refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*refund.Refund, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *refund.Refund); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefunds provides a mock function with given fields: ctx, statuses
+func (_m *RefundRepository) GetRefunds(ctx context.Context, statuses []refund.Status) ([]refund.Refund, error) {
+	ret := _m.Called(ctx, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefunds")
+	}
+
+	var r0 []refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []refund.Status) ([]refund.Refund, error)); ok {
+		return rf(ctx, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []refund.Status) []refund.Refund); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []refund.Status) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, failure
+func (_m *RefundRepository) MarkFailed(ctx context.Context, id uuid.UUID, failure refund.Failure) error {
+	ret := _m.Called(ctx, id, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, refund.Failure) error); ok {
+		r0 = rf(ctx, id, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkIssued provides a mock function with given fields: ctx, id, issue
+func (_m *RefundRepository) MarkIssued(ctx context.Context, id uuid.UUID, issue refund.Issue) error {
+	ret := _m.Called(ctx, id, issue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkIssued")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, refund.Issue) error); ok {
+		r0 = rf(ctx, id, issue)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkIssuedByHand provides a mock function with given fields: ctx, id, actor
+func (_m *RefundRepository) MarkIssuedByHand(ctx context.Context, id uuid.UUID, actor uuid.UUID) (*refund.Refund, error) {
+	ret := _m.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkIssuedByHand")
+	}
+
+	var r0 *refund.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*refund.Refund, error)); ok {
+		return rf(ctx, id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *refund.Refund); ok {
+		r0 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*refund.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRefundRepository creates a new instance of RefundRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefundRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefundRepository {
+	mock := &RefundRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
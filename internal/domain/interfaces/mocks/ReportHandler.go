@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReportHandler is an autogenerated mock type for the ReportHandler type
+type ReportHandler struct {
+	mock.Mock
+}
+
+// GetLowStock provides a mock function with given fields: w, r
+func (_m *ReportHandler) GetLowStock(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetRevenue provides a mock function with given fields: w, r
+func (_m *ReportHandler) GetRevenue(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetSummary provides a mock function with given fields: w, r
+func (_m *ReportHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetTopSellers provides a mock function with given fields: w, r
+func (_m *ReportHandler) GetTopSellers(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetTurnover provides a mock function with given fields: w, r
+func (_m *ReportHandler) GetTurnover(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewReportHandler creates a new instance of ReportHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportHandler {
+	mock := &ReportHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
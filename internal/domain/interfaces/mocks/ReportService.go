@@ -0,0 +1,180 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	report "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+)
+
+// ReportService is an autogenerated mock type for the ReportService type
+type ReportService struct {
+	mock.Mock
+}
+
+// GetLowStock provides a mock function with given fields: ctx, rng, threshold, limit
+func (_m *ReportService) GetLowStock(ctx context.Context, rng report.Range, threshold int, limit int) (*report.StockReport, error) {
+	ret := _m.Called(ctx, rng, threshold, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLowStock")
+	}
+
+	var r0 *report.StockReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int, int) (*report.StockReport, error)); ok {
+		return rf(ctx, rng, threshold, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int, int) *report.StockReport); ok {
+		r0 = rf(ctx, rng, threshold, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.StockReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range, int, int) error); ok {
+		r1 = rf(ctx, rng, threshold, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevenue provides a mock function with given fields: ctx, rng, interval
+func (_m *ReportService) GetRevenue(ctx context.Context, rng report.Range, interval report.Interval) (*report.Revenue, error) {
+	ret := _m.Called(ctx, rng, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevenue")
+	}
+
+	var r0 *report.Revenue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, report.Interval) (*report.Revenue, error)); ok {
+		return rf(ctx, rng, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, report.Interval) *report.Revenue); ok {
+		r0 = rf(ctx, rng, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.Revenue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range, report.Interval) error); ok {
+		r1 = rf(ctx, rng, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSummary provides a mock function with given fields: ctx, rng
+func (_m *ReportService) GetSummary(ctx context.Context, rng report.Range) (*report.Summary, error) {
+	ret := _m.Called(ctx, rng)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *report.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range) (*report.Summary, error)); ok {
+		return rf(ctx, rng)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range) *report.Summary); ok {
+		r0 = rf(ctx, rng)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.Summary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range) error); ok {
+		r1 = rf(ctx, rng)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTopSellers provides a mock function with given fields: ctx, rng, limit
+func (_m *ReportService) GetTopSellers(ctx context.Context, rng report.Range, limit int) (*report.TopSellers, error) {
+	ret := _m.Called(ctx, rng, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopSellers")
+	}
+
+	var r0 *report.TopSellers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int) (*report.TopSellers, error)); ok {
+		return rf(ctx, rng, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int) *report.TopSellers); ok {
+		r0 = rf(ctx, rng, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.TopSellers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range, int) error); ok {
+		r1 = rf(ctx, rng, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTurnover provides a mock function with given fields: ctx, rng, limit
+func (_m *ReportService) GetTurnover(ctx context.Context, rng report.Range, limit int) (*report.StockReport, error) {
+	ret := _m.Called(ctx, rng, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTurnover")
+	}
+
+	var r0 *report.StockReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int) (*report.StockReport, error)); ok {
+		return rf(ctx, rng, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, report.Range, int) *report.StockReport); ok {
+		r0 = rf(ctx, rng, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.StockReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, report.Range, int) error); ok {
+		r1 = rf(ctx, rng, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReportService creates a new instance of ReportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportService {
+	mock := &ReportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReturnHandler is an autogenerated mock type for the ReturnHandler type
+type ReturnHandler struct {
+	mock.Mock
+}
+
+// ApproveReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) ApproveReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetMyReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) GetMyReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetMyReturns provides a mock function with given fields: w, r
+func (_m *ReturnHandler) GetMyReturns(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) GetReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetReturns provides a mock function with given fields: w, r
+func (_m *ReturnHandler) GetReturns(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ReceiveReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) ReceiveReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RejectReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) RejectReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RequestReturn provides a mock function with given fields: w, r
+func (_m *ReturnHandler) RequestReturn(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewReturnHandler creates a new instance of ReturnHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReturnHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReturnHandler {
+	mock := &ReturnHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
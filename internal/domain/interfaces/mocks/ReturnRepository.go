@@ -0,0 +1,244 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+
+	rma "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ReturnRepository is an autogenerated mock type for the ReturnRepository type
+type ReturnRepository struct {
+	mock.Mock
+}
+
+// CreateReturn provides a mock function with given fields: ctx, orderID, userID, note, items
+func (_m *ReturnRepository) CreateReturn(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, note string, items []rma.ItemRequest) (uuid.UUID, error) {
+	ret := _m.Called(ctx, orderID, userID, note, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReturn")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, []rma.ItemRequest) (uuid.UUID, error)); ok {
+		return rf(ctx, orderID, userID, note, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, []rma.ItemRequest) uuid.UUID); ok {
+		r0 = rf(ctx, orderID, userID, note, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, []rma.ItemRequest) error); ok {
+		r1 = rf(ctx, orderID, userID, note, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturn provides a mock function with given fields: ctx, id
+func (_m *ReturnRepository) GetReturn(ctx context.Context, id uuid.UUID) (*rma.Return, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*rma.Return, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *rma.Return); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturnsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *ReturnRepository) GetReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]rma.Return, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturnsByOrderID")
+	}
+
+	var r0 []rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]rma.Return, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []rma.Return); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturnsByStatus provides a mock function with given fields: ctx, status
+func (_m *ReturnRepository) GetReturnsByStatus(ctx context.Context, status rma.Status) ([]rma.Return, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturnsByStatus")
+	}
+
+	var r0 []rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, rma.Status) ([]rma.Return, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, rma.Status) []rma.Return); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, rma.Status) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturnsByUserID provides a mock function with given fields: ctx, userID
+func (_m *ReturnRepository) GetReturnsByUserID(ctx context.Context, userID uuid.UUID) ([]rma.Return, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturnsByUserID")
+	}
+
+	var r0 []rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]rma.Return, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []rma.Return); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveReturn provides a mock function with given fields: ctx, id, actor
+func (_m *ReturnRepository) ReceiveReturn(ctx context.Context, id uuid.UUID, actor order.Actor) (*rma.Receipt, error) {
+	ret := _m.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceiveReturn")
+	}
+
+	var r0 *rma.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, order.Actor) (*rma.Receipt, error)); ok {
+		return rf(ctx, id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, order.Actor) *rma.Receipt); ok {
+		r0 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, order.Actor) error); ok {
+		r1 = rf(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, change
+func (_m *ReturnRepository) UpdateStatus(ctx context.Context, change rma.StatusChange) (*rma.Return, error) {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, rma.StatusChange) (*rma.Return, error)); ok {
+		return rf(ctx, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, rma.StatusChange) *rma.Return); ok {
+		r0 = rf(ctx, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, rma.StatusChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReturnRepository creates a new instance of ReturnRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReturnRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReturnRepository {
+	mock := &ReturnRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,272 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rma "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ReturnService is an autogenerated mock type for the ReturnService type
+type ReturnService struct {
+	mock.Mock
+}
+
+// ApproveReturn provides a mock function with given fields: ctx, actorID, id, req
+func (_m *ReturnService) ApproveReturn(ctx context.Context, actorID string, id uuid.UUID, req rma.ResolveRequest) (*rma.Return, error) {
+	ret := _m.Called(ctx, actorID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) (*rma.Return, error)); ok {
+		return rf(ctx, actorID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) *rma.Return); ok {
+		r0 = rf(ctx, actorID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) error); ok {
+		r1 = rf(ctx, actorID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomerReturn provides a mock function with given fields: ctx, userID, id
+func (_m *ReturnService) GetCustomerReturn(ctx context.Context, userID string, id uuid.UUID) (*rma.Return, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*rma.Return, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *rma.Return); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomerReturns provides a mock function with given fields: ctx, userID
+func (_m *ReturnService) GetCustomerReturns(ctx context.Context, userID string) ([]rma.Return, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerReturns")
+	}
+
+	var r0 []rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]rma.Return, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []rma.Return); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturn provides a mock function with given fields: ctx, id
+func (_m *ReturnService) GetReturn(ctx context.Context, id uuid.UUID) (*rma.Return, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*rma.Return, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *rma.Return); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturns provides a mock function with given fields: ctx, status
+func (_m *ReturnService) GetReturns(ctx context.Context, status rma.Status) ([]rma.Return, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReturns")
+	}
+
+	var r0 []rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, rma.Status) ([]rma.Return, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, rma.Status) []rma.Return); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, rma.Status) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveReturn provides a mock function with given fields: ctx, actorID, id
+func (_m *ReturnService) ReceiveReturn(ctx context.Context, actorID string, id uuid.UUID) (*rma.Return, error) {
+	ret := _m.Called(ctx, actorID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceiveReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*rma.Return, error)); ok {
+		return rf(ctx, actorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *rma.Return); ok {
+		r0 = rf(ctx, actorID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, actorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectReturn provides a mock function with given fields: ctx, actorID, id, req
+func (_m *ReturnService) RejectReturn(ctx context.Context, actorID string, id uuid.UUID, req rma.ResolveRequest) (*rma.Return, error) {
+	ret := _m.Called(ctx, actorID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) (*rma.Return, error)); ok {
+		return rf(ctx, actorID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) *rma.Return); ok {
+		r0 = rf(ctx, actorID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, rma.ResolveRequest) error); ok {
+		r1 = rf(ctx, actorID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestReturn provides a mock function with given fields: ctx, userID, req
+func (_m *ReturnService) RequestReturn(ctx context.Context, userID string, req rma.CreateRequest) (*rma.Return, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReturn")
+	}
+
+	var r0 *rma.Return
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, rma.CreateRequest) (*rma.Return, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, rma.CreateRequest) *rma.Return); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rma.Return)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, rma.CreateRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReturnService creates a new instance of ReturnService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReturnService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReturnService {
+	mock := &ReturnService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewHandler is an autogenerated mock type for the ReviewHandler type
+type ReviewHandler struct {
+	mock.Mock
+}
+
+// ApproveReview provides a mock function with given fields: w, r
+func (_m *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeleteReview provides a mock function with given fields: w, r
+func (_m *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetQueue provides a mock function with given fields: w, r
+func (_m *ReviewHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetReviews provides a mock function with given fields: w, r
+func (_m *ReviewHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RejectReview provides a mock function with given fields: w, r
+func (_m *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SaveReview provides a mock function with given fields: w, r
+func (_m *ReviewHandler) SaveReview(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Vote provides a mock function with given fields: w, r
+func (_m *ReviewHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewReviewHandler creates a new instance of ReviewHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewHandler {
+	mock := &ReviewHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,316 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	review "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ReviewRepository is an autogenerated mock type for the ReviewRepository type
+type ReviewRepository struct {
+	mock.Mock
+}
+
+// DeleteReview provides a mock function with given fields: ctx, userID, id
+func (_m *ReviewRepository) DeleteReview(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDistribution provides a mock function with given fields: ctx, bookID
+func (_m *ReviewRepository) GetDistribution(ctx context.Context, bookID uuid.UUID) (map[int]int, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDistribution")
+	}
+
+	var r0 map[int]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (map[int]int, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) map[int]int); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQueue provides a mock function with given fields: ctx, status, limit
+func (_m *ReviewRepository) GetQueue(ctx context.Context, status review.Status, limit int) ([]review.Review, error) {
+	ret := _m.Called(ctx, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueue")
+	}
+
+	var r0 []review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.Status, int) ([]review.Review, error)); ok {
+		return rf(ctx, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.Status, int) []review.Review); ok {
+		r0 = rf(ctx, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.Status, int) error); ok {
+		r1 = rf(ctx, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReview provides a mock function with given fields: ctx, id
+func (_m *ReviewRepository) GetReview(ctx context.Context, id uuid.UUID) (*review.Review, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*review.Review, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *review.Review); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviews provides a mock function with given fields: ctx, bookID, sort
+func (_m *ReviewRepository) GetReviews(ctx context.Context, bookID uuid.UUID, sort review.Sort) ([]review.Review, error) {
+	ret := _m.Called(ctx, bookID, sort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 []review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, review.Sort) ([]review.Review, error)); ok {
+		return rf(ctx, bookID, sort)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, review.Sort) []review.Review); ok {
+		r0 = rf(ctx, bookID, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, review.Sort) error); ok {
+		r1 = rf(ctx, bookID, sort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserReview provides a mock function with given fields: ctx, userID, bookID
+func (_m *ReviewRepository) GetUserReview(ctx context.Context, userID uuid.UUID, bookID uuid.UUID) (*review.Review, error) {
+	ret := _m.Called(ctx, userID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*review.Review, error)); ok {
+		return rf(ctx, userID, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *review.Review); ok {
+		r0 = rf(ctx, userID, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasPurchased provides a mock function with given fields: ctx, userID, bookID
+func (_m *ReviewRepository) HasPurchased(ctx context.Context, userID uuid.UUID, bookID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPurchased")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID, bookID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Moderate provides a mock function with given fields: ctx, change
+func (_m *ReviewRepository) Moderate(ctx context.Context, change review.StatusChange) (*review.Review, error) {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Moderate")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.StatusChange) (*review.Review, error)); ok {
+		return rf(ctx, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.StatusChange) *review.Review); ok {
+		r0 = rf(ctx, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.StatusChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveReview provides a mock function with given fields: ctx, userID, bookID, req
+func (_m *ReviewRepository) SaveReview(ctx context.Context, userID uuid.UUID, bookID uuid.UUID, req review.Request) (*review.Review, error) {
+	ret := _m.Called(ctx, userID, bookID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, review.Request) (*review.Review, error)); ok {
+		return rf(ctx, userID, bookID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, review.Request) *review.Review); ok {
+		r0 = rf(ctx, userID, bookID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, review.Request) error); ok {
+		r1 = rf(ctx, userID, bookID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Vote provides a mock function with given fields: ctx, userID, id
+func (_m *ReviewRepository) Vote(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewRepository creates a new instance of ReviewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewRepository {
+	mock := &ReviewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
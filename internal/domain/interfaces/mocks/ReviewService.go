@@ -0,0 +1,230 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	review "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ReviewService is an autogenerated mock type for the ReviewService type
+type ReviewService struct {
+	mock.Mock
+}
+
+// ApproveReview provides a mock function with given fields: ctx, actorID, id, req
+func (_m *ReviewService) ApproveReview(ctx context.Context, actorID string, id uuid.UUID, req review.ModerateRequest) (*review.Review, error) {
+	ret := _m.Called(ctx, actorID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.ModerateRequest) (*review.Review, error)); ok {
+		return rf(ctx, actorID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.ModerateRequest) *review.Review); ok {
+		r0 = rf(ctx, actorID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, review.ModerateRequest) error); ok {
+		r1 = rf(ctx, actorID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReview provides a mock function with given fields: ctx, userID, bookID, id
+func (_m *ReviewService) DeleteReview(ctx context.Context, userID string, bookID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, bookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, bookID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetQueue provides a mock function with given fields: ctx, status
+func (_m *ReviewService) GetQueue(ctx context.Context, status review.Status) ([]review.Review, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueue")
+	}
+
+	var r0 []review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.Status) ([]review.Review, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.Status) []review.Review); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.Status) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviews provides a mock function with given fields: ctx, userID, bookID, sort
+func (_m *ReviewService) GetReviews(ctx context.Context, userID string, bookID uuid.UUID, sort review.Sort) (*review.Reviews, error) {
+	ret := _m.Called(ctx, userID, bookID, sort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 *review.Reviews
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Sort) (*review.Reviews, error)); ok {
+		return rf(ctx, userID, bookID, sort)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Sort) *review.Reviews); ok {
+		r0 = rf(ctx, userID, bookID, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Reviews)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, review.Sort) error); ok {
+		r1 = rf(ctx, userID, bookID, sort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectReview provides a mock function with given fields: ctx, actorID, id, req
+func (_m *ReviewService) RejectReview(ctx context.Context, actorID string, id uuid.UUID, req review.ModerateRequest) (*review.Review, error) {
+	ret := _m.Called(ctx, actorID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.ModerateRequest) (*review.Review, error)); ok {
+		return rf(ctx, actorID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.ModerateRequest) *review.Review); ok {
+		r0 = rf(ctx, actorID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, review.ModerateRequest) error); ok {
+		r1 = rf(ctx, actorID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveReview provides a mock function with given fields: ctx, userID, bookID, req
+func (_m *ReviewService) SaveReview(ctx context.Context, userID string, bookID uuid.UUID, req review.Request) (*review.Review, error) {
+	ret := _m.Called(ctx, userID, bookID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReview")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Request) (*review.Review, error)); ok {
+		return rf(ctx, userID, bookID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, review.Request) *review.Review); ok {
+		r0 = rf(ctx, userID, bookID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, review.Request) error); ok {
+		r1 = rf(ctx, userID, bookID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Vote provides a mock function with given fields: ctx, userID, bookID, id
+func (_m *ReviewService) Vote(ctx context.Context, userID string, bookID uuid.UUID, id uuid.UUID) (*review.Review, error) {
+	ret := _m.Called(ctx, userID, bookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID) (*review.Review, error)); ok {
+		return rf(ctx, userID, bookID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID) *review.Review); ok {
+		r0 = rf(ctx, userID, bookID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, bookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewService creates a new instance of ReviewService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewService {
+	mock := &ReviewService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShipmentHandler is an autogenerated mock type for the ShipmentHandler type
+type ShipmentHandler struct {
+	mock.Mock
+}
+
+// CancelShipment provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) CancelShipment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// CreateShipment provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeliverShipment provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) DeliverShipment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetOrderShipments provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) GetOrderShipments(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetPickList provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) GetPickList(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetShipment provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ShipShipment provides a mock function with given fields: w, r
+func (_m *ShipmentHandler) ShipShipment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewShipmentHandler creates a new instance of ShipmentHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShipmentHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShipmentHandler {
+	mock := &ShipmentHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	shipment "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ShipmentRepository is an autogenerated mock type for the ShipmentRepository type
+type ShipmentRepository struct {
+	mock.Mock
+}
+
+// CreateShipment provides a mock function with given fields: ctx, orderID, createdBy, items
+func (_m *ShipmentRepository) CreateShipment(ctx context.Context, orderID uuid.UUID, createdBy uuid.NullUUID, items []shipment.ItemRequest) (uuid.UUID, error) {
+	ret := _m.Called(ctx, orderID, createdBy, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShipment")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.NullUUID, []shipment.ItemRequest) (uuid.UUID, error)); ok {
+		return rf(ctx, orderID, createdBy, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.NullUUID, []shipment.ItemRequest) uuid.UUID); ok {
+		r0 = rf(ctx, orderID, createdBy, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.NullUUID, []shipment.ItemRequest) error); ok {
+		r1 = rf(ctx, orderID, createdBy, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderedItems provides a mock function with given fields: ctx, orderID
+func (_m *ShipmentRepository) GetOrderedItems(ctx context.Context, orderID uuid.UUID) ([]shipment.Item, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderedItems")
+	}
+
+	var r0 []shipment.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]shipment.Item, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []shipment.Item); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipment.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShipment provides a mock function with given fields: ctx, id
+func (_m *ShipmentRepository) GetShipment(ctx context.Context, id uuid.UUID) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*shipment.Shipment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *shipment.Shipment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShipmentsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *ShipmentRepository) GetShipmentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]shipment.Shipment, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShipmentsByOrderID")
+	}
+
+	var r0 []shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]shipment.Shipment, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []shipment.Shipment); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShipmentsByUserID provides a mock function with given fields: ctx, userID
+func (_m *ShipmentRepository) GetShipmentsByUserID(ctx context.Context, userID uuid.UUID) ([]shipment.Shipment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShipmentsByUserID")
+	}
+
+	var r0 []shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]shipment.Shipment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []shipment.Shipment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, change
+func (_m *ShipmentRepository) UpdateStatus(ctx context.Context, change shipment.StatusChange) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, shipment.StatusChange) (*shipment.Shipment, error)); ok {
+		return rf(ctx, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, shipment.StatusChange) *shipment.Shipment); ok {
+		r0 = rf(ctx, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, shipment.StatusChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShipmentRepository creates a new instance of ShipmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShipmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShipmentRepository {
+	mock := &ShipmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,272 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	shipment "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ShipmentService is an autogenerated mock type for the ShipmentService type
+type ShipmentService struct {
+	mock.Mock
+}
+
+// CancelShipment provides a mock function with given fields: ctx, actorID, id
+func (_m *ShipmentService) CancelShipment(ctx context.Context, actorID string, id uuid.UUID) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, actorID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*shipment.Shipment, error)); ok {
+		return rf(ctx, actorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *shipment.Shipment); ok {
+		r0 = rf(ctx, actorID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, actorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateShipment provides a mock function with given fields: ctx, actorID, req
+func (_m *ShipmentService) CreateShipment(ctx context.Context, actorID string, req shipment.CreateRequest) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, actorID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, shipment.CreateRequest) (*shipment.Shipment, error)); ok {
+		return rf(ctx, actorID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, shipment.CreateRequest) *shipment.Shipment); ok {
+		r0 = rf(ctx, actorID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, shipment.CreateRequest) error); ok {
+		r1 = rf(ctx, actorID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliverShipment provides a mock function with given fields: ctx, actorID, id
+func (_m *ShipmentService) DeliverShipment(ctx context.Context, actorID string, id uuid.UUID) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, actorID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliverShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*shipment.Shipment, error)); ok {
+		return rf(ctx, actorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *shipment.Shipment); ok {
+		r0 = rf(ctx, actorID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, actorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomerShipments provides a mock function with given fields: ctx, userID, orderID
+func (_m *ShipmentService) GetCustomerShipments(ctx context.Context, userID string, orderID string) ([]shipment.Shipment, error) {
+	ret := _m.Called(ctx, userID, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerShipments")
+	}
+
+	var r0 []shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]shipment.Shipment, error)); ok {
+		return rf(ctx, userID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []shipment.Shipment); ok {
+		r0 = rf(ctx, userID, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderShipments provides a mock function with given fields: ctx, orderID
+func (_m *ShipmentService) GetOrderShipments(ctx context.Context, orderID uuid.UUID) ([]shipment.Shipment, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderShipments")
+	}
+
+	var r0 []shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]shipment.Shipment, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []shipment.Shipment); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPickList provides a mock function with given fields: ctx, id
+func (_m *ShipmentService) GetPickList(ctx context.Context, id uuid.UUID) (*shipment.PickList, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPickList")
+	}
+
+	var r0 *shipment.PickList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*shipment.PickList, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *shipment.PickList); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.PickList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShipment provides a mock function with given fields: ctx, id
+func (_m *ShipmentService) GetShipment(ctx context.Context, id uuid.UUID) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*shipment.Shipment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *shipment.Shipment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShipShipment provides a mock function with given fields: ctx, actorID, id, req
+func (_m *ShipmentService) ShipShipment(ctx context.Context, actorID string, id uuid.UUID, req shipment.ShipRequest) (*shipment.Shipment, error) {
+	ret := _m.Called(ctx, actorID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShipShipment")
+	}
+
+	var r0 *shipment.Shipment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, shipment.ShipRequest) (*shipment.Shipment, error)); ok {
+		return rf(ctx, actorID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, shipment.ShipRequest) *shipment.Shipment); ok {
+		r0 = rf(ctx, actorID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shipment.Shipment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, shipment.ShipRequest) error); ok {
+		r1 = rf(ctx, actorID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShipmentService creates a new instance of ShipmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShipmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShipmentService {
+	mock := &ShipmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	shipping "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+)
+
+// ShippingRateProvider is an autogenerated mock type for the ShippingRateProvider type
+type ShippingRateProvider struct {
+	mock.Mock
+}
+
+// Quotes provides a mock function with given fields: ctx, to, grams
+func (_m *ShippingRateProvider) Quotes(ctx context.Context, to shipping.Destination, grams int) ([]shipping.Quote, error) {
+	ret := _m.Called(ctx, to, grams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quotes")
+	}
+
+	var r0 []shipping.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, shipping.Destination, int) ([]shipping.Quote, error)); ok {
+		return rf(ctx, to, grams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, shipping.Destination, int) []shipping.Quote); ok {
+		r0 = rf(ctx, to, grams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]shipping.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, shipping.Destination, int) error); ok {
+		r1 = rf(ctx, to, grams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShippingRateProvider creates a new instance of ShippingRateProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShippingRateProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShippingRateProvider {
+	mock := &ShippingRateProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StockAlertHandler is an autogenerated mock type for the StockAlertHandler type
+type StockAlertHandler struct {
+	mock.Mock
+}
+
+// DeleteAlert provides a mock function with given fields: w, r
+func (_m *StockAlertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetAlerts provides a mock function with given fields: w, r
+func (_m *StockAlertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetDemand provides a mock function with given fields: w, r
+func (_m *StockAlertHandler) GetDemand(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Subscribe provides a mock function with given fields: w, r
+func (_m *StockAlertHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Unsubscribe provides a mock function with given fields: w, r
+func (_m *StockAlertHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewStockAlertHandler creates a new instance of StockAlertHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockAlertHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockAlertHandler {
+	mock := &StockAlertHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,280 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
+	notification "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+
+	stockalert "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// StockAlertRepository is an autogenerated mock type for the StockAlertRepository type
+type StockAlertRepository struct {
+	mock.Mock
+}
+
+// DeleteAlert provides a mock function with given fields: ctx, userID, id
+func (_m *StockAlertRepository) DeleteAlert(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAlerts provides a mock function with given fields: ctx, userID
+func (_m *StockAlertRepository) GetAlerts(ctx context.Context, userID uuid.UUID) ([]stockalert.Alert, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlerts")
+	}
+
+	var r0 []stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]stockalert.Alert, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []stockalert.Alert); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDemand provides a mock function with given fields: ctx, limit
+func (_m *StockAlertRepository) GetDemand(ctx context.Context, limit int) ([]stockalert.Demand, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDemand")
+	}
+
+	var r0 []stockalert.Demand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]stockalert.Demand, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []stockalert.Demand); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stockalert.Demand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFired provides a mock function with given fields: ctx, limit
+func (_m *StockAlertRepository) GetFired(ctx context.Context, limit int) ([]stockalert.Fired, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFired")
+	}
+
+	var r0 []stockalert.Fired
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]stockalert.Fired, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []stockalert.Fired); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stockalert.Fired)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Notify provides a mock function with given fields: ctx, msg, alertIDs
+func (_m *StockAlertRepository) Notify(ctx context.Context, msg *notification.Message, alertIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, msg, alertIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *notification.Message, []uuid.UUID) error); ok {
+		r0 = rf(ctx, msg, alertIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, userID, req, token
+func (_m *StockAlertRepository) Subscribe(ctx context.Context, userID uuid.UUID, req stockalert.Request, token string) (*stockalert.Alert, error) {
+	ret := _m.Called(ctx, userID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, stockalert.Request, string) (*stockalert.Alert, error)); ok {
+		return rf(ctx, userID, req, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, stockalert.Request, string) *stockalert.Alert); ok {
+		r0 = rf(ctx, userID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, stockalert.Request, string) error); ok {
+		r1 = rf(ctx, userID, req, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerBackInStock provides a mock function with given fields: ctx, bookID, at
+func (_m *StockAlertRepository) TriggerBackInStock(ctx context.Context, bookID uuid.UUID, at time.Time) (int, error) {
+	ret := _m.Called(ctx, bookID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerBackInStock")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (int, error)); ok {
+		return rf(ctx, bookID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) int); ok {
+		r0 = rf(ctx, bookID, at)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, bookID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerPriceDrop provides a mock function with given fields: ctx, bookID, oldPrice, newPrice, at
+func (_m *StockAlertRepository) TriggerPriceDrop(ctx context.Context, bookID uuid.UUID, oldPrice money.Money, newPrice money.Money, at time.Time) (int, error) {
+	ret := _m.Called(ctx, bookID, oldPrice, newPrice, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerPriceDrop")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, money.Money, money.Money, time.Time) (int, error)); ok {
+		return rf(ctx, bookID, oldPrice, newPrice, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, money.Money, money.Money, time.Time) int); ok {
+		r0 = rf(ctx, bookID, oldPrice, newPrice, at)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, money.Money, money.Money, time.Time) error); ok {
+		r1 = rf(ctx, bookID, oldPrice, newPrice, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unsubscribe provides a mock function with given fields: ctx, token
+func (_m *StockAlertRepository) Unsubscribe(ctx context.Context, token string) (*stockalert.Alert, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 *stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*stockalert.Alert, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *stockalert.Alert); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStockAlertRepository creates a new instance of StockAlertRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockAlertRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockAlertRepository {
+	mock := &StockAlertRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	stockalert "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// StockAlertService is an autogenerated mock type for the StockAlertService type
+type StockAlertService struct {
+	mock.Mock
+}
+
+// DeleteAlert provides a mock function with given fields: ctx, userID, id
+func (_m *StockAlertService) DeleteAlert(ctx context.Context, userID string, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAlerts provides a mock function with given fields: ctx, userID
+func (_m *StockAlertService) GetAlerts(ctx context.Context, userID string) ([]stockalert.Alert, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlerts")
+	}
+
+	var r0 []stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]stockalert.Alert, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []stockalert.Alert); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDemand provides a mock function with given fields: ctx
+func (_m *StockAlertService) GetDemand(ctx context.Context) ([]stockalert.Demand, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDemand")
+	}
+
+	var r0 []stockalert.Demand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]stockalert.Demand, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []stockalert.Demand); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stockalert.Demand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: ctx, userID, req
+func (_m *StockAlertService) Subscribe(ctx context.Context, userID string, req stockalert.Request) (*stockalert.Alert, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, stockalert.Request) (*stockalert.Alert, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, stockalert.Request) *stockalert.Alert); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, stockalert.Request) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unsubscribe provides a mock function with given fields: ctx, token
+func (_m *StockAlertService) Unsubscribe(ctx context.Context, token string) (*stockalert.Alert, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 *stockalert.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*stockalert.Alert, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *stockalert.Alert); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stockalert.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStockAlertService creates a new instance of StockAlertService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockAlertService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockAlertService {
+	mock := &StockAlertService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
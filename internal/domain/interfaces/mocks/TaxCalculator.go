@@ -0,0 +1,58 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	tax "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+)
+
+// TaxCalculator is an autogenerated mock type for the TaxCalculator type
+type TaxCalculator struct {
+	mock.Mock
+}
+
+// Calculate provides a mock function with given fields: ctx, jurisdiction, lines
+func (_m *TaxCalculator) Calculate(ctx context.Context, jurisdiction tax.Jurisdiction, lines []tax.Line) (tax.Result, error) {
+	ret := _m.Called(ctx, jurisdiction, lines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Calculate")
+	}
+
+	var r0 tax.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tax.Jurisdiction, []tax.Line) (tax.Result, error)); ok {
+		return rf(ctx, jurisdiction, lines)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tax.Jurisdiction, []tax.Line) tax.Result); ok {
+		r0 = rf(ctx, jurisdiction, lines)
+	} else {
+		r0 = ret.Get(0).(tax.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tax.Jurisdiction, []tax.Line) error); ok {
+		r1 = rf(ctx, jurisdiction, lines)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxCalculator creates a new instance of TaxCalculator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxCalculator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxCalculator {
+	mock := &TaxCalculator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
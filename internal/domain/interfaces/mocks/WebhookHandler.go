@@ -0,0 +1,63 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookHandler is an autogenerated mock type for the WebhookHandler type
+type WebhookHandler struct {
+	mock.Mock
+}
+
+// CreateEndpoint provides a mock function with given fields: w, r
+func (_m *WebhookHandler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeleteEndpoint provides a mock function with given fields: w, r
+func (_m *WebhookHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetDeliveries provides a mock function with given fields: w, r
+func (_m *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetEndpoint provides a mock function with given fields: w, r
+func (_m *WebhookHandler) GetEndpoint(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetEndpoints provides a mock function with given fields: w, r
+func (_m *WebhookHandler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SendTestEvent provides a mock function with given fields: w, r
+func (_m *WebhookHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdateEndpoint provides a mock function with given fields: w, r
+func (_m *WebhookHandler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewWebhookHandler creates a new instance of WebhookHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookHandler {
+	mock := &WebhookHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
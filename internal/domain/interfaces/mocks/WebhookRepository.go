@@ -0,0 +1,324 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	event "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+
+	jsontext "encoding/json/jsontext"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+
+	webhook "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease, limit
+func (_m *WebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Outgoing, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []webhook.Outgoing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]webhook.Outgoing, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []webhook.Outgoing); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Outgoing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDelivery provides a mock function with given fields: ctx, endpointID, eventType, payload
+func (_m *WebhookRepository) CreateDelivery(ctx context.Context, endpointID uuid.UUID, eventType event.Type, payload jsontext.Value) (*webhook.Delivery, error) {
+	ret := _m.Called(ctx, endpointID, eventType, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelivery")
+	}
+
+	var r0 *webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, event.Type, jsontext.Value) (*webhook.Delivery, error)); ok {
+		return rf(ctx, endpointID, eventType, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, event.Type, jsontext.Value) *webhook.Delivery); ok {
+		r0 = rf(ctx, endpointID, eventType, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, event.Type, jsontext.Value) error); ok {
+		r1 = rf(ctx, endpointID, eventType, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEndpoint provides a mock function with given fields: ctx, endpoint
+func (_m *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint webhook.Endpoint) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Endpoint) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Endpoint) *webhook.Endpoint); ok {
+		r0 = rf(ctx, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Endpoint) error); ok {
+		r1 = rf(ctx, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEndpoint provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEndpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enqueue provides a mock function with given fields: ctx, e
+func (_m *WebhookRepository) Enqueue(ctx context.Context, e event.Event) (int, error) {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, event.Event) (int, error)); ok {
+		return rf(ctx, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, event.Event) int); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, event.Event) error); ok {
+		r1 = rf(ctx, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, endpointID, limit
+func (_m *WebhookRepository) GetDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, endpointID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, endpointID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []webhook.Delivery); ok {
+		r0 = rf(ctx, endpointID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, endpointID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEndpoint provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *webhook.Endpoint); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEndpoints provides a mock function with given fields: ctx
+func (_m *WebhookRepository) GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoints")
+	}
+
+	var r0 []webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhook.Endpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhook.Endpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAttempt provides a mock function with given fields: ctx, attempt, disableAfter
+func (_m *WebhookRepository) RecordAttempt(ctx context.Context, attempt webhook.Attempt, disableAfter int) (*webhook.Delivery, error) {
+	ret := _m.Called(ctx, attempt, disableAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAttempt")
+	}
+
+	var r0 *webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Attempt, int) (*webhook.Delivery, error)); ok {
+		return rf(ctx, attempt, disableAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Attempt, int) *webhook.Delivery); ok {
+		r0 = rf(ctx, attempt, disableAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Attempt, int) error); ok {
+		r1 = rf(ctx, attempt, disableAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEndpoint provides a mock function with given fields: ctx, endpoint
+func (_m *WebhookRepository) UpdateEndpoint(ctx context.Context, endpoint webhook.Endpoint) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Endpoint) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Endpoint) *webhook.Endpoint); ok {
+		r0 = rf(ctx, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Endpoint) error); ok {
+		r1 = rf(ctx, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhook "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+)
+
+// WebhookSender is an autogenerated mock type for the WebhookSender type
+type WebhookSender struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, out
+func (_m *WebhookSender) Send(ctx context.Context, out webhook.Outgoing) webhook.Result {
+	ret := _m.Called(ctx, out)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 webhook.Result
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Outgoing) webhook.Result); ok {
+		r0 = rf(ctx, out)
+	} else {
+		r0 = ret.Get(0).(webhook.Result)
+	}
+
+	return r0
+}
+
+// NewWebhookSender creates a new instance of WebhookSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookSender {
+	mock := &WebhookSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,230 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+
+	webhook "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+// CreateEndpoint provides a mock function with given fields: ctx, req
+func (_m *WebhookService) CreateEndpoint(ctx context.Context, req webhook.EndpointRequest) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.EndpointRequest) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.EndpointRequest) *webhook.Endpoint); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.EndpointRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEndpoint provides a mock function with given fields: ctx, id
+func (_m *WebhookService) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEndpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, endpointID
+func (_m *WebhookService) GetDeliveries(ctx context.Context, endpointID uuid.UUID) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, endpointID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, endpointID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []webhook.Delivery); ok {
+		r0 = rf(ctx, endpointID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, endpointID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEndpoint provides a mock function with given fields: ctx, id
+func (_m *WebhookService) GetEndpoint(ctx context.Context, id uuid.UUID) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *webhook.Endpoint); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEndpoints provides a mock function with given fields: ctx
+func (_m *WebhookService) GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndpoints")
+	}
+
+	var r0 []webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhook.Endpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhook.Endpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTestEvent provides a mock function with given fields: ctx, endpointID
+func (_m *WebhookService) SendTestEvent(ctx context.Context, endpointID uuid.UUID) (*webhook.Delivery, error) {
+	ret := _m.Called(ctx, endpointID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTestEvent")
+	}
+
+	var r0 *webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*webhook.Delivery, error)); ok {
+		return rf(ctx, endpointID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *webhook.Delivery); ok {
+		r0 = rf(ctx, endpointID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, endpointID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEndpoint provides a mock function with given fields: ctx, id, req
+func (_m *WebhookService) UpdateEndpoint(ctx context.Context, id uuid.UUID, req webhook.EndpointRequest) (*webhook.Endpoint, error) {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEndpoint")
+	}
+
+	var r0 *webhook.Endpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, webhook.EndpointRequest) (*webhook.Endpoint, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, webhook.EndpointRequest) *webhook.Endpoint); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Endpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, webhook.EndpointRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
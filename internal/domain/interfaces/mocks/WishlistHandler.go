@@ -0,0 +1,78 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WishlistHandler is an autogenerated mock type for the WishlistHandler type
+type WishlistHandler struct {
+	mock.Mock
+}
+
+// AddItem provides a mock function with given fields: w, r
+func (_m *WishlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// CreateWishlist provides a mock function with given fields: w, r
+func (_m *WishlistHandler) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DeleteWishlist provides a mock function with given fields: w, r
+func (_m *WishlistHandler) DeleteWishlist(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetSharedWishlist provides a mock function with given fields: w, r
+func (_m *WishlistHandler) GetSharedWishlist(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetWishlist provides a mock function with given fields: w, r
+func (_m *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// GetWishlists provides a mock function with given fields: w, r
+func (_m *WishlistHandler) GetWishlists(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// MoveToCart provides a mock function with given fields: w, r
+func (_m *WishlistHandler) MoveToCart(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// RemoveItem provides a mock function with given fields: w, r
+func (_m *WishlistHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SaveForLater provides a mock function with given fields: w, r
+func (_m *WishlistHandler) SaveForLater(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UpdateWishlist provides a mock function with given fields: w, r
+func (_m *WishlistHandler) UpdateWishlist(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NewWishlistHandler creates a new instance of WishlistHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWishlistHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WishlistHandler {
+	mock := &WishlistHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
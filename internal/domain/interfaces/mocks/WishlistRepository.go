@@ -0,0 +1,234 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+
+	wishlist "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+)
+
+// WishlistRepository is an autogenerated mock type for the WishlistRepository type
+type WishlistRepository struct {
+	mock.Mock
+}
+
+// AddItem provides a mock function with given fields: ctx, wishlistID, bookID
+func (_m *WishlistRepository) AddItem(ctx context.Context, wishlistID uuid.UUID, bookID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, wishlistID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddItem")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, wishlistID, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, wishlistID, bookID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, wishlistID, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWishlist provides a mock function with given fields: ctx, w
+func (_m *WishlistRepository) CreateWishlist(ctx context.Context, w wishlist.Wishlist) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, wishlist.Wishlist) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, wishlist.Wishlist) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, wishlist.Wishlist) error); ok {
+		r1 = rf(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWishlist provides a mock function with given fields: ctx, userID, id
+func (_m *WishlistRepository) DeleteWishlist(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWishlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSharedWishlist provides a mock function with given fields: ctx, token
+func (_m *WishlistRepository) GetSharedWishlist(ctx context.Context, token string) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWishlist provides a mock function with given fields: ctx, userID, id
+func (_m *WishlistRepository) GetWishlist(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWishlists provides a mock function with given fields: ctx, userID
+func (_m *WishlistRepository) GetWishlists(ctx context.Context, userID uuid.UUID) ([]wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWishlists")
+	}
+
+	var r0 []wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveItem provides a mock function with given fields: ctx, wishlistID, bookID
+func (_m *WishlistRepository) RemoveItem(ctx context.Context, wishlistID uuid.UUID, bookID uuid.UUID) error {
+	ret := _m.Called(ctx, wishlistID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, wishlistID, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWishlist provides a mock function with given fields: ctx, userID, id, req
+func (_m *WishlistRepository) UpdateWishlist(ctx context.Context, userID uuid.UUID, id uuid.UUID, req wishlist.Request) error {
+	ret := _m.Called(ctx, userID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWishlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, wishlist.Request) error); ok {
+		r0 = rf(ctx, userID, id, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWishlistRepository creates a new instance of WishlistRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWishlistRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WishlistRepository {
+	mock := &WishlistRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
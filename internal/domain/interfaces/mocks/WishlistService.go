@@ -0,0 +1,320 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+
+	wishlist "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+)
+
+// WishlistService is an autogenerated mock type for the WishlistService type
+type WishlistService struct {
+	mock.Mock
+}
+
+// AddItem provides a mock function with given fields: ctx, userID, id, req
+func (_m *WishlistService) AddItem(ctx context.Context, userID string, id uuid.UUID, req wishlist.ItemRequest) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddItem")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) error); ok {
+		r1 = rf(ctx, userID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWishlist provides a mock function with given fields: ctx, userID, req
+func (_m *WishlistService) CreateWishlist(ctx context.Context, userID string, req wishlist.Request) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, wishlist.Request) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, wishlist.Request) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, wishlist.Request) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWishlist provides a mock function with given fields: ctx, userID, id
+func (_m *WishlistService) DeleteWishlist(ctx context.Context, userID string, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWishlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSharedWishlist provides a mock function with given fields: ctx, token
+func (_m *WishlistService) GetSharedWishlist(ctx context.Context, token string) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWishlist provides a mock function with given fields: ctx, userID, id
+func (_m *WishlistService) GetWishlist(ctx context.Context, userID string, id uuid.UUID) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWishlists provides a mock function with given fields: ctx, userID
+func (_m *WishlistService) GetWishlists(ctx context.Context, userID string) ([]wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWishlists")
+	}
+
+	var r0 []wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveToCart provides a mock function with given fields: ctx, userID, id, bookID, req
+func (_m *WishlistService) MoveToCart(ctx context.Context, userID string, id uuid.UUID, bookID uuid.UUID, req wishlist.MoveToCartRequest) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id, bookID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveToCart")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID, wishlist.MoveToCartRequest) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id, bookID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID, wishlist.MoveToCartRequest) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id, bookID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, uuid.UUID, wishlist.MoveToCartRequest) error); ok {
+		r1 = rf(ctx, userID, id, bookID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveItem provides a mock function with given fields: ctx, userID, id, bookID
+func (_m *WishlistService) RemoveItem(ctx context.Context, userID string, id uuid.UUID, bookID uuid.UUID) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveItem")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, uuid.UUID) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveForLater provides a mock function with given fields: ctx, userID, id, req
+func (_m *WishlistService) SaveForLater(ctx context.Context, userID string, id uuid.UUID, req wishlist.ItemRequest) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveForLater")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, wishlist.ItemRequest) error); ok {
+		r1 = rf(ctx, userID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWishlist provides a mock function with given fields: ctx, userID, id, req
+func (_m *WishlistService) UpdateWishlist(ctx context.Context, userID string, id uuid.UUID, req wishlist.Request) (*wishlist.Wishlist, error) {
+	ret := _m.Called(ctx, userID, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWishlist")
+	}
+
+	var r0 *wishlist.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.Request) (*wishlist.Wishlist, error)); ok {
+		return rf(ctx, userID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, wishlist.Request) *wishlist.Wishlist); ok {
+		r0 = rf(ctx, userID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wishlist.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, wishlist.Request) error); ok {
+		r1 = rf(ctx, userID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWishlistService creates a new instance of WishlistService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWishlistService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WishlistService {
+	mock := &WishlistService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name NotificationRepository
+type (
+	NotificationRepository interface {
+		GetRecipient(ctx context.Context, userID uuid.UUID) (*notification.Recipient, error)
+		GetOptOuts(ctx context.Context, userID uuid.UUID) ([]notification.Kind, error)
+		SetPreferences(ctx context.Context, userID uuid.UUID, req notification.PreferencesRequest) error
+		// Enqueue queues msg unless its event already produced an email of
+		// the same kind, and reports whether it was queued.
+		Enqueue(ctx context.Context, msg notification.Message) (bool, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]notification.Message, error)
+		MarkSent(ctx context.Context, id uuid.UUID) error
+		MarkFailed(ctx context.Context, id uuid.UUID, failure notification.Failure) error
+	}
+)
+
+// Mailer hands an email over for delivery.
+//
+//go:generate mockery --name Mailer
+type (
+	Mailer interface {
+		Send(ctx context.Context, email notification.Email) error
+	}
+)
+
+//go:generate mockery --name NotificationService
+type (
+	NotificationService interface {
+		GetPreferences(ctx context.Context, userID string) ([]notification.Preference, error)
+		UpdatePreferences(ctx context.Context, userID string, req notification.PreferencesRequest) ([]notification.Preference, error)
+		ResendOrderConfirmation(ctx context.Context, orderID uuid.UUID) error
+	}
+)
+
+//go:generate mockery --name NotificationHandler
+type (
+	NotificationHandler interface {
+		GetPreferences(w http.ResponseWriter, r *http.Request)
+		UpdatePreferences(w http.ResponseWriter, r *http.Request)
+	}
+)
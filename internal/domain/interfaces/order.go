@@ -2,8 +2,14 @@ package interfaces
 
 import (
 	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
 	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"net/http"
 )
@@ -14,14 +20,22 @@ type (
 		GetOrdersByUserID(ctx context.Context, userID string) ([]orderModels.HistoryOrderItem, error)
 		GetUsersOrder(ctx context.Context, userId string) (*orderModel.Model, error)
 		GetUserOrderByUserID(ctx context.Context, orderId string) (*orderModel.Model, error)
-		ChangeStatusOfCart(ctx context.Context, userId, orderId string) error
 		CreateUserOrder(ctx context.Context, userID string) error
 		CheckOrderExists(ctx context.Context, userID string) (bool, error)
-		AlterUserOrder(ctx context.Context, userID string) error
 		AddOrderItemIntoOrder(ctx context.Context, userID string, items *[]orderModels.OrderItem) error
 		GetOrderItemsFromOrderID(ctx context.Context, orderID string) (*[]orderModels.OrderItemFull, error)
 		RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error
-		AlterUserOrderByID(ctx context.Context, userID, orderID uuid.UUID) error
+		SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error
+		GetOrderByID(ctx context.Context, orderID uuid.UUID) (*orderModel.Model, error)
+		SetCheckoutCurrency(ctx context.Context, orderID uuid.UUID, currency money.Currency, rate money.Rate) error
+		SetShipping(ctx context.Context, orderID uuid.UUID, shipping, billing *address.Snapshot, method string) error
+		GetPricingLines(ctx context.Context, orderID uuid.UUID) ([]promotion.Line, error)
+		SavePricing(ctx context.Context, orderID uuid.UUID, breakdown promotion.Breakdown) error
+		UpdateStatus(ctx context.Context, change orderModel.StatusChange) (orderModel.Status, error)
+		ChangeStatus(ctx context.Context, tx *sql.Tx, change orderModel.StatusChange) (orderModel.Status, error)
+		GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]orderModel.StatusHistoryEntry, error)
+		ReleaseExpiredReservations(ctx context.Context, limit int) (int, error)
+		MergeCart(ctx context.Context, fromUserID, toUserID string) error
 	}
 )
 
@@ -31,9 +45,22 @@ type (
 		GetUsersOrder(ctx context.Context, userId string) (*orderModel.Model, error)
 		GetUserOrderByUserID(ctx context.Context, orderId string) (*orderModel.Model, error)
 		CreateUserOrder(ctx context.Context, userID string) error
-		AlterUserOrder(ctx context.Context, userID string) error
+		AlterUserOrder(ctx context.Context, userID string, currency money.Currency) error
 		AddOrderItemIntoOrder(ctx context.Context, userID string, bookIDs *[]orderModels.OrderItem) error
-		AlterUserOrderByID(ctx context.Context, userID, orderID string) error
+		RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error
+		SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error
+		GetCart(ctx context.Context, userID string, currency money.Currency) (*cart.Cart, error)
+		ApplyPromotion(ctx context.Context, userID, code string) (*promotion.Breakdown, error)
+		RemovePromotion(ctx context.Context, userID, code string) (*promotion.Breakdown, error)
+		GetPriceBreakdown(ctx context.Context, userID string) (*promotion.Breakdown, error)
+		GetShippingOptions(ctx context.Context, userID string, addressID uuid.UUID) ([]shipping.Quote, error)
+		SetShipping(ctx context.Context, userID string, req orderModel.ShippingRequest) (*promotion.Breakdown, error)
+		AlterUserOrderByID(ctx context.Context, userID, orderID string, currency money.Currency) error
+		Checkout(ctx context.Context, userID string, currency money.Currency) error
+		Transition(ctx context.Context, orderID uuid.UUID, to orderModel.Status, actor orderModel.Actor, reason string) error
+		Cancel(ctx context.Context, userID, orderID string, asAdmin bool, reason string) error
+		GetStatusHistory(ctx context.Context, userID, orderID string) ([]orderModel.StatusHistoryEntry, error)
+		MergeCart(ctx context.Context, fromUserID, toUserID string) error
 	}
 )
 
@@ -45,5 +72,19 @@ type (
 		CreateUserOrder(w http.ResponseWriter, r *http.Request)
 		AlterUserOrder(w http.ResponseWriter, r *http.Request)
 		AddOrderItemIntoOrder(w http.ResponseWriter, r *http.Request)
+		GetStatusHistory(w http.ResponseWriter, r *http.Request)
+		GetShipments(w http.ResponseWriter, r *http.Request)
+		GetInvoice(w http.ResponseWriter, r *http.Request)
+		CancelOrder(w http.ResponseWriter, r *http.Request)
+		GetPriceBreakdown(w http.ResponseWriter, r *http.Request)
+		ApplyPromotion(w http.ResponseWriter, r *http.Request)
+		RemovePromotion(w http.ResponseWriter, r *http.Request)
+		GetShippingOptions(w http.ResponseWriter, r *http.Request)
+		SetShipping(w http.ResponseWriter, r *http.Request)
+		GetCart(w http.ResponseWriter, r *http.Request)
+		GetCartItems(w http.ResponseWriter, r *http.Request)
+		GetCartItem(w http.ResponseWriter, r *http.Request)
+		SetCartItemQuantity(w http.ResponseWriter, r *http.Request)
+		RemoveCartItem(w http.ResponseWriter, r *http.Request)
 	}
 )
@@ -0,0 +1,59 @@
+package interfaces
+
+import (
+	"context"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name PaymentProvider
+type (
+	PaymentProvider interface {
+		Name() string
+		CreateIntent(ctx context.Context, req paymentModel.IntentRequest) (*paymentModel.Result, error)
+		Capture(ctx context.Context, providerRef string) (*paymentModel.Result, error)
+		Refund(ctx context.Context, providerRef string, amount money.Money) (*paymentModel.Result, error)
+		VerifyWebhook(payload []byte, signature string) (*paymentModel.WebhookEvent, error)
+	}
+)
+
+//go:generate mockery --name PaymentRepository
+type (
+	PaymentRepository interface {
+		CreatePayment(ctx context.Context, payment *paymentModel.Payment) error
+		GetPayment(ctx context.Context, id uuid.UUID) (*paymentModel.Payment, error)
+		GetPaymentByProviderRef(ctx context.Context, provider, providerRef string) (*paymentModel.Payment, error)
+		GetSettledPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*paymentModel.Payment, error)
+		GetPaymentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]paymentModel.Payment, error)
+		SetProviderRef(ctx context.Context, paymentID uuid.UUID, providerRef string) error
+		SettlePayment(ctx context.Context, paymentID uuid.UUID, status paymentModel.Status, failureReason string, change orderModel.StatusChange) (bool, orderModel.Status, error)
+		AddRefund(ctx context.Context, paymentID uuid.UUID, amount money.Money) error
+		RecordWebhookEvent(ctx context.Context, provider string, event *paymentModel.WebhookEvent, payload []byte) (bool, error)
+		MarkWebhookEventProcessed(ctx context.Context, provider, eventID string) error
+		ReleaseWebhookEvent(ctx context.Context, provider, eventID string) error
+	}
+)
+
+//go:generate mockery --name PaymentService
+type (
+	PaymentService interface {
+		Charge(ctx context.Context, order *orderModel.Model, actor orderModel.Actor) (*paymentModel.Payment, error)
+		HandleWebhook(ctx context.Context, payload []byte, signature string) error
+		IssueRefunds(ctx context.Context, orderID uuid.UUID) error
+		GetRefunds(ctx context.Context, status string) ([]refund.Refund, error)
+		ResolveRefund(ctx context.Context, actorID string, id uuid.UUID) (*refund.Refund, error)
+	}
+)
+
+//go:generate mockery --name PaymentHandler
+type (
+	PaymentHandler interface {
+		Webhook(w http.ResponseWriter, r *http.Request)
+		GetRefunds(w http.ResponseWriter, r *http.Request)
+		ResolveRefund(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name PromotionRepository
+type (
+	PromotionRepository interface {
+		CreatePromotion(ctx context.Context, p promotion.Promotion) (uuid.UUID, error)
+		UpdatePromotion(ctx context.Context, p promotion.Promotion) error
+		DeactivatePromotion(ctx context.Context, id uuid.UUID) error
+		GetPromotions(ctx context.Context) ([]promotion.Promotion, error)
+		GetPromotionByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error)
+		GetPromotionByCode(ctx context.Context, code string) (*promotion.Promotion, error)
+		GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]promotion.Promotion, error)
+		AttachToOrder(ctx context.Context, orderID, promotionID uuid.UUID) error
+		DetachFromOrder(ctx context.Context, orderID, promotionID uuid.UUID) error
+		CountUserRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error)
+	}
+)
+
+//go:generate mockery --name PromotionService
+type (
+	PromotionService interface {
+		CreatePromotion(ctx context.Context, req promotion.Request) (uuid.UUID, error)
+		UpdatePromotion(ctx context.Context, id uuid.UUID, req promotion.Request) error
+		DeactivatePromotion(ctx context.Context, id uuid.UUID) error
+		GetPromotions(ctx context.Context) ([]promotion.Promotion, error)
+		GetPromotionByID(ctx context.Context, id uuid.UUID) (*promotion.Promotion, error)
+	}
+)
+
+//go:generate mockery --name PromotionHandler
+type (
+	PromotionHandler interface {
+		CreatePromotion(w http.ResponseWriter, r *http.Request)
+		UpdatePromotion(w http.ResponseWriter, r *http.Request)
+		DeactivatePromotion(w http.ResponseWriter, r *http.Request)
+		GetPromotions(w http.ResponseWriter, r *http.Request)
+		GetPromotionByID(w http.ResponseWriter, r *http.Request)
+	}
+)
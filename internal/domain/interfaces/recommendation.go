@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name RecommendationRepository
+type (
+	RecommendationRepository interface {
+		Rebuild(ctx context.Context, perBook int) (int64, error)
+		GetAlsoBought(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]recommendation.Recommendation, error)
+		GetSimilar(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]recommendation.Recommendation, error)
+	}
+)
+
+//go:generate mockery --name RecommendationService
+type (
+	RecommendationService interface {
+		GetRecommendations(ctx context.Context, bookID uuid.UUID, limit int) ([]recommendation.Recommendation, error)
+		// GetCartRecommendations suggests books to go with the user's cart,
+		// or none while the cart is empty.
+		GetCartRecommendations(ctx context.Context, userID string, limit int) ([]recommendation.Recommendation, error)
+	}
+)
+
+//go:generate mockery --name RecommendationHandler
+type (
+	RecommendationHandler interface {
+		GetRecommendations(w http.ResponseWriter, r *http.Request)
+		GetCartRecommendations(w http.ResponseWriter, r *http.Request)
+	}
+)
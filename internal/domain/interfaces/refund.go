@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+//go:generate mockery --name RefundRepository
+type (
+	RefundRepository interface {
+		CreateRefund(ctx context.Context, tx *sql.Tx, refund *refund.Refund) error
+		GetRefund(ctx context.Context, id uuid.UUID) (*refund.Refund, error)
+		GetRefunds(ctx context.Context, statuses []refund.Status) ([]refund.Refund, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]refund.Refund, error)
+		ClaimOrderRefunds(ctx context.Context, orderID uuid.UUID, now time.Time, lease time.Duration) ([]refund.Refund, error)
+		MarkIssued(ctx context.Context, id uuid.UUID, issue refund.Issue) error
+		MarkFailed(ctx context.Context, id uuid.UUID, failure refund.Failure) error
+		MarkIssuedByHand(ctx context.Context, id uuid.UUID, actor uuid.UUID) (*refund.Refund, error)
+	}
+)
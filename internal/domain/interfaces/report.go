@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"net/http"
+)
+
+//go:generate mockery --name ReportRepository
+type (
+	ReportRepository interface {
+		GetSummary(ctx context.Context, rng report.Range) (*report.Summary, error)
+		GetRevenue(ctx context.Context, rng report.Range, interval report.Interval) (*report.Revenue, error)
+		GetTopSellers(ctx context.Context, rng report.Range, limit int) (*report.TopSellers, error)
+		GetTurnover(ctx context.Context, rng report.Range, limit int) (*report.StockReport, error)
+		GetLowStock(ctx context.Context, rng report.Range, threshold, limit int) (*report.StockReport, error)
+	}
+)
+
+//go:generate mockery --name ReportService
+type (
+	ReportService interface {
+		GetSummary(ctx context.Context, rng report.Range) (*report.Summary, error)
+		GetRevenue(ctx context.Context, rng report.Range, interval report.Interval) (*report.Revenue, error)
+		GetTopSellers(ctx context.Context, rng report.Range, limit int) (*report.TopSellers, error)
+		GetTurnover(ctx context.Context, rng report.Range, limit int) (*report.StockReport, error)
+		GetLowStock(ctx context.Context, rng report.Range, threshold, limit int) (*report.StockReport, error)
+	}
+)
+
+//go:generate mockery --name ReportHandler
+type (
+	ReportHandler interface {
+		GetSummary(w http.ResponseWriter, r *http.Request)
+		GetRevenue(w http.ResponseWriter, r *http.Request)
+		GetTopSellers(w http.ResponseWriter, r *http.Request)
+		GetTurnover(w http.ResponseWriter, r *http.Request)
+		GetLowStock(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,52 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name ReviewRepository
+type (
+	ReviewRepository interface {
+		HasPurchased(ctx context.Context, userID, bookID uuid.UUID) (bool, error)
+		SaveReview(ctx context.Context, userID, bookID uuid.UUID, req review.Request) (*review.Review, error)
+		GetReview(ctx context.Context, id uuid.UUID) (*review.Review, error)
+		GetUserReview(ctx context.Context, userID, bookID uuid.UUID) (*review.Review, error)
+		GetReviews(ctx context.Context, bookID uuid.UUID, sort review.Sort) ([]review.Review, error)
+		GetDistribution(ctx context.Context, bookID uuid.UUID) (map[int]int, error)
+		DeleteReview(ctx context.Context, userID, id uuid.UUID) error
+		Vote(ctx context.Context, userID, id uuid.UUID) (bool, error)
+		GetQueue(ctx context.Context, status review.Status, limit int) ([]review.Review, error)
+		Moderate(ctx context.Context, change review.StatusChange) (*review.Review, error)
+	}
+)
+
+//go:generate mockery --name ReviewService
+type (
+	ReviewService interface {
+		// GetReviews returns the book's rating and approved reviews. userID
+		// is empty for visitors who are not logged in.
+		GetReviews(ctx context.Context, userID string, bookID uuid.UUID, sort review.Sort) (*review.Reviews, error)
+		SaveReview(ctx context.Context, userID string, bookID uuid.UUID, req review.Request) (*review.Review, error)
+		DeleteReview(ctx context.Context, userID string, bookID, id uuid.UUID) error
+		Vote(ctx context.Context, userID string, bookID, id uuid.UUID) (*review.Review, error)
+		GetQueue(ctx context.Context, status review.Status) ([]review.Review, error)
+		ApproveReview(ctx context.Context, actorID string, id uuid.UUID, req review.ModerateRequest) (*review.Review, error)
+		RejectReview(ctx context.Context, actorID string, id uuid.UUID, req review.ModerateRequest) (*review.Review, error)
+	}
+)
+
+//go:generate mockery --name ReviewHandler
+type (
+	ReviewHandler interface {
+		GetReviews(w http.ResponseWriter, r *http.Request)
+		SaveReview(w http.ResponseWriter, r *http.Request)
+		DeleteReview(w http.ResponseWriter, r *http.Request)
+		Vote(w http.ResponseWriter, r *http.Request)
+		GetQueue(w http.ResponseWriter, r *http.Request)
+		ApproveReview(w http.ResponseWriter, r *http.Request)
+		RejectReview(w http.ResponseWriter, r *http.Request)
+	}
+)
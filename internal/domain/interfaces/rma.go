@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name ReturnRepository
+type (
+	ReturnRepository interface {
+		CreateReturn(ctx context.Context, orderID, userID uuid.UUID, note string, items []rma.ItemRequest) (uuid.UUID, error)
+		GetReturn(ctx context.Context, id uuid.UUID) (*rma.Return, error)
+		GetReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]rma.Return, error)
+		GetReturnsByUserID(ctx context.Context, userID uuid.UUID) ([]rma.Return, error)
+		GetReturnsByStatus(ctx context.Context, status rma.Status) ([]rma.Return, error)
+		UpdateStatus(ctx context.Context, change rma.StatusChange) (*rma.Return, error)
+		ReceiveReturn(ctx context.Context, id uuid.UUID, actor orderModel.Actor) (*rma.Receipt, error)
+	}
+)
+
+//go:generate mockery --name ReturnService
+type (
+	ReturnService interface {
+		RequestReturn(ctx context.Context, userID string, req rma.CreateRequest) (*rma.Return, error)
+		GetCustomerReturns(ctx context.Context, userID string) ([]rma.Return, error)
+		GetCustomerReturn(ctx context.Context, userID string, id uuid.UUID) (*rma.Return, error)
+		GetReturns(ctx context.Context, status rma.Status) ([]rma.Return, error)
+		GetReturn(ctx context.Context, id uuid.UUID) (*rma.Return, error)
+		ApproveReturn(ctx context.Context, actorID string, id uuid.UUID, req rma.ResolveRequest) (*rma.Return, error)
+		RejectReturn(ctx context.Context, actorID string, id uuid.UUID, req rma.ResolveRequest) (*rma.Return, error)
+		ReceiveReturn(ctx context.Context, actorID string, id uuid.UUID) (*rma.Return, error)
+	}
+)
+
+//go:generate mockery --name ReturnHandler
+type (
+	ReturnHandler interface {
+		RequestReturn(w http.ResponseWriter, r *http.Request)
+		GetMyReturns(w http.ResponseWriter, r *http.Request)
+		GetMyReturn(w http.ResponseWriter, r *http.Request)
+		GetReturns(w http.ResponseWriter, r *http.Request)
+		GetReturn(w http.ResponseWriter, r *http.Request)
+		ApproveReturn(w http.ResponseWriter, r *http.Request)
+		RejectReturn(w http.ResponseWriter, r *http.Request)
+		ReceiveReturn(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name ShipmentRepository
+type (
+	ShipmentRepository interface {
+		CreateShipment(ctx context.Context, orderID uuid.UUID, createdBy uuid.NullUUID, items []shipment.ItemRequest) (uuid.UUID, error)
+		GetShipment(ctx context.Context, id uuid.UUID) (*shipment.Shipment, error)
+		GetShipmentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]shipment.Shipment, error)
+		GetShipmentsByUserID(ctx context.Context, userID uuid.UUID) ([]shipment.Shipment, error)
+		GetOrderedItems(ctx context.Context, orderID uuid.UUID) ([]shipment.Item, error)
+		UpdateStatus(ctx context.Context, change shipment.StatusChange) (*shipment.Shipment, error)
+	}
+)
+
+//go:generate mockery --name ShipmentService
+type (
+	ShipmentService interface {
+		CreateShipment(ctx context.Context, actorID string, req shipment.CreateRequest) (*shipment.Shipment, error)
+		GetShipment(ctx context.Context, id uuid.UUID) (*shipment.Shipment, error)
+		GetOrderShipments(ctx context.Context, orderID uuid.UUID) ([]shipment.Shipment, error)
+		GetCustomerShipments(ctx context.Context, userID, orderID string) ([]shipment.Shipment, error)
+		GetPickList(ctx context.Context, id uuid.UUID) (*shipment.PickList, error)
+		ShipShipment(ctx context.Context, actorID string, id uuid.UUID, req shipment.ShipRequest) (*shipment.Shipment, error)
+		DeliverShipment(ctx context.Context, actorID string, id uuid.UUID) (*shipment.Shipment, error)
+		CancelShipment(ctx context.Context, actorID string, id uuid.UUID) (*shipment.Shipment, error)
+	}
+)
+
+//go:generate mockery --name ShipmentHandler
+type (
+	ShipmentHandler interface {
+		CreateShipment(w http.ResponseWriter, r *http.Request)
+		GetShipment(w http.ResponseWriter, r *http.Request)
+		GetOrderShipments(w http.ResponseWriter, r *http.Request)
+		GetPickList(w http.ResponseWriter, r *http.Request)
+		ShipShipment(w http.ResponseWriter, r *http.Request)
+		DeliverShipment(w http.ResponseWriter, r *http.Request)
+		CancelShipment(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+)
+
+//go:generate mockery --name ShippingRateProvider
+type (
+	ShippingRateProvider interface {
+		Quotes(ctx context.Context, to shipping.Destination, grams int) ([]shipping.Quote, error)
+	}
+)
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name StockAlertRepository
+type (
+	StockAlertRepository interface {
+		Subscribe(ctx context.Context, userID uuid.UUID, req stockalert.Request, token string) (*stockalert.Alert, error)
+		GetAlerts(ctx context.Context, userID uuid.UUID) ([]stockalert.Alert, error)
+		DeleteAlert(ctx context.Context, userID, id uuid.UUID) error
+		Unsubscribe(ctx context.Context, token string) (*stockalert.Alert, error)
+		TriggerBackInStock(ctx context.Context, bookID uuid.UUID, at time.Time) (int, error)
+		TriggerPriceDrop(ctx context.Context, bookID uuid.UUID, oldPrice, newPrice money.Money, at time.Time) (int, error)
+		GetFired(ctx context.Context, limit int) ([]stockalert.Fired, error)
+		// Notify clears the pending flag of the alerts and queues msg with
+		// it, unless msg is nil or another sender already cleared them all.
+		Notify(ctx context.Context, msg *notification.Message, alertIDs []uuid.UUID) error
+		GetDemand(ctx context.Context, limit int) ([]stockalert.Demand, error)
+	}
+)
+
+//go:generate mockery --name StockAlertService
+type (
+	StockAlertService interface {
+		Subscribe(ctx context.Context, userID string, req stockalert.Request) (*stockalert.Alert, error)
+		GetAlerts(ctx context.Context, userID string) ([]stockalert.Alert, error)
+		DeleteAlert(ctx context.Context, userID string, id uuid.UUID) error
+		Unsubscribe(ctx context.Context, token string) (*stockalert.Alert, error)
+		GetDemand(ctx context.Context) ([]stockalert.Demand, error)
+	}
+)
+
+//go:generate mockery --name StockAlertHandler
+type (
+	StockAlertHandler interface {
+		GetAlerts(w http.ResponseWriter, r *http.Request)
+		Subscribe(w http.ResponseWriter, r *http.Request)
+		DeleteAlert(w http.ResponseWriter, r *http.Request)
+		Unsubscribe(w http.ResponseWriter, r *http.Request)
+		GetDemand(w http.ResponseWriter, r *http.Request)
+	}
+)
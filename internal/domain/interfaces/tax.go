@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+)
+
+//go:generate mockery --name TaxCalculator
+type (
+	TaxCalculator interface {
+		Calculate(ctx context.Context, jurisdiction tax.Jurisdiction, lines []tax.Line) (tax.Result, error)
+	}
+)
@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name WebhookRepository
+type (
+	WebhookRepository interface {
+		CreateEndpoint(ctx context.Context, endpoint webhook.Endpoint) (*webhook.Endpoint, error)
+		GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error)
+		GetEndpoint(ctx context.Context, id uuid.UUID) (*webhook.Endpoint, error)
+		UpdateEndpoint(ctx context.Context, endpoint webhook.Endpoint) (*webhook.Endpoint, error)
+		DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+		Enqueue(ctx context.Context, e event.Event) (int, error)
+		CreateDelivery(ctx context.Context, endpointID uuid.UUID, eventType event.Type, payload json.RawMessage) (*webhook.Delivery, error)
+		ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Outgoing, error)
+		RecordAttempt(ctx context.Context, attempt webhook.Attempt, disableAfter int) (*webhook.Delivery, error)
+		GetDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]webhook.Delivery, error)
+	}
+)
+
+// WebhookSender posts one delivery to an endpoint. Failing to get an answer
+// is reported in the result, not as an error.
+//
+//go:generate mockery --name WebhookSender
+type (
+	WebhookSender interface {
+		Send(ctx context.Context, out webhook.Outgoing) webhook.Result
+	}
+)
+
+//go:generate mockery --name WebhookService
+type (
+	WebhookService interface {
+		CreateEndpoint(ctx context.Context, req webhook.EndpointRequest) (*webhook.Endpoint, error)
+		GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error)
+		GetEndpoint(ctx context.Context, id uuid.UUID) (*webhook.Endpoint, error)
+		UpdateEndpoint(ctx context.Context, id uuid.UUID, req webhook.EndpointRequest) (*webhook.Endpoint, error)
+		DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+		GetDeliveries(ctx context.Context, endpointID uuid.UUID) ([]webhook.Delivery, error)
+		SendTestEvent(ctx context.Context, endpointID uuid.UUID) (*webhook.Delivery, error)
+	}
+)
+
+//go:generate mockery --name WebhookHandler
+type (
+	WebhookHandler interface {
+		GetEndpoints(w http.ResponseWriter, r *http.Request)
+		CreateEndpoint(w http.ResponseWriter, r *http.Request)
+		GetEndpoint(w http.ResponseWriter, r *http.Request)
+		UpdateEndpoint(w http.ResponseWriter, r *http.Request)
+		DeleteEndpoint(w http.ResponseWriter, r *http.Request)
+		GetDeliveries(w http.ResponseWriter, r *http.Request)
+		SendTestEvent(w http.ResponseWriter, r *http.Request)
+	}
+)
@@ -0,0 +1,58 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+//go:generate mockery --name WishlistRepository
+type (
+	WishlistRepository interface {
+		CreateWishlist(ctx context.Context, w wishlist.Wishlist) (*wishlist.Wishlist, error)
+		GetWishlists(ctx context.Context, userID uuid.UUID) ([]wishlist.Wishlist, error)
+		GetWishlist(ctx context.Context, userID, id uuid.UUID) (*wishlist.Wishlist, error)
+		// GetSharedWishlist finds a wishlist by its share token, as long as
+		// it is still shared.
+		GetSharedWishlist(ctx context.Context, token string) (*wishlist.Wishlist, error)
+		UpdateWishlist(ctx context.Context, userID, id uuid.UUID, req wishlist.Request) error
+		DeleteWishlist(ctx context.Context, userID, id uuid.UUID) error
+		// AddItem reports whether the book was added, as opposed to being on
+		// the wishlist already.
+		AddItem(ctx context.Context, wishlistID, bookID uuid.UUID) (bool, error)
+		RemoveItem(ctx context.Context, wishlistID, bookID uuid.UUID) error
+	}
+)
+
+//go:generate mockery --name WishlistService
+type (
+	WishlistService interface {
+		CreateWishlist(ctx context.Context, userID string, req wishlist.Request) (*wishlist.Wishlist, error)
+		GetWishlists(ctx context.Context, userID string) ([]wishlist.Wishlist, error)
+		GetWishlist(ctx context.Context, userID string, id uuid.UUID) (*wishlist.Wishlist, error)
+		UpdateWishlist(ctx context.Context, userID string, id uuid.UUID, req wishlist.Request) (*wishlist.Wishlist, error)
+		DeleteWishlist(ctx context.Context, userID string, id uuid.UUID) error
+		AddItem(ctx context.Context, userID string, id uuid.UUID, req wishlist.ItemRequest) (*wishlist.Wishlist, error)
+		RemoveItem(ctx context.Context, userID string, id, bookID uuid.UUID) (*wishlist.Wishlist, error)
+		MoveToCart(ctx context.Context, userID string, id, bookID uuid.UUID, req wishlist.MoveToCartRequest) (*wishlist.Wishlist, error)
+		SaveForLater(ctx context.Context, userID string, id uuid.UUID, req wishlist.ItemRequest) (*wishlist.Wishlist, error)
+		GetSharedWishlist(ctx context.Context, token string) (*wishlist.Wishlist, error)
+	}
+)
+
+//go:generate mockery --name WishlistHandler
+type (
+	WishlistHandler interface {
+		GetWishlists(w http.ResponseWriter, r *http.Request)
+		CreateWishlist(w http.ResponseWriter, r *http.Request)
+		GetWishlist(w http.ResponseWriter, r *http.Request)
+		UpdateWishlist(w http.ResponseWriter, r *http.Request)
+		DeleteWishlist(w http.ResponseWriter, r *http.Request)
+		AddItem(w http.ResponseWriter, r *http.Request)
+		RemoveItem(w http.ResponseWriter, r *http.Request)
+		MoveToCart(w http.ResponseWriter, r *http.Request)
+		SaveForLater(w http.ResponseWriter, r *http.Request)
+		GetSharedWishlist(w http.ResponseWriter, r *http.Request)
+	}
+)
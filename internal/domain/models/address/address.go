@@ -0,0 +1,221 @@
+package address
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/gofrs/uuid"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Address struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Label      string    `json:"label" example:"Home"`
+	FullName   string    `json:"full_name" example:"Ada Lovelace"`
+	Line1      string    `json:"line1" example:"12 St James's Square"`
+	Line2      string    `json:"line2"`
+	City       string    `json:"city" example:"London"`
+	Region     string    `json:"region"`
+	PostalCode string    `json:"postal_code" example:"SW1Y 4JH"`
+	Country    string    `json:"country" example:"GB"`
+	Phone      string    `json:"phone"`
+	IsDefault  bool      `json:"is_default"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+} // @name AddressModel
+
+type Request struct {
+	Label      string `json:"label" example:"Home"`
+	FullName   string `json:"full_name" example:"Ada Lovelace"`
+	Line1      string `json:"line1" example:"12 St James's Square"`
+	Line2      string `json:"line2"`
+	City       string `json:"city" example:"London"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code" example:"SW1Y 4JH"`
+	Country    string `json:"country" example:"GB"`
+	Phone      string `json:"phone"`
+	IsDefault  bool   `json:"is_default"`
+} // @name AddressRequestModel
+
+// ValidationErrors maps each invalid field to what is wrong with it.
+type ValidationErrors map[string]string
+
+func (v ValidationErrors) Error() string {
+	fields := make([]string, 0, len(v))
+	for field, problem := range v {
+		fields = append(fields, field+": "+problem)
+	}
+	sort.Strings(fields)
+	return "invalid address: " + strings.Join(fields, "; ")
+}
+
+var (
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	regionPattern  = regexp.MustCompile(`^[A-Z0-9]{1,3}$`)
+	phonePattern   = regexp.MustCompile(`^\+?[0-9 ()-]{5,32}$`)
+	postalPattern  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,18}[A-Z0-9]$`)
+
+	// postalFormats are checked on top of postalPattern where the format is
+	// simple and strict enough to be worth it.
+	postalFormats = map[string]*regexp.Regexp{
+		"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+		"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+		"DE": regexp.MustCompile(`^\d{5}$`),
+		"FR": regexp.MustCompile(`^\d{5}$`),
+		"KZ": regexp.MustCompile(`^\d{6}$`),
+		"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	}
+
+	// regionRequired lists countries whose addresses, taxes and rates all
+	// depend on the state or province.
+	regionRequired = map[string]bool{"US": true, "CA": true}
+)
+
+// Normalize trims every field and upper-cases the codes.
+func (r Request) Normalize() Request {
+	trim := strings.TrimSpace
+	return Request{
+		Label:      trim(r.Label),
+		FullName:   trim(r.FullName),
+		Line1:      trim(r.Line1),
+		Line2:      trim(r.Line2),
+		City:       trim(r.City),
+		Region:     strings.ToUpper(trim(r.Region)),
+		PostalCode: strings.ToUpper(trim(r.PostalCode)),
+		Country:    strings.ToUpper(trim(r.Country)),
+		Phone:      trim(r.Phone),
+		IsDefault:  r.IsDefault,
+	}
+}
+
+// Validate checks a normalized request and reports every problem at once.
+func (r Request) Validate() error {
+	errs := ValidationErrors{}
+
+	required := func(field, value string, max int) {
+		switch {
+		case value == "":
+			errs[field] = "is required"
+		case len(value) > max:
+			errs[field] = fmt.Sprintf("must be at most %d characters", max)
+		}
+	}
+	optional := func(field, value string, max int) {
+		if len(value) > max {
+			errs[field] = fmt.Sprintf("must be at most %d characters", max)
+		}
+	}
+
+	optional("label", r.Label, 50)
+	required("full_name", r.FullName, 100)
+	required("line1", r.Line1, 200)
+	optional("line2", r.Line2, 200)
+	required("city", r.City, 100)
+
+	if !countryPattern.MatchString(r.Country) {
+		errs["country"] = "must be a two-letter ISO country code"
+	}
+
+	switch {
+	case r.Region == "" && regionRequired[r.Country]:
+		errs["region"] = "is required for " + r.Country
+	case r.Region != "" && !regionPattern.MatchString(r.Region):
+		errs["region"] = "must be an ISO subdivision code such as NY"
+	}
+
+	if r.PostalCode == "" {
+		errs["postal_code"] = "is required"
+	} else if format, ok := postalFormats[r.Country]; (ok && !format.MatchString(r.PostalCode)) || !postalPattern.MatchString(r.PostalCode) {
+		errs["postal_code"] = "is not a valid postal code for " + r.Country
+	}
+
+	if r.Phone != "" && !phonePattern.MatchString(r.Phone) {
+		errs["phone"] = "is not a valid phone number"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Address builds the address book entry a valid request describes.
+func (r Request) Address(userID uuid.UUID) Address {
+	return Address{
+		UserID:     userID,
+		Label:      r.Label,
+		FullName:   r.FullName,
+		Line1:      r.Line1,
+		Line2:      r.Line2,
+		City:       r.City,
+		Region:     r.Region,
+		PostalCode: r.PostalCode,
+		Country:    r.Country,
+		Phone:      r.Phone,
+		IsDefault:  r.IsDefault,
+	}
+}
+
+// Snapshot is an address as copied onto an order.
+type Snapshot struct {
+	FullName   string `json:"full_name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone,omitempty"`
+} // @name AddressSnapshotModel
+
+func (a Address) Snapshot() *Snapshot {
+	return &Snapshot{
+		FullName:   a.FullName,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		Phone:      a.Phone,
+	}
+}
+
+func (s Snapshot) Destination() shipping.Destination {
+	return shipping.Destination{Country: s.Country, Region: s.Region}
+}
+
+func (s Snapshot) Jurisdiction() tax.Jurisdiction {
+	return tax.Jurisdiction{Country: s.Country, Region: s.Region}
+}
+
+// Lines formats the address for a label, one line per element.
+func (s Snapshot) Lines() []string {
+	lines := []string{s.FullName, s.Line1}
+	if s.Line2 != "" {
+		lines = append(lines, s.Line2)
+	}
+	city := strings.TrimSpace(strings.Join([]string{s.City, s.Region, s.PostalCode}, " "))
+	return append(lines, city, s.Country)
+}
+
+// Scan reads a JSONB column.
+func (s *Snapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	}
+	return fmt.Errorf("cannot scan %T into an address", src)
+}
+
+func (s Snapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
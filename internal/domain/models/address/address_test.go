@@ -0,0 +1,80 @@
+package address
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func valid() Request {
+	return Request{
+		FullName:   "Ada Lovelace",
+		Line1:      "12 St James's Square",
+		City:       "London",
+		PostalCode: "SW1Y 4JH",
+		Country:    "GB",
+	}
+}
+
+func TestRequestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(r *Request)
+		wantFields []string
+	}{
+		{name: "valid uk address", change: func(r *Request) {}},
+		{name: "valid us zip+4", change: func(r *Request) {
+			r.Country, r.Region, r.PostalCode = "us", "ny", "10001-1234"
+		}},
+		{name: "valid canadian postal code", change: func(r *Request) {
+			r.Country, r.Region, r.PostalCode = "CA", "ON", "k1a 0b1"
+		}},
+		{name: "missing required fields", change: func(r *Request) {
+			r.FullName, r.Line1, r.City, r.PostalCode = "", " ", "", ""
+		}, wantFields: []string{"full_name", "line1", "city", "postal_code"}},
+		{name: "country must be a code", change: func(r *Request) {
+			r.Country = "United Kingdom"
+		}, wantFields: []string{"country"}},
+		{name: "us needs a state and a zip code", change: func(r *Request) {
+			r.Country, r.PostalCode = "US", "ABCDE"
+		}, wantFields: []string{"region", "postal_code"}},
+		{name: "german postal codes are five digits", change: func(r *Request) {
+			r.Country, r.PostalCode = "DE", "1011"
+		}, wantFields: []string{"postal_code"}},
+		{name: "bad phone", change: func(r *Request) {
+			r.Phone = "call me maybe"
+		}, wantFields: []string{"phone"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Normalize().Validate()
+			if len(tt.wantFields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var invalid ValidationErrors
+			require.ErrorAs(t, err, &invalid)
+			assert.Len(t, invalid, len(tt.wantFields))
+			for _, field := range tt.wantFields {
+				assert.Contains(t, invalid, field)
+			}
+		})
+	}
+}
+
+func TestSnapshotScan(t *testing.T) {
+	want := Address{FullName: "Ada Lovelace", Line1: "12 St James's Square", City: "London", PostalCode: "SW1Y 4JH", Country: "GB"}.Snapshot()
+
+	value, err := want.Value()
+	require.NoError(t, err)
+
+	var got Snapshot
+	require.NoError(t, got.Scan(value))
+	assert.Equal(t, *want, got)
+	assert.Equal(t, "GB", got.Jurisdiction().String())
+}
@@ -0,0 +1,228 @@
+package adminorder
+
+import (
+	"errors"
+	"fmt"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DateLayout is how filter dates are written in query strings.
+	DateLayout = "2006-01-02"
+
+	DefaultLimit = 50
+	MaxLimit     = 200
+	// MaxNoteLength keeps internal notes to a few paragraphs.
+	MaxNoteLength = 2000
+)
+
+// Filter narrows the orders an admin searches. Zero fields do not filter.
+// Without a Status, drafts are left out: they are open carts, not orders.
+type Filter struct {
+	Status orderModel.Status
+	// From and To are the days the order was created on, both included.
+	From time.Time
+	To   time.Time
+	// Email matches any part of the customer's email, ignoring case.
+	Email string
+	// MinTotal is the smallest grand total, in the catalogue currency.
+	MinTotal money.Money
+	Limit    int
+	Offset   int
+}
+
+// ParseFilter reads a filter from the query string of an order search.
+func ParseFilter(query url.Values) (Filter, error) {
+	f := Filter{
+		Status: orderModel.Status(query.Get("status")),
+		Email:  strings.TrimSpace(query.Get("email")),
+		Limit:  DefaultLimit,
+	}
+	var err error
+
+	if f.Status != "" && !f.Status.IsValid() {
+		return Filter{}, fmt.Errorf("unknown status %q", f.Status)
+	}
+
+	if f.From, err = parseDate("from", query.Get("from")); err != nil {
+		return Filter{}, err
+	}
+	if f.To, err = parseDate("to", query.Get("to")); err != nil {
+		return Filter{}, err
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return Filter{}, errors.New("from must not be after to")
+	}
+
+	if s := query.Get("min_total"); s != "" {
+		f.MinTotal, err = money.Parse(s, money.DefaultCurrency)
+		if err != nil || f.MinTotal.IsNegative() {
+			return Filter{}, fmt.Errorf("min_total must be an amount like 25.00, got %q", s)
+		}
+	}
+
+	if s := query.Get("limit"); s != "" {
+		f.Limit, err = strconv.Atoi(s)
+		if err != nil || f.Limit <= 0 {
+			return Filter{}, fmt.Errorf("limit must be a positive number, got %q", s)
+		}
+		f.Limit = min(f.Limit, MaxLimit)
+	}
+
+	if s := query.Get("offset"); s != "" {
+		f.Offset, err = strconv.Atoi(s)
+		if err != nil || f.Offset < 0 {
+			return Filter{}, fmt.Errorf("offset must not be negative, got %q", s)
+		}
+	}
+
+	return f, nil
+}
+
+func parseDate(name, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date like %s, got %q", name, DateLayout, s)
+	}
+
+	return t, nil
+}
+
+// Until is the first moment after To, or zero when To is not set.
+func (f Filter) Until() time.Time {
+	if f.To.IsZero() {
+		return time.Time{}
+	}
+	return f.To.AddDate(0, 0, 1)
+}
+
+// Summary is one row of the order list.
+type Summary struct {
+	ID         uuid.UUID         `json:"id"`
+	UserID     uuid.UUID         `json:"user_id"`
+	Email      string            `json:"email"`
+	Username   string            `json:"username"`
+	Status     orderModel.Status `json:"status"`
+	TotalPrice money.Money       `json:"total_price" swaggertype:"string" example:"28.29"`
+	// Currency is what the customer was quoted in; TotalPrice stays in the
+	// catalogue currency.
+	Currency  money.Currency `json:"currency" swaggertype:"string" example:"EUR"`
+	Items     int            `json:"items"`
+	CreatedAt time.Time      `json:"created_at"`
+} // @name AdminOrderSummaryModel
+
+// Page is one page of search results and how many orders match in total.
+type Page struct {
+	Orders []Summary `json:"orders"`
+	Total  int       `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+} // @name AdminOrderPageModel
+
+// Customer is who placed an order.
+type Customer struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+} // @name AdminOrderCustomerModel
+
+// Note is an internal remark staff left on an order. Customers never see
+// notes.
+type Note struct {
+	ID        uuid.UUID `json:"id"`
+	OrderID   uuid.UUID `json:"order_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+} // @name AdminOrderNoteModel
+
+// Detail is everything staff need to handle one order.
+type Detail struct {
+	Order     *orderModel.Model               `json:"order"`
+	Customer  Customer                        `json:"customer"`
+	CreatedAt time.Time                       `json:"created_at"`
+	Items     []orderItem.OrderItemFull       `json:"items"`
+	Payments  []payment.Payment               `json:"payments"`
+	Shipments []shipment.Shipment             `json:"shipments"`
+	History   []orderModel.StatusHistoryEntry `json:"history"`
+	Notes     []Note                          `json:"notes"`
+} // @name AdminOrderDetailModel
+
+// NextStatuses lists the statuses staff can move the order to from here.
+func (d Detail) NextStatuses() []orderModel.Status {
+	return StatusTargets(d.Order.Status)
+}
+
+// Resendable reports whether the order confirmation can be sent again.
+func (d Detail) Resendable() bool {
+	return d.Order.Status.IsSettled()
+}
+
+// StatusTargets lists the statuses an admin may move an order to by hand:
+// confirming a payment taken outside the provider, and cancelling. Every
+// other move belongs to the subsystem that does the work behind it. Checkout
+// prices a draft and takes it to payment, shipments move an order through
+// fulfilment, and returns and cancellations book refunds and send the money
+// back.
+func StatusTargets(from orderModel.Status) []orderModel.Status {
+	var targets []orderModel.Status
+	if from == orderModel.StatusPendingPayment && from.CanTransitionTo(orderModel.StatusPaid) {
+		targets = append(targets, orderModel.StatusPaid)
+	}
+	if from.CancellableBy(orderModel.ActorAdmin) {
+		targets = append(targets, orderModel.StatusCancelled)
+	}
+	return targets
+}
+
+// StatusRequest moves an order to another status.
+type StatusRequest struct {
+	Status orderModel.Status `json:"status" example:"cancelled"`
+	Reason string            `json:"reason"`
+} // @name AdminOrderStatusRequestModel
+
+func (r StatusRequest) Validate() error {
+	if !r.Status.IsValid() {
+		return fmt.Errorf("unknown status %q", r.Status)
+	}
+	switch r.Status {
+	case orderModel.StatusRefunded, orderModel.StatusPartiallyRefunded:
+		return errors.New("refunds are made by cancelling the order or through a return")
+	case orderModel.StatusFulfilled, orderModel.StatusShipped, orderModel.StatusDelivered:
+		return errors.New("fulfilment follows the order's shipments")
+	case orderModel.StatusDraft, orderModel.StatusPendingPayment:
+		return errors.New("only the customer's checkout takes an order to payment")
+	}
+	return nil
+}
+
+// NoteRequest adds an internal note to an order.
+type NoteRequest struct {
+	Body string `json:"body" example:"Customer called, wants gift wrapping"`
+} // @name AdminOrderNoteRequestModel
+
+func (r *NoteRequest) Validate() error {
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" {
+		return errors.New("note must not be empty")
+	}
+	if utf8.RuneCountInString(r.Body) > MaxNoteLength {
+		return fmt.Errorf("note must be at most %d characters", MaxNoteLength)
+	}
+	return nil
+}
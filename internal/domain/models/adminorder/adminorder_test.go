@@ -0,0 +1,97 @@
+package adminorder
+
+import (
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Filter
+		wantErr bool
+	}{
+		{name: "empty", want: Filter{Limit: DefaultLimit}},
+		{
+			name:  "all filters",
+			query: "status=paid&from=2025-03-01&to=2025-03-31&email=%20Ann@Example.com&min_total=25.00&limit=20&offset=40",
+			want: Filter{
+				Status:   orderModel.StatusPaid,
+				From:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+				Email:    "Ann@Example.com",
+				MinTotal: money.MustParse("25.00", money.DefaultCurrency),
+				Limit:    20,
+				Offset:   40,
+			},
+		},
+		{name: "limit capped", query: "limit=1000", want: Filter{Limit: MaxLimit}},
+		{name: "unknown status", query: "status=lost", wantErr: true},
+		{name: "bad date", query: "from=03/01/2025", wantErr: true},
+		{name: "from after to", query: "from=2025-03-02&to=2025-03-01", wantErr: true},
+		{name: "bad min total", query: "min_total=lots", wantErr: true},
+		{name: "negative min total", query: "min_total=-1.00", wantErr: true},
+		{name: "zero limit", query: "limit=0", wantErr: true},
+		{name: "negative offset", query: "offset=-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			got, err := ParseFilter(query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_Until(t *testing.T) {
+	assert.True(t, Filter{}.Until().IsZero())
+	assert.Equal(t,
+		time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Filter{To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}.Until())
+}
+
+func TestStatusTargets(t *testing.T) {
+	assert.Equal(t, []orderModel.Status{orderModel.StatusCancelled}, StatusTargets(orderModel.StatusDraft))
+	assert.Equal(t,
+		[]orderModel.Status{orderModel.StatusPaid, orderModel.StatusCancelled},
+		StatusTargets(orderModel.StatusPendingPayment))
+	assert.Equal(t, []orderModel.Status{orderModel.StatusCancelled}, StatusTargets(orderModel.StatusPaid))
+	assert.Equal(t, []orderModel.Status{orderModel.StatusCancelled}, StatusTargets(orderModel.StatusFulfilled))
+	assert.Empty(t, StatusTargets(orderModel.StatusShipped))
+	assert.Empty(t, StatusTargets(orderModel.StatusDelivered))
+	assert.Empty(t, StatusTargets(orderModel.StatusCancelled))
+}
+
+func TestStatusRequest_Validate(t *testing.T) {
+	assert.NoError(t, StatusRequest{Status: orderModel.StatusCancelled}.Validate())
+	assert.NoError(t, StatusRequest{Status: orderModel.StatusPaid}.Validate())
+	assert.Error(t, StatusRequest{Status: "lost"}.Validate())
+	assert.Error(t, StatusRequest{Status: orderModel.StatusPendingPayment}.Validate())
+	assert.Error(t, StatusRequest{Status: orderModel.StatusShipped}.Validate())
+	assert.Error(t, StatusRequest{Status: orderModel.StatusRefunded}.Validate())
+	assert.Error(t, StatusRequest{Status: orderModel.StatusPartiallyRefunded}.Validate())
+}
+
+func TestNoteRequest_Validate(t *testing.T) {
+	req := NoteRequest{Body: "  called the customer \n"}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "called the customer", req.Body)
+
+	blank := NoteRequest{Body: " \t "}
+	assert.Error(t, blank.Validate())
+
+	long := NoteRequest{Body: strings.Repeat("é", MaxNoteLength+1)}
+	assert.Error(t, long.Validate())
+}
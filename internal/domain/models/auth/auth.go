@@ -1,5 +1,10 @@
 package model
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Login struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -10,3 +15,20 @@ type Registration struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 } // @name RegistrationModel
+
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+} // @name PasswordChangeModel
+
+const minPasswordLength = 8
+
+func (p PasswordChange) Validate() error {
+	if len(p.NewPassword) < minPasswordLength {
+		return fmt.Errorf("new password must be at least %d characters", minPasswordLength)
+	}
+	if p.NewPassword == p.CurrentPassword {
+		return errors.New("new password must differ from the current one")
+	}
+	return nil
+}
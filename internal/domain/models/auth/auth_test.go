@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPasswordChange_Validate(t *testing.T) {
+	assert.NoError(t, PasswordChange{CurrentPassword: "password123", NewPassword: "correct horse"}.Validate())
+	assert.Error(t, PasswordChange{CurrentPassword: "password123", NewPassword: "short"}.Validate())
+	assert.Error(t, PasswordChange{CurrentPassword: "password123", NewPassword: "password123"}.Validate())
+}
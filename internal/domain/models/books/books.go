@@ -1,11 +1,23 @@
 package books
 
-import "github.com/gofrs/uuid"
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+)
 
 type Book struct {
-	ID     uuid.UUID `json:"id"`
-	Title  string    `json:"title"`
-	Author string    `json:"author"`
-	Price  float64   `json:"price"`
-	Stock  int       `json:"stock"`
+	ID       uuid.UUID   `json:"id"`
+	Title    string      `json:"title"`
+	Author   string      `json:"author"`
+	Category string      `json:"category"`
+	TaxClass tax.Class   `json:"tax_class" swaggertype:"string" example:"reduced"`
+	Price    money.Money `json:"price" swaggertype:"string" example:"12.99"`
+	Stock    int         `json:"stock"`
+	// WeightGrams is the shipping weight of one copy.
+	WeightGrams int `json:"weight_grams" example:"350"`
+	// RatingAverage and RatingCount sum up the approved reviews. They are
+	// kept by the reviews and ignored when a book is written.
+	RatingAverage float64 `json:"rating_average" example:"4.25"`
+	RatingCount   int     `json:"rating_count"`
 } // @name BookModel
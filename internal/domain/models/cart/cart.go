@@ -0,0 +1,77 @@
+package cart
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+)
+
+// MaxQuantity caps one line of a cart.
+const MaxQuantity = 99
+
+// QuantityRequest sets how many copies of a book the cart holds. Zero takes
+// the book out of the cart.
+type QuantityRequest struct {
+	Quantity int `json:"quantity" example:"2"`
+} // @name CartQuantityRequestModel
+
+func (r QuantityRequest) Validate() error {
+	if r.Quantity < 0 || r.Quantity > MaxQuantity {
+		return fmt.Errorf("quantity must be between 0 and %d, got %d", MaxQuantity, r.Quantity)
+	}
+	return nil
+}
+
+// Cart is the user's draft order as the cart shows it: its lines and what
+// they come to, promotions included.
+type Cart struct {
+	// OrderID is the draft order behind the cart, absent while it is empty.
+	OrderID uuid.UUID                 `json:"order_id"`
+	Items   []orderItem.OrderItemFull `json:"items"`
+	// Units is the number of copies in the cart, over all lines.
+	Units   int                 `json:"units"`
+	Summary promotion.Breakdown `json:"summary"`
+} // @name CartModel
+
+// New builds a cart from the lines of a draft order and their price.
+func New(orderID uuid.UUID, items []orderItem.OrderItemFull, summary promotion.Breakdown) Cart {
+	if items == nil {
+		items = []orderItem.OrderItemFull{}
+	}
+
+	c := Cart{OrderID: orderID, Items: items, Summary: summary}
+	for _, item := range items {
+		c.Units += item.Quantity
+	}
+	return c
+}
+
+// Empty is the cart of a user without a draft order.
+func Empty() Cart {
+	return New(uuid.Nil, nil, promotion.Breakdown{Currency: money.DefaultCurrency, Discounts: []promotion.Discount{}})
+}
+
+// Item finds the line of a book.
+func (c Cart) Item(bookID uuid.UUID) (orderItem.OrderItemFull, bool) {
+	for _, item := range c.Items {
+		if item.BookID == bookID {
+			return item, true
+		}
+	}
+	return orderItem.OrderItemFull{}, false
+}
+
+// Convert shows the cart in another currency at the given rate.
+func (c Cart) Convert(to money.Currency, rate money.Rate) Cart {
+	converted := c
+	converted.Items = make([]orderItem.OrderItemFull, len(c.Items))
+	for i, item := range c.Items {
+		item.Price = item.Price.Convert(to, rate)
+		item.Tax = item.Tax.Convert(to, rate)
+		converted.Items[i] = item
+	}
+	converted.Summary = c.Summary.Convert(to, rate)
+	return converted
+}
@@ -0,0 +1,57 @@
+package cart
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQuantityRequest_Validate(t *testing.T) {
+	assert.NoError(t, QuantityRequest{Quantity: 0}.Validate())
+	assert.NoError(t, QuantityRequest{Quantity: 2}.Validate())
+	assert.NoError(t, QuantityRequest{Quantity: MaxQuantity}.Validate())
+	assert.Error(t, QuantityRequest{Quantity: -1}.Validate())
+	assert.Error(t, QuantityRequest{Quantity: MaxQuantity + 1}.Validate())
+}
+
+func TestNew(t *testing.T) {
+	dune := uuid.Must(uuid.NewV4())
+	c := New(uuid.Must(uuid.NewV4()), []orderItem.OrderItemFull{
+		{BookID: dune, Quantity: 2},
+		{BookID: uuid.Must(uuid.NewV4()), Quantity: 3},
+	}, promotion.Breakdown{})
+
+	assert.Equal(t, 5, c.Units)
+
+	item, ok := c.Item(dune)
+	assert.True(t, ok)
+	assert.Equal(t, 2, item.Quantity)
+
+	_, ok = c.Item(uuid.Must(uuid.NewV4()))
+	assert.False(t, ok)
+}
+
+func TestEmpty(t *testing.T) {
+	c := Empty()
+
+	assert.Equal(t, uuid.Nil, c.OrderID)
+	assert.NotNil(t, c.Items)
+	assert.Zero(t, c.Units)
+	assert.Equal(t, money.DefaultCurrency, c.Summary.Currency)
+}
+
+func TestCart_Convert(t *testing.T) {
+	c := New(uuid.Must(uuid.NewV4()), []orderItem.OrderItemFull{
+		{Quantity: 1, Price: money.MustParse("10.00", money.DefaultCurrency)},
+	}, promotion.Breakdown{Currency: money.DefaultCurrency, Total: money.MustParse("10.00", money.DefaultCurrency)})
+
+	converted := c.Convert(money.EUR, money.MustParseRate("2"))
+
+	assert.Equal(t, "20.00", converted.Items[0].Price.String())
+	assert.Equal(t, money.EUR, converted.Summary.Currency)
+	assert.Equal(t, "20.00", converted.Summary.Total.String())
+	assert.Equal(t, "10.00", c.Items[0].Price.String())
+}
@@ -0,0 +1,190 @@
+package event
+
+import (
+	"encoding/json"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeOrderItemsAdded  Type = "order.items_added"
+	TypeBookOutOfStock   Type = "book.out_of_stock"
+	TypeBookBackInStock  Type = "book.back_in_stock"
+	TypeBookPriceChanged Type = "book.price_changed"
+
+	TypeUserPasswordChanged Type = "user.password_changed"
+)
+
+// OrderStatusType names the event recorded when an order enters a status,
+// e.g. "order.paid" or "order.cancelled".
+func OrderStatusType(status orderModel.Status) Type {
+	return Type("order." + string(status))
+}
+
+// IsKnown reports whether anything in the system raises events of type t.
+func (t Type) IsKnown() bool {
+	switch t {
+	case TypeOrderItemsAdded, TypeBookOutOfStock, TypeBookBackInStock, TypeBookPriceChanged,
+		TypeUserPasswordChanged:
+		return true
+	}
+	status, ok := strings.CutPrefix(string(t), "order.")
+	return ok && orderModel.Status(status).IsValid()
+}
+
+const (
+	AggregateOrder = "order"
+	AggregateBook  = "book"
+	AggregateUser  = "user"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	// StatusDead marks an event that ran out of attempts. It stays in the
+	// outbox until an admin sends it again.
+	StatusDead Status = "dead"
+)
+
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          Type            `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        Status          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	HandledBy     []string        `json:"handled_by"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+} // @name DomainEventModel
+
+// New builds a pending event about an aggregate with payload encoded as JSON.
+func New(typ Type, aggregateType string, aggregateID uuid.UUID, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Type:          typ,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       data,
+		Status:        StatusPending,
+	}, nil
+}
+
+// Decode unmarshals the payload into v.
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+type ItemsAdded struct {
+	OrderID uuid.UUID  `json:"order_id"`
+	UserID  string     `json:"user_id"`
+	Items   []ItemLine `json:"items"`
+}
+
+type ItemLine struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Quantity int       `json:"quantity"`
+}
+
+type StatusChanged struct {
+	OrderID   uuid.UUID            `json:"order_id"`
+	From      orderModel.Status    `json:"from"`
+	To        orderModel.Status    `json:"to"`
+	ActorID   uuid.NullUUID        `json:"actor_id"`
+	ActorKind orderModel.ActorKind `json:"actor_kind"`
+	Reason    string               `json:"reason"`
+}
+
+type StockLevel struct {
+	BookID    uuid.UUID `json:"book_id"`
+	Available int       `json:"available"`
+}
+
+type PriceChanged struct {
+	BookID   uuid.UUID   `json:"book_id"`
+	Title    string      `json:"title"`
+	OldPrice money.Money `json:"old_price"`
+	NewPrice money.Money `json:"new_price"`
+}
+
+type PasswordChanged struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// StockEvent returns the event raised by a stock change of delta that left
+// available books for sale, if the book ran out or came back after running
+// out.
+func StockEvent(bookID uuid.UUID, delta, available int) (Event, bool, error) {
+	var typ Type
+	switch before := available - delta; {
+	case before > 0 && available <= 0:
+		typ = TypeBookOutOfStock
+	case before <= 0 && available > 0:
+		typ = TypeBookBackInStock
+	default:
+		return Event{}, false, nil
+	}
+
+	e, err := New(typ, AggregateBook, bookID, StockLevel{BookID: bookID, Available: available})
+	if err != nil {
+		return Event{}, false, err
+	}
+	return e, true, nil
+}
+
+// RetryPolicy decides when a failed event is tried again. The delay starts at
+// BaseDelay and doubles with each failure up to MaxDelay; after MaxAttempts
+// failures the event is dead-lettered.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Failure is what the outbox records about a delivery that did not reach
+// every subscriber.
+type Failure struct {
+	Attempts      int
+	Error         string
+	HandledBy     []string
+	NextAttemptAt time.Time
+	Dead          bool
+}
+
+// Fail records one more failed delivery of e at now.
+func (p RetryPolicy) Fail(e Event, handledBy []string, err error, now time.Time) Failure {
+	attempts := e.Attempts + 1
+	return Failure{
+		Attempts:      attempts,
+		Error:         err.Error(),
+		HandledBy:     handledBy,
+		NextAttemptAt: now.Add(p.Backoff(attempts)),
+		Dead:          attempts >= p.MaxAttempts,
+	}
+}
@@ -0,0 +1,91 @@
+package event
+
+import (
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var book = uuid.Must(uuid.FromString("7a1c2d3e-0000-4000-8000-000000000001"))
+
+func TestOrderStatusType(t *testing.T) {
+	assert.Equal(t, Type("order.paid"), OrderStatusType(orderModel.StatusPaid))
+	assert.Equal(t, Type("order.cancelled"), OrderStatusType(orderModel.StatusCancelled))
+}
+
+func TestType_IsKnown(t *testing.T) {
+	assert.True(t, TypeBookPriceChanged.IsKnown())
+	assert.True(t, Type("order.shipped").IsKnown())
+	assert.True(t, TypeUserPasswordChanged.IsKnown())
+	assert.False(t, Type("order.lost").IsKnown())
+	assert.False(t, Type("book.burned").IsKnown())
+}
+
+func TestNew(t *testing.T) {
+	e, err := New(TypeBookOutOfStock, AggregateBook, book, StockLevel{BookID: book, Available: 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusPending, e.Status)
+	assert.Equal(t, book, e.AggregateID)
+
+	var level StockLevel
+	require.NoError(t, e.Decode(&level))
+	assert.Equal(t, book, level.BookID)
+}
+
+func TestStockEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		delta     int
+		available int
+		want      Type
+		wantOK    bool
+	}{
+		{name: "last copy reserved", delta: -1, available: 0, want: TypeBookOutOfStock, wantOK: true},
+		{name: "restocked from nothing", delta: 5, available: 5, want: TypeBookBackInStock, wantOK: true},
+		{name: "still in stock", delta: -1, available: 3},
+		{name: "topped up", delta: 2, available: 4},
+		{name: "still out", delta: 0, available: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok, err := StockEvent(book, tt.delta, tt.available)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, e.Type)
+				assert.Equal(t, AggregateBook, e.AggregateType)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Second, MaxDelay: time.Minute}
+
+	assert.Equal(t, 10*time.Second, p.Backoff(1))
+	assert.Equal(t, 20*time.Second, p.Backoff(2))
+	assert.Equal(t, 40*time.Second, p.Backoff(3))
+	assert.Equal(t, time.Minute, p.Backoff(4))
+	assert.Equal(t, time.Minute, p.Backoff(50))
+}
+
+func TestRetryPolicy_Fail(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Hour}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	f := p.Fail(Event{Attempts: 1}, []string{"log"}, errors.New("boom"), now)
+	assert.Equal(t, 2, f.Attempts)
+	assert.Equal(t, "boom", f.Error)
+	assert.Equal(t, []string{"log"}, f.HandledBy)
+	assert.Equal(t, now.Add(2*time.Second), f.NextAttemptAt)
+	assert.False(t, f.Dead)
+
+	f = p.Fail(Event{Attempts: 2}, nil, errors.New("boom"), now)
+	assert.True(t, f.Dead)
+}
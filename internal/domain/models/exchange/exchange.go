@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"time"
+)
+
+var ErrUnknownRate = errors.New("no exchange rate for currency")
+
+// Table is a set of rates quoted against one base currency, the shape both
+// the rates file and the rates API use:
+//
+//	{"base": "USD", "rates": {"EUR": "0.92", "KZT": "476.5"}}
+type Table struct {
+	Base      money.Currency                `json:"base"`
+	Rates     map[money.Currency]money.Rate `json:"rates"`
+	FetchedAt time.Time                     `json:"-"`
+} // @name ExchangeRateTableModel
+
+// Rate converts from one currency to another, crossing through the base
+// currency when neither side is the base.
+func (t *Table) Rate(from, to money.Currency) (money.Rate, error) {
+	if from == to {
+		return money.One, nil
+	}
+
+	fromRate, err := t.baseRate(from)
+	if err != nil {
+		return money.Rate{}, err
+	}
+	toRate, err := t.baseRate(to)
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	return toRate.Div(fromRate), nil
+}
+
+func (t *Table) baseRate(currency money.Currency) (money.Rate, error) {
+	if currency == t.Base {
+		return money.One, nil
+	}
+
+	rate, ok := t.Rates[currency]
+	if !ok || rate.IsZero() {
+		return money.Rate{}, fmt.Errorf("%s: %w", currency, ErrUnknownRate)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,107 @@
+package guest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/gofrs/uuid"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CookieName is the cookie that ties an anonymous visitor to their cart.
+	CookieName = "guest-cart"
+
+	maxEmailLength = 100
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid guest cart token")
+	ErrTokenExpired = errors.New("guest cart token expired")
+)
+
+// Sign writes the guest cart cookie value for a guest: the guest's ID and
+// when the cookie was issued, followed by an HMAC of both so a visitor cannot
+// point the cookie at someone else's cart.
+func Sign(id uuid.UUID, issued time.Time, secret []byte) string {
+	payload := id.String() + "." + strconv.FormatInt(issued.Unix(), 10)
+	return payload + "." + signature(payload, secret)
+}
+
+// Verify checks a guest cart cookie value and returns the guest it belongs
+// to. Cookies older than ttl are refused, the purge job may already have
+// removed their guest.
+func Verify(value string, secret []byte, ttl time.Duration, now time.Time) (uuid.UUID, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(signature(payload, secret))) {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	id, err := uuid.FromString(parts[0])
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	issued, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+	if now.Sub(time.Unix(issued, 0)) > ttl {
+		return uuid.Nil, ErrTokenExpired
+	}
+
+	return id, nil
+}
+
+func signature(payload string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CheckoutRequest pays for a guest's cart. The order is shipped to Address
+// and the confirmation goes to Email. Without a DeliveryMethod the cheapest
+// one that reaches the address is used.
+type CheckoutRequest struct {
+	Email          string          `json:"email" example:"ada@example.com"`
+	Address        address.Request `json:"address"`
+	DeliveryMethod string          `json:"delivery_method" example:"standard"`
+} // @name GuestCheckoutRequestModel
+
+// Normalize trims the request and lower-cases the email.
+func (r CheckoutRequest) Normalize() CheckoutRequest {
+	return CheckoutRequest{
+		Email:          strings.ToLower(strings.TrimSpace(r.Email)),
+		Address:        r.Address.Normalize(),
+		DeliveryMethod: strings.TrimSpace(r.DeliveryMethod),
+	}
+}
+
+// Validate checks the email of a normalized request. The address is checked
+// when it is saved.
+func (r CheckoutRequest) Validate() error {
+	if r.Email == "" {
+		return errors.New("email is required")
+	}
+	if len(r.Email) > maxEmailLength {
+		return fmt.Errorf("email must be at most %d characters", maxEmailLength)
+	}
+
+	parsed, err := mail.ParseAddress(r.Email)
+	if err != nil || parsed.Address != r.Email {
+		return fmt.Errorf("%q is not a valid email address", r.Email)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package guest
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	id := uuid.Must(uuid.NewV4())
+	issued := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	value := Sign(id, issued, secret)
+
+	got, err := Verify(value, secret, 24*time.Hour, issued.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	_, err = Verify(value, secret, 24*time.Hour, issued.Add(25*time.Hour))
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	_, err = Verify(value, []byte("other"), 24*time.Hour, issued.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	other := uuid.Must(uuid.NewV4())
+	forged := other.String() + value[len(id.String()):]
+	_, err = Verify(forged, secret, 24*time.Hour, issued.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = Verify("garbage", secret, 24*time.Hour, issued)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestCheckoutRequest_Validate(t *testing.T) {
+	req := CheckoutRequest{Email: "  Ada@Example.com "}.Normalize()
+	assert.Equal(t, "ada@example.com", req.Email)
+	assert.NoError(t, req.Validate())
+
+	assert.Error(t, CheckoutRequest{}.Validate())
+	assert.Error(t, CheckoutRequest{Email: "not-an-email"}.Validate())
+	assert.Error(t, CheckoutRequest{Email: "Ada <ada@example.com>"}.Validate())
+}
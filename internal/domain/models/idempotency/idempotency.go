@@ -0,0 +1,26 @@
+package idempotency
+
+import (
+	"net/http"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusInFlight  Status = "in_flight"
+	StatusCompleted Status = "completed"
+)
+
+// Record is the stored outcome of the first request made with a key. Scope
+// keeps keys of different users apart.
+type Record struct {
+	Scope           string
+	Key             string
+	Fingerprint     string
+	Status          Status
+	ResponseStatus  int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+	ExpiresAt       time.Time
+}
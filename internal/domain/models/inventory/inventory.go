@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type Reason string
+
+const (
+	ReasonSale        Reason = "sale"
+	ReasonReservation Reason = "reservation"
+	ReasonRelease     Reason = "release"
+	ReasonRestock     Reason = "restock"
+	ReasonAdjustment  Reason = "adjustment"
+	ReasonReturn      Reason = "return"
+)
+
+type Movement struct {
+	ID            uuid.UUID     `json:"id"`
+	BookID        uuid.UUID     `json:"book_id"`
+	OrderID       uuid.NullUUID `json:"order_id" swaggertype:"string"`
+	ActorID       uuid.NullUUID `json:"actor_id" swaggertype:"string"`
+	Reason        Reason        `json:"reason"`
+	OnHandDelta   int           `json:"on_hand_delta"`
+	ReservedDelta int           `json:"reserved_delta"`
+	Note          string        `json:"note"`
+	CreatedAt     time.Time     `json:"created_at"`
+} // @name StockMovementModel
+
+type Level struct {
+	BookID    uuid.UUID `json:"book_id"`
+	OnHand    int       `json:"on_hand"`
+	Reserved  int       `json:"reserved"`
+	Available int       `json:"available"`
+} // @name StockLevelModel
+
+type AdjustmentRequest struct {
+	Reason   Reason `json:"reason"`
+	Quantity int    `json:"quantity"`
+	Note     string `json:"note"`
+} // @name StockAdjustmentRequestModel
+
+// NewMovement builds a movement for quantity units of a book and splits it into
+// on-hand and reserved deltas according to the reason. A sale consumes a
+// reservation, so it lowers both buckets and leaves availability untouched.
+func NewMovement(bookID uuid.UUID, reason Reason, quantity int) Movement {
+	m := Movement{
+		BookID: bookID,
+		Reason: reason,
+	}
+
+	switch reason {
+	case ReasonSale:
+		m.OnHandDelta = -quantity
+		m.ReservedDelta = -quantity
+	case ReasonReservation:
+		m.ReservedDelta = quantity
+	case ReasonRelease:
+		m.ReservedDelta = -quantity
+	case ReasonRestock, ReasonReturn, ReasonAdjustment:
+		m.OnHandDelta = quantity
+	}
+
+	return m
+}
+
+// AvailableDelta is the change the movement makes to the sellable stock
+// cached in books.stock.
+func (m Movement) AvailableDelta() int {
+	return m.OnHandDelta - m.ReservedDelta
+}
+
+// IsManual reports whether admins may post the reason by hand.
+func (r Reason) IsManual() bool {
+	return r == ReasonRestock || r == ReasonAdjustment || r == ReasonReturn
+}
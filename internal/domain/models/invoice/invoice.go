@@ -0,0 +1,188 @@
+package invoice
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ErrNotInvoiced is returned for orders that were never paid and so have no
+// invoice.
+var ErrNotInvoiced = errors.New("order has not been paid")
+
+// Invoice is issued once, when an order is paid, and never changes: Number
+// comes from a gap-free sequence and Details are copied from the order at
+// that moment.
+type Invoice struct {
+	ID       uuid.UUID `json:"id"`
+	Number   int64     `json:"number"`
+	OrderID  uuid.UUID `json:"order_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	IssuedAt time.Time `json:"issued_at"`
+	Details  Details   `json:"details"`
+	// PDF is the rendered document, stored the first time it is downloaded.
+	PDF []byte `json:"-"`
+} // @name InvoiceModel
+
+// IsInvoiced reports whether an order in this status has been paid for and
+// so is owed an invoice. Refunds do not take the invoice back.
+func IsInvoiced(s orderModel.Status) bool {
+	return s.IsSettled() || s == orderModel.StatusRefunded
+}
+
+// Reference is the invoice number as printed, "INV-000042".
+func (i Invoice) Reference(prefix string) string {
+	return fmt.Sprintf("%s%06d", prefix, i.Number)
+}
+
+// File is an invoice ready to download.
+type File struct {
+	Reference string
+	Content   []byte
+}
+
+// Details is what the invoice says. Amounts are in the currency the customer
+// paid in.
+type Details struct {
+	Currency        money.Currency    `json:"currency"`
+	Lines           []Line            `json:"lines"`
+	Taxes           []Tax             `json:"taxes"`
+	Subtotal        money.Money       `json:"subtotal"`
+	DiscountTotal   money.Money       `json:"discount_total"`
+	TaxTotal        money.Money       `json:"tax_total"`
+	TaxIncluded     money.Money       `json:"tax_included"`
+	ShippingTotal   money.Money       `json:"shipping_total"`
+	Total           money.Money       `json:"total"`
+	BillingAddress  *address.Snapshot `json:"billing_address,omitempty"`
+	ShippingAddress *address.Snapshot `json:"shipping_address,omitempty"`
+	DeliveryMethod  string            `json:"delivery_method,omitempty"`
+}
+
+// Line is an order line as invoiced. Tax is all the tax charged on it,
+// whether included in the price or added.
+type Line struct {
+	ItemID    uuid.UUID   `json:"item_id"`
+	Title     string      `json:"title"`
+	Quantity  int         `json:"quantity"`
+	UnitPrice money.Money `json:"unit_price"`
+	Total     money.Money `json:"total"`
+	Tax       money.Money `json:"tax"`
+}
+
+// Tax is one rate of tax on the invoice, summed over the lines it applies to.
+type Tax struct {
+	Name      string      `json:"name"`
+	Rate      tax.Rate    `json:"rate"`
+	Taxable   money.Money `json:"taxable"`
+	Amount    money.Money `json:"amount"`
+	Inclusive bool        `json:"inclusive"`
+}
+
+// Snapshot copies an order, its lines and the tax charged on them into what
+// the invoice says, converting everything into the checkout currency. Lines
+// only need ItemID, Title, Quantity and UnitPrice set.
+func Snapshot(order *orderModel.Model, lines []Line, taxes []tax.LineTax) Details {
+	lineTax := map[uuid.UUID][]money.Money{}
+	for _, t := range taxes {
+		lineTax[t.ItemID] = append(lineTax[t.ItemID], t.Amount)
+	}
+
+	details := Details{
+		Currency:        order.ChargeTotal().Currency(),
+		Lines:           make([]Line, 0, len(lines)),
+		Taxes:           summarize(order, taxes),
+		Subtotal:        order.Convert(order.Subtotal),
+		DiscountTotal:   order.Convert(order.DiscountTotal),
+		TaxTotal:        order.Convert(order.TaxTotal),
+		TaxIncluded:     order.Convert(order.TaxIncluded),
+		ShippingTotal:   order.Convert(order.ShippingTotal),
+		Total:           order.ChargeTotal(),
+		BillingAddress:  order.BillingAddress,
+		ShippingAddress: order.ShippingAddress,
+		DeliveryMethod:  order.DeliveryMethod,
+	}
+	if details.BillingAddress == nil {
+		details.BillingAddress = order.ShippingAddress
+	}
+
+	for _, line := range lines {
+		line.Total = order.Convert(line.UnitPrice.Mul(line.Quantity))
+		line.UnitPrice = order.Convert(line.UnitPrice)
+		line.Tax = order.Convert(money.Sum(lineTax[line.ItemID]...))
+		details.Lines = append(details.Lines, line)
+	}
+
+	return details
+}
+
+// summarize groups line taxes by name, rate and whether they were included
+// in the price, in the order an accountant expects to read them.
+func summarize(order *orderModel.Model, taxes []tax.LineTax) []Tax {
+	type key struct {
+		name      string
+		rate      string
+		inclusive bool
+	}
+
+	index := map[key]int{}
+	var summary []Tax
+	for _, t := range taxes {
+		k := key{name: t.Name, rate: t.Rate.String(), inclusive: t.Inclusive}
+		i, ok := index[k]
+		if !ok {
+			i = len(summary)
+			index[k] = i
+			summary = append(summary, Tax{
+				Name:      t.Name,
+				Rate:      t.Rate,
+				Taxable:   money.Zero(t.Taxable.Currency()),
+				Amount:    money.Zero(t.Amount.Currency()),
+				Inclusive: t.Inclusive,
+			})
+		}
+		summary[i].Taxable = summary[i].Taxable.Add(t.Taxable)
+		summary[i].Amount = summary[i].Amount.Add(t.Amount)
+	}
+
+	for i := range summary {
+		summary[i].Taxable = order.Convert(summary[i].Taxable)
+		summary[i].Amount = order.Convert(summary[i].Amount)
+	}
+	sort.SliceStable(summary, func(i, j int) bool {
+		if summary[i].Name != summary[j].Name {
+			return summary[i].Name < summary[j].Name
+		}
+		return percent(summary[i].Rate) < percent(summary[j].Rate)
+	})
+
+	return summary
+}
+
+func percent(r tax.Rate) float64 {
+	f, _ := strconv.ParseFloat(r.String(), 64)
+	return f
+}
+
+// Scan reads the JSONB details column.
+func (d *Details) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	}
+	return fmt.Errorf("cannot scan %T into invoice details", src)
+}
+
+func (d Details) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
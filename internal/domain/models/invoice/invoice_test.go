@@ -0,0 +1,161 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	duneLine = uuid.Must(uuid.FromString("9b1c2d3e-0000-4000-8000-000000000001"))
+	emmaLine = uuid.Must(uuid.FromString("9b1c2d3e-0000-4000-8000-000000000002"))
+	home     = &address.Snapshot{FullName: "Ada Lovelace", Line1: "1 Main St", City: "London", PostalCode: "N1", Country: "GB"}
+)
+
+func usd(s string) money.Money {
+	return money.MustParse(s, "USD")
+}
+
+func paidOrder() *orderModel.Model {
+	return &orderModel.Model{
+		Subtotal:        usd("30.00"),
+		DiscountTotal:   usd("3.00"),
+		TaxTotal:        usd("2.70"),
+		TaxIncluded:     usd("0.00"),
+		ShippingTotal:   usd("5.00"),
+		TotalPrice:      usd("34.70"),
+		Currency:        "EUR",
+		ExchangeRate:    money.MustParseRate("0.5"),
+		ShippingAddress: home,
+		DeliveryMethod:  "standard",
+	}
+}
+
+func orderLines() []Line {
+	return []Line{
+		{ItemID: duneLine, Title: "Dune", Quantity: 2, UnitPrice: usd("10.00")},
+		{ItemID: emmaLine, Title: "Emma", Quantity: 1, UnitPrice: usd("10.00")},
+	}
+}
+
+func orderTaxes() []tax.LineTax {
+	return []tax.LineTax{
+		{ItemID: duneLine, Name: "VAT", Rate: tax.MustParseRate("10"), Taxable: usd("18.00"), Amount: usd("1.80")},
+		{ItemID: emmaLine, Name: "VAT", Rate: tax.MustParseRate("10"), Taxable: usd("9.00"), Amount: usd("0.90")},
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	details := Snapshot(paidOrder(), orderLines(), orderTaxes())
+
+	assert.Equal(t, money.Currency("EUR"), details.Currency)
+	assert.Equal(t, "17.35", details.Total.String())
+	assert.Equal(t, "15.00", details.Subtotal.String())
+	assert.Equal(t, home, details.BillingAddress, "billing falls back to the shipping address")
+
+	require.Len(t, details.Lines, 2)
+	assert.Equal(t, "5.00", details.Lines[0].UnitPrice.String())
+	assert.Equal(t, "10.00", details.Lines[0].Total.String())
+	assert.Equal(t, "0.90", details.Lines[0].Tax.String())
+
+	require.Len(t, details.Taxes, 1)
+	assert.Equal(t, "VAT", details.Taxes[0].Name)
+	assert.Equal(t, "13.50", details.Taxes[0].Taxable.String())
+	assert.Equal(t, "1.35", details.Taxes[0].Amount.String())
+}
+
+func TestSummarizeKeepsRatesApart(t *testing.T) {
+	taxes := append(orderTaxes(),
+		tax.LineTax{ItemID: emmaLine, Name: "VAT", Rate: tax.MustParseRate("5"), Taxable: usd("9.00"), Amount: usd("0.45")},
+		tax.LineTax{ItemID: duneLine, Name: "City tax", Rate: tax.MustParseRate("1"), Taxable: usd("18.00"), Amount: usd("0.18"), Inclusive: true},
+	)
+	order := paidOrder()
+	order.Currency = ""
+
+	summary := summarize(order, taxes)
+
+	require.Len(t, summary, 3)
+	assert.Equal(t, "City tax", summary[0].Name)
+	assert.True(t, summary[0].Inclusive)
+	assert.Equal(t, "5", summary[1].Rate.String())
+	assert.Equal(t, "10", summary[2].Rate.String())
+	assert.Equal(t, "2.70", summary[2].Amount.String())
+}
+
+func TestDetailsRoundTrip(t *testing.T) {
+	details := Snapshot(paidOrder(), orderLines(), orderTaxes())
+
+	value, err := details.Value()
+	require.NoError(t, err)
+
+	var scanned Details
+	require.NoError(t, scanned.Scan(value))
+
+	again, err := json.Marshal(scanned)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(value.([]byte)), string(again))
+}
+
+func TestIsInvoiced(t *testing.T) {
+	assert.True(t, IsInvoiced(orderModel.StatusPaid))
+	assert.True(t, IsInvoiced(orderModel.StatusDelivered))
+	assert.True(t, IsInvoiced(orderModel.StatusRefunded))
+	assert.False(t, IsInvoiced(orderModel.StatusPendingPayment))
+	assert.False(t, IsInvoiced(orderModel.StatusCancelled))
+}
+
+func TestRender(t *testing.T) {
+	inv := &Invoice{
+		Number:   42,
+		OrderID:  uuid.Must(uuid.FromString("9b1c2d3e-0000-4000-8000-0000000000aa")),
+		IssuedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Details:  Snapshot(paidOrder(), orderLines(), orderTaxes()),
+	}
+	seller := Seller{Name: "Books Ltd", Address: []string{"2 High St", "Leeds"}, TaxID: "GB123"}
+
+	out, err := Render(inv, inv.Reference("INV-"), seller)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	assert.Contains(t, string(out), "/Title (Invoice INV-000042)")
+
+	again, err := Render(inv, inv.Reference("INV-"), seller)
+	require.NoError(t, err)
+	assert.Equal(t, out, again)
+
+	t.Run("long orders run onto more pages", func(t *testing.T) {
+		long := *inv
+		for i := 0; i < 80; i++ {
+			long.Details.Lines = append(long.Details.Lines, Line{Title: "Dune", Quantity: 1, UnitPrice: usd("1.00"), Total: usd("1.00"), Tax: usd("0.00")})
+		}
+		out, err := Render(&long, long.Reference("INV-"), seller)
+		require.NoError(t, err)
+		assert.False(t, bytes.Contains(out, []byte("/Count 1 ")), "expected more than one page")
+	})
+}
+
+func TestTotalLines(t *testing.T) {
+	lines := totalLines(Snapshot(paidOrder(), orderLines(), orderTaxes()))
+
+	var text []string
+	for _, line := range lines {
+		text = append(text, line.label+" "+line.amount)
+	}
+	assert.Equal(t, "Subtotal 15.00|Discount -1.50|Shipping 2.50|VAT 10% 1.35|Total EUR 17.35", strings.Join(text, "|"))
+	assert.True(t, lines[len(lines)-1].bold)
+}
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, "Dune", fit("Dune", 0, 9, 100))
+	short := fit(strings.Repeat("Long title ", 20), 0, 9, 100)
+	assert.True(t, strings.HasSuffix(short, "..."))
+	assert.Less(t, len(short), 40)
+}
@@ -0,0 +1,192 @@
+package invoice
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/pdf"
+	"strconv"
+	"strings"
+)
+
+// Seller is the business the invoice is issued by.
+type Seller struct {
+	Name    string
+	Address []string
+	TaxID   string
+}
+
+const (
+	margin     = 50.0
+	right      = pdf.A4Width - margin
+	lineHeight = 14.0
+	bodySize   = 9.0
+	// pageBottom is as low as a table row may go before it moves to the next
+	// page.
+	pageBottom = margin + 2*lineHeight
+)
+
+// Table columns, by the x they start at. Numbers are set flush right against
+// the start of the next column, the last against the right edge.
+const (
+	colItem     = margin + 4
+	colQuantity = 300.0
+	colUnit     = 385.0
+	colTax      = 465.0
+	colEnd      = right - 4
+)
+
+// Render lays the invoice out on A4 pages. number is the reference printed on
+// it. Nothing about the time it is rendered ends up in the document, so the
+// same invoice always renders to the same bytes.
+func Render(inv *Invoice, number string, seller Seller) ([]byte, error) {
+	doc := pdf.New()
+	doc.Title = "Invoice " + number
+	doc.Author = seller.Name
+
+	d := inv.Details
+	page := doc.AddPage()
+	y := pdf.A4Height - margin - 18
+
+	page.Text(margin, y, pdf.HelveticaBold, 18, seller.Name)
+	page.TextRight(right, y, pdf.HelveticaBold, 18, "INVOICE")
+
+	header := []string{
+		"Invoice no. " + number,
+		"Date: " + inv.IssuedAt.Format("2006-01-02"),
+		"Order: " + inv.OrderID.String(),
+	}
+	from := append([]string{}, seller.Address...)
+	if seller.TaxID != "" {
+		from = append(from, "Tax ID: "+seller.TaxID)
+	}
+	y -= 2 * lineHeight
+	for i := 0; i < len(from) || i < len(header); i++ {
+		if i < len(from) {
+			page.Text(margin, y, pdf.Helvetica, bodySize, from[i])
+		}
+		if i < len(header) {
+			page.TextRight(right, y, pdf.Helvetica, bodySize, header[i])
+		}
+		y -= lineHeight
+	}
+
+	y -= lineHeight
+	billTo, shipTo := addressLines(d)
+	page.Text(margin, y, pdf.HelveticaBold, bodySize, "Bill to")
+	if len(shipTo) > 0 {
+		page.Text(colUnit-80, y, pdf.HelveticaBold, bodySize, "Ship to")
+	}
+	y -= lineHeight
+	for i := 0; i < len(billTo) || i < len(shipTo); i++ {
+		if i < len(billTo) {
+			page.Text(margin, y, pdf.Helvetica, bodySize, billTo[i])
+		}
+		if i < len(shipTo) {
+			page.Text(colUnit-80, y, pdf.Helvetica, bodySize, shipTo[i])
+		}
+		y -= lineHeight
+	}
+
+	y -= lineHeight
+	y = tableHeader(page, y)
+	for _, line := range d.Lines {
+		if y < pageBottom {
+			page = doc.AddPage()
+			y = tableHeader(page, pdf.A4Height-margin-lineHeight)
+		}
+		page.Text(colItem, y, pdf.Helvetica, bodySize, fit(line.Title, pdf.Helvetica, bodySize, colQuantity-colItem-30))
+		page.TextRight(colQuantity, y, pdf.Helvetica, bodySize, strconv.Itoa(line.Quantity))
+		page.TextRight(colUnit, y, pdf.Helvetica, bodySize, line.UnitPrice.String())
+		page.TextRight(colTax, y, pdf.Helvetica, bodySize, line.Tax.String())
+		page.TextRight(colEnd, y, pdf.Helvetica, bodySize, line.Total.String())
+		y -= lineHeight
+	}
+	page.Line(margin, y+lineHeight-4, right, y+lineHeight-4, 0.5)
+
+	totals := totalLines(d)
+	if y-float64(len(totals)+3)*lineHeight < margin {
+		page = doc.AddPage()
+		y = pdf.A4Height - margin - lineHeight
+	}
+	y -= 4
+	for _, total := range totals {
+		font := pdf.Helvetica
+		if total.bold {
+			font = pdf.HelveticaBold
+		}
+		page.TextRight(colTax, y, font, bodySize, total.label)
+		page.TextRight(colEnd, y, font, bodySize, total.amount)
+		y -= lineHeight
+	}
+
+	y -= lineHeight
+	note := "Amounts in " + string(d.Currency) + "."
+	if d.DeliveryMethod != "" {
+		note += " Delivery: " + d.DeliveryMethod + "."
+	}
+	page.Text(margin, y, pdf.Helvetica, bodySize, note)
+	page.Text(margin, y-lineHeight, pdf.Helvetica, bodySize, "Paid in full. Thank you for your order.")
+
+	return doc.Bytes()
+}
+
+func tableHeader(page *pdf.Page, y float64) float64 {
+	page.FillRect(margin, y-4, right-margin, lineHeight+2, 0.9)
+	page.Text(colItem, y, pdf.HelveticaBold, bodySize, "Item")
+	page.TextRight(colQuantity, y, pdf.HelveticaBold, bodySize, "Qty")
+	page.TextRight(colUnit, y, pdf.HelveticaBold, bodySize, "Unit price")
+	page.TextRight(colTax, y, pdf.HelveticaBold, bodySize, "Tax")
+	page.TextRight(colEnd, y, pdf.HelveticaBold, bodySize, "Amount")
+	return y - lineHeight - 4
+}
+
+// addressLines lists where the invoice is billed and, when it differs, where
+// the order was shipped.
+func addressLines(d Details) (billTo, shipTo []string) {
+	if d.BillingAddress != nil {
+		billTo = d.BillingAddress.Lines()
+	}
+	if d.ShippingAddress != nil && (d.BillingAddress == nil || *d.ShippingAddress != *d.BillingAddress) {
+		shipTo = d.ShippingAddress.Lines()
+	}
+	return billTo, shipTo
+}
+
+type totalLine struct {
+	label  string
+	amount string
+	bold   bool
+}
+
+// totalLines is the summary under the table: it adds up to the total, with
+// taxes already in the prices listed for information.
+func totalLines(d Details) []totalLine {
+	totals := []totalLine{{label: "Subtotal", amount: d.Subtotal.String()}}
+	if d.DiscountTotal.IsPositive() {
+		totals = append(totals, totalLine{label: "Discount", amount: d.DiscountTotal.Neg().String()})
+	}
+	if d.ShippingTotal.IsPositive() {
+		totals = append(totals, totalLine{label: "Shipping", amount: d.ShippingTotal.String()})
+	}
+	for _, t := range d.Taxes {
+		label := t.Name + " " + t.Rate.String() + "%"
+		if t.Inclusive {
+			label += " (included)"
+		}
+		totals = append(totals, totalLine{label: label, amount: t.Amount.String()})
+	}
+	if len(d.Taxes) == 0 && d.TaxTotal.IsPositive() {
+		totals = append(totals, totalLine{label: "Tax", amount: d.TaxTotal.String()})
+	}
+	return append(totals, totalLine{label: "Total " + string(d.Currency), amount: d.Total.String(), bold: true})
+}
+
+// fit shortens s with an ellipsis until it is no wider than width.
+func fit(s string, font pdf.Font, size, width float64) string {
+	if pdf.Width(font, size, s) <= width {
+		return s
+	}
+	runes := []rune(strings.TrimSpace(s))
+	for len(runes) > 0 && pdf.Width(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -1,5 +1,7 @@
 package mainPageParams
 
+import "github.com/TeslaMode1X/DockerWireAPI/packages/money"
+
 type Model struct {
 	Page           string
 	ErrorMessage   string
@@ -8,4 +10,5 @@ type Model struct {
 	SortBy         string
 	UserName       string
 	Role           int
+	Currency       money.Currency
 } // @name MainPageModelParams
@@ -0,0 +1,164 @@
+package notification
+
+import (
+	"fmt"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// Kind is one kind of email a customer can receive. Each has a template of
+// the same name.
+type Kind string
+
+const (
+	KindOrderConfirmation Kind = "order_confirmation"
+	KindOrderShipped      Kind = "order_shipped"
+	KindOrderCancelled    Kind = "order_cancelled"
+	KindOrderRefunded     Kind = "order_refunded"
+	// KindStockAlert collects the back-in-stock and price-drop alerts that
+	// fired for a customer.
+	KindStockAlert Kind = "stock_alert"
+	// KindPasswordChanged is a security notice and is always sent.
+	KindPasswordChanged Kind = "password_changed"
+)
+
+// Kinds lists every kind in the order preferences are shown.
+var Kinds = []Kind{
+	KindOrderConfirmation,
+	KindOrderShipped,
+	KindOrderCancelled,
+	KindOrderRefunded,
+	KindStockAlert,
+	KindPasswordChanged,
+}
+
+func (k Kind) IsKnown() bool {
+	for _, known := range Kinds {
+		if k == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Required reports whether customers cannot opt out of k.
+func (k Kind) Required() bool {
+	return k == KindPasswordChanged
+}
+
+// Preference says whether a customer gets one kind of email.
+type Preference struct {
+	Kind     Kind `json:"kind" example:"order_shipped"`
+	Enabled  bool `json:"enabled"`
+	Required bool `json:"required"`
+} // @name NotificationPreferenceModel
+
+// Preferences lists every kind, enabled unless opted out of.
+func Preferences(optedOut []Kind) []Preference {
+	prefs := make([]Preference, 0, len(Kinds))
+	for _, k := range Kinds {
+		enabled := true
+		for _, out := range optedOut {
+			if out == k && !k.Required() {
+				enabled = false
+			}
+		}
+		prefs = append(prefs, Preference{Kind: k, Enabled: enabled, Required: k.Required()})
+	}
+	return prefs
+}
+
+// PreferencesRequest switches kinds of email on or off. Kinds left out keep
+// their current setting.
+type PreferencesRequest map[Kind]bool // @name NotificationPreferencesRequestModel
+
+func (r PreferencesRequest) Validate() error {
+	for k, enabled := range r {
+		if !k.IsKnown() {
+			return fmt.Errorf("unknown notification kind %q", k)
+		}
+		if k.Required() && !enabled {
+			return fmt.Errorf("%s emails cannot be turned off", k)
+		}
+	}
+	return nil
+}
+
+// Recipient is who an email goes to.
+type Recipient struct {
+	UserID   uuid.UUID
+	Username string
+	Email    string
+}
+
+// Data is what the templates are rendered with. Only the fields the kind
+// needs are set.
+type Data struct {
+	Recipient Recipient
+	Order     *orderModel.Model
+	Items     []orderItem.OrderItemFull
+	Shipments []shipment.Shipment
+	Alerts    []stockalert.Notice
+	Reason    string
+	ChangedAt time.Time
+}
+
+// Email is a rendered email, ready for a Mailer.
+type Email struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	// StatusFailed is final: the email ran out of attempts.
+	StatusFailed Status = "failed"
+)
+
+// Message is a rendered email waiting in the queue, or the record of one
+// that was sent.
+type Message struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	EventID       uuid.NullUUID
+	Kind          Kind
+	To            string
+	Subject       string
+	Text          string
+	HTML          string
+	Status        Status
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// Email is the message as handed to a Mailer.
+func (m Message) Email(from string) Email {
+	return Email{
+		From:    from,
+		To:      m.To,
+		Subject: m.Subject,
+		Text:    m.Text,
+		HTML:    m.HTML,
+	}
+}
+
+// Failure is what the queue records about an email that could not be sent.
+type Failure struct {
+	Attempts      int
+	Error         string
+	NextAttemptAt time.Time
+	Final         bool
+}
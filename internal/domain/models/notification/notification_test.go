@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPreferences(t *testing.T) {
+	prefs := Preferences([]Kind{KindOrderShipped, KindPasswordChanged})
+
+	assert.Len(t, prefs, len(Kinds))
+	for _, p := range prefs {
+		switch p.Kind {
+		case KindOrderShipped:
+			assert.False(t, p.Enabled)
+		case KindPasswordChanged:
+			assert.True(t, p.Enabled, "required kinds cannot be opted out of")
+			assert.True(t, p.Required)
+		default:
+			assert.True(t, p.Enabled)
+			assert.False(t, p.Required)
+		}
+	}
+}
+
+func TestPreferencesRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     PreferencesRequest
+		wantErr bool
+	}{
+		{name: "opt out", req: PreferencesRequest{KindOrderShipped: false, KindOrderRefunded: true}},
+		{name: "empty", req: PreferencesRequest{}},
+		{name: "unknown kind", req: PreferencesRequest{"newsletter": false}, wantErr: true},
+		{name: "required kind off", req: PreferencesRequest{KindPasswordChanged: false}, wantErr: true},
+		{name: "required kind on", req: PreferencesRequest{KindPasswordChanged: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
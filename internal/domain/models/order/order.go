@@ -1,10 +1,65 @@
 package order
 
-import "github.com/gofrs/uuid"
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+)
 
 type Model struct {
-	ID         uuid.UUID `json:"id"`
-	UserID     uuid.UUID `json:"user_id"`
-	Status     string    `json:"status"`
-	TotalPrice float64   `json:"total_price"`
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Status Status    `json:"status"`
+	// Subtotal is what the lines add up to. TotalPrice, the grand total, is
+	// what is charged: Subtotal less DiscountTotal, plus the tax not already
+	// in the prices (TaxTotal - TaxIncluded), plus ShippingTotal.
+	Subtotal      money.Money `json:"subtotal" swaggertype:"string" example:"28.48"`
+	DiscountTotal money.Money `json:"discount_total" swaggertype:"string" example:"2.50"`
+	TaxTotal      money.Money `json:"tax_total" swaggertype:"string" example:"2.31"`
+	TaxIncluded   money.Money `json:"tax_included" swaggertype:"string" example:"0.00"`
+	ShippingTotal money.Money `json:"shipping_total" swaggertype:"string" example:"0.00"`
+	TotalPrice    money.Money `json:"total_price" swaggertype:"string" example:"28.29"`
+	// RefundedTotal is how much of TotalPrice went back to the customer for
+	// returned books.
+	RefundedTotal money.Money `json:"refunded_total" swaggertype:"string" example:"0.00"`
+	// Currency and ExchangeRate are what the customer was quoted at checkout;
+	// TotalPrice stays in the catalogue currency.
+	Currency     money.Currency `json:"currency" swaggertype:"string" example:"EUR"`
+	ExchangeRate money.Rate     `json:"exchange_rate" swaggertype:"string" example:"0.92"`
+	// ShippingAddress and BillingAddress are copies of the address book
+	// entries chosen at checkout, so later edits do not move the order.
+	ShippingAddress *address.Snapshot `json:"shipping_address,omitempty"`
+	BillingAddress  *address.Snapshot `json:"billing_address,omitempty"`
+	DeliveryMethod  string            `json:"delivery_method,omitempty" example:"standard"`
 } // @name OrderModel
+
+// Convert prices a catalogue amount in the order's checkout currency.
+func (m *Model) Convert(amount money.Money) money.Money {
+	return ConvertAt(amount, m.Currency, m.ExchangeRate)
+}
+
+// ChargeTotal is the amount the customer pays, in the checkout currency.
+func (m *Model) ChargeTotal() money.Money {
+	return m.Convert(m.TotalPrice)
+}
+
+// ConvertAt converts at a recorded rate. Orders that never went through
+// checkout have no currency yet and stay in the catalogue currency.
+func ConvertAt(amount money.Money, currency money.Currency, rate money.Rate) money.Money {
+	if currency == "" || rate.IsZero() {
+		return amount
+	}
+	return amount.Convert(currency, rate)
+}
+
+// ShippingRequest picks where a draft order goes, who is billed and how it is
+// delivered. Billing defaults to the shipping address.
+type ShippingRequest struct {
+	ShippingAddressID uuid.UUID `json:"shipping_address_id"`
+	BillingAddressID  uuid.UUID `json:"billing_address_id"`
+	DeliveryMethod    string    `json:"delivery_method" example:"standard"`
+} // @name ShippingRequestModel
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+} // @name CancelOrderRequestModel
@@ -0,0 +1,156 @@
+package order
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusDraft          Status = "draft"
+	StatusPendingPayment Status = "pending_payment"
+	StatusPaid           Status = "paid"
+	StatusFulfilled      Status = "fulfilled"
+	StatusShipped        Status = "shipped"
+	StatusDelivered      Status = "delivered"
+	StatusCancelled      Status = "cancelled"
+	// StatusPartiallyRefunded is a delivered order some of whose books came
+	// back and were refunded.
+	StatusPartiallyRefunded Status = "partially_refunded"
+	StatusRefunded          Status = "refunded"
+)
+
+// transitions lists every status an order may move to from a given status.
+// Statuses missing from the map are terminal. A fulfilled order goes back to
+// paid when a parcel is called back before it leaves. Returns refund a
+// delivered order a part at a time until every book is back.
+var transitions = map[Status][]Status{
+	StatusDraft:             {StatusPendingPayment, StatusCancelled},
+	StatusPendingPayment:    {StatusPaid, StatusDraft, StatusCancelled},
+	StatusPaid:              {StatusFulfilled, StatusCancelled, StatusRefunded},
+	StatusFulfilled:         {StatusShipped, StatusPaid, StatusCancelled},
+	StatusShipped:           {StatusDelivered},
+	StatusDelivered:         {StatusPartiallyRefunded, StatusRefunded},
+	StatusPartiallyRefunded: {StatusRefunded},
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusDraft, StatusPendingPayment, StatusPaid, StatusFulfilled,
+		StatusShipped, StatusDelivered, StatusCancelled, StatusPartiallyRefunded, StatusRefunded:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// cancellable lists, per actor, the statuses an order may be cancelled from.
+// Customers and staff alike can stop it up to the moment it is handed to the
+// carrier; only staff can cancel a cart.
+var cancellable = map[ActorKind][]Status{
+	ActorCustomer: {StatusPendingPayment, StatusPaid, StatusFulfilled},
+	ActorAdmin:    {StatusDraft, StatusPendingPayment, StatusPaid, StatusFulfilled},
+	ActorSystem:   {StatusDraft, StatusPendingPayment},
+}
+
+func CancellableStatuses(kind ActorKind) []Status {
+	return cancellable[kind]
+}
+
+func (s Status) CancellableBy(kind ActorKind) bool {
+	for _, status := range cancellable[kind] {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSettled reports whether stock for the order has been sold, i.e. money was
+// taken and a cancellation has to put the books back on the shelf.
+func (s Status) IsSettled() bool {
+	for _, settled := range SettledStatuses() {
+		if s == settled {
+			return true
+		}
+	}
+	return false
+}
+
+// SettledStatuses lists every status IsSettled reports true for.
+func SettledStatuses() []Status {
+	return []Status{StatusPaid, StatusFulfilled, StatusShipped, StatusDelivered, StatusPartiallyRefunded}
+}
+
+// TransitionError reports a status change the state machine does not allow.
+type TransitionError struct {
+	From Status
+	To   Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("illegal order status transition from %q to %q", e.From, e.To)
+}
+
+func ValidateTransition(from, to Status) error {
+	if !to.IsValid() || !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+type ActorKind string
+
+const (
+	ActorCustomer ActorKind = "customer"
+	ActorAdmin    ActorKind = "admin"
+	ActorSystem   ActorKind = "system"
+)
+
+type Actor struct {
+	ID   uuid.NullUUID
+	Kind ActorKind
+}
+
+func CustomerActor(userID uuid.UUID) Actor {
+	return Actor{ID: uuid.NullUUID{UUID: userID, Valid: true}, Kind: ActorCustomer}
+}
+
+func AdminActor(userID uuid.UUID) Actor {
+	return Actor{ID: uuid.NullUUID{UUID: userID, Valid: true}, Kind: ActorAdmin}
+}
+
+func SystemActor() Actor {
+	return Actor{Kind: ActorSystem}
+}
+
+type StatusChange struct {
+	OrderID uuid.UUID
+	To      Status
+	Actor   Actor
+	Reason  string
+	// AllowedFrom narrows the state machine for this change: when set, the
+	// order's current status must be one of these.
+	AllowedFrom []Status
+}
+
+type StatusHistoryEntry struct {
+	ID         uuid.UUID     `json:"id"`
+	OrderID    uuid.UUID     `json:"order_id"`
+	FromStatus Status        `json:"from_status"`
+	ToStatus   Status        `json:"to_status"`
+	ActorID    uuid.NullUUID `json:"actor_id" swaggertype:"string"`
+	ActorKind  ActorKind     `json:"actor_kind"`
+	Reason     string        `json:"reason"`
+	CreatedAt  time.Time     `json:"created_at"`
+} // @name OrderStatusHistoryModel
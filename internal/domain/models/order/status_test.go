@@ -0,0 +1,30 @@
+package order
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatus_CancellableBy(t *testing.T) {
+	tests := []struct {
+		status          Status
+		customer, admin bool
+		system          bool
+	}{
+		{status: StatusDraft, admin: true, system: true},
+		{status: StatusPendingPayment, customer: true, admin: true, system: true},
+		{status: StatusPaid, customer: true, admin: true},
+		{status: StatusFulfilled, customer: true, admin: true},
+		{status: StatusShipped},
+		{status: StatusDelivered},
+		{status: StatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.customer, tt.status.CancellableBy(ActorCustomer))
+			assert.Equal(t, tt.admin, tt.status.CancellableBy(ActorAdmin))
+			assert.Equal(t, tt.system, tt.status.CancellableBy(ActorSystem))
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package orderItem
 
 import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"time"
 )
@@ -11,21 +16,69 @@ type OrderItem struct {
 } // @name OrderItemModel
 
 type HistoryOrderItem struct {
-	ID         uuid.UUID       `json:"id"`
-	TotalPrice float64         `json:"total_price"`
-	Status     string          `json:"status"`
-	CreatedAt  time.Time       `json:"created_at"`
-	Items      []OrderItemFull `json:"items"`
+	ID            uuid.UUID         `json:"id"`
+	Subtotal      money.Money       `json:"subtotal" swaggertype:"string"`
+	DiscountTotal money.Money       `json:"discount_total" swaggertype:"string"`
+	TaxTotal      money.Money       `json:"tax_total" swaggertype:"string"`
+	TaxIncluded   money.Money       `json:"tax_included" swaggertype:"string"`
+	ShippingTotal money.Money       `json:"shipping_total" swaggertype:"string"`
+	TotalPrice    money.Money       `json:"total_price" swaggertype:"string"`
+	RefundedTotal money.Money       `json:"refunded_total" swaggertype:"string"`
+	Currency      money.Currency    `json:"currency" swaggertype:"string"`
+	ExchangeRate  money.Rate        `json:"exchange_rate" swaggertype:"string"`
+	Status        orderModel.Status `json:"status"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Items         []OrderItemFull   `json:"items"`
+	// Shipments are the parcels the order went out in.
+	Shipments []shipment.Shipment `json:"shipments,omitempty"`
+	// Returns are the customer's requests to send books of the order back.
+	Returns []rma.Return `json:"returns,omitempty"`
 } // @name HistoryOrderItemModel
 
+// Convert prices a catalogue amount in the order's checkout currency.
+func (o HistoryOrderItem) Convert(amount money.Money) money.Money {
+	return orderModel.ConvertAt(amount, o.Currency, o.ExchangeRate)
+}
+
+func (o HistoryOrderItem) ChargeTotal() money.Money {
+	return o.Convert(o.TotalPrice)
+}
+
+// Returnable reports whether the customer can ask to send books back.
+func (o HistoryOrderItem) Returnable() bool {
+	return rma.IsReturnable(o.Status)
+}
+
+// Invoiced reports whether the order has an invoice to download.
+func (o HistoryOrderItem) Invoiced() bool {
+	return invoice.IsInvoiced(o.Status)
+}
+
 type OrderItemFull struct {
-	ID       uuid.UUID `json:"id"`
-	Name     string    `json:"name"`
-	BookID   uuid.UUID `json:"book_id"`
-	Quantity int       `json:"quantity"`
-	Price    float64   `json:"price"`
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	BookID        uuid.UUID   `json:"book_id"`
+	Quantity      int         `json:"quantity"`
+	Price         money.Money `json:"price" swaggertype:"string"`
+	Tax           money.Money `json:"tax" swaggertype:"string"`
+	ReservedUntil *time.Time  `json:"reserved_until,omitempty"`
 } // @name OrderItemFullModel
 
 type CreateOrderItemRequest struct {
 	Items []OrderItem `json:"items"`
 } // @name CreateOrderItemRequestModel
+
+// LineTotal is the price of the line, unit price times quantity.
+func (i OrderItemFull) LineTotal() money.Money {
+	return i.Price.Mul(i.Quantity)
+}
+
+// Total is what an order costs: the sum of its lines. Order totals are always
+// derived from this rather than adjusted as lines come and go.
+func Total(items []OrderItemFull) money.Money {
+	lines := make([]money.Money, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, item.LineTotal())
+	}
+	return money.Sum(lines...)
+}
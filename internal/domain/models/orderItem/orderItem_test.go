@@ -0,0 +1,45 @@
+package orderItem
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/quick"
+)
+
+func TestTotalEqualsSumOfLines(t *testing.T) {
+	property := func(prices []uint16, quantities []uint8) bool {
+		var items []OrderItemFull
+		var want int64
+		for i, price := range prices {
+			qty := 1
+			if i < len(quantities) {
+				qty = int(quantities[i])
+			}
+			items = append(items, OrderItemFull{Price: money.New(int64(price), money.USD), Quantity: qty})
+			want += int64(price) * int64(qty)
+		}
+
+		total := Total(items)
+		return total.Amount() == want && total.Currency() == money.USD
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestTotalIsIndependentOfLineOrder(t *testing.T) {
+	property := func(prices []uint16) bool {
+		var items, reversed []OrderItemFull
+		for i, price := range prices {
+			items = append(items, OrderItemFull{Price: money.New(int64(price), money.USD), Quantity: i%3 + 1})
+		}
+		for i := len(items) - 1; i >= 0; i-- {
+			reversed = append(reversed, items[i])
+		}
+		return Total(items) == Total(reversed)
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestTotalOfNoLinesIsZero(t *testing.T) {
+	require.True(t, Total(nil).IsZero())
+}
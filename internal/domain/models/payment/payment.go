@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// ErrInvalidSignature is returned by providers for webhook deliveries whose
+// signature does not match the payload.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRefunded  Status = "refunded"
+)
+
+// EventType is the kind of asynchronous notification a provider sends to the
+// webhook endpoint.
+type EventType string
+
+const (
+	EventPaymentSucceeded EventType = "payment.succeeded"
+	EventPaymentFailed    EventType = "payment.failed"
+	EventRefundSucceeded  EventType = "refund.succeeded"
+)
+
+type Payment struct {
+	ID             uuid.UUID   `json:"id"`
+	OrderID        uuid.UUID   `json:"order_id"`
+	Provider       string      `json:"provider"`
+	ProviderRef    string      `json:"provider_ref"`
+	Amount         money.Money `json:"amount" swaggertype:"string"`
+	RefundedAmount money.Money `json:"refunded_amount" swaggertype:"string"`
+	Status         Status      `json:"status"`
+	FailureReason  string      `json:"failure_reason,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+} // @name PaymentModel
+
+// IntentRequest asks a provider to prepare a charge. PaymentID doubles as the
+// idempotency key on the provider side.
+type IntentRequest struct {
+	PaymentID uuid.UUID
+	OrderID   uuid.UUID
+	Amount    money.Money
+}
+
+// Result is what a provider reports back for an intent, a capture or a refund.
+// A provider that settles asynchronously answers StatusPending and confirms
+// through a webhook later.
+type Result struct {
+	ProviderRef   string
+	Status        Status
+	FailureReason string
+}
+
+// WebhookEvent is a provider notification after its signature was checked.
+type WebhookEvent struct {
+	ID            string      `json:"id"`
+	Type          EventType   `json:"type"`
+	ProviderRef   string      `json:"provider_ref"`
+	Amount        money.Money `json:"amount"`
+	FailureReason string      `json:"failure_reason,omitempty"`
+}
@@ -0,0 +1,288 @@
+package promotion
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Line is an order line as the promotion engine sees it.
+type Line struct {
+	ItemID   uuid.UUID
+	BookID   uuid.UUID
+	Author   string
+	Category string
+	TaxClass tax.Class
+	Price    money.Money
+	Quantity int
+	// WeightGrams is the shipping weight of one unit.
+	WeightGrams int
+}
+
+// Discount is what one promotion applied to an order takes off. A promotion
+// that stays on the order but no longer applies, say because the cart
+// dropped below its minimum, takes off nothing and says why in Reason.
+type Discount struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount" swaggertype:"string" example:"2.50"`
+	Reason      string      `json:"reason,omitempty"`
+} // @name DiscountModel
+
+// Breakdown is how an order's total is made up. Total is Subtotal, less
+// DiscountTotal, plus the part of TaxTotal not already in the prices
+// (TaxIncluded), plus ShippingTotal.
+type Breakdown struct {
+	Currency       money.Currency   `json:"currency" swaggertype:"string" example:"USD"`
+	Subtotal       money.Money      `json:"subtotal" swaggertype:"string" example:"25.98"`
+	Discounts      []Discount       `json:"discounts"`
+	DiscountTotal  money.Money      `json:"discount_total" swaggertype:"string" example:"2.50"`
+	Jurisdiction   tax.Jurisdiction `json:"jurisdiction"`
+	Taxes          []tax.LineTax    `json:"taxes"`
+	TaxTotal       money.Money      `json:"tax_total" swaggertype:"string" example:"2.08"`
+	TaxIncluded    money.Money      `json:"tax_included" swaggertype:"string" example:"0.00"`
+	ShippingTotal  money.Money      `json:"shipping_total" swaggertype:"string" example:"0.00"`
+	DeliveryMethod string           `json:"delivery_method,omitempty" example:"standard"`
+	Total          money.Money      `json:"total" swaggertype:"string" example:"25.56"`
+	// LineTotals is what each line costs once the discounts are taken off,
+	// in the order the lines were priced.
+	LineTotals []money.Money `json:"-"`
+} // @name PriceBreakdownModel
+
+// Subtotal is what the lines cost before any discount.
+func Subtotal(lines []Line) money.Money {
+	amounts := make([]money.Money, 0, len(lines))
+	for _, line := range lines {
+		amounts = append(amounts, line.Price.Mul(line.Quantity))
+	}
+	return money.Sum(amounts...)
+}
+
+// Weight is what the lines weigh together, in grams.
+func Weight(lines []Line) int {
+	grams := 0
+	for _, line := range lines {
+		grams += line.WeightGrams * line.Quantity
+	}
+	return grams
+}
+
+// Price works out what the promotions applied to an order, in the order they
+// were applied, take off its lines at now.
+//
+// Promotions that are not eligible, or that cannot stack with one applied
+// before them, take off nothing. The rest are priced free items first, then
+// percentages, then fixed amounts, each on what the earlier ones left of a
+// line, so no line and no order ever goes below zero.
+func Price(lines []Line, promotions []Promotion, now time.Time) Breakdown {
+	subtotal := Subtotal(lines)
+	currency := subtotal.Currency()
+
+	b := Breakdown{
+		Currency:      currency,
+		Subtotal:      subtotal,
+		Discounts:     make([]Discount, len(promotions)),
+		TaxTotal:      money.Zero(currency),
+		TaxIncluded:   money.Zero(currency),
+		ShippingTotal: money.Zero(currency),
+	}
+
+	var accepted []Promotion
+	var order []int
+	for i, p := range promotions {
+		b.Discounts[i] = Discount{
+			PromotionID: p.ID,
+			Code:        p.Code,
+			Description: p.Description,
+			Amount:      money.Zero(currency),
+		}
+
+		err := p.Eligible(lines, now)
+		if err == nil {
+			err = CanStack(accepted, p)
+		}
+		if err != nil {
+			b.Discounts[i].Reason = reason(err)
+			continue
+		}
+
+		accepted = append(accepted, p)
+		order = append(order, i)
+	}
+
+	sort.SliceStable(order, func(a, c int) bool {
+		return kindRank[promotions[order[a]].Kind] < kindRank[promotions[order[c]].Kind]
+	})
+
+	remaining := make([]money.Money, len(lines))
+	for i, line := range lines {
+		remaining[i] = line.Price.Mul(line.Quantity)
+	}
+
+	amounts := make([]money.Money, 0, len(order))
+	for _, i := range order {
+		amount := promotions[i].discount(lines, remaining)
+		if !amount.IsZero() {
+			b.Discounts[i].Amount = amount
+		}
+		amounts = append(amounts, amount)
+	}
+
+	b.DiscountTotal = money.Sum(amounts...)
+	if b.DiscountTotal.IsZero() {
+		b.DiscountTotal = money.Zero(currency)
+	}
+	b.Total = subtotal.Sub(b.DiscountTotal)
+	b.LineTotals = remaining
+
+	return b
+}
+
+// TaxLines are the lines as the tax calculator sees them, at what they cost
+// after the breakdown's discounts.
+func (b Breakdown) TaxLines(lines []Line) []tax.Line {
+	taxLines := make([]tax.Line, 0, len(lines))
+	for i, line := range lines {
+		amount := line.Price.Mul(line.Quantity)
+		if i < len(b.LineTotals) {
+			amount = b.LineTotals[i]
+		}
+		taxLines = append(taxLines, tax.Line{
+			ItemID: line.ItemID,
+			BookID: line.BookID,
+			Class:  line.TaxClass,
+			Amount: amount,
+		})
+	}
+	return taxLines
+}
+
+// WithShipping adds the price of delivering the order. Call it before
+// WithTax, which works out the total.
+func (b Breakdown) WithShipping(method string, price money.Money) Breakdown {
+	b.DeliveryMethod = method
+	b.ShippingTotal = price
+	return b
+}
+
+// WithTax adds the order's tax to the breakdown and works out its total again.
+func (b Breakdown) WithTax(result tax.Result) Breakdown {
+	b.Jurisdiction = result.Jurisdiction
+	b.Taxes = result.Lines
+	b.TaxTotal = result.Total
+	b.TaxIncluded = result.Included
+	b.Total = b.Subtotal.Sub(b.DiscountTotal).Add(result.Added()).Add(b.ShippingTotal)
+	return b
+}
+
+// Convert shows the breakdown in another currency at the given rate.
+func (b Breakdown) Convert(to money.Currency, rate money.Rate) Breakdown {
+	converted := Breakdown{
+		Currency:       to,
+		Subtotal:       b.Subtotal.Convert(to, rate),
+		Discounts:      make([]Discount, len(b.Discounts)),
+		DiscountTotal:  b.DiscountTotal.Convert(to, rate),
+		Jurisdiction:   b.Jurisdiction,
+		Taxes:          make([]tax.LineTax, len(b.Taxes)),
+		TaxTotal:       b.TaxTotal.Convert(to, rate),
+		TaxIncluded:    b.TaxIncluded.Convert(to, rate),
+		ShippingTotal:  b.ShippingTotal.Convert(to, rate),
+		DeliveryMethod: b.DeliveryMethod,
+		Total:          b.Total.Convert(to, rate),
+	}
+	for i, d := range b.Discounts {
+		d.Amount = d.Amount.Convert(to, rate)
+		converted.Discounts[i] = d
+	}
+	for i, t := range b.Taxes {
+		t.Taxable = t.Taxable.Convert(to, rate)
+		t.Amount = t.Amount.Convert(to, rate)
+		converted.Taxes[i] = t
+	}
+	return converted
+}
+
+// Discount finds the discount of the promotion with the given id.
+func (b Breakdown) Discount(promotionID uuid.UUID) (Discount, bool) {
+	for _, d := range b.Discounts {
+		if d.PromotionID == promotionID {
+			return d, true
+		}
+	}
+	return Discount{}, false
+}
+
+var kindRank = map[Kind]int{
+	KindBuyXGetY:   0,
+	KindPercentage: 1,
+	KindFixed:      2,
+}
+
+// discount takes the promotion off the lines it covers and returns how much
+// it took. remaining holds what is left to pay of each line and is updated.
+func (p Promotion) discount(lines []Line, remaining []money.Money) money.Money {
+	var taken []money.Money
+	take := func(i int, amount money.Money) {
+		amount = amount.Min(remaining[i])
+		if !amount.IsPositive() {
+			return
+		}
+		remaining[i] = remaining[i].Sub(amount)
+		taken = append(taken, amount)
+	}
+
+	switch p.Kind {
+	case KindPercentage:
+		for i, line := range lines {
+			if p.Covers(line) {
+				take(i, remaining[i].Percent(p.Percent))
+			}
+		}
+	case KindFixed:
+		left := p.Amount
+		for i, line := range lines {
+			if !left.IsPositive() {
+				break
+			}
+			if p.Covers(line) {
+				before := remaining[i]
+				take(i, left)
+				left = left.Sub(before.Sub(remaining[i]))
+			}
+		}
+	case KindBuyXGetY:
+		type unit struct {
+			line  int
+			price money.Money
+		}
+		var units []unit
+		for i, line := range lines {
+			if !p.Covers(line) {
+				continue
+			}
+			for n := 0; n < line.Quantity; n++ {
+				units = append(units, unit{line: i, price: line.Price})
+			}
+		}
+		sort.SliceStable(units, func(a, c int) bool {
+			return units[a].price.GreaterThan(units[c].price)
+		})
+
+		group := p.BuyQuantity + p.GetQuantity
+		for start := 0; start+group <= len(units); start += group {
+			for _, u := range units[start+p.BuyQuantity : start+group] {
+				take(u.line, u.price)
+			}
+		}
+	}
+
+	return money.Sum(taken...)
+}
+
+func reason(err error) string {
+	return strings.TrimPrefix(err.Error(), ErrIneligible.Error()+": ")
+}
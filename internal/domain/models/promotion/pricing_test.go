@@ -0,0 +1,273 @@
+package promotion
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func usd(s string) money.Money {
+	return money.MustParse(s, money.USD)
+}
+
+func line(price string, quantity int, author, category string) Line {
+	return Line{
+		BookID:   uuid.Must(uuid.NewV4()),
+		Author:   author,
+		Category: category,
+		Price:    usd(price),
+		Quantity: quantity,
+	}
+}
+
+func promo(code string, kind Kind) Promotion {
+	return Promotion{
+		ID:     uuid.Must(uuid.NewV4()),
+		Code:   code,
+		Kind:   kind,
+		Scope:  ScopeOrder,
+		Active: true,
+	}
+}
+
+func TestPrice(t *testing.T) {
+	lines := []Line{
+		line("20.00", 1, "Tolkien", "fantasy"),
+		line("10.00", 2, "Orwell", "classics"),
+		line("5.00", 1, "Orwell", "classics"),
+	}
+
+	percent := promo("TEN", KindPercentage)
+	percent.Percent = 10
+
+	fixed := promo("FIVE", KindFixed)
+	fixed.Amount = usd("5.00")
+
+	bigFixed := promo("HUNDRED", KindFixed)
+	bigFixed.Amount = usd("100.00")
+
+	classics := promo("CLASSICS", KindPercentage)
+	classics.Percent = 50
+	classics.Scope, classics.ScopeValue = ScopeCategory, "Classics"
+
+	tolkien := promo("TOLKIEN", KindFixed)
+	tolkien.Amount = usd("30.00")
+	tolkien.Scope, tolkien.ScopeValue = ScopeAuthor, "tolkien"
+
+	threeForTwo := promo("3FOR2", KindBuyXGetY)
+	threeForTwo.BuyQuantity, threeForTwo.GetQuantity = 2, 1
+
+	minimum := promo("BIG", KindFixed)
+	minimum.Amount = usd("5.00")
+	minimum.MinOrderValue = usd("100.00")
+
+	stackPercent := percent
+	stackPercent.Stackable = true
+	stackFixed := fixed
+	stackFixed.Stackable = true
+
+	cases := []struct {
+		name       string
+		promotions []Promotion
+		discounts  []string
+		reasons    []string
+		total      string
+	}{
+		{"no promotions", nil, nil, nil, "45.00"},
+		{"percentage", []Promotion{percent}, []string{"4.50"}, []string{""}, "40.50"},
+		{"fixed", []Promotion{fixed}, []string{"5.00"}, []string{""}, "40.00"},
+		{"fixed capped at subtotal", []Promotion{bigFixed}, []string{"45.00"}, []string{""}, "0.00"},
+		{"category scope", []Promotion{classics}, []string{"12.50"}, []string{""}, "32.50"},
+		{"author scope capped at covered lines", []Promotion{tolkien}, []string{"20.00"}, []string{""}, "25.00"},
+		{"buy two get the cheapest free", []Promotion{threeForTwo}, []string{"10.00"}, []string{""}, "35.00"},
+		{"minimum not met", []Promotion{minimum}, []string{"0.00"}, []string{"order is below the minimum value"}, "45.00"},
+		{"not stackable", []Promotion{percent, fixed}, []string{"4.50", "0.00"},
+			[]string{"", "cannot be combined with the other promotions on the order"}, "40.50"},
+		{"stackable, percentage before fixed", []Promotion{stackFixed, stackPercent}, []string{"5.00", "4.50"},
+			[]string{"", ""}, "35.50"},
+		{"free items before percentage", []Promotion{withStack(percent), withStack(threeForTwo)}, []string{"3.50", "10.00"},
+			[]string{"", ""}, "31.50"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := Price(lines, c.promotions, now)
+
+			assert.Equal(t, usd("45.00"), b.Subtotal)
+			require.Len(t, b.Discounts, len(c.discounts))
+			for i, d := range b.Discounts {
+				assert.Equal(t, c.discounts[i], d.Amount.String(), d.Code)
+				assert.Equal(t, c.reasons[i], d.Reason, d.Code)
+			}
+			assert.Equal(t, c.total, b.Total.String())
+			assert.Equal(t, b.Subtotal.Sub(b.DiscountTotal), b.Total)
+		})
+	}
+}
+
+func withStack(p Promotion) Promotion {
+	p.Stackable = true
+	return p
+}
+
+func TestEligible(t *testing.T) {
+	lines := []Line{line("10.00", 2, "Orwell", "classics")}
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	inactive := promo("OFF", KindFixed)
+	inactive.Active = false
+
+	notStarted := promo("SOON", KindFixed)
+	notStarted.StartsAt = &after
+
+	ended := promo("GONE", KindFixed)
+	ended.EndsAt = &before
+
+	running := promo("NOW", KindFixed)
+	running.StartsAt, running.EndsAt = &before, &after
+
+	otherAuthor := promo("TOLKIEN", KindFixed)
+	otherAuthor.Scope, otherAuthor.ScopeValue = ScopeAuthor, "Tolkien"
+
+	tooFewItems := promo("3FOR2", KindBuyXGetY)
+	tooFewItems.BuyQuantity, tooFewItems.GetQuantity = 2, 1
+
+	assert.ErrorIs(t, inactive.Eligible(lines, now), ErrInactive)
+	assert.ErrorIs(t, notStarted.Eligible(lines, now), ErrInactive)
+	assert.ErrorIs(t, ended.Eligible(lines, now), ErrInactive)
+	assert.NoError(t, running.Eligible(lines, now))
+	assert.ErrorIs(t, otherAuthor.Eligible(lines, now), ErrNoEligibleItems)
+	assert.ErrorIs(t, tooFewItems.Eligible(lines, now), ErrNoEligibleItems)
+	assert.ErrorIs(t, ErrMinOrderValue, ErrIneligible)
+}
+
+func TestCanStack(t *testing.T) {
+	solo := promo("SOLO", KindFixed)
+	a, b := withStack(promo("A", KindFixed)), withStack(promo("B", KindFixed))
+
+	assert.NoError(t, CanStack(nil, solo))
+	assert.NoError(t, CanStack([]Promotion{a}, b))
+	assert.ErrorIs(t, CanStack([]Promotion{a}, solo), ErrNotStackable)
+	assert.ErrorIs(t, CanStack([]Promotion{solo}, a), ErrNotStackable)
+}
+
+func TestValidate(t *testing.T) {
+	valid := promo("OK", KindPercentage)
+	valid.Percent = 15
+	require.NoError(t, valid.Validate())
+
+	cases := map[string]func(p *Promotion){
+		"missing code":        func(p *Promotion) { p.Code = "" },
+		"percent over 100":    func(p *Promotion) { p.Percent = 101 },
+		"unknown kind":        func(p *Promotion) { p.Kind = "bogus" },
+		"scope without value": func(p *Promotion) { p.Scope = ScopeCategory },
+		"negative limit":      func(p *Promotion) { p.UsageLimit = -1 },
+		"window ends first": func(p *Promotion) {
+			start, end := now, now.Add(-time.Hour)
+			p.StartsAt, p.EndsAt = &start, &end
+		},
+	}
+	for name, mutate := range cases {
+		p := valid
+		mutate(&p)
+		assert.Error(t, p.Validate(), name)
+	}
+}
+
+// However promotions are combined, an order never costs less than nothing
+// and never more than its lines.
+func TestPriceStaysWithinSubtotal(t *testing.T) {
+	property := func(prices []uint16, quantities []uint8, percent uint8, fixed uint16, buy, get uint8) bool {
+		var lines []Line
+		for i, price := range prices {
+			qty := 1
+			if i < len(quantities) {
+				qty = int(quantities[i]%5) + 1
+			}
+			lines = append(lines, Line{Price: money.New(int64(price), money.USD), Quantity: qty})
+		}
+
+		p1 := withStack(promo("P", KindPercentage))
+		p1.Percent = int(percent%100) + 1
+		p2 := withStack(promo("F", KindFixed))
+		p2.Amount = money.New(int64(fixed), money.USD)
+		p3 := withStack(promo("B", KindBuyXGetY))
+		p3.BuyQuantity, p3.GetQuantity = int(buy%3)+1, int(get%3)+1
+
+		b := Price(lines, []Promotion{p2, p1, p3}, now)
+		return !b.Total.IsNegative() &&
+			!b.Total.GreaterThan(b.Subtotal) &&
+			b.Subtotal.Sub(b.DiscountTotal) == b.Total
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestBreakdownWithTax(t *testing.T) {
+	lines := []Line{
+		line("20.00", 1, "Tolkien", "fantasy"),
+		line("10.00", 1, "Orwell", "classics"),
+	}
+	lines[1].TaxClass = tax.ClassReduced
+
+	classics := promo("CLASSICS", KindPercentage)
+	classics.Percent = 50
+	classics.Scope, classics.ScopeValue = ScopeCategory, "classics"
+
+	b := Price(lines, []Promotion{classics}, now)
+
+	taxLines := b.TaxLines(lines)
+	require.Len(t, taxLines, 2)
+	assert.Equal(t, "20.00", taxLines[0].Amount.String())
+	assert.Equal(t, "5.00", taxLines[1].Amount.String(), "tax is on what the line costs after discounts")
+	assert.Equal(t, tax.ClassReduced, taxLines[1].Class)
+	assert.Equal(t, lines[1].BookID, taxLines[1].BookID)
+
+	t.Run("tax added on top", func(t *testing.T) {
+		taxed := b.WithTax(tax.Result{
+			Jurisdiction: tax.Jurisdiction{Country: "US", Region: "NY"},
+			Total:        usd("2.22"),
+			Included:     usd("0.00"),
+		})
+		assert.Equal(t, "27.22", taxed.Total.String())
+		assert.Equal(t, "US-NY", taxed.Jurisdiction.String())
+	})
+
+	t.Run("tax already in the prices", func(t *testing.T) {
+		taxed := b.WithTax(tax.Result{
+			Jurisdiction: tax.Jurisdiction{Country: "GB"},
+			Total:        usd("3.57"),
+			Included:     usd("3.57"),
+		})
+		assert.Equal(t, "25.00", taxed.Total.String())
+		assert.Equal(t, "3.57", taxed.TaxTotal.String())
+	})
+}
+
+func TestBreakdownWithShipping(t *testing.T) {
+	lines := []Line{
+		line("20.00", 2, "Tolkien", "fantasy"),
+		line("10.00", 1, "Orwell", "classics"),
+	}
+	lines[0].WeightGrams = 450
+	lines[1].WeightGrams = 300
+
+	assert.Equal(t, 1200, Weight(lines))
+
+	b := Price(lines, nil, now).WithShipping("express", usd("14.99"))
+	assert.Equal(t, "express", b.DeliveryMethod)
+
+	taxed := b.WithTax(tax.Result{
+		Jurisdiction: tax.Jurisdiction{Country: "US", Region: "NY"},
+		Total:        usd("4.44"),
+		Included:     usd("0.00"),
+	})
+	assert.Equal(t, "69.43", taxed.Total.String(), "shipping is added to the total but not taxed")
+	assert.Equal(t, "14.99", taxed.ShippingTotal.String())
+}
@@ -0,0 +1,245 @@
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+type Kind string
+
+const (
+	// KindPercentage takes Percent off the eligible lines.
+	KindPercentage Kind = "percentage"
+	// KindFixed takes Amount off the eligible lines, never more than they cost.
+	KindFixed Kind = "fixed"
+	// KindBuyXGetY makes GetQuantity of every BuyQuantity+GetQuantity
+	// eligible books free, the cheapest ones of each group.
+	KindBuyXGetY Kind = "buy_x_get_y"
+)
+
+type Scope string
+
+const (
+	ScopeOrder    Scope = "order"
+	ScopeCategory Scope = "category"
+	ScopeAuthor   Scope = "author"
+)
+
+// ErrIneligible is wrapped by every reason a promotion does not apply, so
+// callers can tell "this code does not work here" from a failure.
+var ErrIneligible = errors.New("promotion does not apply")
+
+var (
+	ErrInactive        = fmt.Errorf("%w: not active", ErrIneligible)
+	ErrMinOrderValue   = fmt.Errorf("%w: order is below the minimum value", ErrIneligible)
+	ErrNoEligibleItems = fmt.Errorf("%w: no items in the order qualify", ErrIneligible)
+	ErrUsageLimit      = fmt.Errorf("%w: usage limit reached", ErrIneligible)
+	ErrNotStackable    = fmt.Errorf("%w: cannot be combined with the other promotions on the order", ErrIneligible)
+)
+
+type Promotion struct {
+	ID          uuid.UUID   `json:"id"`
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Kind        Kind        `json:"kind"`
+	Percent     int         `json:"percent"`
+	Amount      money.Money `json:"amount" swaggertype:"string" example:"5.00"`
+	BuyQuantity int         `json:"buy_quantity"`
+	GetQuantity int         `json:"get_quantity"`
+	Scope       Scope       `json:"scope"`
+	// ScopeValue is the category or author the promotion is limited to.
+	ScopeValue    string      `json:"scope_value"`
+	MinOrderValue money.Money `json:"min_order_value" swaggertype:"string" example:"20.00"`
+	// UsageLimit and PerUserLimit cap redemptions overall and per customer;
+	// zero means unlimited.
+	UsageLimit   int        `json:"usage_limit"`
+	PerUserLimit int        `json:"per_user_limit"`
+	TimesUsed    int        `json:"times_used"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	// Stackable promotions may share an order with other stackable ones; a
+	// promotion that is not stackable has to be the only one.
+	Stackable bool      `json:"stackable"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name PromotionModel
+
+type Request struct {
+	Code          string      `json:"code"`
+	Description   string      `json:"description"`
+	Kind          Kind        `json:"kind"`
+	Percent       int         `json:"percent"`
+	Amount        money.Money `json:"amount" swaggertype:"string" example:"5.00"`
+	BuyQuantity   int         `json:"buy_quantity"`
+	GetQuantity   int         `json:"get_quantity"`
+	Scope         Scope       `json:"scope"`
+	ScopeValue    string      `json:"scope_value"`
+	MinOrderValue money.Money `json:"min_order_value" swaggertype:"string" example:"20.00"`
+	UsageLimit    int         `json:"usage_limit"`
+	PerUserLimit  int         `json:"per_user_limit"`
+	StartsAt      *time.Time  `json:"starts_at,omitempty"`
+	EndsAt        *time.Time  `json:"ends_at,omitempty"`
+	Stackable     bool        `json:"stackable"`
+	Active        bool        `json:"active"`
+} // @name PromotionRequestModel
+
+type ApplyRequest struct {
+	Code string `json:"code"`
+} // @name ApplyPromotionRequestModel
+
+// Promotion builds the promotion an admin request describes, with the code
+// normalised and amounts in the catalogue currency.
+func (r Request) Promotion() Promotion {
+	scope := r.Scope
+	if scope == "" {
+		scope = ScopeOrder
+	}
+
+	return Promotion{
+		Code:          NormalizeCode(r.Code),
+		Description:   strings.TrimSpace(r.Description),
+		Kind:          r.Kind,
+		Percent:       r.Percent,
+		Amount:        money.New(r.Amount.Amount(), money.DefaultCurrency),
+		BuyQuantity:   r.BuyQuantity,
+		GetQuantity:   r.GetQuantity,
+		Scope:         scope,
+		ScopeValue:    strings.TrimSpace(r.ScopeValue),
+		MinOrderValue: money.New(r.MinOrderValue.Amount(), money.DefaultCurrency),
+		UsageLimit:    r.UsageLimit,
+		PerUserLimit:  r.PerUserLimit,
+		StartsAt:      r.StartsAt,
+		EndsAt:        r.EndsAt,
+		Stackable:     r.Stackable,
+		Active:        r.Active,
+	}
+}
+
+// NormalizeCode makes codes case-insensitive: customers type them by hand.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks that the promotion is something the engine can price.
+func (p Promotion) Validate() error {
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+
+	switch p.Kind {
+	case KindPercentage:
+		if p.Percent <= 0 || p.Percent > 100 {
+			return errors.New("percent must be between 1 and 100")
+		}
+	case KindFixed:
+		if !p.Amount.IsPositive() {
+			return errors.New("amount must be positive")
+		}
+	case KindBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return errors.New("buy_quantity and get_quantity must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", p.Kind)
+	}
+
+	switch p.Scope {
+	case ScopeOrder:
+	case ScopeCategory, ScopeAuthor:
+		if p.ScopeValue == "" {
+			return fmt.Errorf("scope_value is required for %s promotions", p.Scope)
+		}
+	default:
+		return fmt.Errorf("unknown scope %q", p.Scope)
+	}
+
+	if p.MinOrderValue.IsNegative() {
+		return errors.New("min_order_value must not be negative")
+	}
+	if p.UsageLimit < 0 || p.PerUserLimit < 0 {
+		return errors.New("usage limits must not be negative")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+// IsLive reports whether the promotion is switched on and inside its
+// validity window at now.
+func (p Promotion) IsLive(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Exhausted reports whether the promotion has been redeemed as often as it
+// may be overall.
+func (p Promotion) Exhausted() bool {
+	return p.UsageLimit > 0 && p.TimesUsed >= p.UsageLimit
+}
+
+// Covers reports whether a line falls within the promotion's scope.
+func (p Promotion) Covers(line Line) bool {
+	switch p.Scope {
+	case ScopeCategory:
+		return strings.EqualFold(line.Category, p.ScopeValue)
+	case ScopeAuthor:
+		return strings.EqualFold(line.Author, p.ScopeValue)
+	}
+	return true
+}
+
+// Eligible checks whether the promotion can apply to an order made of lines
+// at now. Usage limits are not checked here, they depend on redemptions.
+func (p Promotion) Eligible(lines []Line, now time.Time) error {
+	if !p.IsLive(now) {
+		return ErrInactive
+	}
+
+	if p.MinOrderValue.IsPositive() && Subtotal(lines).LessThan(p.MinOrderValue) {
+		return ErrMinOrderValue
+	}
+
+	units := 0
+	for _, line := range lines {
+		if p.Covers(line) {
+			units += line.Quantity
+		}
+	}
+	if units == 0 || (p.Kind == KindBuyXGetY && units < p.BuyQuantity+p.GetQuantity) {
+		return ErrNoEligibleItems
+	}
+
+	return nil
+}
+
+// CanStack checks whether next may join the promotions already applied to an
+// order.
+func CanStack(applied []Promotion, next Promotion) error {
+	if len(applied) == 0 {
+		return nil
+	}
+	if !next.Stackable {
+		return ErrNotStackable
+	}
+	for _, p := range applied {
+		if !p.Stackable {
+			return ErrNotStackable
+		}
+	}
+	return nil
+}
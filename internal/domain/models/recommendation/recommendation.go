@@ -0,0 +1,76 @@
+package recommendation
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/gofrs/uuid"
+	"strconv"
+)
+
+const (
+	// DefaultLimit is how many books are suggested when the caller does not
+	// say.
+	DefaultLimit = 6
+	MaxLimit     = 20
+)
+
+// Reason tells why a book is suggested.
+type Reason string
+
+const (
+	// ReasonAlsoBought comes from paid orders that had both books in them.
+	ReasonAlsoBought Reason = "also_bought"
+	// ReasonSameAuthor and ReasonSameCategory fill in for books nobody has
+	// bought together with anything yet.
+	ReasonSameAuthor   Reason = "same_author"
+	ReasonSameCategory Reason = "same_category"
+)
+
+// Recommendation is a book suggested next to another book or a cart.
+type Recommendation struct {
+	books.Book
+	Reason Reason `json:"reason" swaggertype:"string" example:"also_bought"`
+	// Score ranks the also-bought suggestions, between 0 and 1 per seed
+	// book. It is 0 for the fallbacks.
+	Score float64 `json:"score" example:"0.42"`
+} // @name Recommendation
+
+// ParseLimit reads the number of suggestions from a query string, capped at
+// MaxLimit.
+func ParseLimit(s string) (int, error) {
+	if s == "" {
+		return DefaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(s)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive number, got %q", s)
+	}
+
+	return min(limit, MaxLimit), nil
+}
+
+// Merge tops up primary with fallback up to limit, leaving out books already
+// suggested and the ones in exclude.
+func Merge(primary, fallback []Recommendation, exclude []uuid.UUID, limit int) []Recommendation {
+	seen := make(map[uuid.UUID]bool, len(exclude)+limit)
+	for _, id := range exclude {
+		seen[id] = true
+	}
+
+	merged := make([]Recommendation, 0, limit)
+	for _, list := range [][]Recommendation{primary, fallback} {
+		for _, rec := range list {
+			if len(merged) == limit {
+				return merged
+			}
+			if seen[rec.ID] {
+				continue
+			}
+			seen[rec.ID] = true
+			merged = append(merged, rec)
+		}
+	}
+
+	return merged
+}
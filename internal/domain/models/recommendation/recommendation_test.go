@@ -0,0 +1,66 @@
+package recommendation
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{name: "default", in: "", want: DefaultLimit},
+		{name: "given", in: "3", want: 3},
+		{name: "capped", in: "500", want: MaxLimit},
+		{name: "zero", in: "0", wantErr: true},
+		{name: "negative", in: "-2", wantErr: true},
+		{name: "not a number", in: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func rec(id uuid.UUID, reason Reason) Recommendation {
+	return Recommendation{Book: books.Book{ID: id}, Reason: reason}
+}
+
+func TestMerge(t *testing.T) {
+	seed := uuid.Must(uuid.NewV4())
+	a := uuid.Must(uuid.NewV4())
+	b := uuid.Must(uuid.NewV4())
+	c := uuid.Must(uuid.NewV4())
+
+	primary := []Recommendation{rec(a, ReasonAlsoBought)}
+	fallback := []Recommendation{rec(seed, ReasonSameAuthor), rec(a, ReasonSameAuthor), rec(b, ReasonSameAuthor), rec(c, ReasonSameCategory)}
+
+	t.Run("tops up without duplicates", func(t *testing.T) {
+		got := Merge(primary, fallback, []uuid.UUID{seed}, 10)
+		assert.Equal(t, []Recommendation{rec(a, ReasonAlsoBought), rec(b, ReasonSameAuthor), rec(c, ReasonSameCategory)}, got)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		got := Merge(primary, fallback, []uuid.UUID{seed}, 2)
+		assert.Equal(t, []Recommendation{rec(a, ReasonAlsoBought), rec(b, ReasonSameAuthor)}, got)
+	})
+
+	t.Run("nothing to suggest", func(t *testing.T) {
+		got := Merge(nil, nil, nil, 5)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+}
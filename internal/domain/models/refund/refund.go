@@ -0,0 +1,86 @@
+package refund
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type Status string
+
+const (
+	// StatusOwed refunds are sent to the provider, and retried until they go
+	// through or run out of attempts.
+	StatusOwed Status = "owed"
+	// StatusIssued refunds went out, through the provider or by hand.
+	StatusIssued Status = "issued"
+	// StatusManual refunds cannot go through the provider, for an order paid
+	// outside it or one the provider kept refusing; staff issue them by hand.
+	StatusManual Status = "manual"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOwed, StatusIssued, StatusManual:
+		return true
+	}
+	return false
+}
+
+// Refund is money owed back to a customer, in the currency they paid in.
+type Refund struct {
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"`
+	// PaymentID is the payment the refund goes against. A refund recorded
+	// without one goes against the order's latest captured payment and keeps
+	// the payment it went out on.
+	PaymentID uuid.NullUUID `json:"payment_id" swaggertype:"string"`
+	// ReturnID is the return whose books the refund pays for. Issuing the
+	// refund records on the return that the money went out.
+	ReturnID uuid.NullUUID `json:"return_id" swaggertype:"string"`
+	// Amount is at most what is owed; the refund never takes more than is
+	// left of the payment.
+	Amount        money.Money   `json:"amount" swaggertype:"string" example:"12.99"`
+	Reason        string        `json:"reason"`
+	Status        Status        `json:"status" example:"owed"`
+	Attempts      int           `json:"attempts"`
+	LastError     string        `json:"last_error,omitempty"`
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+	IssuedBy      uuid.NullUUID `json:"-"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	IssuedAt      *time.Time    `json:"issued_at,omitempty"`
+} // @name RefundModel
+
+// Failure is what is recorded about an attempt the provider did not take.
+type Failure struct {
+	Attempts      int
+	Error         string
+	NextAttemptAt time.Time
+	// Manual hands the refund to staff instead of trying again.
+	Manual bool
+}
+
+// Issue is what is recorded about a refund that went out through the
+// provider.
+type Issue struct {
+	PaymentID uuid.UUID
+	Amount    money.Money
+	// Settled is set when the provider refunded at once; asynchronous
+	// refunds are added to the payment when refund.succeeded arrives.
+	Settled bool
+}
+
+// ParseStatus reads the status filter of the refunds list, where nothing
+// means every refund that has not been issued yet.
+func ParseStatus(s string) ([]Status, error) {
+	if s == "" {
+		return []Status{StatusOwed, StatusManual}, nil
+	}
+	status := Status(s)
+	if !status.IsValid() {
+		return nil, fmt.Errorf("unknown refund status %q", s)
+	}
+	return []Status{status}, nil
+}
@@ -0,0 +1,19 @@
+package refund
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	statuses, err := ParseStatus("")
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{StatusOwed, StatusManual}, statuses)
+
+	statuses, err = ParseStatus("issued")
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{StatusIssued}, statuses)
+
+	_, err = ParseStatus("lost")
+	assert.Error(t, err)
+}
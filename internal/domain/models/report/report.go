@@ -0,0 +1,301 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// DateLayout is how report dates are written in query strings and CSV.
+	DateLayout = "2006-01-02"
+	// DefaultDays is the length of the range when the caller gives none.
+	DefaultDays = 30
+	// MaxDays keeps a single report from scanning years of orders.
+	MaxDays = 731
+
+	DefaultLimit = 10
+	MaxLimit     = 100
+	// DefaultThreshold is the stock at or below which a book is low.
+	DefaultThreshold = 5
+)
+
+// Range is the days a report covers, From and To both included. Orders
+// count on the day they were paid.
+type Range struct {
+	From time.Time `json:"from" swaggertype:"string" example:"2025-01-01"`
+	To   time.Time `json:"to" swaggertype:"string" example:"2025-01-31"`
+}
+
+// ParseRange reads a range from YYYY-MM-DD dates. A missing To is today and
+// a missing From is DefaultDays before To.
+func ParseRange(from, to string, now time.Time) (Range, error) {
+	var rng Range
+	var err error
+
+	if to == "" {
+		rng.To = truncateDay(now)
+	} else if rng.To, err = time.Parse(DateLayout, to); err != nil {
+		return Range{}, fmt.Errorf("to must be a date like %s, got %q", DateLayout, to)
+	}
+
+	if from == "" {
+		rng.From = rng.To.AddDate(0, 0, -(DefaultDays - 1))
+	} else if rng.From, err = time.Parse(DateLayout, from); err != nil {
+		return Range{}, fmt.Errorf("from must be a date like %s, got %q", DateLayout, from)
+	}
+
+	if rng.From.After(rng.To) {
+		return Range{}, errors.New("from must not be after to")
+	}
+	if rng.Days() > MaxDays {
+		return Range{}, fmt.Errorf("a report covers at most %d days", MaxDays)
+	}
+
+	return rng, nil
+}
+
+// Days is the number of days in the range.
+func (r Range) Days() int {
+	return int(r.Until().Sub(r.From).Hours() / 24)
+}
+
+// Until is the first moment after the range.
+func (r Range) Until() time.Time {
+	return r.To.AddDate(0, 0, 1)
+}
+
+func (r Range) String() string {
+	return r.From.Format(DateLayout) + "_" + r.To.Format(DateLayout)
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Interval is the width of one bucket of the revenue report.
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// ParseInterval reads an interval from a query string, daily by default.
+func ParseInterval(s string) (Interval, error) {
+	switch interval := Interval(s); interval {
+	case "":
+		return IntervalDay, nil
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return interval, nil
+	}
+	return "", fmt.Errorf("unknown interval %q", s)
+}
+
+// ParseCount reads a positive number from a query string, fallback when it
+// is missing, capped at max.
+func ParseCount(name, s string, fallback, max int) (int, error) {
+	if s == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number, got %q", name, s)
+	}
+
+	return min(n, max), nil
+}
+
+// ParseThreshold reads the low-stock threshold from a query string. Zero
+// lists only the books that ran out.
+func ParseThreshold(s string) (int, error) {
+	if s == "" {
+		return DefaultThreshold, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("threshold must be zero or more, got %q", s)
+	}
+
+	return n, nil
+}
+
+// Table is a report laid out for CSV export.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Summary sums up the orders of a range.
+type Summary struct {
+	Range Range `json:"range"`
+	// Orders were paid in the range; Revenue is what they were charged and
+	// Refunded what has been given back on them since.
+	Orders            int         `json:"orders"`
+	Revenue           money.Money `json:"revenue" swaggertype:"string" example:"1520.40"`
+	Refunded          money.Money `json:"refunded" swaggertype:"string" example:"35.00"`
+	NetRevenue        money.Money `json:"net_revenue" swaggertype:"string" example:"1485.40"`
+	AverageOrderValue money.Money `json:"average_order_value" swaggertype:"string" example:"30.41"`
+	UnitsSold         int         `json:"units_sold"`
+	// Carts is the number of orders started in the range and Converted how
+	// many of those were paid, whenever that happened.
+	Carts          int     `json:"carts"`
+	Converted      int     `json:"converted"`
+	ConversionRate float64 `json:"conversion_rate" example:"12.5"`
+}
+
+// Finish works out the figures derived from the counted ones.
+func (s *Summary) Finish() {
+	s.NetRevenue = s.Revenue.Sub(s.Refunded)
+	s.AverageOrderValue = money.Zero(s.Revenue.Currency())
+	if s.Orders > 0 {
+		s.AverageOrderValue = s.Revenue.Ratio(1, int64(s.Orders))
+	}
+	s.ConversionRate = percent(s.Converted, s.Carts)
+}
+
+func (s Summary) Table() Table {
+	return Table{
+		Header: []string{"from", "to", "orders", "revenue", "refunded", "net_revenue", "average_order_value",
+			"units_sold", "carts", "converted", "conversion_rate"},
+		Rows: [][]string{{
+			s.Range.From.Format(DateLayout), s.Range.To.Format(DateLayout), strconv.Itoa(s.Orders), s.Revenue.String(),
+			s.Refunded.String(), s.NetRevenue.String(), s.AverageOrderValue.String(), strconv.Itoa(s.UnitsSold),
+			strconv.Itoa(s.Carts), strconv.Itoa(s.Converted), formatFloat(s.ConversionRate),
+		}},
+	}
+}
+
+// Point is the revenue of the orders paid in one bucket.
+type Point struct {
+	Period   time.Time   `json:"period" swaggertype:"string" example:"2025-01-06"`
+	Orders   int         `json:"orders"`
+	Revenue  money.Money `json:"revenue" swaggertype:"string" example:"220.15"`
+	Refunded money.Money `json:"refunded" swaggertype:"string" example:"0.00"`
+	// Share is the revenue as a percentage of the best bucket in the report,
+	// for drawing bars.
+	Share float64 `json:"-"`
+}
+
+// Revenue is revenue per day, week or month. Buckets without orders are
+// listed with zeros.
+type Revenue struct {
+	Range    Range    `json:"range"`
+	Interval Interval `json:"interval" swaggertype:"string" example:"week"`
+	Points   []Point  `json:"points"`
+}
+
+// Finish sets the share of each point.
+func (r *Revenue) Finish() {
+	var best int64
+	for _, p := range r.Points {
+		best = max(best, p.Revenue.Amount())
+	}
+	for i := range r.Points {
+		r.Points[i].Share = percent(int(r.Points[i].Revenue.Amount()), int(best))
+	}
+}
+
+func (r Revenue) Table() Table {
+	t := Table{Header: []string{"period", "orders", "revenue", "refunded"}}
+	for _, p := range r.Points {
+		t.Rows = append(t.Rows, []string{p.Period.Format(DateLayout), strconv.Itoa(p.Orders), p.Revenue.String(), p.Refunded.String()})
+	}
+	return t
+}
+
+// TopSeller is a book by the copies sold in paid orders.
+type TopSeller struct {
+	BookID  uuid.UUID   `json:"book_id"`
+	Title   string      `json:"title"`
+	Author  string      `json:"author"`
+	Units   int         `json:"units"`
+	Revenue money.Money `json:"revenue" swaggertype:"string" example:"129.90"`
+}
+
+type TopSellers struct {
+	Range Range       `json:"range"`
+	Books []TopSeller `json:"books"`
+}
+
+func (t TopSellers) Table() Table {
+	table := Table{Header: []string{"book_id", "title", "author", "units", "revenue"}}
+	for _, b := range t.Books {
+		table.Rows = append(table.Rows, []string{b.BookID.String(), b.Title, b.Author, strconv.Itoa(b.Units), b.Revenue.String()})
+	}
+	return table
+}
+
+// Stock is how fast a book sold in the range against what is left of it.
+type Stock struct {
+	BookID    uuid.UUID `json:"book_id"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	Stock     int       `json:"stock"`
+	UnitsSold int       `json:"units_sold"`
+	// SellThrough is the percentage of the copies on hand over the range
+	// that sold: sold / (sold + stock).
+	SellThrough float64 `json:"sell_through" example:"62.5"`
+	// DaysOfCover is how many days the stock lasts at the range's rate of
+	// sale, absent when nothing sold.
+	DaysOfCover *float64 `json:"days_of_cover,omitempty" example:"18.5"`
+}
+
+// Finish works out the rates of a book over a range of days.
+func (s *Stock) Finish(days int) {
+	s.SellThrough = percent(s.UnitsSold, s.UnitsSold+max(s.Stock, 0))
+	s.DaysOfCover = nil
+	if s.UnitsSold > 0 && days > 0 {
+		cover := round2(float64(max(s.Stock, 0)) * float64(days) / float64(s.UnitsSold))
+		s.DaysOfCover = &cover
+	}
+}
+
+// Cover is DaysOfCover written out, empty when nothing sold.
+func (s Stock) Cover() string {
+	if s.DaysOfCover == nil {
+		return ""
+	}
+	return formatFloat(*s.DaysOfCover)
+}
+
+// StockReport lists books for the turnover and low-stock reports.
+type StockReport struct {
+	Range Range `json:"range"`
+	// Threshold is the stock at or below which books are listed, zero for
+	// the turnover report.
+	Threshold int     `json:"threshold,omitempty"`
+	Books     []Stock `json:"books"`
+}
+
+func (s StockReport) Table() Table {
+	t := Table{Header: []string{"book_id", "title", "author", "stock", "units_sold", "sell_through", "days_of_cover"}}
+	for _, b := range s.Books {
+		t.Rows = append(t.Rows, []string{b.BookID.String(), b.Title, b.Author, strconv.Itoa(b.Stock), strconv.Itoa(b.UnitsSold),
+			formatFloat(b.SellThrough), b.Cover()})
+	}
+	return t
+}
+
+func percent(part, whole int) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return round2(float64(part) * 100 / float64(whole))
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
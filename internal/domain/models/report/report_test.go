@@ -0,0 +1,149 @@
+package report
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse(DateLayout, s)
+	return t
+}
+
+func TestParseRange(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     Range
+		wantErr  bool
+	}{
+		{name: "default", want: Range{From: date("2025-02-14"), To: date("2025-03-15")}},
+		{name: "from only", from: "2025-03-01", want: Range{From: date("2025-03-01"), To: date("2025-03-15")}},
+		{name: "to only", to: "2025-01-31", want: Range{From: date("2025-01-02"), To: date("2025-01-31")}},
+		{name: "single day", from: "2025-03-01", to: "2025-03-01", want: Range{From: date("2025-03-01"), To: date("2025-03-01")}},
+		{name: "from after to", from: "2025-03-02", to: "2025-03-01", wantErr: true},
+		{name: "bad date", from: "01/03/2025", wantErr: true},
+		{name: "too long", from: "2020-01-01", to: "2025-01-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.from, tt.to, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRange_Days(t *testing.T) {
+	assert.Equal(t, 1, Range{From: date("2025-03-01"), To: date("2025-03-01")}.Days())
+	assert.Equal(t, DefaultDays, Range{From: date("2025-02-14"), To: date("2025-03-15")}.Days())
+}
+
+func TestParseInterval(t *testing.T) {
+	got, err := ParseInterval("")
+	assert.NoError(t, err)
+	assert.Equal(t, IntervalDay, got)
+
+	got, err = ParseInterval("month")
+	assert.NoError(t, err)
+	assert.Equal(t, IntervalMonth, got)
+
+	_, err = ParseInterval("year")
+	assert.Error(t, err)
+}
+
+func TestParseCount(t *testing.T) {
+	n, err := ParseCount("limit", "", DefaultLimit, MaxLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLimit, n)
+
+	n, err = ParseCount("limit", "1000", DefaultLimit, MaxLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, MaxLimit, n)
+
+	_, err = ParseCount("limit", "0", DefaultLimit, MaxLimit)
+	assert.Error(t, err)
+}
+
+func TestParseThreshold(t *testing.T) {
+	n, err := ParseThreshold("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultThreshold, n)
+
+	n, err = ParseThreshold("0")
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	_, err = ParseThreshold("-1")
+	assert.Error(t, err)
+}
+
+func TestSummary_Finish(t *testing.T) {
+	s := Summary{
+		Orders:    3,
+		Revenue:   money.MustParse("100.00", money.DefaultCurrency),
+		Refunded:  money.MustParse("10.00", money.DefaultCurrency),
+		Carts:     8,
+		Converted: 3,
+	}
+	s.Finish()
+
+	assert.Equal(t, "90.00", s.NetRevenue.String())
+	assert.Equal(t, "33.33", s.AverageOrderValue.String())
+	assert.Equal(t, 37.5, s.ConversionRate)
+
+	empty := Summary{}
+	empty.Finish()
+	assert.True(t, empty.AverageOrderValue.IsZero())
+	assert.Zero(t, empty.ConversionRate)
+}
+
+func TestRevenue_Finish(t *testing.T) {
+	r := Revenue{Points: []Point{
+		{Revenue: money.MustParse("50.00", money.DefaultCurrency)},
+		{Revenue: money.MustParse("200.00", money.DefaultCurrency)},
+		{},
+	}}
+	r.Finish()
+
+	assert.Equal(t, 25.0, r.Points[0].Share)
+	assert.Equal(t, 100.0, r.Points[1].Share)
+	assert.Zero(t, r.Points[2].Share)
+}
+
+func TestStock_Finish(t *testing.T) {
+	s := Stock{Stock: 6, UnitsSold: 10}
+	s.Finish(30)
+
+	assert.Equal(t, 62.5, s.SellThrough)
+	if assert.NotNil(t, s.DaysOfCover) {
+		assert.Equal(t, 18.0, *s.DaysOfCover)
+	}
+
+	unsold := Stock{Stock: 4}
+	unsold.Finish(30)
+	assert.Zero(t, unsold.SellThrough)
+	assert.Nil(t, unsold.DaysOfCover)
+}
+
+func TestStockReport_Table(t *testing.T) {
+	cover := 18.0
+	table := StockReport{Books: []Stock{
+		{Title: "Dune", Author: "Frank Herbert", Stock: 6, UnitsSold: 10, SellThrough: 62.5, DaysOfCover: &cover},
+		{Title: "Emma", Stock: 4},
+	}}.Table()
+
+	assert.Len(t, table.Rows, 2)
+	assert.Equal(t, len(table.Header), len(table.Rows[0]))
+	assert.Equal(t, []string{"Dune", "Frank Herbert", "6", "10", "62.50", "18.00"}, table.Rows[0][1:])
+	assert.Equal(t, "", table.Rows[1][6])
+}
@@ -0,0 +1,202 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+
+	MaxTitleLength = 120
+	MaxBodyLength  = 5000
+	maxNoteLength  = 500
+)
+
+var (
+	// ErrNotVerified is returned when a customer who never paid for a book
+	// tries to review it.
+	ErrNotVerified = errors.New("only customers who bought the book can review it")
+	// ErrOwnReview is returned when a customer votes for their own review.
+	ErrOwnReview = errors.New("you cannot vote for your own review")
+)
+
+type Status string
+
+const (
+	// StatusPending reviews wait for staff and are only shown to their
+	// author.
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// transitions lists every status staff may move a review to from a given
+// status. An approved review can still be taken down, and a rejected one
+// reinstated.
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusApproved, StatusRejected},
+	StatusApproved: {StatusRejected},
+	StatusRejected: {StatusApproved},
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError reports a moderation decision the workflow does not allow.
+type TransitionError struct {
+	From Status
+	To   Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("review cannot go from %s to %s", e.From, e.To)
+}
+
+// Review is a customer's rating of a book they bought, with optional text.
+// Only approved reviews count towards the book's rating.
+type Review struct {
+	ID        uuid.UUID `json:"id"`
+	BookID    uuid.UUID `json:"book_id"`
+	BookTitle string    `json:"book_title"`
+	UserID    uuid.UUID `json:"-"`
+	// Author is the reviewer's username.
+	Author string `json:"author"`
+	Rating int    `json:"rating" example:"4"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body,omitempty"`
+	Status Status `json:"status" example:"approved"`
+	// ModerationNote is what staff said when rejecting the review.
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	HelpfulCount   int        `json:"helpful_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+} // @name ReviewModel
+
+// Request writes the customer's review of a book. Writing it again replaces
+// it and sends it back to moderation.
+type Request struct {
+	Rating int    `json:"rating" example:"4"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+} // @name ReviewRequestModel
+
+func (r *Request) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Body = strings.TrimSpace(r.Body)
+}
+
+func (r Request) Validate() error {
+	switch {
+	case r.Rating < MinRating || r.Rating > MaxRating:
+		return fmt.Errorf("rating must be between %d and %d", MinRating, MaxRating)
+	case len(r.Title) > MaxTitleLength:
+		return fmt.Errorf("title must be at most %d characters", MaxTitleLength)
+	case len(r.Body) > MaxBodyLength:
+		return fmt.Errorf("body must be at most %d characters", MaxBodyLength)
+	}
+	return nil
+}
+
+type ModerateRequest struct {
+	Note string `json:"note"`
+} // @name ModerateReviewRequestModel
+
+func (r ModerateRequest) Validate() error {
+	if len(r.Note) > maxNoteLength {
+		return fmt.Errorf("note must be at most %d characters", maxNoteLength)
+	}
+	return nil
+}
+
+type StatusChange struct {
+	ReviewID uuid.UUID
+	To       Status
+	Actor    uuid.NullUUID
+	Note     string
+}
+
+// Sort is the order approved reviews of a book are listed in.
+type Sort string
+
+const (
+	SortHelpful Sort = "helpful"
+	SortRecent  Sort = "recent"
+	SortHighest Sort = "highest"
+	SortLowest  Sort = "lowest"
+)
+
+// ParseSort reads a sort from a query string, most helpful first by default.
+func ParseSort(s string) (Sort, error) {
+	switch sort := Sort(s); sort {
+	case "":
+		return SortHelpful, nil
+	case SortHelpful, SortRecent, SortHighest, SortLowest:
+		return sort, nil
+	}
+	return "", fmt.Errorf("unknown sort %q", s)
+}
+
+// Rating is the aggregate of a book's approved reviews.
+type Rating struct {
+	Average float64 `json:"average" example:"4.25"`
+	Count   int     `json:"count"`
+	// Distribution counts the approved reviews per star, 1 to 5.
+	Distribution map[int]int `json:"distribution"`
+} // @name RatingModel
+
+// NewRating builds the aggregate from how many approved reviews gave each
+// number of stars. The average is rounded to two places like the one kept on
+// the book.
+func NewRating(distribution map[int]int) Rating {
+	rating := Rating{Distribution: make(map[int]int, MaxRating)}
+	var total int
+	for stars := MinRating; stars <= MaxRating; stars++ {
+		n := distribution[stars]
+		rating.Distribution[stars] = n
+		rating.Count += n
+		total += stars * n
+	}
+	if rating.Count > 0 {
+		rating.Average = math.Round(float64(total)/float64(rating.Count)*100) / 100
+	}
+	return rating
+}
+
+// Share is the part of the reviews that gave stars, as a whole percentage,
+// for drawing distribution bars.
+func (r Rating) Share(stars int) int {
+	if r.Count == 0 {
+		return 0
+	}
+	return r.Distribution[stars] * 100 / r.Count
+}
+
+// Reviews is what a book page shows: the rating, the approved reviews and,
+// for a logged-in customer, their own review whatever its status.
+type Reviews struct {
+	BookID  uuid.UUID `json:"book_id"`
+	Rating  Rating    `json:"rating"`
+	Reviews []Review  `json:"reviews"`
+	Mine    *Review   `json:"mine,omitempty"`
+} // @name ReviewsModel
@@ -0,0 +1,79 @@
+package review
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		wantErr bool
+	}{
+		{name: "rating only", req: Request{Rating: 5}},
+		{name: "with text", req: Request{Rating: 1, Title: "Dull", Body: "Could not finish it."}},
+		{name: "no rating", req: Request{Title: "Dull"}, wantErr: true},
+		{name: "six stars", req: Request{Rating: 6}, wantErr: true},
+		{name: "title too long", req: Request{Rating: 3, Title: strings.Repeat("a", MaxTitleLength+1)}, wantErr: true},
+		{name: "body too long", req: Request{Rating: 3, Body: strings.Repeat("a", MaxBodyLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequest_Normalize(t *testing.T) {
+	req := Request{Rating: 4, Title: "  Great  ", Body: "\n Loved it \n"}
+	req.Normalize()
+
+	assert.Equal(t, Request{Rating: 4, Title: "Great", Body: "Loved it"}, req)
+}
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, StatusPending.CanTransitionTo(StatusApproved))
+	assert.True(t, StatusPending.CanTransitionTo(StatusRejected))
+	assert.True(t, StatusApproved.CanTransitionTo(StatusRejected))
+	assert.True(t, StatusRejected.CanTransitionTo(StatusApproved))
+
+	assert.False(t, StatusApproved.CanTransitionTo(StatusApproved))
+	assert.False(t, StatusApproved.CanTransitionTo(StatusPending))
+	assert.False(t, StatusRejected.CanTransitionTo(StatusRejected))
+}
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort("")
+	assert.NoError(t, err)
+	assert.Equal(t, SortHelpful, sort)
+
+	sort, err = ParseSort("recent")
+	assert.NoError(t, err)
+	assert.Equal(t, SortRecent, sort)
+
+	_, err = ParseSort("random")
+	assert.Error(t, err)
+}
+
+func TestNewRating(t *testing.T) {
+	rating := NewRating(map[int]int{5: 2, 4: 1})
+
+	assert.Equal(t, 3, rating.Count)
+	assert.Equal(t, 4.67, rating.Average)
+	assert.Equal(t, map[int]int{1: 0, 2: 0, 3: 0, 4: 1, 5: 2}, rating.Distribution)
+	assert.Equal(t, 66, rating.Share(5))
+	assert.Equal(t, 0, rating.Share(1))
+
+	empty := NewRating(nil)
+	assert.Equal(t, 0, empty.Count)
+	assert.Equal(t, 0.0, empty.Average)
+	assert.Equal(t, 0, empty.Share(5))
+}
@@ -0,0 +1,278 @@
+package rma
+
+import (
+	"errors"
+	"fmt"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+var (
+	// ErrNotReturnable is returned when a return is requested for an order
+	// that has not been delivered, or was refunded in full already.
+	ErrNotReturnable = errors.New("order cannot be returned")
+	// ErrItems is returned when a return names books the order does not have,
+	// or more copies than were bought and not yet sent back.
+	ErrItems = errors.New("items cannot be returned")
+)
+
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusReceived  Status = "received"
+)
+
+// transitions lists every status a return may move to from a given status.
+// Books are restocked and refunded only once they are back in the warehouse.
+var transitions = map[Status][]Status{
+	StatusRequested: {StatusApproved, StatusRejected},
+	StatusApproved:  {StatusReceived, StatusRejected},
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusRequested, StatusApproved, StatusRejected, StatusReceived:
+		return true
+	}
+	return false
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the books of a return are still on their way back,
+// or already are, so they cannot be asked for again.
+func (s Status) IsOpen() bool {
+	return s != StatusRejected
+}
+
+// TransitionError reports a status change the return workflow does not allow.
+type TransitionError struct {
+	From Status
+	To   Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("illegal return status transition from %q to %q", e.From, e.To)
+}
+
+type Reason string
+
+const (
+	ReasonDamaged        Reason = "damaged"
+	ReasonWrongItem      Reason = "wrong_item"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonChangedMind    Reason = "changed_mind"
+	ReasonOther          Reason = "other"
+)
+
+const maxNoteLength = 1000
+
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonDamaged, ReasonWrongItem, ReasonNotAsDescribed, ReasonChangedMind, ReasonOther:
+		return true
+	}
+	return false
+}
+
+type Return struct {
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Status  Status    `json:"status" example:"requested"`
+	Items   []Item    `json:"items"`
+	Note    string    `json:"note,omitempty"`
+	// ResolutionNote is what staff told the customer when approving or
+	// rejecting the return.
+	ResolutionNote string `json:"resolution_note,omitempty"`
+	// RefundAmount is set once the books are received, in the catalogue
+	// currency like the order totals.
+	RefundAmount money.Money   `json:"refund_amount" swaggertype:"string" example:"12.99"`
+	ResolvedBy   uuid.NullUUID `json:"-"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	ReceivedAt   *time.Time    `json:"received_at,omitempty"`
+	// RefundIssuedAt is when the refund for the received books went out. A
+	// received return with a refund owed and no issue time still has to be
+	// paid out.
+	RefundIssuedAt *time.Time `json:"refund_issued_at,omitempty"`
+} // @name ReturnModel
+
+type Item struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Title    string    `json:"title"`
+	Quantity int       `json:"quantity"`
+	Reason   Reason    `json:"reason" example:"damaged"`
+} // @name ReturnItemModel
+
+type CreateRequest struct {
+	OrderID uuid.UUID     `json:"order_id"`
+	Items   []ItemRequest `json:"items"`
+	Note    string        `json:"note"`
+} // @name CreateReturnRequestModel
+
+type ItemRequest struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Quantity int       `json:"quantity"`
+	Reason   Reason    `json:"reason" example:"damaged"`
+} // @name ReturnItemRequestModel
+
+type ResolveRequest struct {
+	Note string `json:"note"`
+} // @name ResolveReturnRequestModel
+
+type StatusChange struct {
+	ReturnID uuid.UUID
+	To       Status
+	Actor    uuid.NullUUID
+	Note     string
+}
+
+// Line is a book as it was bought: how many copies, what they cost together
+// and the tax added on top of that.
+type Line struct {
+	BookID   uuid.UUID
+	Title    string
+	Quantity int
+	Total    money.Money
+	Tax      money.Money
+}
+
+// Receipt is what taking a return back did to its order.
+type Receipt struct {
+	Return *Return
+	Order  *orderModel.Model
+	// Refund is what is owed for the books, in the catalogue currency.
+	Refund money.Money
+}
+
+func ValidateNote(note string) error {
+	if len(note) > maxNoteLength {
+		return fmt.Errorf("note must be at most %d characters", maxNoteLength)
+	}
+	return nil
+}
+
+// IsReturnable reports whether books of an order in the given status can be
+// sent back: the order has arrived and not everything was refunded yet.
+func IsReturnable(status orderModel.Status) bool {
+	return status == orderModel.StatusDelivered || status == orderModel.StatusPartiallyRefunded
+}
+
+// Returned counts, per book, the copies of an order that are on their way
+// back or already returned.
+func Returned(returns []Return) map[uuid.UUID]int {
+	returned := make(map[uuid.UUID]int)
+	for _, r := range returns {
+		if !r.Status.IsOpen() {
+			continue
+		}
+		for _, item := range r.Items {
+			returned[item.BookID] += item.Quantity
+		}
+	}
+	return returned
+}
+
+// Allocate checks a return request against what was bought and what earlier
+// returns already claimed, and names the books it sends back.
+func Allocate(lines []Line, returns []Return, req []ItemRequest) ([]Item, error) {
+	if len(req) == 0 {
+		return nil, fmt.Errorf("%w: no items to return", ErrItems)
+	}
+
+	bought := make(map[uuid.UUID]Line, len(lines))
+	for _, line := range lines {
+		bought[line.BookID] = line
+	}
+	returned := Returned(returns)
+
+	items := make([]Item, 0, len(req))
+	seen := make(map[uuid.UUID]bool, len(req))
+	for _, r := range req {
+		line, ok := bought[r.BookID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: book %s is not in the order", ErrItems, r.BookID)
+		case seen[r.BookID]:
+			return nil, fmt.Errorf("%w: book %s is listed twice", ErrItems, r.BookID)
+		case r.Quantity <= 0:
+			return nil, fmt.Errorf("%w: quantity of %q must be positive", ErrItems, line.Title)
+		case !r.Reason.IsValid():
+			return nil, fmt.Errorf("%w: unknown reason %q", ErrItems, r.Reason)
+		case r.Quantity > line.Quantity-returned[r.BookID]:
+			return nil, fmt.Errorf("%w: %d of %q bought, %d already returned",
+				ErrItems, line.Quantity, line.Title, returned[r.BookID])
+		}
+		seen[r.BookID] = true
+		items = append(items, Item{BookID: r.BookID, Title: line.Title, Quantity: r.Quantity, Reason: r.Reason})
+	}
+
+	return items, nil
+}
+
+// Refund is what the customer paid for the returned books: their price and
+// the tax added on them, less their share of the order discount. Shipping is
+// not refunded, and nothing beyond what is left of the order total is.
+func Refund(order *orderModel.Model, lines []Line, items []Item) money.Money {
+	bought := make(map[uuid.UUID]Line, len(lines))
+	for _, line := range lines {
+		bought[line.BookID] = line
+	}
+
+	var amounts []money.Money
+	for _, item := range items {
+		line, ok := bought[item.BookID]
+		if !ok || line.Quantity == 0 {
+			continue
+		}
+		value := line.Total.Ratio(int64(item.Quantity), int64(line.Quantity))
+		amounts = append(amounts, value, line.Tax.Ratio(int64(item.Quantity), int64(line.Quantity)))
+		if order.Subtotal.IsPositive() {
+			amounts = append(amounts, order.DiscountTotal.Ratio(value.Amount(), order.Subtotal.Amount()).Neg())
+		}
+	}
+	refund := money.Sum(amounts...)
+
+	left := order.TotalPrice.Sub(order.ShippingTotal).Sub(order.RefundedTotal)
+	if refund.GreaterThan(left) {
+		refund = left
+	}
+	if refund.IsNegative() {
+		refund = money.Zero(refund.Currency())
+	}
+	return refund
+}
+
+// OrderStatus is where an order stands once the given returns are received:
+// refunded when every copy came back, partially refunded otherwise.
+func OrderStatus(lines []Line, returns []Return) orderModel.Status {
+	received := make(map[uuid.UUID]int)
+	for _, r := range returns {
+		if r.Status != StatusReceived {
+			continue
+		}
+		for _, item := range r.Items {
+			received[item.BookID] += item.Quantity
+		}
+	}
+
+	for _, line := range lines {
+		if received[line.BookID] < line.Quantity {
+			return orderModel.StatusPartiallyRefunded
+		}
+	}
+	return orderModel.StatusRefunded
+}
@@ -0,0 +1,191 @@
+package rma
+
+import (
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	dune  = uuid.Must(uuid.FromString("7a1c2d3e-0000-4000-8000-000000000001"))
+	emma  = uuid.Must(uuid.FromString("7a1c2d3e-0000-4000-8000-000000000002"))
+	lines = []Line{
+		{BookID: dune, Title: "Dune", Quantity: 2, Total: usd("20.00"), Tax: usd("2.00")},
+		{BookID: emma, Title: "Emma", Quantity: 1, Total: usd("10.00"), Tax: usd("1.00")},
+	}
+)
+
+func usd(s string) money.Money {
+	return money.MustParse(s, "USD")
+}
+
+func returned(status Status, items ...Item) Return {
+	return Return{Status: status, Items: items}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		returns []Return
+		req     []ItemRequest
+		want    []Item
+		wantErr bool
+	}{
+		{
+			name: "part of a line",
+			req:  []ItemRequest{{BookID: dune, Quantity: 1, Reason: ReasonDamaged}},
+			want: []Item{{BookID: dune, Title: "Dune", Quantity: 1, Reason: ReasonDamaged}},
+		},
+		{
+			name:    "rejected returns give their books back",
+			returns: []Return{returned(StatusRejected, Item{BookID: dune, Quantity: 2})},
+			req:     []ItemRequest{{BookID: dune, Quantity: 2, Reason: ReasonChangedMind}},
+			want:    []Item{{BookID: dune, Title: "Dune", Quantity: 2, Reason: ReasonChangedMind}},
+		},
+		{
+			name:    "more than was bought",
+			req:     []ItemRequest{{BookID: emma, Quantity: 2, Reason: ReasonDamaged}},
+			wantErr: true,
+		},
+		{
+			name:    "more than is left after an earlier return",
+			returns: []Return{returned(StatusApproved, Item{BookID: dune, Quantity: 1})},
+			req:     []ItemRequest{{BookID: dune, Quantity: 2, Reason: ReasonDamaged}},
+			wantErr: true,
+		},
+		{
+			name:    "already received",
+			returns: []Return{returned(StatusReceived, Item{BookID: emma, Quantity: 1})},
+			req:     []ItemRequest{{BookID: emma, Quantity: 1, Reason: ReasonDamaged}},
+			wantErr: true,
+		},
+		{
+			name:    "book not in the order",
+			req:     []ItemRequest{{BookID: uuid.Must(uuid.NewV4()), Quantity: 1, Reason: ReasonOther}},
+			wantErr: true,
+		},
+		{
+			name:    "book listed twice",
+			req:     []ItemRequest{{BookID: dune, Quantity: 1, Reason: ReasonOther}, {BookID: dune, Quantity: 1, Reason: ReasonOther}},
+			wantErr: true,
+		},
+		{
+			name:    "zero quantity",
+			req:     []ItemRequest{{BookID: dune, Quantity: 0, Reason: ReasonOther}},
+			wantErr: true,
+		},
+		{
+			name:    "negative quantity",
+			req:     []ItemRequest{{BookID: dune, Quantity: -1, Reason: ReasonOther}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown reason",
+			req:     []ItemRequest{{BookID: dune, Quantity: 1, Reason: "bored"}},
+			wantErr: true,
+		},
+		{
+			name:    "nothing to return",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Allocate(lines, tt.returns, tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrItems)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, items)
+		})
+	}
+}
+
+func TestRefund(t *testing.T) {
+	order := &orderModel.Model{
+		Subtotal:      usd("30.00"),
+		DiscountTotal: usd("3.00"),
+		ShippingTotal: usd("5.00"),
+		TotalPrice:    usd("35.00"),
+	}
+
+	tests := []struct {
+		name     string
+		refunded string
+		items    []Item
+		want     string
+	}{
+		{
+			name:  "one copy with its tax, less its share of the discount",
+			items: []Item{{BookID: dune, Quantity: 1}},
+			want:  "10.00",
+		},
+		{
+			name:  "everything but shipping",
+			items: []Item{{BookID: dune, Quantity: 2}, {BookID: emma, Quantity: 1}},
+			want:  "30.00",
+		},
+		{
+			name:     "never more than is left",
+			refunded: "25.00",
+			items:    []Item{{BookID: dune, Quantity: 2}},
+			want:     "5.00",
+		},
+		{
+			name:  "unknown books are worth nothing",
+			items: []Item{{BookID: uuid.Must(uuid.NewV4()), Quantity: 1}},
+			want:  "0.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := *order
+			o.RefundedTotal = usd("0.00")
+			if tt.refunded != "" {
+				o.RefundedTotal = usd(tt.refunded)
+			}
+			assert.Equal(t, tt.want, Refund(&o, lines, tt.items).String())
+		})
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	oneDune := Item{BookID: dune, Quantity: 1}
+
+	assert.Equal(t, orderModel.StatusPartiallyRefunded,
+		OrderStatus(lines, []Return{returned(StatusReceived, oneDune)}))
+	assert.Equal(t, orderModel.StatusPartiallyRefunded,
+		OrderStatus(lines, []Return{
+			returned(StatusReceived, Item{BookID: dune, Quantity: 2}),
+			returned(StatusApproved, Item{BookID: emma, Quantity: 1}),
+		}))
+	assert.Equal(t, orderModel.StatusRefunded,
+		OrderStatus(lines, []Return{
+			returned(StatusReceived, oneDune),
+			returned(StatusReceived, oneDune, Item{BookID: emma, Quantity: 1}),
+		}))
+}
+
+func TestOrderCanMoveToWhatReturnsDecide(t *testing.T) {
+	for _, from := range []orderModel.Status{orderModel.StatusDelivered, orderModel.StatusPartiallyRefunded} {
+		assert.True(t, IsReturnable(from))
+		assert.True(t, from.CanTransitionTo(orderModel.StatusRefunded), "%s -> refunded", from)
+	}
+	assert.True(t, orderModel.StatusDelivered.CanTransitionTo(orderModel.StatusPartiallyRefunded))
+	assert.False(t, IsReturnable(orderModel.StatusShipped))
+	assert.False(t, IsReturnable(orderModel.StatusRefunded))
+}
+
+func TestStatusTransitions(t *testing.T) {
+	assert.True(t, StatusRequested.CanTransitionTo(StatusApproved))
+	assert.True(t, StatusApproved.CanTransitionTo(StatusReceived))
+	assert.False(t, StatusRequested.CanTransitionTo(StatusReceived))
+	assert.False(t, StatusReceived.CanTransitionTo(StatusRejected))
+	assert.False(t, StatusRejected.CanTransitionTo(StatusApproved))
+}
@@ -0,0 +1,270 @@
+package shipment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/gofrs/uuid"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotShippable is returned when shipments are created for an order
+	// that has not been paid for, or has already left the warehouse.
+	ErrNotShippable = errors.New("order cannot be shipped")
+	// ErrAllocation is returned when a shipment asks for more of a book than
+	// the order has left to pack.
+	ErrAllocation = errors.New("items not available to ship")
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+)
+
+// transitions lists every status a shipment may move to from a given status.
+// A parcel can only be called back before the carrier has it.
+var transitions = map[Status][]Status{
+	StatusPending: {StatusShipped, StatusCancelled},
+	StatusShipped: {StatusDelivered},
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError reports a status change the shipment workflow does not
+// allow.
+type TransitionError struct {
+	From Status
+	To   Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("illegal shipment status transition from %q to %q", e.From, e.To)
+}
+
+type Shipment struct {
+	ID             uuid.UUID     `json:"id"`
+	OrderID        uuid.UUID     `json:"order_id"`
+	Status         Status        `json:"status" example:"shipped"`
+	Carrier        string        `json:"carrier,omitempty" example:"ups"`
+	TrackingNumber string        `json:"tracking_number,omitempty" example:"1Z999AA10123456784"`
+	TrackingURL    string        `json:"tracking_url,omitempty"`
+	Items          []Item        `json:"items"`
+	CreatedBy      uuid.NullUUID `json:"-"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ShippedAt      *time.Time    `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time    `json:"delivered_at,omitempty"`
+} // @name ShipmentModel
+
+type Item struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Title    string    `json:"title"`
+	Quantity int       `json:"quantity"`
+} // @name ShipmentItemModel
+
+// CreateRequest lists the books going into a new parcel for an order.
+// Without items the parcel takes everything the order has left to pack.
+type CreateRequest struct {
+	OrderID uuid.UUID     `json:"order_id"`
+	Items   []ItemRequest `json:"items"`
+} // @name CreateShipmentRequestModel
+
+type ItemRequest struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Quantity int       `json:"quantity"`
+} // @name ShipmentItemRequestModel
+
+type ShipRequest struct {
+	Carrier        string `json:"carrier" example:"ups"`
+	TrackingNumber string `json:"tracking_number" example:"1Z999AA10123456784"`
+} // @name ShipShipmentRequestModel
+
+// StatusChange moves a shipment on. Carrier and TrackingNumber are recorded
+// when it ships.
+type StatusChange struct {
+	ShipmentID     uuid.UUID
+	To             Status
+	Carrier        string
+	TrackingNumber string
+}
+
+// PickList is what the warehouse needs to pack one parcel.
+type PickList struct {
+	ShipmentID     uuid.UUID         `json:"shipment_id"`
+	OrderID        uuid.UUID         `json:"order_id"`
+	ShipTo         *address.Snapshot `json:"ship_to,omitempty"`
+	DeliveryMethod string            `json:"delivery_method,omitempty"`
+	Items          []Item            `json:"items"`
+	CreatedAt      time.Time         `json:"created_at"`
+} // @name PickListModel
+
+func NewPickList(s Shipment, order *orderModel.Model) *PickList {
+	items := append([]Item(nil), s.Items...)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Title < items[j].Title
+	})
+
+	return &PickList{
+		ShipmentID:     s.ID,
+		OrderID:        s.OrderID,
+		ShipTo:         order.ShippingAddress,
+		DeliveryMethod: order.DeliveryMethod,
+		Items:          items,
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+// IsShippable reports whether parcels may still be made up for an order in
+// the given status.
+func IsShippable(status orderModel.Status) bool {
+	return status == orderModel.StatusPaid || status == orderModel.StatusFulfilled
+}
+
+// Remaining is what is left to pack of each book: what was ordered less what
+// the shipments that have not been cancelled already hold.
+func Remaining(ordered []Item, shipments []Shipment) map[uuid.UUID]int {
+	remaining := make(map[uuid.UUID]int, len(ordered))
+	for _, item := range ordered {
+		remaining[item.BookID] += item.Quantity
+	}
+	for _, s := range shipments {
+		if s.Status == StatusCancelled {
+			continue
+		}
+		for _, item := range s.Items {
+			remaining[item.BookID] -= item.Quantity
+		}
+	}
+	return remaining
+}
+
+// Allocate picks the items of a new parcel out of what the order has left to
+// pack. An empty request takes all of it.
+func Allocate(ordered []Item, shipments []Shipment, req []ItemRequest) ([]Item, error) {
+	remaining := Remaining(ordered, shipments)
+
+	titles := make(map[uuid.UUID]string, len(ordered))
+	for _, item := range ordered {
+		titles[item.BookID] = item.Title
+	}
+
+	var items []Item
+	if len(req) == 0 {
+		for _, item := range ordered {
+			if remaining[item.BookID] > 0 {
+				items = append(items, Item{BookID: item.BookID, Title: item.Title, Quantity: remaining[item.BookID]})
+				remaining[item.BookID] = 0
+			}
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("%w: everything has been packed already", ErrAllocation)
+		}
+		return items, nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req))
+	for _, r := range req {
+		title, ok := titles[r.BookID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: book %s is not in the order", ErrAllocation, r.BookID)
+		case seen[r.BookID]:
+			return nil, fmt.Errorf("%w: book %s is listed twice", ErrAllocation, r.BookID)
+		case r.Quantity <= 0:
+			return nil, fmt.Errorf("%w: quantity of %q must be positive", ErrAllocation, title)
+		case r.Quantity > remaining[r.BookID]:
+			return nil, fmt.Errorf("%w: only %d of %q left to pack", ErrAllocation, remaining[r.BookID], title)
+		}
+		seen[r.BookID] = true
+		items = append(items, Item{BookID: r.BookID, Title: title, Quantity: r.Quantity})
+	}
+
+	return items, nil
+}
+
+// OrderStatus is where an order's shipments put it: paid until every item is
+// packed, then fulfilled, shipped once every parcel has left and delivered
+// once every parcel has arrived.
+func OrderStatus(ordered []Item, shipments []Shipment) orderModel.Status {
+	for _, left := range Remaining(ordered, shipments) {
+		if left > 0 {
+			return orderModel.StatusPaid
+		}
+	}
+
+	shipped, delivered := true, true
+	for _, s := range shipments {
+		switch s.Status {
+		case StatusPending:
+			shipped, delivered = false, false
+		case StatusShipped:
+			delivered = false
+		}
+	}
+
+	switch {
+	case delivered:
+		return orderModel.StatusDelivered
+	case shipped:
+		return orderModel.StatusShipped
+	}
+	return orderModel.StatusFulfilled
+}
+
+// fulfillment is the order status chain shipments drive.
+var fulfillment = []orderModel.Status{
+	orderModel.StatusPaid,
+	orderModel.StatusFulfilled,
+	orderModel.StatusShipped,
+	orderModel.StatusDelivered,
+}
+
+// OrderPath lists the statuses an order steps through to get from one point
+// of the fulfillment chain to another. Orders only move forward, except that a
+// fulfilled order drops back to paid when a parcel is called back. Anything
+// else yields no steps.
+func OrderPath(from, to orderModel.Status) []orderModel.Status {
+	if from == orderModel.StatusFulfilled && to == orderModel.StatusPaid {
+		return []orderModel.Status{orderModel.StatusPaid}
+	}
+
+	i, j := slices.Index(fulfillment, from), slices.Index(fulfillment, to)
+	if i < 0 || j <= i {
+		return nil
+	}
+	return fulfillment[i+1 : j+1]
+}
+
+// trackingURLs are the public tracking pages of the carriers the store uses.
+var trackingURLs = map[string]string{
+	"ups":   "https://www.ups.com/track?tracknum=",
+	"usps":  "https://tools.usps.com/go/TrackConfirmAction?tLabels=",
+	"fedex": "https://www.fedex.com/fedextrack/?trknbr=",
+	"dhl":   "https://www.dhl.com/en/express/tracking.html?AWB=",
+}
+
+// TrackingURL links to the carrier's tracking page, or is empty for carriers
+// the store does not know.
+func TrackingURL(carrier, trackingNumber string) string {
+	base, ok := trackingURLs[strings.ToLower(carrier)]
+	if !ok || trackingNumber == "" {
+		return ""
+	}
+	return base + url.QueryEscape(trackingNumber)
+}
@@ -0,0 +1,142 @@
+package shipment
+
+import (
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	dune    = uuid.Must(uuid.FromString("6f1c2d3e-0000-4000-8000-000000000001"))
+	emma    = uuid.Must(uuid.FromString("6f1c2d3e-0000-4000-8000-000000000002"))
+	ordered = []Item{
+		{BookID: dune, Title: "Dune", Quantity: 2},
+		{BookID: emma, Title: "Emma", Quantity: 1},
+	}
+)
+
+func parcel(status Status, items ...Item) Shipment {
+	return Shipment{Status: status, Items: items}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name      string
+		shipments []Shipment
+		req       []ItemRequest
+		want      []Item
+		wantErr   bool
+	}{
+		{
+			name: "empty request takes everything",
+			want: ordered,
+		},
+		{
+			name:      "empty request takes what is left",
+			shipments: []Shipment{parcel(StatusShipped, Item{BookID: dune, Quantity: 1})},
+			want:      []Item{{BookID: dune, Title: "Dune", Quantity: 1}, {BookID: emma, Title: "Emma", Quantity: 1}},
+		},
+		{
+			name:      "cancelled parcels give their books back",
+			shipments: []Shipment{parcel(StatusCancelled, ordered...)},
+			req:       []ItemRequest{{BookID: emma, Quantity: 1}},
+			want:      []Item{{BookID: emma, Title: "Emma", Quantity: 1}},
+		},
+		{
+			name:      "split request within what is left",
+			shipments: []Shipment{parcel(StatusPending, Item{BookID: dune, Quantity: 1})},
+			req:       []ItemRequest{{BookID: dune, Quantity: 1}},
+			want:      []Item{{BookID: dune, Title: "Dune", Quantity: 1}},
+		},
+		{
+			name:      "more than is left",
+			shipments: []Shipment{parcel(StatusPending, Item{BookID: dune, Quantity: 1})},
+			req:       []ItemRequest{{BookID: dune, Quantity: 2}},
+			wantErr:   true,
+		},
+		{
+			name:    "book not in the order",
+			req:     []ItemRequest{{BookID: uuid.Must(uuid.NewV4()), Quantity: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "book listed twice",
+			req:     []ItemRequest{{BookID: dune, Quantity: 1}, {BookID: dune, Quantity: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "zero quantity",
+			req:     []ItemRequest{{BookID: emma, Quantity: 0}},
+			wantErr: true,
+		},
+		{
+			name:      "nothing left to pack",
+			shipments: []Shipment{parcel(StatusPending, ordered...)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Allocate(ordered, tt.shipments, tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrAllocation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, items)
+		})
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	half := Item{BookID: dune, Quantity: 1}
+	rest := []Item{{BookID: dune, Quantity: 1}, {BookID: emma, Quantity: 1}}
+
+	tests := []struct {
+		name      string
+		shipments []Shipment
+		want      orderModel.Status
+	}{
+		{name: "nothing packed", want: orderModel.StatusPaid},
+		{name: "partly packed and shipped", shipments: []Shipment{parcel(StatusShipped, half)}, want: orderModel.StatusPaid},
+		{name: "all packed", shipments: []Shipment{parcel(StatusShipped, half), parcel(StatusPending, rest...)}, want: orderModel.StatusFulfilled},
+		{name: "all shipped", shipments: []Shipment{parcel(StatusDelivered, half), parcel(StatusShipped, rest...)}, want: orderModel.StatusShipped},
+		{name: "all delivered", shipments: []Shipment{parcel(StatusDelivered, half), parcel(StatusDelivered, rest...)}, want: orderModel.StatusDelivered},
+		{name: "cancelled parcel unpacks", shipments: []Shipment{parcel(StatusShipped, half), parcel(StatusCancelled, rest...)}, want: orderModel.StatusPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OrderStatus(ordered, tt.shipments))
+		})
+	}
+}
+
+func TestOrderPath(t *testing.T) {
+	assert.Equal(t,
+		[]orderModel.Status{orderModel.StatusFulfilled, orderModel.StatusShipped, orderModel.StatusDelivered},
+		OrderPath(orderModel.StatusPaid, orderModel.StatusDelivered))
+	assert.Equal(t, []orderModel.Status{orderModel.StatusPaid}, OrderPath(orderModel.StatusFulfilled, orderModel.StatusPaid))
+	assert.Empty(t, OrderPath(orderModel.StatusShipped, orderModel.StatusPaid))
+	assert.Empty(t, OrderPath(orderModel.StatusCancelled, orderModel.StatusFulfilled))
+	assert.Empty(t, OrderPath(orderModel.StatusPaid, orderModel.StatusPaid))
+
+	for _, from := range fulfillment {
+		for _, to := range fulfillment {
+			step := from
+			for _, next := range OrderPath(from, to) {
+				assert.True(t, step.CanTransitionTo(next), "%s -> %s", step, next)
+				step = next
+			}
+		}
+	}
+}
+
+func TestTrackingURL(t *testing.T) {
+	assert.Equal(t, "https://www.ups.com/track?tracknum=1Z+99", TrackingURL("UPS", "1Z 99"))
+	assert.Empty(t, TrackingURL("pigeon", "42"))
+	assert.Empty(t, TrackingURL("ups", ""))
+}
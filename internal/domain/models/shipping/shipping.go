@@ -0,0 +1,170 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"sort"
+	"strings"
+)
+
+// ErrUnavailable is returned when a parcel cannot be sent somewhere, or not
+// with the delivery method asked for.
+var ErrUnavailable = errors.New("shipping unavailable")
+
+// Destination is where a parcel goes, as far as rates care.
+type Destination struct {
+	Country string
+	Region  string
+}
+
+func (d Destination) String() string {
+	if d.Region == "" {
+		return d.Country
+	}
+	return d.Country + "-" + d.Region
+}
+
+// Method is a delivery method the store offers, such as standard or express.
+type Method struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days"`
+}
+
+// Zone groups the places that share rates. Places are ISO country codes,
+// country-region codes such as "US-AK" for places priced apart from the rest
+// of their country, or "*" for everywhere else.
+type Zone struct {
+	Code   string   `json:"code"`
+	Places []string `json:"places"`
+}
+
+// Rate is the price of sending a parcel of up to MaxGrams with a method to a
+// zone. A rate without MaxGrams has no upper limit.
+type Rate struct {
+	Zone     string      `json:"zone"`
+	Method   string      `json:"method"`
+	MaxGrams int         `json:"max_grams,omitempty"`
+	Price    money.Money `json:"price"`
+}
+
+// Table is a shipping rates table, the shape of the shipping rates file:
+//
+//	{"methods": [{"code": "standard", "name": "Standard", "min_days": 3, "max_days": 5}],
+//	 "zones": [{"code": "domestic", "places": ["US"]}, {"code": "world", "places": ["*"]}],
+//	 "rates": [{"zone": "domestic", "method": "standard", "max_grams": 1000, "price": "4.99"},
+//	           {"zone": "domestic", "method": "standard", "price": "9.99"}]}
+type Table struct {
+	Methods []Method `json:"methods"`
+	Zones   []Zone   `json:"zones"`
+	Rates   []Rate   `json:"rates"`
+}
+
+// Quote is what sending an order with one delivery method costs.
+type Quote struct {
+	Method  string      `json:"method" example:"standard"`
+	Name    string      `json:"name" example:"Standard"`
+	Price   money.Money `json:"price" swaggertype:"string" example:"4.99"`
+	MinDays int         `json:"min_days" example:"3"`
+	MaxDays int         `json:"max_days" example:"5"`
+} // @name ShippingQuoteModel
+
+// Zone finds the zone a destination belongs to. A zone listing the region
+// wins over one listing the country, which wins over the catch-all.
+func (t Table) Zone(to Destination) (string, bool) {
+	country := strings.ToUpper(to.Country)
+	region := strings.ToUpper(to.Region)
+
+	best, bestScore := "", -1
+	for _, zone := range t.Zones {
+		for _, place := range zone.Places {
+			score := -1
+			switch strings.ToUpper(place) {
+			case "*":
+				score = 0
+			case country:
+				score = 1
+			case country + "-" + region:
+				if region != "" {
+					score = 2
+				}
+			}
+			if score > bestScore {
+				best, bestScore = zone.Code, score
+			}
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// Quotes prices a parcel of the given weight to a destination with every
+// method that goes there, cheapest first.
+func (t Table) Quotes(to Destination, grams int) ([]Quote, error) {
+	zone, ok := t.Zone(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: no delivery to %s", ErrUnavailable, to)
+	}
+
+	var quotes []Quote
+	for _, method := range t.Methods {
+		rate, ok := t.rate(zone, method.Code, grams)
+		if !ok {
+			continue
+		}
+		quotes = append(quotes, Quote{
+			Method:  method.Code,
+			Name:    method.Name,
+			Price:   rate.Price,
+			MinDays: method.MinDays,
+			MaxDays: method.MaxDays,
+		})
+	}
+
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("%w: no delivery method takes %dg to %s", ErrUnavailable, grams, to)
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Price.LessThan(quotes[j].Price)
+	})
+
+	return quotes, nil
+}
+
+// rate finds the smallest weight bracket of a method in a zone the parcel
+// fits.
+func (t Table) rate(zone, method string, grams int) (Rate, bool) {
+	best, found := Rate{}, false
+	for _, rate := range t.Rates {
+		if rate.Zone != zone || rate.Method != method {
+			continue
+		}
+		if rate.MaxGrams != 0 && grams > rate.MaxGrams {
+			continue
+		}
+		if !found || fitsTighter(rate, best) {
+			best, found = rate, true
+		}
+	}
+	return best, found
+}
+
+func fitsTighter(a, b Rate) bool {
+	if b.MaxGrams == 0 {
+		return a.MaxGrams != 0
+	}
+	return a.MaxGrams != 0 && a.MaxGrams < b.MaxGrams
+}
+
+// Find picks the quote for a delivery method.
+func Find(quotes []Quote, method string) (Quote, bool) {
+	for _, q := range quotes {
+		if q.Method == method {
+			return q, true
+		}
+	}
+	return Quote{}, false
+}
@@ -0,0 +1,118 @@
+package stockalert
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindBackInStock Kind = "back_in_stock"
+	// KindPriceBelow fires when the price drops to or under Threshold.
+	KindPriceBelow Kind = "price_below"
+)
+
+func (k Kind) IsValid() bool {
+	return k == KindBackInStock || k == KindPriceBelow
+}
+
+// Alert is a customer's wish to hear about a book. It stays in place after
+// firing, so the customer hears again the next time the book sells out and
+// comes back or its price drops under the threshold again.
+type Alert struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"-"`
+	BookID    uuid.UUID    `json:"book_id"`
+	Title     string       `json:"title"`
+	Kind      Kind         `json:"kind" example:"price_below"`
+	Threshold *money.Money `json:"threshold,omitempty" swaggertype:"string" example:"9.99"`
+	// Pending is set while the alert has fired and the customer has not been
+	// emailed yet.
+	Pending     bool       `json:"pending"`
+	Token       string     `json:"-"`
+	TriggeredAt *time.Time `json:"triggered_at,omitempty"`
+	NotifiedAt  *time.Time `json:"notified_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+} // @name StockAlertModel
+
+type Request struct {
+	BookID uuid.UUID `json:"book_id"`
+	Kind   Kind      `json:"kind" example:"price_below"`
+	// Threshold is the price to wait for. Only price_below alerts take one.
+	Threshold *money.Money `json:"threshold,omitempty" swaggertype:"string" example:"9.99"`
+} // @name StockAlertRequestModel
+
+func (r Request) Validate() error {
+	if r.BookID == uuid.Nil {
+		return errors.New("book_id is required")
+	}
+
+	switch r.Kind {
+	case KindBackInStock:
+		if r.Threshold != nil {
+			return errors.New("back_in_stock alerts take no threshold")
+		}
+	case KindPriceBelow:
+		if r.Threshold == nil || !r.Threshold.IsPositive() {
+			return errors.New("price_below alerts need a positive threshold")
+		}
+	default:
+		return fmt.Errorf("unknown alert kind %q", r.Kind)
+	}
+
+	return nil
+}
+
+// AlreadyMet reports whether the alert would fire right away for a book at
+// price with stock copies available, which makes subscribing pointless.
+func (r Request) AlreadyMet(price money.Money, stock int) error {
+	switch {
+	case r.Kind == KindBackInStock && stock > 0:
+		return errors.New("the book is in stock")
+	case r.Kind == KindPriceBelow && r.Threshold != nil && !price.GreaterThan(*r.Threshold):
+		return fmt.Errorf("the book already costs %s", price.Format())
+	}
+	return nil
+}
+
+// Fired is an alert that has fired, with the book as it is now.
+type Fired struct {
+	Alert
+	Price money.Money
+	Stock int
+}
+
+// StillMet reports whether what the alert fired for still holds. A book that
+// sold out again, or went back up in price, before the customer was emailed
+// is not worth an email.
+func (f Fired) StillMet() bool {
+	switch f.Kind {
+	case KindBackInStock:
+		return f.Stock > 0
+	case KindPriceBelow:
+		return f.Threshold != nil && !f.Price.GreaterThan(*f.Threshold)
+	}
+	return false
+}
+
+// Notice is one book in a stock alert email.
+type Notice struct {
+	Title          string
+	Kind           Kind
+	Price          money.Money
+	Threshold      *money.Money
+	UnsubscribeURL string
+}
+
+// Demand is how many customers wait for an out-of-stock book.
+type Demand struct {
+	BookID      uuid.UUID `json:"book_id"`
+	Title       string    `json:"title"`
+	Author      string    `json:"author"`
+	Stock       int       `json:"stock"`
+	Subscribers int       `json:"subscribers"`
+} // @name StockAlertDemandModel
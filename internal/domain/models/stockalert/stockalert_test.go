@@ -0,0 +1,73 @@
+package stockalert
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var book = uuid.Must(uuid.FromString("0c5a3e77-0a4f-4b8b-9b0e-6f3c1d2e4a51"))
+
+func price(s string) *money.Money {
+	m := money.MustParse(s, money.USD)
+	return &m
+}
+
+func TestRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		wantErr bool
+	}{
+		{name: "back in stock", req: Request{BookID: book, Kind: KindBackInStock}},
+		{name: "price below", req: Request{BookID: book, Kind: KindPriceBelow, Threshold: price("9.99")}},
+		{name: "no book", req: Request{Kind: KindBackInStock}, wantErr: true},
+		{name: "unknown kind", req: Request{BookID: book, Kind: "signed_copy"}, wantErr: true},
+		{name: "price below without threshold", req: Request{BookID: book, Kind: KindPriceBelow}, wantErr: true},
+		{name: "price below zero", req: Request{BookID: book, Kind: KindPriceBelow, Threshold: price("0")}, wantErr: true},
+		{name: "back in stock with threshold", req: Request{BookID: book, Kind: KindBackInStock, Threshold: price("5")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequest_AlreadyMet(t *testing.T) {
+	backInStock := Request{BookID: book, Kind: KindBackInStock}
+	assert.Error(t, backInStock.AlreadyMet(*price("12.00"), 3))
+	assert.NoError(t, backInStock.AlreadyMet(*price("12.00"), 0))
+
+	priceBelow := Request{BookID: book, Kind: KindPriceBelow, Threshold: price("10.00")}
+	assert.NoError(t, priceBelow.AlreadyMet(*price("12.00"), 0))
+	assert.Error(t, priceBelow.AlreadyMet(*price("10.00"), 0))
+	assert.Error(t, priceBelow.AlreadyMet(*price("8.00"), 0))
+}
+
+func TestFired_StillMet(t *testing.T) {
+	tests := []struct {
+		name  string
+		fired Fired
+		want  bool
+	}{
+		{name: "back in stock", fired: Fired{Alert: Alert{Kind: KindBackInStock}, Stock: 2}, want: true},
+		{name: "sold out again", fired: Fired{Alert: Alert{Kind: KindBackInStock}, Stock: 0}},
+		{name: "price still low", fired: Fired{Alert: Alert{Kind: KindPriceBelow, Threshold: price("10.00")}, Price: *price("9.50")}, want: true},
+		{name: "price at threshold", fired: Fired{Alert: Alert{Kind: KindPriceBelow, Threshold: price("10.00")}, Price: *price("10.00")}, want: true},
+		{name: "price back up", fired: Fired{Alert: Alert{Kind: KindPriceBelow, Threshold: price("10.00")}, Price: *price("11.00")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fired.StillMet())
+		})
+	}
+}
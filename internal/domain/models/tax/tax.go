@@ -0,0 +1,292 @@
+package tax
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"strconv"
+	"strings"
+)
+
+var ErrNoJurisdiction = errors.New("no tax jurisdiction")
+
+// Class is how a product is taxed. Most goods are standard rated; many
+// jurisdictions tax books at a reduced rate or not at all.
+type Class string
+
+const (
+	ClassStandard Class = "standard"
+	ClassReduced  Class = "reduced"
+	ClassZero     Class = "zero"
+)
+
+func (c Class) IsValid() bool {
+	switch c {
+	case ClassStandard, ClassReduced, ClassZero:
+		return true
+	}
+	return false
+}
+
+// ParseClass reads a tax class; empty means standard.
+func ParseClass(s string) (Class, error) {
+	class := Class(strings.ToLower(strings.TrimSpace(s)))
+	if class == "" {
+		return ClassStandard, nil
+	}
+	if !class.IsValid() {
+		return "", fmt.Errorf("unknown tax class %q", s)
+	}
+	return class, nil
+}
+
+// UnmarshalJSON rejects unknown classes so they fail at the API boundary.
+// An empty class stays empty and is stored as standard.
+func (c *Class) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	if text == "" {
+		*c = ""
+		return nil
+	}
+	class, err := ParseClass(text)
+	if err != nil {
+		return err
+	}
+	*c = class
+	return nil
+}
+
+// Jurisdiction is where an order is taxed: an ISO 3166-1 country code and,
+// where taxes differ inside the country, an ISO 3166-2 subdivision code
+// without the country prefix, "NY" rather than "US-NY".
+type Jurisdiction struct {
+	Country string `json:"country" example:"US"`
+	Region  string `json:"region,omitempty" example:"NY"`
+} // @name TaxJurisdictionModel
+
+func (j Jurisdiction) Normalize() Jurisdiction {
+	return Jurisdiction{
+		Country: strings.ToUpper(strings.TrimSpace(j.Country)),
+		Region:  strings.ToUpper(strings.TrimSpace(j.Region)),
+	}
+}
+
+func (j Jurisdiction) String() string {
+	if j.Region == "" {
+		return j.Country
+	}
+	return j.Country + "-" + j.Region
+}
+
+// Rule is one rate in a tax table. A rule with a region applies only there,
+// one without applies to the whole country; a rule with a class applies only
+// to that class, one without to every class. Region rates are the combined
+// rate charged there, not a surcharge on the country rate.
+type Rule struct {
+	Country string `json:"country"`
+	Region  string `json:"region,omitempty"`
+	Class   Class  `json:"class,omitempty"`
+	// Name is what the tax is called on invoices, "VAT" or "Sales tax".
+	Name string `json:"name"`
+	Rate Rate   `json:"rate"`
+	// Inclusive rules are for jurisdictions where shelf prices already
+	// include the tax; it is worked out of the price instead of added on top.
+	Inclusive bool `json:"inclusive"`
+}
+
+// Table is a set of tax rules, the shape of the tax rates file:
+//
+//	{"rules": [{"country": "GB", "name": "VAT", "rate": "20", "inclusive": true},
+//	           {"country": "GB", "class": "zero", "name": "VAT", "rate": "0", "inclusive": true}]}
+type Table struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Match finds the rule for goods of a class in a jurisdiction. The most
+// specific rule wins: region and class, then region, then country and class,
+// then country. There is no rule where the store does not collect tax.
+func (t Table) Match(j Jurisdiction, class Class) (Rule, bool) {
+	j = j.Normalize()
+
+	best, bestScore := Rule{}, -1
+	for _, rule := range t.Rules {
+		if !strings.EqualFold(rule.Country, j.Country) {
+			continue
+		}
+		if rule.Region != "" && !strings.EqualFold(rule.Region, j.Region) {
+			continue
+		}
+		if rule.Class != "" && rule.Class != class {
+			continue
+		}
+
+		score := 0
+		if rule.Region != "" {
+			score += 2
+		}
+		if rule.Class != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// Line is an order line as the tax calculator sees it. Amount is what the
+// line costs after discounts, so tax is never charged on money taken off.
+type Line struct {
+	ItemID uuid.UUID
+	BookID uuid.UUID
+	Class  Class
+	Amount money.Money
+}
+
+// LineTax is the tax charged on one order line.
+type LineTax struct {
+	ItemID       uuid.UUID   `json:"item_id"`
+	BookID       uuid.UUID   `json:"book_id"`
+	Name         string      `json:"name" example:"VAT"`
+	Jurisdiction string      `json:"jurisdiction" example:"GB"`
+	Class        Class       `json:"class" example:"standard"`
+	Rate         Rate        `json:"rate" swaggertype:"string" example:"20"`
+	Taxable      money.Money `json:"taxable" swaggertype:"string" example:"12.50"`
+	Amount       money.Money `json:"amount" swaggertype:"string" example:"2.08"`
+	Inclusive    bool        `json:"inclusive"`
+} // @name TaxLineModel
+
+// Result is the tax on an order. Included is the part of Total already in
+// the line prices; the rest is added to what the customer pays.
+type Result struct {
+	Jurisdiction Jurisdiction
+	Lines        []LineTax
+	Total        money.Money
+	Included     money.Money
+}
+
+// Added is the tax charged on top of the line prices.
+func (r Result) Added() money.Money {
+	return r.Total.Sub(r.Included)
+}
+
+// rateScale is the number of decimal places of a percent rates are kept to,
+// enough for rates such as 8.875%.
+const rateScale = 4
+
+const percentUnit = 10_000
+
+// Rate is a tax rate as a percentage, "20" or "8.875", kept as a fixed-point
+// decimal.
+type Rate struct {
+	units int64
+}
+
+// ParseRate reads a percentage between 0 and 100.
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > rateScale || !isDigits(whole) || (frac != "" && !isDigits(frac)) {
+		return Rate{}, fmt.Errorf("invalid tax rate %q", s)
+	}
+
+	units, err := strconv.ParseInt(whole+frac+strings.Repeat("0", rateScale-len(frac)), 10, 64)
+	if err != nil || units > 100*percentUnit {
+		return Rate{}, fmt.Errorf("tax rate %q must be between 0 and 100", s)
+	}
+
+	return Rate{units: units}, nil
+}
+
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (r Rate) IsZero() bool {
+	return r.units == 0
+}
+
+// Of is the tax at this rate on a net amount.
+func (r Rate) Of(amount money.Money) money.Money {
+	return amount.Ratio(r.units, 100*percentUnit)
+}
+
+// IncludedIn is the tax at this rate contained in a gross amount.
+func (r Rate) IncludedIn(amount money.Money) money.Money {
+	return amount.Ratio(r.units, 100*percentUnit+r.units)
+}
+
+func (r Rate) String() string {
+	text := strconv.FormatInt(r.units/percentUnit, 10)
+	if frac := r.units % percentUnit; frac != 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%04d", frac), "0")
+	}
+	return text
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts the rate as a string or as a JSON number.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC column.
+func (r *Rate) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("cannot scan %T into a tax rate", src)
+	}
+
+	parsed, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package tax
+
+import (
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	for in, want := range map[string]string{"20": "20", "8.875": "8.875", "5.50": "5.5", "0": "0", "7%": "7", "100": "100"} {
+		rate, err := ParseRate(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, rate.String())
+	}
+
+	for _, in := range []string{"", "-1", "100.01", "1.23456", "abc", ".5"} {
+		_, err := ParseRate(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestRateScan(t *testing.T) {
+	var rate Rate
+	require.NoError(t, rate.Scan([]byte("8.8750")))
+	assert.Equal(t, "8.875", rate.String())
+
+	require.NoError(t, rate.Scan(int64(20)))
+	assert.Equal(t, "20", rate.String())
+}
+
+func TestRateOfAndIncludedIn(t *testing.T) {
+	rate := MustParseRate("20")
+
+	assert.Equal(t, "2.00", rate.Of(money.MustParse("10.00", money.USD)).String())
+	assert.Equal(t, "2.00", rate.IncludedIn(money.MustParse("12.00", money.USD)).String())
+	assert.Equal(t, "0.00", MustParseRate("0").IncludedIn(money.MustParse("12.00", money.USD)).String())
+}
+
+func TestMatchPrefersTheMostSpecificRule(t *testing.T) {
+	table := Table{Rules: []Rule{
+		{Country: "CA", Name: "GST", Rate: MustParseRate("5")},
+		{Country: "CA", Class: ClassZero, Name: "GST", Rate: MustParseRate("0")},
+		{Country: "CA", Region: "ON", Name: "HST", Rate: MustParseRate("13")},
+		{Country: "CA", Region: "ON", Class: ClassReduced, Name: "GST", Rate: MustParseRate("5")},
+	}}
+
+	tests := []struct {
+		jurisdiction Jurisdiction
+		class        Class
+		want         string
+	}{
+		{Jurisdiction{Country: "CA", Region: "ON"}, ClassReduced, "GST 5"},
+		{Jurisdiction{Country: "CA", Region: "ON"}, ClassStandard, "HST 13"},
+		{Jurisdiction{Country: "CA", Region: "ON"}, ClassZero, "HST 13"},
+		{Jurisdiction{Country: "CA", Region: "BC"}, ClassZero, "GST 0"},
+		{Jurisdiction{Country: "CA"}, ClassStandard, "GST 5"},
+	}
+	for _, tt := range tests {
+		rule, ok := table.Match(tt.jurisdiction, tt.class)
+		require.True(t, ok)
+		assert.Equal(t, tt.want, rule.Name+" "+rule.Rate.String(), "%s %s", tt.jurisdiction, tt.class)
+	}
+
+	_, ok := table.Match(Jurisdiction{Country: "US"}, ClassStandard)
+	assert.False(t, ok)
+}
+
+func TestParseClass(t *testing.T) {
+	class, err := ParseClass("")
+	require.NoError(t, err)
+	assert.Equal(t, ClassStandard, class)
+
+	class, err = ParseClass(" Reduced ")
+	require.NoError(t, err)
+	assert.Equal(t, ClassReduced, class)
+
+	_, err = ParseClass("luxury")
+	assert.Error(t, err)
+}
+
+func TestClassUnmarshalJSON(t *testing.T) {
+	var book struct {
+		TaxClass Class `json:"tax_class"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"tax_class":"Reduced"}`), &book))
+	assert.Equal(t, ClassReduced, book.TaxClass)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"tax_class":""}`), &book))
+	assert.Equal(t, Class(""), book.TaxClass)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"tax_class":"luxury"}`), &book))
+}
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/gofrs/uuid"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>" where
+	// the HMAC is taken with the endpoint's secret over "<t>.<raw body>".
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// TestEventType is sent by the "send test event" action. Endpoints cannot
+// subscribe to it; it goes only to the endpoint being tested.
+const TestEventType event.Type = "webhook.test"
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+type Endpoint struct {
+	ID                  uuid.UUID    `json:"id"`
+	URL                 string       `json:"url"`
+	Secret              string       `json:"secret,omitempty"`
+	EventTypes          []event.Type `json:"event_types" swaggertype:"array,string"`
+	Enabled             bool         `json:"enabled"`
+	DisabledReason      string       `json:"disabled_reason"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	CreatedAt           time.Time    `json:"created_at"`
+	UpdatedAt           time.Time    `json:"updated_at"`
+} // @name WebhookEndpointModel
+
+type EndpointRequest struct {
+	URL string `json:"url" example:"https://erp.example.com/hooks/bookstore"`
+	// Secret signs the deliveries. Left empty on create, one is generated;
+	// left empty on update, the current one is kept.
+	Secret     string       `json:"secret"`
+	EventTypes []event.Type `json:"event_types" swaggertype:"array,string" example:"order.paid,book.price_changed"`
+	// Enabled switches the endpoint on or off. Switching it back on clears
+	// its failure count.
+	Enabled *bool `json:"enabled"`
+} // @name WebhookEndpointRequestModel
+
+const maxURLLength = 2048
+
+func (r EndpointRequest) Validate() error {
+	if len(r.URL) > maxURLLength {
+		return errors.New("url is too long")
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	if len(r.EventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for _, t := range r.EventTypes {
+		if !t.IsKnown() {
+			return fmt.Errorf("unknown event type %q", t)
+		}
+	}
+
+	return nil
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed is final: the delivery ran out of attempts, or was a
+	// test that failed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+type Delivery struct {
+	ID            uuid.UUID       `json:"id"`
+	EndpointID    uuid.UUID       `json:"endpoint_id"`
+	EventID       uuid.NullUUID   `json:"event_id" swaggertype:"string"`
+	EventType     event.Type      `json:"event_type"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        DeliveryStatus  `json:"status"`
+	Attempts      int             `json:"attempts"`
+	ResponseCode  *int            `json:"response_code,omitempty"`
+	ResponseBody  string          `json:"response_body"`
+	LastError     string          `json:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+} // @name WebhookDeliveryModel
+
+// Outgoing is a delivery claimed for sending, with where it goes.
+type Outgoing struct {
+	Delivery Delivery
+	URL      string
+	Secret   string
+}
+
+// Envelope is the JSON body of every delivery.
+type Envelope struct {
+	ID         uuid.UUID       `json:"id"`
+	EventID    uuid.NullUUID   `json:"event_id"`
+	Type       event.Type      `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (d Delivery) Envelope() Envelope {
+	return Envelope{
+		ID:         d.ID,
+		EventID:    d.EventID,
+		Type:       d.EventType,
+		OccurredAt: d.CreatedAt.UTC(),
+		Data:       d.Payload,
+	}
+}
+
+// Result is what came back from one attempt to send a delivery. Err is set
+// when no response was received at all.
+type Result struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (r Result) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Error describes a failed attempt for the delivery log.
+func (r Result) Error() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if r.Succeeded() {
+		return ""
+	}
+	return fmt.Sprintf("endpoint answered %d", r.StatusCode)
+}
+
+// Attempt is what the delivery log records about one send.
+type Attempt struct {
+	DeliveryID    uuid.UUID
+	EndpointID    uuid.UUID
+	Result        Result
+	Attempts      int
+	NextAttemptAt time.Time
+	// Final marks the last attempt the delivery gets.
+	Final bool
+	// Test attempts leave the endpoint's failure count alone.
+	Test bool
+}
+
+// Sign returns the signature header value of body sent at t.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + mac(secret, ts, body)
+}
+
+// Verify checks a signature header against body, rejecting signatures made
+// more than tolerance away from now so captured deliveries cannot be
+// replayed later.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return ErrInvalidSignature
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(mac(secret, ts, body)), []byte(sig)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
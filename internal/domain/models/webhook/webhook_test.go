@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpointRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     EndpointRequest
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			req:  EndpointRequest{URL: "https://erp.example.com/hooks", EventTypes: []event.Type{"order.paid", event.TypeBookPriceChanged}},
+		},
+		{
+			name:    "relative url",
+			req:     EndpointRequest{URL: "/hooks", EventTypes: []event.Type{"order.paid"}},
+			wantErr: true,
+		},
+		{
+			name:    "not http",
+			req:     EndpointRequest{URL: "ftp://erp.example.com/hooks", EventTypes: []event.Type{"order.paid"}},
+			wantErr: true,
+		},
+		{
+			name:    "no event types",
+			req:     EndpointRequest{URL: "https://erp.example.com/hooks"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown event type",
+			req:     EndpointRequest{URL: "https://erp.example.com/hooks", EventTypes: []event.Type{"order.lost"}},
+			wantErr: true,
+		},
+		{
+			name:    "test events cannot be subscribed to",
+			req:     EndpointRequest{URL: "https://erp.example.com/hooks", EventTypes: []event.Type{TestEventType}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"type":"order.paid"}`)
+	sentAt := time.Unix(1700000000, 0)
+	header := Sign("whsec_test", sentAt, body)
+
+	require.True(t, strings.HasPrefix(header, "t=1700000000,v1="))
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "valid", secret: "whsec_test", header: header, body: body, now: sentAt.Add(time.Minute)},
+		{name: "wrong secret", secret: "whsec_other", header: header, body: body, now: sentAt, wantErr: true},
+		{name: "tampered body", secret: "whsec_test", header: header, body: []byte(`{"type":"order.cancelled"}`), now: sentAt, wantErr: true},
+		{name: "replayed later", secret: "whsec_test", header: header, body: body, now: sentAt.Add(time.Hour), wantErr: true},
+		{name: "garbage header", secret: "whsec_test", header: "nonsense", body: body, now: sentAt, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSignature)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	assert.True(t, Result{StatusCode: 204}.Succeeded())
+	assert.Equal(t, "", Result{StatusCode: 200}.Error())
+
+	assert.False(t, Result{StatusCode: 500}.Succeeded())
+	assert.Equal(t, "endpoint answered 500", Result{StatusCode: 500}.Error())
+
+	failed := Result{Err: errors.New("connection refused")}
+	assert.False(t, failed.Succeeded())
+	assert.Equal(t, "connection refused", failed.Error())
+}
@@ -0,0 +1,101 @@
+package wishlist
+
+import (
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the longest name a wishlist can have, in characters.
+const MaxNameLength = 100
+
+// Wishlist is a named list of books a customer wants, kept apart from the
+// cart so nothing is reserved. A shared wishlist can be viewed, and bought
+// from, by anyone with its share link.
+type Wishlist struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"-"`
+	Name   string    `json:"name" example:"Birthday"`
+	Shared bool      `json:"shared"`
+	// ShareToken identifies the wishlist in its share link. It is only
+	// shown to the owner, and only while the wishlist is shared.
+	ShareToken string `json:"share_token,omitempty"`
+	// Owner is the owner's username, set on shared wishlists viewed by others.
+	Owner     string    `json:"owner,omitempty"`
+	Items     []Item    `json:"items"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name WishlistModel
+
+// Item is a book on a wishlist, with its current price and stock.
+type Item struct {
+	BookID  uuid.UUID   `json:"book_id"`
+	Title   string      `json:"title"`
+	Author  string      `json:"author"`
+	Price   money.Money `json:"price" swaggertype:"string" example:"12.99"`
+	Stock   int         `json:"stock"`
+	AddedAt time.Time   `json:"added_at"`
+} // @name WishlistItemModel
+
+// Contains reports whether the book is on the wishlist.
+func (w Wishlist) Contains(bookID uuid.UUID) bool {
+	for _, item := range w.Items {
+		if item.BookID == bookID {
+			return true
+		}
+	}
+	return false
+}
+
+type Request struct {
+	Name   string `json:"name" example:"Birthday"`
+	Shared bool   `json:"shared"`
+} // @name WishlistRequestModel
+
+// Normalize trims the name.
+func (r *Request) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+func (r Request) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(r.Name) > MaxNameLength {
+		return errors.New("name is too long")
+	}
+	return nil
+}
+
+type ItemRequest struct {
+	BookID uuid.UUID `json:"book_id"`
+} // @name WishlistItemRequestModel
+
+func (r ItemRequest) Validate() error {
+	if r.BookID == uuid.Nil {
+		return errors.New("book_id is required")
+	}
+	return nil
+}
+
+// MoveToCartRequest says how many copies of a wishlist book go into the cart.
+type MoveToCartRequest struct {
+	Quantity int `json:"quantity" example:"1"`
+} // @name WishlistMoveToCartRequestModel
+
+// Normalize defaults the quantity to one copy.
+func (r *MoveToCartRequest) Normalize() {
+	if r.Quantity == 0 {
+		r.Quantity = 1
+	}
+}
+
+func (r MoveToCartRequest) Validate() error {
+	if r.Quantity < 1 {
+		return errors.New("quantity must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package wishlist
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		wantErr bool
+	}{
+		{name: "valid", req: Request{Name: "  Birthday  "}},
+		{name: "blank name", req: Request{Name: "   "}, wantErr: true},
+		{name: "longest name", req: Request{Name: strings.Repeat("ж", MaxNameLength)}},
+		{name: "name too long", req: Request{Name: strings.Repeat("a", MaxNameLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Normalize()
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequest_Normalize(t *testing.T) {
+	req := Request{Name: "  Birthday  "}
+	req.Normalize()
+	assert.Equal(t, "Birthday", req.Name)
+}
+
+func TestItemRequest_Validate(t *testing.T) {
+	assert.Error(t, ItemRequest{}.Validate())
+	assert.NoError(t, ItemRequest{BookID: uuid.Must(uuid.NewV4())}.Validate())
+}
+
+func TestMoveToCartRequest(t *testing.T) {
+	req := MoveToCartRequest{}
+	req.Normalize()
+	assert.Equal(t, 1, req.Quantity)
+	assert.NoError(t, req.Validate())
+
+	assert.Error(t, MoveToCartRequest{Quantity: -2}.Validate())
+}
+
+func TestWishlist_Contains(t *testing.T) {
+	book := uuid.Must(uuid.NewV4())
+	w := Wishlist{Items: []Item{{BookID: book}}}
+
+	assert.True(t, w.Contains(book))
+	assert.False(t, w.Contains(uuid.Must(uuid.NewV4())))
+}
@@ -0,0 +1,64 @@
+package address
+
+import (
+	"database/sql"
+	addressHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	addressRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/address"
+	addressSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/address"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *addressHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *addressSvc.Service
+	svcOnce sync.Once
+
+	repo     *addressRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.AddressHandler), new(*addressHdl.Handler)),
+	wire.Bind(new(interfaces.AddressService), new(*addressSvc.Service)),
+	wire.Bind(new(interfaces.AddressRepository), new(*addressRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.AddressService, log *slog.Logger) *addressHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &addressHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.AddressRepository) *addressSvc.Service {
+	svcOnce.Do(func() {
+		svc = &addressSvc.Service{
+			AddressRepo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *addressRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &addressRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
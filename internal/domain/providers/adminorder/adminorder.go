@@ -0,0 +1,76 @@
+package adminorder
+
+import (
+	"database/sql"
+	adminOrderHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/adminorder"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	adminOrderRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/adminorder"
+	adminOrderSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/adminorder"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *adminOrderHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *adminOrderSvc.Service
+	svcOnce sync.Once
+
+	repo     *adminOrderRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.AdminOrderHandler), new(*adminOrderHdl.Handler)),
+	wire.Bind(new(interfaces.AdminOrderService), new(*adminOrderSvc.Service)),
+	wire.Bind(new(interfaces.AdminOrderRepository), new(*adminOrderRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.AdminOrderService, log *slog.Logger) *adminOrderHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &adminOrderHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(
+	repo interfaces.AdminOrderRepository,
+	orderRepo interfaces.OrderRepository,
+	orderSvc interfaces.OrderService,
+	paymentRepo interfaces.PaymentRepository,
+	shipmentRepo interfaces.ShipmentRepository,
+	notifications interfaces.NotificationService,
+) *adminOrderSvc.Service {
+	svcOnce.Do(func() {
+		svc = &adminOrderSvc.Service{
+			AdminOrderRepo: repo,
+			OrderRepo:      orderRepo,
+			OrderSvc:       orderSvc,
+			PaymentRepo:    paymentRepo,
+			ShipmentRepo:   shipmentRepo,
+			Notifications:  notifications,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *adminOrderRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &adminOrderRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	authHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/auth"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	authRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/auth"
 	authSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/auth"
 	"github.com/google/wire"
@@ -32,11 +33,13 @@ var ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.AuthRepository), new(*authRepo.Repository)),
 )
 
-func ProvideSetHandler(svc interfaces.AuthService, log *slog.Logger) *authHdl.Handler {
+func ProvideSetHandler(svc interfaces.AuthService, guests interfaces.GuestService, guest *middle.Guest, log *slog.Logger) *authHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &authHdl.Handler{
-			Svc: svc,
-			Log: log,
+			Svc:    svc,
+			Guests: guests,
+			Guest:  guest,
+			Log:    log,
 		}
 	})
 
@@ -54,10 +57,11 @@ func ProvideSetService(repo interfaces.AuthRepository, userRepo interfaces.UserR
 	return svc
 }
 
-func ProvideSetRepository(db *sql.DB) *authRepo.Repository {
+func ProvideSetRepository(db *sql.DB, eventRepo interfaces.EventRepository) *authRepo.Repository {
 	repoOnce.Do(func() {
 		repo = &authRepo.Repository{
-			DB: db,
+			DB:     db,
+			Events: eventRepo,
 		}
 	})
 
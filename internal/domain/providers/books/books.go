@@ -53,10 +53,12 @@ func ProvideSetService(repo interfaces.BookRepository) *bookSvc.Service {
 	return svc
 }
 
-func ProvideSetRepository(db *sql.DB) *bookRepo.Repository {
+func ProvideSetRepository(db *sql.DB, inventoryRepo interfaces.InventoryRepository, eventRepo interfaces.EventRepository) *bookRepo.Repository {
 	repoOnce.Do(func() {
 		repo = &bookRepo.Repository{
-			DB: db,
+			DB:        db,
+			Inventory: inventoryRepo,
+			Events:    eventRepo,
 		}
 	})
 
@@ -0,0 +1,75 @@
+package currency
+
+import (
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/exchange/remote"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/exchange/static"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	curSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/currency"
+	"github.com/google/wire"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	svc     *curSvc.Service
+	svcOnce sync.Once
+
+	mw     *middle.Currency
+	mwOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetService,
+	ProvideMiddleware,
+	ProvideExchangeRateProvider,
+
+	wire.Bind(new(interfaces.CurrencyService), new(*curSvc.Service)),
+)
+
+func ProvideSetService(rates interfaces.ExchangeRateProvider, cfg *config.Config) *curSvc.Service {
+	svcOnce.Do(func() {
+		svc = &curSvc.Service{
+			Rates:               rates,
+			SupportedCurrencies: cfg.Currency.Supported,
+		}
+	})
+
+	return svc
+}
+
+func ProvideMiddleware(cfg *config.Config) *middle.Currency {
+	mwOnce.Do(func() {
+		mw = &middle.Currency{
+			Supported: cfg.Currency.Supported,
+		}
+	})
+
+	return mw
+}
+
+// ProvideExchangeRateProvider picks where exchange rates come from.
+func ProvideExchangeRateProvider(cfg *config.Config) (interfaces.ExchangeRateProvider, error) {
+	switch cfg.Currency.RatesProvider {
+	case static.Name:
+		provider, err := static.Load(cfg.Currency.RatesFile)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case remote.Name:
+		if cfg.Currency.RatesURL == "" {
+			return nil, fmt.Errorf("exchange rates provider %q needs EXCHANGE_RATES_URL", remote.Name)
+		}
+		return &remote.Provider{
+			URL:    cfg.Currency.RatesURL,
+			Client: &http.Client{Timeout: 10 * time.Second},
+			TTL:    cfg.Currency.RatesTTL,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rates provider %q", cfg.Currency.RatesProvider)
+	}
+}
@@ -0,0 +1,108 @@
+package event
+
+import (
+	"database/sql"
+	eventHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	eventRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/event"
+	eventSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/event"
+	notificationSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/notification"
+	stockAlertSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/stockalert"
+	webhookSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/webhook"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *eventHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *eventSvc.Service
+	svcOnce sync.Once
+
+	repo     *eventRepo.Repository
+	repoOnce sync.Once
+
+	dispatcher     *eventSvc.Dispatcher
+	dispatcherOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideDispatcher,
+	ProvideSubscribers,
+
+	wire.Bind(new(interfaces.EventHandler), new(*eventHdl.Handler)),
+	wire.Bind(new(interfaces.EventService), new(*eventSvc.Service)),
+	wire.Bind(new(interfaces.EventRepository), new(*eventRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.EventService, log *slog.Logger) *eventHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &eventHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.EventRepository, cfg *config.Config) *eventSvc.Service {
+	svcOnce.Do(func() {
+		svc = &eventSvc.Service{
+			EventRepo:  repo,
+			StuckAfter: cfg.Events.StuckAfter,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *eventRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &eventRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+// ProvideSubscribers lists everything that reacts to domain events. A new
+// subscriber is registered by taking it as a parameter here, provided by its
+// own provider set, and appending it to the list.
+func ProvideSubscribers(log *slog.Logger, webhooks *webhookSvc.Subscriber, emails *notificationSvc.Subscriber,
+	stockAlerts *stockAlertSvc.Watcher) eventSvc.Subscribers {
+	return eventSvc.Subscribers{
+		&eventSvc.LogSubscriber{Log: log},
+		webhooks,
+		emails,
+		stockAlerts,
+	}
+}
+
+func ProvideDispatcher(repo interfaces.EventRepository, subscribers eventSvc.Subscribers, cfg *config.Config, log *slog.Logger) *eventSvc.Dispatcher {
+	dispatcherOnce.Do(func() {
+		dispatcher = &eventSvc.Dispatcher{
+			EventRepo:   repo,
+			Subscribers: subscribers,
+			Retry: model.RetryPolicy{
+				MaxAttempts: cfg.Events.MaxAttempts,
+				BaseDelay:   cfg.Events.RetryBaseDelay,
+				MaxDelay:    cfg.Events.RetryMaxDelay,
+			},
+			Lease:     cfg.Events.Lease,
+			Every:     cfg.Events.DispatchInterval,
+			BatchSize: cfg.Events.BatchSize,
+			Log:       log,
+		}
+	})
+
+	return dispatcher
+}
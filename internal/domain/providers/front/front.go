@@ -3,6 +3,7 @@ package front
 import (
 	frontHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/front"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	frontSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/front"
 	"github.com/google/wire"
 	"html/template"
@@ -27,26 +28,40 @@ var ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.FrontService), new(*frontSvc.Service)),
 )
 
-func ProvideSetHandler(svc interfaces.FrontService, svcUser interfaces.UserService, log *slog.Logger) *frontHdl.Handler {
+func ProvideSetHandler(svc interfaces.FrontService, svcUser interfaces.UserService, idempotency *middle.Idempotency, currency *middle.Currency, guest *middle.Guest, guests interfaces.GuestService, log *slog.Logger) *frontHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &frontHdl.Handler{
-			Svc:     svc,
-			SvcUser: svcUser,
-			Log:     log,
+			Svc:         svc,
+			SvcUser:     svcUser,
+			Idempotency: idempotency,
+			Currency:    currency,
+			Guest:       guest,
+			Guests:      guests,
+			Log:         log,
 		}
 	})
 
 	return hdl
 }
 
-func ProvideSetService(userRepo interfaces.UserRepository, authRepo interfaces.AuthRepository, bookRepo interfaces.BookRepository, orderRepo interfaces.OrderRepository, templates map[string]*template.Template) *frontSvc.Service {
+func ProvideSetService(userRepo interfaces.UserRepository, authRepo interfaces.AuthRepository, bookRepo interfaces.BookRepository, orderRepo interfaces.OrderRepository, orderSvc interfaces.OrderService, shipmentRepo interfaces.ShipmentRepository, shipmentSvc interfaces.ShipmentService, returnRepo interfaces.ReturnRepository, currencySvc interfaces.CurrencyService, wishlistSvc interfaces.WishlistService, reviewSvc interfaces.ReviewService, recommendationSvc interfaces.RecommendationService, reportSvc interfaces.ReportService, adminOrderSvc interfaces.AdminOrderService, templates map[string]*template.Template) *frontSvc.Service {
 	svcOnce.Do(func() {
 		svc = &frontSvc.Service{
-			UserRepo:  userRepo,
-			AuthRepo:  authRepo,
-			BookRepo:  bookRepo,
-			OrderRepo: orderRepo,
-			Templates: templates,
+			UserRepo:          userRepo,
+			AuthRepo:          authRepo,
+			BookRepo:          bookRepo,
+			OrderRepo:         orderRepo,
+			OrderSvc:          orderSvc,
+			ShipmentRepo:      shipmentRepo,
+			ShipmentSvc:       shipmentSvc,
+			ReturnRepo:        returnRepo,
+			CurrencySvc:       currencySvc,
+			WishlistSvc:       wishlistSvc,
+			ReviewSvc:         reviewSvc,
+			RecommendationSvc: recommendationSvc,
+			ReportSvc:         reportSvc,
+			AdminOrderSvc:     adminOrderSvc,
+			Templates:         templates,
 		}
 	})
 
@@ -61,5 +76,11 @@ func ProvideSetTemplates() map[string]*template.Template {
 		"registration": template.Must(template.ParseFiles("templates/registration.html")),
 		"admin":        template.Must(template.ParseFiles("templates/admin.html")),
 		"history":      template.Must(template.ParseFiles("templates/history.html")),
+		"addresses":    template.Must(template.ParseFiles("templates/addresses.html")),
+		"picklist":     template.Must(template.ParseFiles("templates/picklist.html")),
+		"wishlist":     template.Must(template.ParseFiles("templates/wishlist.html")),
+		"book":         template.Must(template.ParseFiles("templates/book.html")),
+		"reports":      template.Must(template.ParseFiles("templates/reports.html")),
+		"orders":       template.Must(template.ParseFiles("templates/orders.html")),
 	}
 }
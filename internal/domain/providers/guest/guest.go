@@ -0,0 +1,114 @@
+package guest
+
+import (
+	"database/sql"
+	"errors"
+	guestHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	guestRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/guest"
+	guestSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/guest"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *guestHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *guestSvc.Service
+	svcOnce sync.Once
+
+	repo     *guestRepo.Repository
+	repoOnce sync.Once
+
+	mw     *middle.Guest
+	mwOnce sync.Once
+
+	purger     *guestSvc.CartPurger
+	purgerOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideMiddleware,
+	ProvideCartPurger,
+
+	wire.Bind(new(interfaces.GuestHandler), new(*guestHdl.Handler)),
+	wire.Bind(new(interfaces.GuestService), new(*guestSvc.Service)),
+	wire.Bind(new(interfaces.GuestRepository), new(*guestRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.GuestService, guest *middle.Guest, idempotency *middle.Idempotency, currency *middle.Currency, log *slog.Logger) *guestHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &guestHdl.Handler{
+			Svc:         svc,
+			Guest:       guest,
+			Idempotency: idempotency,
+			Currency:    currency,
+			Log:         log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.GuestRepository, orderRepo interfaces.OrderRepository, orderSvc interfaces.OrderService, addressSvc interfaces.AddressService) *guestSvc.Service {
+	svcOnce.Do(func() {
+		svc = &guestSvc.Service{
+			GuestRepo: repo,
+			OrderRepo: orderRepo,
+			OrderSvc:  orderSvc,
+			Addresses: addressSvc,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *guestRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &guestRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+// ProvideMiddleware refuses to start without a secret to sign the guest cart
+// cookie with: an empty key would let anyone forge a cookie for another
+// guest's cart.
+func ProvideMiddleware(repo interfaces.GuestRepository, cfg *config.Config, log *slog.Logger) (*middle.Guest, error) {
+	if cfg.Cart.GuestSecret == "" {
+		return nil, errors.New("guest cart secret is not set: set CART_GUEST_SECRET or SECRET_KEY_AUTH")
+	}
+
+	mwOnce.Do(func() {
+		mw = &middle.Guest{
+			Store:  repo,
+			Secret: []byte(cfg.Cart.GuestSecret),
+			TTL:    cfg.Cart.GuestTTL,
+			Log:    log,
+		}
+	})
+
+	return mw, nil
+}
+
+func ProvideCartPurger(repo interfaces.GuestRepository, cfg *config.Config) *guestSvc.CartPurger {
+	purgerOnce.Do(func() {
+		purger = &guestSvc.CartPurger{
+			GuestRepo: repo,
+			TTL:       cfg.Cart.GuestTTL,
+			Every:     cfg.Cart.GuestPurgeInterval,
+			BatchSize: cfg.Cart.GuestPurgeBatchSize,
+		}
+	})
+
+	return purger
+}
@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	idemRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/idempotency"
+	idemSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/idempotency"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	mw     *middle.Idempotency
+	mwOnce sync.Once
+
+	repo     *idemRepo.Repository
+	repoOnce sync.Once
+
+	sweeper     *idemSvc.KeySweeper
+	sweeperOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideMiddleware,
+	ProvideSetRepository,
+	ProvideKeySweeper,
+
+	wire.Bind(new(interfaces.IdempotencyRepository), new(*idemRepo.Repository)),
+)
+
+func ProvideMiddleware(repo interfaces.IdempotencyRepository, cfg *config.Config, log *slog.Logger) *middle.Idempotency {
+	mwOnce.Do(func() {
+		mw = &middle.Idempotency{
+			Store: repo,
+			TTL:   cfg.Idempotency.TTL,
+			Log:   log,
+		}
+	})
+
+	return mw
+}
+
+func ProvideSetRepository(db *sql.DB) *idemRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &idemRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+func ProvideKeySweeper(repo interfaces.IdempotencyRepository, cfg *config.Config) *idemSvc.KeySweeper {
+	sweeperOnce.Do(func() {
+		sweeper = &idemSvc.KeySweeper{
+			Store:     repo,
+			Every:     cfg.Idempotency.SweepInterval,
+			BatchSize: cfg.Idempotency.SweepBatchSize,
+		}
+	})
+
+	return sweeper
+}
@@ -0,0 +1,65 @@
+package inventory
+
+import (
+	"database/sql"
+	invHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	invRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/inventory"
+	invSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/inventory"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *invHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *invSvc.Service
+	svcOnce sync.Once
+
+	repo     *invRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.InventoryHandler), new(*invHdl.Handler)),
+	wire.Bind(new(interfaces.InventoryService), new(*invSvc.Service)),
+	wire.Bind(new(interfaces.InventoryRepository), new(*invRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.InventoryService, log *slog.Logger) *invHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &invHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.InventoryRepository) *invSvc.Service {
+	svcOnce.Do(func() {
+		svc = &invSvc.Service{
+			InventoryRepo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB, eventRepo interfaces.EventRepository) *invRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &invRepo.Repository{
+			DB:     db,
+			Events: eventRepo,
+		}
+	})
+
+	return repo
+}
@@ -0,0 +1,55 @@
+package invoice
+
+import (
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	invoiceRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/invoice"
+	invoiceSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/invoice"
+	"github.com/google/wire"
+	"sync"
+)
+
+var (
+	svc     *invoiceSvc.Service
+	svcOnce sync.Once
+
+	repo     *invoiceRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.InvoiceService), new(*invoiceSvc.Service)),
+	wire.Bind(new(interfaces.InvoiceRepository), new(*invoiceRepo.Repository)),
+)
+
+func ProvideSetService(repo interfaces.InvoiceRepository, orderRepo interfaces.OrderRepository, cfg *config.Config) *invoiceSvc.Service {
+	svcOnce.Do(func() {
+		svc = &invoiceSvc.Service{
+			InvoiceRepo: repo,
+			OrderRepo:   orderRepo,
+			Prefix:      cfg.Invoice.Prefix,
+			Seller: model.Seller{
+				Name:    cfg.Invoice.SellerName,
+				Address: cfg.Invoice.SellerAddress,
+				TaxID:   cfg.Invoice.SellerTaxID,
+			},
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *invoiceRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &invoiceRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/jobs"
+	eventSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/event"
+	guestSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/guest"
+	idemSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/idempotency"
+	notificationSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/notification"
+	ordSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/order"
+	paymentSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/payment"
+	recommendationSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/recommendation"
+	stockAlertSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/stockalert"
+	webhookSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/webhook"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	runner     *jobs.Runner
+	runnerOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideRunner,
+)
+
+func ProvideRunner(log *slog.Logger, sweeper *ordSvc.ReservationSweeper, keySweeper *idemSvc.KeySweeper, dispatcher *eventSvc.Dispatcher, webhookSender *webhookSvc.Dispatcher,
+	emailSender *notificationSvc.Sender, alertDigest *stockAlertSvc.Digest, recommendationBuilder *recommendationSvc.Builder, guestPurger *guestSvc.CartPurger,
+	refundRetrier *paymentSvc.RefundRetrier) *jobs.Runner {
+	runnerOnce.Do(func() {
+		runner = &jobs.Runner{
+			Jobs: []jobs.Job{
+				sweeper,
+				keySweeper,
+				dispatcher,
+				webhookSender,
+				emailSender,
+				alertDigest,
+				recommendationBuilder,
+				guestPurger,
+				refundRetrier,
+			},
+			Log: log,
+		}
+	})
+
+	return runner
+}
@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"database/sql"
+	notificationHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/notification"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/mail/filesink"
+	notificationRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/notification"
+	notificationSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/notification"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *notificationHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *notificationSvc.Service
+	svcOnce sync.Once
+
+	repo     *notificationRepo.Repository
+	repoOnce sync.Once
+
+	mailer     *filesink.Mailer
+	mailerOnce sync.Once
+
+	subscriber     *notificationSvc.Subscriber
+	subscriberOnce sync.Once
+
+	sender     *notificationSvc.Sender
+	senderOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideMailer,
+	ProvideSubscriber,
+	ProvideSender,
+
+	wire.Bind(new(interfaces.NotificationHandler), new(*notificationHdl.Handler)),
+	wire.Bind(new(interfaces.NotificationService), new(*notificationSvc.Service)),
+	wire.Bind(new(interfaces.NotificationRepository), new(*notificationRepo.Repository)),
+	wire.Bind(new(interfaces.Mailer), new(*filesink.Mailer)),
+)
+
+func ProvideSetHandler(svc interfaces.NotificationService, log *slog.Logger) *notificationHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &notificationHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.NotificationRepository, orderRepo interfaces.OrderRepository) *notificationSvc.Service {
+	svcOnce.Do(func() {
+		svc = &notificationSvc.Service{
+			NotificationRepo: repo,
+			OrderRepo:        orderRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *notificationRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &notificationRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+// ProvideMailer writes emails to files. A mailer that talks to a real mail
+// server is swapped in here.
+func ProvideMailer(cfg *config.Config) *filesink.Mailer {
+	mailerOnce.Do(func() {
+		mailer = &filesink.Mailer{
+			Dir: cfg.Mail.SinkDir,
+		}
+	})
+
+	return mailer
+}
+
+func ProvideSubscriber(repo interfaces.NotificationRepository, orderRepo interfaces.OrderRepository, shipmentRepo interfaces.ShipmentRepository) *notificationSvc.Subscriber {
+	subscriberOnce.Do(func() {
+		subscriber = &notificationSvc.Subscriber{
+			NotificationRepo: repo,
+			OrderRepo:        orderRepo,
+			ShipmentRepo:     shipmentRepo,
+		}
+	})
+
+	return subscriber
+}
+
+func ProvideSender(repo interfaces.NotificationRepository, mailer interfaces.Mailer, cfg *config.Config, log *slog.Logger) *notificationSvc.Sender {
+	senderOnce.Do(func() {
+		sender = &notificationSvc.Sender{
+			NotificationRepo: repo,
+			Mailer:           mailer,
+			From:             cfg.Mail.From,
+			Retry: event.RetryPolicy{
+				MaxAttempts: cfg.Mail.MaxAttempts,
+				BaseDelay:   cfg.Mail.RetryBaseDelay,
+				MaxDelay:    cfg.Mail.RetryMaxDelay,
+			},
+			Lease:     cfg.Mail.Lease,
+			Every:     cfg.Mail.SendInterval,
+			BatchSize: cfg.Mail.BatchSize,
+			Log:       log,
+		}
+	})
+
+	return sender
+}
@@ -3,7 +3,10 @@ package order
 import (
 	"database/sql"
 	ordHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
 	ordRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/order"
 	ordSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/order"
 	"github.com/google/wire"
@@ -20,45 +23,83 @@ var (
 
 	repo     *ordRepo.Repository
 	repoOnce sync.Once
+
+	sweeper     *ordSvc.ReservationSweeper
+	sweeperOnce sync.Once
 )
 
 var ProviderSet wire.ProviderSet = wire.NewSet(
 	ProvideUserHandler,
 	ProvideUserService,
 	ProvideUserRepository,
+	ProvideReservationSweeper,
 
 	wire.Bind(new(interfaces.OrderHandler), new(*ordHdl.Handler)),
 	wire.Bind(new(interfaces.OrderService), new(*ordSvc.Service)),
 	wire.Bind(new(interfaces.OrderRepository), new(*ordRepo.Repository)),
 )
 
-func ProvideUserHandler(svc interfaces.OrderService, log *slog.Logger) *ordHdl.Handler {
+func ProvideUserHandler(svc interfaces.OrderService, shipments interfaces.ShipmentService, invoices interfaces.InvoiceService, idempotency *middle.Idempotency, currency *middle.Currency, guest *middle.Guest, log *slog.Logger) *ordHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &ordHdl.Handler{
-			Svc: svc,
-			Log: log,
+			Svc:         svc,
+			Shipments:   shipments,
+			Invoices:    invoices,
+			Idempotency: idempotency,
+			Currency:    currency,
+			Guest:       guest,
+			Log:         log,
 		}
 	})
 
 	return hdl
 }
 
-func ProvideUserService(repo interfaces.OrderRepository) *ordSvc.Service {
+func ProvideUserService(repo interfaces.OrderRepository, promotions interfaces.PromotionRepository, payments interfaces.PaymentService, currency interfaces.CurrencyService, taxes interfaces.TaxCalculator, addresses interfaces.AddressRepository, rates interfaces.ShippingRateProvider, cfg *config.Config, log *slog.Logger) *ordSvc.Service {
 	svcOnce.Do(func() {
 		svc = &ordSvc.Service{
-			OrderRepo: repo,
+			OrderRepo:  repo,
+			Promotions: promotions,
+			Payments:   payments,
+			Currency:   currency,
+			Tax:        taxes,
+			Addresses:  addresses,
+			Shipping:   rates,
+			TaxJurisdiction: tax.Jurisdiction{
+				Country: cfg.Tax.Country,
+				Region:  cfg.Tax.Region,
+			}.Normalize(),
+			Log: log,
 		}
 	})
 
 	return svc
 }
 
-func ProvideUserRepository(db *sql.DB) *ordRepo.Repository {
+func ProvideUserRepository(db *sql.DB, inventoryRepo interfaces.InventoryRepository, invoiceRepo interfaces.InvoiceRepository, eventRepo interfaces.EventRepository,
+	refundRepo interfaces.RefundRepository, cfg *config.Config) *ordRepo.Repository {
 	repoOnce.Do(func() {
 		repo = &ordRepo.Repository{
-			DB: db,
+			DB:             db,
+			Inventory:      inventoryRepo,
+			Invoices:       invoiceRepo,
+			Events:         eventRepo,
+			Refunds:        refundRepo,
+			ReservationTTL: cfg.Cart.ReservationTTL,
 		}
 	})
 
 	return repo
 }
+
+func ProvideReservationSweeper(repo interfaces.OrderRepository, cfg *config.Config) *ordSvc.ReservationSweeper {
+	sweeperOnce.Do(func() {
+		sweeper = &ordSvc.ReservationSweeper{
+			OrderRepo: repo,
+			Every:     cfg.Cart.SweepInterval,
+			BatchSize: cfg.Cart.SweepBatchSize,
+		}
+	})
+
+	return sweeper
+}
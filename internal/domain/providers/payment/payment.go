@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"database/sql"
+	"fmt"
+	payHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/payment/fake"
+	payRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/payment"
+	paySvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/payment"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *payHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *paySvc.Service
+	svcOnce sync.Once
+
+	repo     *payRepo.Repository
+	repoOnce sync.Once
+
+	retrier     *paySvc.RefundRetrier
+	retrierOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvidePaymentProvider,
+	ProvideRefundRetrier,
+
+	wire.Bind(new(interfaces.PaymentHandler), new(*payHdl.Handler)),
+	wire.Bind(new(interfaces.PaymentService), new(*paySvc.Service)),
+	wire.Bind(new(interfaces.PaymentRepository), new(*payRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.PaymentService, log *slog.Logger) *payHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &payHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(provider interfaces.PaymentProvider, paymentRepo interfaces.PaymentRepository, refundRepo interfaces.RefundRepository, cfg *config.Config, log *slog.Logger) *paySvc.Service {
+	svcOnce.Do(func() {
+		svc = &paySvc.Service{
+			Provider:    provider,
+			PaymentRepo: paymentRepo,
+			RefundRepo:  refundRepo,
+			Retry: event.RetryPolicy{
+				MaxAttempts: cfg.Payment.RefundMaxAttempts,
+				BaseDelay:   cfg.Payment.RefundRetryBaseDelay,
+				MaxDelay:    cfg.Payment.RefundRetryMaxDelay,
+			},
+			RefundLease: cfg.Payment.RefundLease,
+			Log:         log,
+		}
+	})
+
+	return svc
+}
+
+func ProvideRefundRetrier(svc *paySvc.Service, cfg *config.Config) *paySvc.RefundRetrier {
+	retrierOnce.Do(func() {
+		retrier = &paySvc.RefundRetrier{
+			Payments:  svc,
+			Every:     cfg.Payment.RefundInterval,
+			BatchSize: cfg.Payment.RefundBatchSize,
+		}
+	})
+
+	return retrier
+}
+
+func ProvideSetRepository(db *sql.DB, orderRepo interfaces.OrderRepository, refundRepo interfaces.RefundRepository) *payRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &payRepo.Repository{
+			DB:      db,
+			Orders:  orderRepo,
+			Refunds: refundRepo,
+		}
+	})
+
+	return repo
+}
+
+// ProvidePaymentProvider picks the provider checkout charges through.
+func ProvidePaymentProvider(cfg *config.Config) (interfaces.PaymentProvider, error) {
+	switch cfg.Payment.Provider {
+	case fake.Name:
+		return &fake.Provider{
+			Secret:      []byte(cfg.Payment.WebhookSecret),
+			DeclineOver: cfg.Payment.DeclineOver,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider)
+	}
+}
@@ -0,0 +1,64 @@
+package promotion
+
+import (
+	"database/sql"
+	promoHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	promoRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/promotion"
+	promoSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/promotion"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *promoHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *promoSvc.Service
+	svcOnce sync.Once
+
+	repo     *promoRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.PromotionHandler), new(*promoHdl.Handler)),
+	wire.Bind(new(interfaces.PromotionService), new(*promoSvc.Service)),
+	wire.Bind(new(interfaces.PromotionRepository), new(*promoRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.PromotionService, log *slog.Logger) *promoHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &promoHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.PromotionRepository) *promoSvc.Service {
+	svcOnce.Do(func() {
+		svc = &promoSvc.Service{
+			PromotionRepo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *promoRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &promoRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
@@ -0,0 +1,86 @@
+package recommendation
+
+import (
+	"database/sql"
+	recommendationHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	middle "github.com/TeslaMode1X/DockerWireAPI/internal/middleware"
+	recommendationRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/recommendation"
+	recommendationSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/recommendation"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *recommendationHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *recommendationSvc.Service
+	svcOnce sync.Once
+
+	repo     *recommendationRepo.Repository
+	repoOnce sync.Once
+
+	builder     *recommendationSvc.Builder
+	builderOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideBuilder,
+
+	wire.Bind(new(interfaces.RecommendationHandler), new(*recommendationHdl.Handler)),
+	wire.Bind(new(interfaces.RecommendationService), new(*recommendationSvc.Service)),
+	wire.Bind(new(interfaces.RecommendationRepository), new(*recommendationRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.RecommendationService, guest *middle.Guest, log *slog.Logger) *recommendationHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &recommendationHdl.Handler{
+			Svc:   svc,
+			Guest: guest,
+			Log:   log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.RecommendationRepository, bookRepo interfaces.BookRepository, orderRepo interfaces.OrderRepository) *recommendationSvc.Service {
+	svcOnce.Do(func() {
+		svc = &recommendationSvc.Service{
+			RecommendationRepo: repo,
+			BookRepo:           bookRepo,
+			OrderRepo:          orderRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *recommendationRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &recommendationRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+func ProvideBuilder(repo interfaces.RecommendationRepository, log *slog.Logger, cfg *config.Config) *recommendationSvc.Builder {
+	builderOnce.Do(func() {
+		builder = &recommendationSvc.Builder{
+			RecommendationRepo: repo,
+			Log:                log,
+			Every:              cfg.Recommendation.RebuildInterval,
+			PerBook:            cfg.Recommendation.Neighbours,
+		}
+	})
+
+	return builder
+}
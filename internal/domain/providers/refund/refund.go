@@ -0,0 +1,30 @@
+package refund
+
+import (
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	refundRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/refund"
+	"github.com/google/wire"
+	"sync"
+)
+
+var (
+	repo     *refundRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.RefundRepository), new(*refundRepo.Repository)),
+)
+
+func ProvideSetRepository(db *sql.DB) *refundRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &refundRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
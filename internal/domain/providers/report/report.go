@@ -0,0 +1,64 @@
+package report
+
+import (
+	"database/sql"
+	reportHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	reportRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/report"
+	reportSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/report"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *reportHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *reportSvc.Service
+	svcOnce sync.Once
+
+	repo     *reportRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.ReportHandler), new(*reportHdl.Handler)),
+	wire.Bind(new(interfaces.ReportService), new(*reportSvc.Service)),
+	wire.Bind(new(interfaces.ReportRepository), new(*reportRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.ReportService, log *slog.Logger) *reportHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &reportHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.ReportRepository) *reportSvc.Service {
+	svcOnce.Do(func() {
+		svc = &reportSvc.Service{
+			ReportRepo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *reportRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &reportRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
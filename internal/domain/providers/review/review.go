@@ -0,0 +1,65 @@
+package review
+
+import (
+	"database/sql"
+	reviewHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	reviewRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/review"
+	reviewSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/review"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *reviewHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *reviewSvc.Service
+	svcOnce sync.Once
+
+	repo     *reviewRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.ReviewHandler), new(*reviewHdl.Handler)),
+	wire.Bind(new(interfaces.ReviewService), new(*reviewSvc.Service)),
+	wire.Bind(new(interfaces.ReviewRepository), new(*reviewRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.ReviewService, log *slog.Logger) *reviewHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &reviewHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.ReviewRepository, bookRepo interfaces.BookRepository) *reviewSvc.Service {
+	svcOnce.Do(func() {
+		svc = &reviewSvc.Service{
+			ReviewRepo: repo,
+			BookRepo:   bookRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *reviewRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &reviewRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
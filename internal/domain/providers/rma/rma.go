@@ -0,0 +1,70 @@
+package rma
+
+import (
+	"database/sql"
+	rmaHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	rmaRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/rma"
+	rmaSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/rma"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *rmaHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *rmaSvc.Service
+	svcOnce sync.Once
+
+	repo     *rmaRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.ReturnHandler), new(*rmaHdl.Handler)),
+	wire.Bind(new(interfaces.ReturnService), new(*rmaSvc.Service)),
+	wire.Bind(new(interfaces.ReturnRepository), new(*rmaRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.ReturnService, log *slog.Logger) *rmaHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &rmaHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.ReturnRepository, payments interfaces.PaymentService, log *slog.Logger) *rmaSvc.Service {
+	svcOnce.Do(func() {
+		svc = &rmaSvc.Service{
+			ReturnRepo: repo,
+			Payments:   payments,
+			Log:        log,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB, inventoryRepo interfaces.InventoryRepository, orderRepo interfaces.OrderRepository,
+	refundRepo interfaces.RefundRepository) *rmaRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &rmaRepo.Repository{
+			DB:        db,
+			Inventory: inventoryRepo,
+			Orders:    orderRepo,
+			Refunds:   refundRepo,
+		}
+	})
+
+	return repo
+}
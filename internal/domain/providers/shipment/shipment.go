@@ -0,0 +1,65 @@
+package shipment
+
+import (
+	"database/sql"
+	shipHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	shipRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/shipment"
+	shipSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/shipment"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *shipHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *shipSvc.Service
+	svcOnce sync.Once
+
+	repo     *shipRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.ShipmentHandler), new(*shipHdl.Handler)),
+	wire.Bind(new(interfaces.ShipmentService), new(*shipSvc.Service)),
+	wire.Bind(new(interfaces.ShipmentRepository), new(*shipRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.ShipmentService, log *slog.Logger) *shipHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &shipHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.ShipmentRepository, orderRepo interfaces.OrderRepository) *shipSvc.Service {
+	svcOnce.Do(func() {
+		svc = &shipSvc.Service{
+			ShipmentRepo: repo,
+			OrderRepo:    orderRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *shipRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &shipRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
@@ -0,0 +1,31 @@
+package shipping
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/shipping/table"
+	"github.com/google/wire"
+	"sync"
+)
+
+var (
+	rates     *table.Provider
+	ratesOnce sync.Once
+	ratesErr  error
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideShippingRateProvider,
+)
+
+// ProvideShippingRateProvider loads the shipping rates table once.
+func ProvideShippingRateProvider(cfg *config.Config) (interfaces.ShippingRateProvider, error) {
+	ratesOnce.Do(func() {
+		rates, ratesErr = table.Load(cfg.Shipping.RatesFile)
+	})
+	if ratesErr != nil {
+		return nil, ratesErr
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,98 @@
+package stockalert
+
+import (
+	"database/sql"
+	stockAlertHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	stockAlertRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/stockalert"
+	stockAlertSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/stockalert"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *stockAlertHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *stockAlertSvc.Service
+	svcOnce sync.Once
+
+	repo     *stockAlertRepo.Repository
+	repoOnce sync.Once
+
+	watcher     *stockAlertSvc.Watcher
+	watcherOnce sync.Once
+
+	digest     *stockAlertSvc.Digest
+	digestOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideWatcher,
+	ProvideDigest,
+
+	wire.Bind(new(interfaces.StockAlertHandler), new(*stockAlertHdl.Handler)),
+	wire.Bind(new(interfaces.StockAlertService), new(*stockAlertSvc.Service)),
+	wire.Bind(new(interfaces.StockAlertRepository), new(*stockAlertRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.StockAlertService, log *slog.Logger) *stockAlertHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &stockAlertHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.StockAlertRepository, bookRepo interfaces.BookRepository) *stockAlertSvc.Service {
+	svcOnce.Do(func() {
+		svc = &stockAlertSvc.Service{
+			StockAlertRepo: repo,
+			BookRepo:       bookRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *stockAlertRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &stockAlertRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+func ProvideWatcher(repo interfaces.StockAlertRepository) *stockAlertSvc.Watcher {
+	watcherOnce.Do(func() {
+		watcher = &stockAlertSvc.Watcher{
+			StockAlertRepo: repo,
+		}
+	})
+
+	return watcher
+}
+
+func ProvideDigest(repo interfaces.StockAlertRepository, notificationRepo interfaces.NotificationRepository, cfg *config.Config) *stockAlertSvc.Digest {
+	digestOnce.Do(func() {
+		digest = &stockAlertSvc.Digest{
+			StockAlertRepo:   repo,
+			NotificationRepo: notificationRepo,
+			BaseURL:          cfg.Mail.BaseURL,
+			Every:            cfg.Mail.AlertInterval,
+			BatchSize:        500,
+		}
+	})
+
+	return digest
+}
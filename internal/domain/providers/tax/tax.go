@@ -0,0 +1,31 @@
+package tax
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/tax/table"
+	"github.com/google/wire"
+	"sync"
+)
+
+var (
+	calc     *table.Calculator
+	calcOnce sync.Once
+	calcErr  error
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideTaxCalculator,
+)
+
+// ProvideTaxCalculator loads the tax rules table once.
+func ProvideTaxCalculator(cfg *config.Config) (interfaces.TaxCalculator, error) {
+	calcOnce.Do(func() {
+		calc, calcErr = table.Load(cfg.Tax.RatesFile)
+	})
+	if calcErr != nil {
+		return nil, calcErr
+	}
+
+	return calc, nil
+}
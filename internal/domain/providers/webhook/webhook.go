@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"database/sql"
+	webhookHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/config"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	webhookRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/webhook"
+	webhookSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/webhook/client"
+	"github.com/google/wire"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+var (
+	hdl     *webhookHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *webhookSvc.Service
+	svcOnce sync.Once
+
+	repo     *webhookRepo.Repository
+	repoOnce sync.Once
+
+	sender     *client.Sender
+	senderOnce sync.Once
+
+	subscriber     *webhookSvc.Subscriber
+	subscriberOnce sync.Once
+
+	dispatcher     *webhookSvc.Dispatcher
+	dispatcherOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+	ProvideSender,
+	ProvideSubscriber,
+	ProvideDispatcher,
+
+	wire.Bind(new(interfaces.WebhookHandler), new(*webhookHdl.Handler)),
+	wire.Bind(new(interfaces.WebhookService), new(*webhookSvc.Service)),
+	wire.Bind(new(interfaces.WebhookRepository), new(*webhookRepo.Repository)),
+	wire.Bind(new(interfaces.WebhookSender), new(*client.Sender)),
+)
+
+func ProvideSetHandler(svc interfaces.WebhookService, log *slog.Logger) *webhookHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &webhookHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.WebhookRepository, sender interfaces.WebhookSender) *webhookSvc.Service {
+	svcOnce.Do(func() {
+		svc = &webhookSvc.Service{
+			WebhookRepo: repo,
+			Sender:      sender,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *webhookRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &webhookRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
+
+func ProvideSender(cfg *config.Config) *client.Sender {
+	senderOnce.Do(func() {
+		sender = &client.Sender{
+			Client: &http.Client{Timeout: cfg.Webhook.Timeout},
+		}
+	})
+
+	return sender
+}
+
+func ProvideSubscriber(repo interfaces.WebhookRepository) *webhookSvc.Subscriber {
+	subscriberOnce.Do(func() {
+		subscriber = &webhookSvc.Subscriber{
+			WebhookRepo: repo,
+		}
+	})
+
+	return subscriber
+}
+
+func ProvideDispatcher(repo interfaces.WebhookRepository, sender interfaces.WebhookSender, cfg *config.Config, log *slog.Logger) *webhookSvc.Dispatcher {
+	dispatcherOnce.Do(func() {
+		dispatcher = &webhookSvc.Dispatcher{
+			WebhookRepo: repo,
+			Sender:      sender,
+			Retry: event.RetryPolicy{
+				MaxAttempts: cfg.Webhook.MaxAttempts,
+				BaseDelay:   cfg.Webhook.RetryBaseDelay,
+				MaxDelay:    cfg.Webhook.RetryMaxDelay,
+			},
+			Lease:        cfg.Webhook.Lease,
+			Every:        cfg.Webhook.SendInterval,
+			BatchSize:    cfg.Webhook.BatchSize,
+			DisableAfter: cfg.Webhook.DisableAfter,
+			Log:          log,
+		}
+	})
+
+	return dispatcher
+}
@@ -0,0 +1,67 @@
+package wishlist
+
+import (
+	"database/sql"
+	wishlistHdl "github.com/TeslaMode1X/DockerWireAPI/internal/api/handler/wishlist"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	wishlistRepo "github.com/TeslaMode1X/DockerWireAPI/internal/repository/wishlist"
+	wishlistSvc "github.com/TeslaMode1X/DockerWireAPI/internal/service/wishlist"
+	"github.com/google/wire"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *wishlistHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *wishlistSvc.Service
+	svcOnce sync.Once
+
+	repo     *wishlistRepo.Repository
+	repoOnce sync.Once
+)
+
+var ProviderSet = wire.NewSet(
+	ProvideSetHandler,
+	ProvideSetService,
+	ProvideSetRepository,
+
+	wire.Bind(new(interfaces.WishlistHandler), new(*wishlistHdl.Handler)),
+	wire.Bind(new(interfaces.WishlistService), new(*wishlistSvc.Service)),
+	wire.Bind(new(interfaces.WishlistRepository), new(*wishlistRepo.Repository)),
+)
+
+func ProvideSetHandler(svc interfaces.WishlistService, log *slog.Logger) *wishlistHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &wishlistHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSetService(repo interfaces.WishlistRepository, bookRepo interfaces.BookRepository, orderRepo interfaces.OrderRepository, orderSvc interfaces.OrderService) *wishlistSvc.Service {
+	svcOnce.Do(func() {
+		svc = &wishlistSvc.Service{
+			WishlistRepo: repo,
+			BookRepo:     bookRepo,
+			OrderRepo:    orderRepo,
+			OrderSvc:     orderSvc,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSetRepository(db *sql.DB) *wishlistRepo.Repository {
+	repoOnce.Do(func() {
+		repo = &wishlistRepo.Repository{
+			DB: db,
+		}
+	})
+
+	return repo
+}
@@ -0,0 +1,102 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/exchange"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const Name = "http"
+
+// Provider fetches exchange rates from an HTTP endpoint that answers GET with
+// an exchange.Table as JSON. The table is cached for TTL; when a refresh
+// fails the last table is kept in use rather than failing checkout.
+type Provider struct {
+	URL    string
+	Client *http.Client
+	TTL    time.Duration
+
+	mu    sync.Mutex
+	table *exchange.Table
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) Rate(ctx context.Context, from, to money.Currency) (money.Rate, error) {
+	const op = "exchange.remote.Rate"
+
+	table, err := p.current(ctx)
+	if err != nil {
+		return money.Rate{}, errors.Wrap(err, op)
+	}
+
+	rate, err := table.Rate(from, to)
+	if err != nil {
+		return money.Rate{}, errors.Wrap(err, op)
+	}
+
+	return rate, nil
+}
+
+func (p *Provider) current(ctx context.Context) (*exchange.Table, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.table != nil && time.Since(p.table.FetchedAt) < p.TTL {
+		return p.table, nil
+	}
+
+	table, err := p.fetch(ctx)
+	if err != nil {
+		if p.table != nil {
+			return p.table, nil
+		}
+		return nil, err
+	}
+
+	p.table = table
+	return table, nil
+}
+
+func (p *Provider) fetch(ctx context.Context) (*exchange.Table, error) {
+	const op = "exchange.remote.fetch"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(errors.Errorf("unexpected status %d", resp.StatusCode), op)
+	}
+
+	var table exchange.Table
+	if err = json.NewDecoder(resp.Body).Decode(&table); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !table.Base.IsValid() {
+		return nil, errors.Wrap(errors.Errorf("unknown base currency %q", table.Base), op)
+	}
+	table.FetchedAt = time.Now()
+
+	return &table, nil
+}
@@ -0,0 +1,75 @@
+package remote
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func stubRatesServer(t *testing.T, healthy *atomic.Bool, calls *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"base":"USD","rates":{"EUR":0.92,"KZT":"476.5"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProvider_Rate(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	healthy.Store(true)
+	srv := stubRatesServer(t, &healthy, &calls)
+
+	p := &Provider{URL: srv.URL, Client: srv.Client(), TTL: time.Hour}
+
+	rate, err := p.Rate(context.Background(), money.USD, money.KZT)
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParseRate("476.5"), rate)
+
+	rate, err = p.Rate(context.Background(), money.EUR, money.USD)
+	require.NoError(t, err)
+	assert.Equal(t, "1.08695652", rate.String())
+
+	assert.Equal(t, int32(1), calls.Load(), "rates should be cached for the TTL")
+}
+
+func TestProvider_KeepsLastTableWhenRefreshFails(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	healthy.Store(true)
+	srv := stubRatesServer(t, &healthy, &calls)
+
+	p := &Provider{URL: srv.URL, Client: srv.Client(), TTL: time.Nanosecond}
+
+	_, err := p.Rate(context.Background(), money.USD, money.EUR)
+	require.NoError(t, err)
+
+	healthy.Store(false)
+	rate, err := p.Rate(context.Background(), money.USD, money.EUR)
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParseRate("0.92"), rate)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestProvider_FailsWithoutAnyTable(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	srv := stubRatesServer(t, &healthy, &calls)
+
+	p := &Provider{URL: srv.URL, Client: srv.Client(), TTL: time.Hour}
+
+	_, err := p.Rate(context.Background(), money.USD, money.EUR)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package static
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/exchange"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+	"os"
+)
+
+const Name = "static"
+
+// Provider serves a fixed table of exchange rates, typically loaded from a
+// JSON file at startup. Rates only change when the process restarts.
+type Provider struct {
+	Table exchange.Table
+}
+
+// Load reads a rates file in the exchange.Table format.
+func Load(path string) (*Provider, error) {
+	const op = "exchange.static.Load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var table exchange.Table
+	if err = json.Unmarshal(data, &table); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !table.Base.IsValid() {
+		return nil, errors.Wrap(errors.Errorf("unknown base currency %q", table.Base), op)
+	}
+
+	return &Provider{Table: table}, nil
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) Rate(ctx context.Context, from, to money.Currency) (money.Rate, error) {
+	const op = "exchange.static.Rate"
+
+	rate, err := p.Table.Rate(from, to)
+	if err != nil {
+		return money.Rate{}, errors.Wrap(err, op)
+	}
+
+	return rate, nil
+}
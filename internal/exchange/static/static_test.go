@@ -0,0 +1,49 @@
+package static
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/exchange"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"USD","rates":{"EUR":"0.92","GBP":"0.79"}}`), 0o600))
+
+	p, err := Load(path)
+	require.NoError(t, err)
+
+	t.Run("from the base", func(t *testing.T) {
+		rate, err := p.Rate(context.Background(), money.USD, money.EUR)
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParseRate("0.92"), rate)
+	})
+
+	t.Run("crossing through the base", func(t *testing.T) {
+		rate, err := p.Rate(context.Background(), money.EUR, money.GBP)
+		require.NoError(t, err)
+		assert.Equal(t, "0.85869565", rate.String())
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := p.Rate(context.Background(), money.USD, money.JPY)
+		assert.ErrorIs(t, err, exchange.ErrUnknownRate)
+	})
+}
+
+func TestLoadRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"XXX","rates":{}}`), 0o600))
+	_, err = Load(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is a piece of background work repeated on a fixed interval.
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
+
+type Runner struct {
+	Jobs []Job
+	Log  *slog.Logger
+}
+
+// Start launches every job on its own ticker until ctx is cancelled. A failed
+// run is logged and simply retried on the next tick.
+func (r *Runner) Start(ctx context.Context) {
+	for _, job := range r.Jobs {
+		go r.loop(ctx, job)
+	}
+}
+
+func (r *Runner) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil {
+				r.Log.Error("background job failed",
+					slog.String("job", job.Name()),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+}
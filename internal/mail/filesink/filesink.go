@@ -0,0 +1,90 @@
+// Package filesink is a Mailer for development: instead of sending emails it
+// writes each one to Dir as an .eml file that any mail client can open.
+package filesink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+type Mailer struct {
+	Dir string
+	Now func() time.Time
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]+`)
+
+func (m *Mailer) Send(ctx context.Context, email notification.Email) error {
+	const op = "mail.filesink.Send"
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if m.Now != nil {
+		now = m.Now()
+	}
+
+	msg, err := encode(email, now)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), unsafeChars.ReplaceAllString(email.To, "_"))
+	if err := os.WriteFile(filepath.Join(m.Dir, name), msg, 0o644); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// encode builds a multipart/alternative message with the plain-text part
+// first, so clients that can show HTML prefer it.
+func encode(email notification.Email, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", email.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,75 @@
+package filesink
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sentAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := &Mailer{Dir: dir, Now: func() time.Time { return sentAt }}
+
+	err := m.Send(context.Background(), notification.Email{
+		From:    "Bookstore <orders@bookstore.test>",
+		To:      "ada@example.com",
+		Subject: "Your order is on its way – with tracking",
+		Text:    "Good news: your order has shipped.\n",
+		HTML:    "<p>Good news: your order has shipped.</p>",
+	})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), "-ada@example.com.eml"))
+
+	raw, err := os.Open(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	defer raw.Close()
+
+	msg, err := mail.ReadMessage(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ada@example.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Your order is on its way – with tracking", subject)
+
+	date, err := msg.Header.Date()
+	require.NoError(t, err)
+	assert.True(t, sentAt.Equal(date))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var got []string
+	for {
+		p, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(p)
+		require.NoError(t, err)
+		got = append(got, p.Header.Get("Content-Type")+": "+string(body))
+	}
+
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: Good news: your order has shipped.\n",
+		"text/html; charset=utf-8: <p>Good news: your order has shipped.</p>",
+	}, got)
+}
@@ -0,0 +1,63 @@
+// Package templates renders the transactional emails. Every notification kind
+// has a plain-text template, <kind>.txt, which also defines the subject as
+// "<kind>.subject", and an HTML alternate, <kind>.html.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+)
+
+//go:embed *.txt *.html
+var files embed.FS
+
+var (
+	text = textTemplate.Must(textTemplate.New("").ParseFS(files, "*.txt"))
+	html = htmlTemplate.Must(htmlTemplate.New("").ParseFS(files, "*.html"))
+)
+
+type view struct {
+	Subject string
+	Data    notification.Data
+}
+
+// Render renders the email of kind for data. From and To are left for the
+// caller.
+func Render(kind notification.Kind, data notification.Data) (notification.Email, error) {
+	v := view{Data: data}
+
+	subject, err := execute(text, string(kind)+".subject", v)
+	if err != nil {
+		return notification.Email{}, err
+	}
+	v.Subject = strings.TrimSpace(subject)
+
+	body, err := execute(text, string(kind)+".txt", v)
+	if err != nil {
+		return notification.Email{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := html.ExecuteTemplate(&buf, string(kind)+".html", v); err != nil {
+		return notification.Email{}, fmt.Errorf("render %s.html: %w", kind, err)
+	}
+
+	return notification.Email{
+		Subject: v.Subject,
+		Text:    strings.TrimSpace(body) + "\n",
+		HTML:    buf.String(),
+	}, nil
+}
+
+func execute(t *textTemplate.Template, name string, v view) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, v); err != nil {
+		return "", fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,148 @@
+package templates
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func data() notification.Data {
+	threshold := money.MustParse("7.00", money.USD)
+	orderID, _ := uuid.FromString("5b0f3c1e-8f5b-4c1c-9d7e-2d6c2f3b9a10")
+	return notification.Data{
+		Recipient: notification.Recipient{Username: "ada"},
+		Order: &orderModel.Model{
+			ID:            orderID,
+			TotalPrice:    money.MustParse("20.00", money.USD),
+			RefundedTotal: money.MustParse("5.00", money.USD),
+			Currency:      money.EUR,
+			ExchangeRate:  money.MustParseRate("0.5"),
+			ShippingAddress: &address.Snapshot{
+				FullName: "Ada Lovelace", Line1: "12 St James's Square", City: "London", PostalCode: "SW1Y 4JH", Country: "GB",
+			},
+		},
+		Items: []orderItem.OrderItemFull{
+			{Name: "Nineteen Eighty-Four", Quantity: 2, Price: money.MustParse("10.00", money.USD)},
+		},
+		Shipments: []shipment.Shipment{
+			{
+				Carrier:        "ups",
+				TrackingNumber: "1Z999AA10123456784",
+				TrackingURL:    "https://www.ups.com/track?tracknum=1Z999AA10123456784",
+				Items:          []shipment.Item{{Title: "Nineteen Eighty-Four", Quantity: 2}},
+			},
+		},
+		Alerts: []stockalert.Notice{
+			{
+				Title:          "Nineteen Eighty-Four",
+				Kind:           stockalert.KindBackInStock,
+				Price:          money.MustParse("10.00", money.USD),
+				UnsubscribeURL: "https://bookstore.test/api/v1/alerts/unsubscribe?token=abc",
+			},
+			{
+				Title:          "Animal Farm",
+				Kind:           stockalert.KindPriceBelow,
+				Price:          money.MustParse("6.50", money.USD),
+				Threshold:      &threshold,
+				UnsubscribeURL: "https://bookstore.test/api/v1/alerts/unsubscribe?token=def",
+			},
+		},
+		Reason:    "out of stock",
+		ChangedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		kind    notification.Kind
+		subject string
+		want    []string
+	}{
+		{
+			kind:    notification.KindOrderConfirmation,
+			subject: "Your order 5b0f3c1e-8f5b-4c1c-9d7e-2d6c2f3b9a10 is confirmed",
+			want:    []string{"Nineteen Eighty-Four: 2 x €5.00", "Total paid: €10.00", "Ada Lovelace"},
+		},
+		{
+			kind:    notification.KindOrderShipped,
+			subject: "Your order 5b0f3c1e-8f5b-4c1c-9d7e-2d6c2f3b9a10 is on its way",
+			want:    []string{"ups tracking number: 1Z999AA10123456784", "Track it at https://www.ups.com/track"},
+		},
+		{
+			kind:    notification.KindOrderCancelled,
+			subject: "Your order 5b0f3c1e-8f5b-4c1c-9d7e-2d6c2f3b9a10 was cancelled",
+			want:    []string{"Reason: out of stock"},
+		},
+		{
+			kind:    notification.KindOrderRefunded,
+			subject: "A refund for your order 5b0f3c1e-8f5b-4c1c-9d7e-2d6c2f3b9a10",
+			want:    []string{"We have refunded €2.50 of the €10.00 you paid"},
+		},
+		{
+			kind:    notification.KindStockAlert,
+			subject: "2 books you are watching have news",
+			want: []string{
+				"Nineteen Eighty-Four: back in stock at $10.00",
+				"Animal Farm: now $6.50, at or under your $7.00",
+				"Stop watching: https://bookstore.test/api/v1/alerts/unsubscribe?token=def",
+			},
+		},
+		{
+			kind:    notification.KindPasswordChanged,
+			subject: "Your password was changed",
+			want:    []string{"changed on 1 March 2024 at 09:30 UTC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			email, err := Render(tt.kind, data())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.subject, email.Subject)
+			assert.Contains(t, email.Text, "Hi ada,")
+			for _, want := range tt.want {
+				assert.Contains(t, email.Text, want)
+			}
+			assert.Contains(t, email.HTML, "<title>"+tt.subject+"</title>")
+		})
+	}
+}
+
+func TestRender_EveryKind(t *testing.T) {
+	for _, kind := range notification.Kinds {
+		_, err := Render(kind, data())
+		assert.NoError(t, err, "kind %s has no templates", kind)
+	}
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	d := data()
+	d.Recipient.Username = "<script>alert(1)</script>"
+
+	email, err := Render(notification.KindPasswordChanged, d)
+	require.NoError(t, err)
+
+	assert.NotContains(t, email.HTML, "<script>")
+	assert.Contains(t, email.HTML, "&lt;script&gt;")
+}
+
+func TestRender_StockAlertSubject(t *testing.T) {
+	d := data()
+	d.Alerts = d.Alerts[1:]
+
+	email, err := Render(notification.KindStockAlert, d)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Animal Farm: price drop", email.Subject)
+	assert.Contains(t, email.HTML, `href="https://bookstore.test/api/v1/alerts/unsubscribe?token=def"`)
+}
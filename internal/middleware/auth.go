@@ -32,6 +32,9 @@ func WithAuth(handler http.Handler) http.Handler {
 		}
 
 		ctx := context.WithValue(r.Context(), "user_id", userID)
+		if role, err := getRoleFromToken(token); err == nil {
+			ctx = context.WithValue(ctx, "role", role)
+		}
 		r = r.WithContext(ctx)
 
 		handler.ServeHTTP(w, r)
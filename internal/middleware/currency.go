@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CurrencyParam switches the storefront currency; the choice is kept in
+	// CurrencyCookie for later requests.
+	CurrencyParam  = "currency"
+	CurrencyCookie = "currency"
+
+	currencyCookieMaxAge = 365 * 24 * time.Hour
+)
+
+// regionCurrencies maps the region subtag of a language tag to its currency.
+var regionCurrencies = map[string]money.Currency{
+	"US": money.USD, "GB": money.GBP, "KZ": money.KZT, "JP": money.JPY,
+	"CH": money.CHF, "LI": money.CHF,
+	"DE": money.EUR, "FR": money.EUR, "ES": money.EUR, "IT": money.EUR,
+	"NL": money.EUR, "BE": money.EUR, "AT": money.EUR, "IE": money.EUR,
+	"PT": money.EUR, "FI": money.EUR, "GR": money.EUR,
+}
+
+// languageCurrencies covers bare language tags whose speakers mostly pay in
+// one currency. English is deliberately missing, it says nothing about where
+// the customer is.
+var languageCurrencies = map[string]money.Currency{
+	"ja": money.JPY, "kk": money.KZT,
+	"de": money.EUR, "fr": money.EUR, "es": money.EUR, "it": money.EUR,
+	"nl": money.EUR, "pt": money.EUR, "fi": money.EUR, "el": money.EUR,
+}
+
+// Currency picks the currency prices are shown and charged in: an explicit
+// ?currency= choice first, then the cookie remembering an earlier choice,
+// then the browser's Accept-Language, then the catalogue currency.
+type Currency struct {
+	Supported []money.Currency
+}
+
+func (m *Currency) Handler(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currency, ok := m.fromParam(r)
+		if ok {
+			http.SetCookie(w, &http.Cookie{
+				Name:     CurrencyCookie,
+				Value:    string(currency),
+				Path:     "/",
+				MaxAge:   int(currencyCookieMaxAge.Seconds()),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+		if !ok {
+			currency, ok = m.fromCookie(r)
+		}
+		if !ok {
+			currency, ok = m.fromAcceptLanguage(r.Header.Get("Accept-Language"))
+		}
+		if !ok {
+			currency = money.DefaultCurrency
+		}
+
+		ctx := context.WithValue(r.Context(), "currency", currency)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// CurrencyFromContext returns the currency chosen for the request, or the
+// catalogue currency when the Currency middleware did not run.
+func CurrencyFromContext(ctx context.Context) money.Currency {
+	currency, ok := ctx.Value("currency").(money.Currency)
+	if !ok {
+		return money.DefaultCurrency
+	}
+	return currency
+}
+
+func (m *Currency) fromParam(r *http.Request) (money.Currency, bool) {
+	return m.supported(r.URL.Query().Get(CurrencyParam))
+}
+
+func (m *Currency) fromCookie(r *http.Request) (money.Currency, bool) {
+	cookie, err := r.Cookie(CurrencyCookie)
+	if err != nil {
+		return "", false
+	}
+	return m.supported(cookie.Value)
+}
+
+func (m *Currency) fromAcceptLanguage(header string) (money.Currency, bool) {
+	for _, tag := range parseAcceptLanguage(header) {
+		subtags := strings.Split(tag, "-")
+		language, region := subtags[0], ""
+		for _, subtag := range subtags[1:] {
+			if len(subtag) == 2 {
+				region = subtag
+			}
+		}
+		if currency, ok := regionCurrencies[strings.ToUpper(region)]; ok && slices.Contains(m.Supported, currency) {
+			return currency, true
+		}
+		if currency, ok := languageCurrencies[strings.ToLower(language)]; ok && slices.Contains(m.Supported, currency) {
+			return currency, true
+		}
+	}
+	return "", false
+}
+
+func (m *Currency) supported(code string) (money.Currency, bool) {
+	if code == "" {
+		return "", false
+	}
+	currency, err := money.ParseCurrency(code)
+	if err != nil || !slices.Contains(m.Supported, currency) {
+		return "", false
+	}
+	return currency, true
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// most preferred first. Tags with q=0 are dropped.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: strings.ReplaceAll(tag, "_", "-"), q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, tag.tag)
+	}
+	return result
+}
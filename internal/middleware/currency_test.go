@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCurrency_Handler(t *testing.T) {
+	m := &Currency{Supported: []money.Currency{money.USD, money.EUR, money.KZT}}
+
+	var chosen money.Currency
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chosen = CurrencyFromContext(r.Context())
+	}))
+
+	cases := []struct {
+		name     string
+		query    string
+		cookie   string
+		language string
+		want     money.Currency
+	}{
+		{"defaults to the catalogue currency", "", "", "", money.USD},
+		{"query parameter wins", "?currency=eur", "KZT", "kk-KZ", money.EUR},
+		{"cookie beats the browser language", "", "KZT", "de-DE", money.KZT},
+		{"region from Accept-Language", "", "", "en-IE,en;q=0.8", money.EUR},
+		{"bare language from Accept-Language", "", "", "kk", money.KZT},
+		{"preference order is respected", "", "", "en-GB;q=0.4, de;q=0.9", money.EUR},
+		{"unsupported region is skipped", "", "", "ja-JP, kk-KZ;q=0.5", money.KZT},
+		{"unsupported query falls back", "?currency=JPY", "", "", money.USD},
+		{"garbage cookie falls back", "", "nope", "", money.USD},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+c.query, nil)
+			if c.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CurrencyCookie, Value: c.cookie})
+			}
+			if c.language != "" {
+				req.Header.Set("Accept-Language", c.language)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, c.want, chosen)
+		})
+	}
+}
+
+func TestCurrency_RemembersExplicitChoice(t *testing.T) {
+	m := &Currency{Supported: []money.Currency{money.USD, money.EUR}}
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?currency=EUR", nil))
+
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, CurrencyCookie, cookies[0].Name)
+		assert.Equal(t, "EUR", cookies[0].Value)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, rec.Result().Cookies())
+}
+
+func TestCurrency_NilPassesThrough(t *testing.T) {
+	var m *Currency
+	var chosen money.Currency
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chosen = CurrencyFromContext(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?currency=EUR", nil))
+	assert.Equal(t, money.DefaultCurrency, chosen)
+}
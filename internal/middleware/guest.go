@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/gofrs/uuid"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Guest lets anonymous visitors keep a cart. Requests that already carry a
+// logged-in user pass through; anyone else is identified by the signed guest
+// cart cookie. A visitor without one gets a guest user and cookie on their
+// first write to the cart only, so crawlers and clients that drop cookies do
+// not leave a user behind on every read. Until then they read as uuid.Nil,
+// which owns no cart. Either way the request goes on with a user_id, as if
+// logged in.
+type Guest struct {
+	Store  interfaces.GuestRepository
+	Secret []byte
+	TTL    time.Duration
+	Log    *slog.Logger
+}
+
+// Handler identifies the visitor, creating a guest for a write: any method
+// but GET, HEAD and OPTIONS.
+func (m *Guest) Handler(next http.Handler) http.Handler {
+	return m.handler(next, false)
+}
+
+// Writer is Handler for routes that fill the cart on a safe method, such as
+// the storefront's add-to-cart link. It always creates a missing guest.
+func (m *Guest) Writer(next http.Handler) http.Handler {
+	return m.handler(next, true)
+}
+
+func (m *Guest) handler(next http.Handler, writes bool) http.Handler {
+	if m == nil {
+		return WithAuth(next)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value("user_id").(string); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		guestID, ok := m.fromCookie(r)
+		if !ok && !writes && isRead(r) {
+			guestID, ok = uuid.Nil, true
+		}
+		if !ok {
+			var err error
+			guestID, err = m.Store.CreateGuest(r.Context())
+			if err != nil {
+				m.Log.Error("failed to create guest", slog.String("error", err.Error()))
+				response.WriteError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+			m.setCookie(w, guestID, time.Now())
+		} else if guestID != uuid.Nil && (writes || !isRead(r)) {
+			// Changing the cart starts its TTL again, for the cookie as for
+			// the purge of abandoned carts.
+			m.setCookie(w, guestID, time.Now())
+		}
+
+		ctx := context.WithValue(r.Context(), "user_id", guestID.String())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Take returns the guest behind the request's cart cookie, if any, and
+// clears the cookie. It is called once the visitor logs in or registers and
+// their cart moves to the account.
+func (m *Guest) Take(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	if m == nil {
+		return uuid.Nil, false
+	}
+
+	guestID, ok := m.fromCookie(r)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     guest.CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return guestID, true
+}
+
+func isRead(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func (m *Guest) fromCookie(r *http.Request) (uuid.UUID, bool) {
+	cookie, err := r.Cookie(guest.CookieName)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	guestID, err := guest.Verify(cookie.Value, m.Secret, m.TTL, time.Now())
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return guestID, true
+}
+
+func (m *Guest) setCookie(w http.ResponseWriter, guestID uuid.UUID, issued time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     guest.CookieName,
+		Value:    guest.Sign(guestID, issued, m.Secret),
+		Path:     "/",
+		Expires:  issued.Add(m.TTL),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var guestSecret = []byte("guest-secret")
+
+func newGuestRouter(store *mocks.GuestRepository, seen *string) *chi.Mux {
+	m := &Guest{
+		Store:  store,
+		Secret: guestSecret,
+		TTL:    time.Hour,
+		Log:    logger.New(logger.EnvLocal),
+	}
+
+	record := func(w http.ResponseWriter, r *http.Request) {
+		*seen, _ = r.Context().Value("user_id").(string)
+	}
+
+	router := chi.NewRouter()
+	router.With(m.Handler).Get("/cart", record)
+	router.With(m.Handler).Post("/cart", record)
+	router.With(m.Writer).Get("/cart/add", record)
+
+	return router
+}
+
+func TestGuest_NewVisitor(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	guestID := uuid.Must(uuid.NewV4())
+	store.On("CreateGuest", mock.Anything).Return(guestID, nil)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/cart", nil)
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, guestID.String(), seen)
+
+	cookies := r.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, guest.CookieName, cookies[0].Name)
+		got, err := guest.Verify(cookies[0].Value, guestSecret, time.Hour, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, guestID, got)
+	}
+}
+
+func TestGuest_NewVisitorReads(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cart", nil)
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, uuid.Nil.String(), seen)
+	assert.Empty(t, r.Result().Cookies())
+	store.AssertNotCalled(t, "CreateGuest", mock.Anything)
+}
+
+func TestGuest_Writer(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	guestID := uuid.Must(uuid.NewV4())
+	store.On("CreateGuest", mock.Anything).Return(guestID, nil)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cart/add", nil)
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, guestID.String(), seen)
+	assert.Len(t, r.Result().Cookies(), 1)
+}
+
+func TestGuest_ReturningVisitor(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	guestID := uuid.Must(uuid.NewV4())
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cart", nil)
+	req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now(), guestSecret)})
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, guestID.String(), seen)
+	assert.Empty(t, r.Result().Cookies())
+	store.AssertNotCalled(t, "CreateGuest", mock.Anything)
+}
+
+func TestGuest_ReturningVisitorWrites(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	guestID := uuid.Must(uuid.NewV4())
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/cart", nil)
+	req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now().Add(-50*time.Minute), guestSecret)})
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, guestID.String(), seen)
+	if cookies := r.Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.WithinDuration(t, time.Now().Add(time.Hour), cookies[0].Expires, time.Minute)
+	}
+	store.AssertNotCalled(t, "CreateGuest", mock.Anything)
+}
+
+func TestGuest_ForgedCookie(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	guestID := uuid.Must(uuid.NewV4())
+	store.On("CreateGuest", mock.Anything).Return(guestID, nil)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/cart", nil)
+	req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(uuid.Must(uuid.NewV4()), time.Now(), []byte("other"))})
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, guestID.String(), seen)
+	store.AssertNumberOfCalls(t, "CreateGuest", 1)
+}
+
+func TestGuest_LoggedIn(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cart", nil)
+	router.ServeHTTP(r, req.WithContext(context.WithValue(req.Context(), "user_id", "123")))
+
+	assert.Equal(t, "123", seen)
+	store.AssertNotCalled(t, "CreateGuest", mock.Anything)
+}
+
+func TestGuest_CreateFails(t *testing.T) {
+	store := mocks.GuestRepository{}
+	var seen string
+	router := newGuestRouter(&store, &seen)
+
+	store.On("CreateGuest", mock.Anything).Return(uuid.Nil, errors.New("error"))
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/cart", nil)
+	router.ServeHTTP(r, req)
+
+	assert.Equal(t, http.StatusInternalServerError, r.Code)
+	assert.Empty(t, seen)
+}
+
+func TestGuest_Take(t *testing.T) {
+	m := &Guest{Secret: guestSecret, TTL: time.Hour}
+	guestID := uuid.Must(uuid.NewV4())
+
+	r := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/login", nil)
+	req.AddCookie(&http.Cookie{Name: guest.CookieName, Value: guest.Sign(guestID, time.Now(), guestSecret)})
+
+	got, ok := m.Take(r, req)
+	assert.True(t, ok)
+	assert.Equal(t, guestID, got)
+	if cookies := r.Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.Equal(t, -1, cookies[0].MaxAge)
+	}
+
+	_, ok = m.Take(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/login", nil))
+	assert.False(t, ok)
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/idempotency"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/utils/response"
+	"github.com/pkg/errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyKeyParam carries the key for plain links and forms, which
+	// cannot set headers.
+	IdempotencyKeyParam = "idempotency_key"
+
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	errKeyInFlight = errors.New("a request with this idempotency key is still being processed")
+	errKeyReused   = errors.New("idempotency key was already used for a different request")
+)
+
+// replayedHeaders are the response headers stored with a key and sent again
+// on replay.
+var replayedHeaders = []string{"Content-Type", "Location"}
+
+// Idempotency makes retries of a request carrying an Idempotency-Key safe:
+// the first response is stored and replayed for every retry within TTL.
+// Requests without a key pass through untouched.
+type Idempotency struct {
+	Store interfaces.IdempotencyRepository
+	TTL   time.Duration
+	Log   *slog.Logger
+}
+
+func (m *Idempotency) Handler(next http.Handler) http.Handler {
+	if m == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			key = r.URL.Query().Get(IdempotencyKeyParam)
+		}
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.WriteError(w, r, http.StatusBadRequest, errors.New("idempotency key is too long"))
+			return
+		}
+
+		fingerprint, err := fingerprintRequest(r)
+		if err != nil {
+			response.WriteError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		scope, _ := r.Context().Value("user_id").(string)
+
+		record, acquired, err := m.Store.Acquire(r.Context(), &model.Record{
+			Scope:       scope,
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   time.Now().Add(m.TTL),
+		})
+		if err != nil {
+			m.Log.Error("failed to acquire idempotency key", slog.String("error", err.Error()))
+			response.WriteError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !acquired {
+			switch {
+			case record.Fingerprint != fingerprint:
+				response.WriteError(w, r, http.StatusUnprocessableEntity, errKeyReused)
+			case record.Status == model.StatusInFlight:
+				response.WriteError(w, r, http.StatusConflict, errKeyInFlight)
+			default:
+				replay(w, record)
+			}
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		completed := false
+		defer func() {
+			if !completed {
+				if err := m.Store.Release(r.Context(), scope, key); err != nil {
+					m.Log.Error("failed to release idempotency key", slog.String("error", err.Error()))
+				}
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		// Server errors are not stored, the client may retry them with the same key.
+		if rec.status >= http.StatusInternalServerError {
+			return
+		}
+
+		record.ResponseStatus = rec.status
+		record.ResponseHeaders = http.Header{}
+		for _, name := range replayedHeaders {
+			if value := rec.Header().Get(name); value != "" {
+				record.ResponseHeaders.Set(name, value)
+			}
+		}
+		record.ResponseBody = rec.body.Bytes()
+
+		if err := m.Store.Complete(r.Context(), record); err != nil {
+			m.Log.Error("failed to store idempotent response", slog.String("error", err.Error()))
+			return
+		}
+		completed = true
+	})
+}
+
+// fingerprintRequest hashes what identifies a request: method, path, query
+// without the key itself, and body. The body is put back for the handler.
+func fingerprintRequest(r *http.Request) (string, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	query := r.URL.Query()
+	query.Del(IdempotencyKeyParam)
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "?" + query.Encode() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func replay(w http.ResponseWriter, record *model.Record) {
+	for name, values := range record.ResponseHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.ResponseStatus)
+	w.Write(record.ResponseBody)
+}
+
+// recordingWriter passes a response through while keeping a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/idempotency"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newIdempotentRouter(store *mocks.IdempotencyRepository, calls *int) *chi.Mux {
+	m := &Idempotency{
+		Store: store,
+		TTL:   time.Hour,
+		Log:   logger.New(logger.EnvLocal),
+	}
+
+	router := chi.NewRouter()
+	router.With(m.Handler).Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`"User Order created"`))
+	})
+	router.With(m.Handler).Post("/fail", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	return router
+}
+
+func newIdempotentRequest(path, key, body string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+}
+
+func TestIdempotency_NoKey(t *testing.T) {
+	store := mocks.IdempotencyRepository{}
+	calls := 0
+	router := newIdempotentRouter(&store, &calls)
+
+	r := httptest.NewRecorder()
+	router.ServeHTTP(r, newIdempotentRequest("/orders", "", `{}`))
+
+	assert.Equal(t, http.StatusCreated, r.Code)
+	assert.Equal(t, 1, calls)
+	store.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_FirstRequest(t *testing.T) {
+	store := mocks.IdempotencyRepository{}
+	calls := 0
+	router := newIdempotentRouter(&store, &calls)
+
+	store.On("Acquire", mock.Anything, mock.MatchedBy(func(rec *model.Record) bool {
+		return rec.Scope == "123" && rec.Key == "key-1"
+	})).Return(func(ctx context.Context, rec *model.Record) *model.Record { return rec }, true, nil)
+	store.On("Complete", mock.Anything, mock.MatchedBy(func(rec *model.Record) bool {
+		return rec.ResponseStatus == http.StatusCreated &&
+			string(rec.ResponseBody) == `"User Order created"` &&
+			rec.ResponseHeaders.Get("Content-Type") == "application/json"
+	})).Return(nil)
+
+	r := httptest.NewRecorder()
+	router.ServeHTTP(r, newIdempotentRequest("/orders", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusCreated, r.Code)
+	assert.Equal(t, 1, calls)
+	store.AssertExpectations(t)
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	store := mocks.IdempotencyRepository{}
+	calls := 0
+	router := newIdempotentRouter(&store, &calls)
+
+	fingerprint, _ := fingerprintRequest(newIdempotentRequest("/orders", "key-1", `{}`))
+	stored := &model.Record{
+		Scope:           "123",
+		Key:             "key-1",
+		Fingerprint:     fingerprint,
+		Status:          model.StatusCompleted,
+		ResponseStatus:  http.StatusCreated,
+		ResponseHeaders: http.Header{"Content-Type": []string{"application/json"}},
+		ResponseBody:    []byte(`"User Order created"`),
+	}
+
+	t.Run("retry gets the stored response", func(t *testing.T) {
+		store.On("Acquire", mock.Anything, mock.Anything).Return(stored, false, nil).Once()
+
+		r := httptest.NewRecorder()
+		router.ServeHTTP(r, newIdempotentRequest("/orders", "key-1", `{}`))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+		assert.Equal(t, `"User Order created"`, r.Body.String())
+		assert.Equal(t, "true", r.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("key reused with a different body", func(t *testing.T) {
+		store.On("Acquire", mock.Anything, mock.Anything).Return(stored, false, nil).Once()
+
+		r := httptest.NewRecorder()
+		router.ServeHTTP(r, newIdempotentRequest("/orders", "key-1", `{"items":[]}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("first request still in flight", func(t *testing.T) {
+		inFlight := *stored
+		inFlight.Status = model.StatusInFlight
+		store.On("Acquire", mock.Anything, mock.Anything).Return(&inFlight, false, nil).Once()
+
+		r := httptest.NewRecorder()
+		router.ServeHTTP(r, newIdempotentRequest("/orders", "key-1", `{}`))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	store := mocks.IdempotencyRepository{}
+	calls := 0
+	router := newIdempotentRouter(&store, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, rec *model.Record) *model.Record { return rec }, true, nil)
+	store.On("Release", mock.Anything, "123", "key-1").Return(nil)
+
+	r := httptest.NewRecorder()
+	router.ServeHTTP(r, newIdempotentRequest("/fail", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusInternalServerError, r.Code)
+	store.AssertCalled(t, "Release", mock.Anything, "123", "key-1")
+	store.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_StoreError(t *testing.T) {
+	store := mocks.IdempotencyRepository{}
+	calls := 0
+	router := newIdempotentRouter(&store, &calls)
+
+	store.On("Acquire", mock.Anything, mock.Anything).Return(nil, false, errors.New("error"))
+
+	r := httptest.NewRecorder()
+	router.ServeHTTP(r, newIdempotentRequest("/orders", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusInternalServerError, r.Code)
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+const Name = "fake"
+
+const (
+	intentPrefix = "fake_pi_"
+	refundPrefix = "fake_re_"
+)
+
+// Provider is an in-process PaymentProvider for tests and local development.
+// It never talks to the network and is fully deterministic: references are
+// derived from the payment ID, every charge settles synchronously, and only
+// amounts above DeclineOver, in DeclineOver's currency, are declined.
+type Provider struct {
+	Secret      []byte
+	DeclineOver money.Money
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) CreateIntent(ctx context.Context, req paymentModel.IntentRequest) (*paymentModel.Result, error) {
+	const op = "payment.fake.CreateIntent"
+
+	if !req.Amount.IsPositive() {
+		return nil, errors.Wrap(errors.New("amount must be positive"), op)
+	}
+
+	result := &paymentModel.Result{
+		ProviderRef: intentPrefix + hex.EncodeToString(req.PaymentID.Bytes()),
+		Status:      paymentModel.StatusPending,
+	}
+
+	if p.declines(req.Amount) {
+		result.Status = paymentModel.StatusFailed
+		result.FailureReason = "card_declined"
+	}
+
+	return result, nil
+}
+
+func (p *Provider) Capture(ctx context.Context, providerRef string) (*paymentModel.Result, error) {
+	const op = "payment.fake.Capture"
+
+	if !strings.HasPrefix(providerRef, intentPrefix) {
+		return nil, errors.Wrap(errors.Errorf("unknown intent %q", providerRef), op)
+	}
+
+	return &paymentModel.Result{
+		ProviderRef: providerRef,
+		Status:      paymentModel.StatusSucceeded,
+	}, nil
+}
+
+func (p *Provider) Refund(ctx context.Context, providerRef string, amount money.Money) (*paymentModel.Result, error) {
+	const op = "payment.fake.Refund"
+
+	if !strings.HasPrefix(providerRef, intentPrefix) {
+		return nil, errors.Wrap(errors.Errorf("unknown intent %q", providerRef), op)
+	}
+	if !amount.IsPositive() {
+		return nil, errors.Wrap(errors.New("amount must be positive"), op)
+	}
+
+	return &paymentModel.Result{
+		ProviderRef: refundPrefix + strings.TrimPrefix(providerRef, intentPrefix),
+		Status:      paymentModel.StatusRefunded,
+	}, nil
+}
+
+// VerifyWebhook accepts a delivery whose signature is the hex encoded
+// HMAC-SHA256 of the raw payload under Secret.
+func (p *Provider) VerifyWebhook(payload []byte, signature string) (*paymentModel.WebhookEvent, error) {
+	const op = "payment.fake.VerifyWebhook"
+
+	if len(p.Secret) == 0 || !hmac.Equal([]byte(p.Sign(payload)), []byte(signature)) {
+		return nil, errors.Wrap(paymentModel.ErrInvalidSignature, op)
+	}
+
+	var event paymentModel.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if event.ID == "" || event.Type == "" || event.ProviderRef == "" {
+		return nil, errors.Wrap(errors.New("incomplete webhook event"), op)
+	}
+
+	return &event, nil
+}
+
+// Sign returns the signature VerifyWebhook expects for payload, so tests and
+// local tooling can produce deliveries.
+func (p *Provider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.Secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *Provider) declines(amount money.Money) bool {
+	return p.DeclineOver.IsPositive() &&
+		amount.Currency() == p.DeclineOver.Currency() &&
+		amount.GreaterThan(p.DeclineOver)
+}
@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"context"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProvider_Charge(t *testing.T) {
+	p := &Provider{Secret: []byte("secret"), DeclineOver: money.MustParse("100", money.USD)}
+	ten := money.MustParse("10", money.USD)
+	paymentID, _ := uuid.NewV4()
+
+	t.Run("same payment gives the same reference", func(t *testing.T) {
+		first, err := p.CreateIntent(context.Background(), paymentModel.IntentRequest{PaymentID: paymentID, Amount: ten})
+		require.NoError(t, err)
+		second, err := p.CreateIntent(context.Background(), paymentModel.IntentRequest{PaymentID: paymentID, Amount: ten})
+		require.NoError(t, err)
+
+		assert.Equal(t, first.ProviderRef, second.ProviderRef)
+		assert.Equal(t, paymentModel.StatusPending, first.Status)
+
+		captured, err := p.Capture(context.Background(), first.ProviderRef)
+		require.NoError(t, err)
+		assert.Equal(t, paymentModel.StatusSucceeded, captured.Status)
+	})
+
+	t.Run("amounts above the limit are declined", func(t *testing.T) {
+		result, err := p.CreateIntent(context.Background(), paymentModel.IntentRequest{PaymentID: paymentID, Amount: money.MustParse("100.01", money.USD)})
+		require.NoError(t, err)
+
+		assert.Equal(t, paymentModel.StatusFailed, result.Status)
+		assert.Equal(t, "card_declined", result.FailureReason)
+	})
+
+	t.Run("refund of an unknown intent fails", func(t *testing.T) {
+		_, err := p.Refund(context.Background(), "pi_elsewhere", ten)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_VerifyWebhook(t *testing.T) {
+	p := &Provider{Secret: []byte("secret")}
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","provider_ref":"fake_pi_1"}`)
+
+	t.Run("valid signature", func(t *testing.T) {
+		event, err := p.VerifyWebhook(payload, p.Sign(payload))
+		require.NoError(t, err)
+
+		assert.Equal(t, "evt_1", event.ID)
+		assert.Equal(t, paymentModel.EventPaymentSucceeded, event.Type)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		signature := p.Sign(payload)
+		tampered := []byte(`{"id":"evt_1","type":"payment.succeeded","provider_ref":"fake_pi_2"}`)
+
+		_, err := p.VerifyWebhook(tampered, signature)
+
+		assert.True(t, errors.Is(err, paymentModel.ErrInvalidSignature))
+	})
+
+	t.Run("no secret configured", func(t *testing.T) {
+		unsigned := &Provider{}
+
+		_, err := unsigned.VerifyWebhook(payload, unsigned.Sign(payload))
+
+		assert.True(t, errors.Is(err, paymentModel.ErrInvalidSignature))
+	})
+}
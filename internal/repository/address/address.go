@@ -0,0 +1,217 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const addressColumns = `
+        id, user_id, label, full_name, line1, line2, city, region, postal_code, country, phone,
+        is_default, created_at, updated_at`
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// CreateAddress adds an address to a user's address book. A user's first
+// address becomes their default whatever the request says.
+func (r *Repository) CreateAddress(ctx context.Context, a model.Address) (id uuid.UUID, err error) {
+	const op = "repository.address.CreateAddress"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if a.IsDefault {
+		if err = clearDefault(ctx, tx, a.UserID); err != nil {
+			return uuid.Nil, errors.Wrap(err, op)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO addresses (user_id, label, full_name, line1, line2, city, region, postal_code, country, phone, is_default)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+            $11 OR NOT EXISTS (SELECT 1 FROM addresses WHERE user_id = $1))
+        RETURNING id`,
+		a.UserID, a.Label, a.FullName, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country, a.Phone, a.IsDefault,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	return id, nil
+}
+
+// UpdateAddress rewrites one of a user's addresses. Orders that were sent to
+// it keep their own copy.
+func (r *Repository) UpdateAddress(ctx context.Context, a model.Address) (err error) {
+	const op = "repository.address.UpdateAddress"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if a.IsDefault {
+		if err = clearDefault(ctx, tx, a.UserID); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        UPDATE addresses
+        SET label = $3, full_name = $4, line1 = $5, line2 = $6, city = $7, region = $8,
+            postal_code = $9, country = $10, phone = $11, is_default = is_default OR $12, updated_at = $13
+        WHERE id = $1 AND user_id = $2`,
+		a.ID, a.UserID, a.Label, a.FullName, a.Line1, a.Line2, a.City, a.Region,
+		a.PostalCode, a.Country, a.Phone, a.IsDefault, time.Now())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err = expectOne(res); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// DeleteAddress removes one of a user's addresses. When it was the default,
+// the most recently added remaining address takes over.
+func (r *Repository) DeleteAddress(ctx context.Context, userID, id uuid.UUID) (err error) {
+	const op = "repository.address.DeleteAddress"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var wasDefault bool
+	err = tx.QueryRowContext(ctx, "DELETE FROM addresses WHERE id = $1 AND user_id = $2 RETURNING is_default",
+		id, userID).Scan(&wasDefault)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrAddressNotFound
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if wasDefault {
+		_, err = tx.ExecContext(ctx, `
+            UPDATE addresses SET is_default = TRUE
+            WHERE id = (SELECT id FROM addresses WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1)`, userID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// GetAddresses lists a user's address book, default first.
+func (r *Repository) GetAddresses(ctx context.Context, userID uuid.UUID) ([]model.Address, error) {
+	const op = "repository.address.GetAddresses"
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+addressColumns+`
+        FROM addresses WHERE user_id = $1
+        ORDER BY is_default DESC, created_at DESC`, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var addresses []model.Address
+	for rows.Next() {
+		a, err := scanAddress(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		addresses = append(addresses, *a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return addresses, nil
+}
+
+// GetAddress finds one of a user's addresses; other users' addresses are not
+// found.
+func (r *Repository) GetAddress(ctx context.Context, userID, id uuid.UUID) (*model.Address, error) {
+	const op = "repository.address.GetAddress"
+
+	a, err := scanAddress(r.DB.QueryRowContext(ctx, "SELECT "+addressColumns+" FROM addresses WHERE id = $1 AND user_id = $2",
+		id, userID))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return a, nil
+}
+
+func clearDefault(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, "UPDATE addresses SET is_default = FALSE WHERE user_id = $1 AND is_default", userID)
+	return err
+}
+
+func expectOne(res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrAddressNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAddress(row scanner) (*model.Address, error) {
+	var a model.Address
+	err := row.Scan(&a.ID, &a.UserID, &a.Label, &a.FullName, &a.Line1, &a.Line2, &a.City, &a.Region,
+		&a.PostalCode, &a.Country, &a.Phone, &a.IsDefault, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAddressNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
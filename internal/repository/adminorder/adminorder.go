@@ -0,0 +1,157 @@
+package adminorder
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const summaryColumns = `o.id, o.user_id, u.email, u.username, o.status, o.total_price, o.currency,
+    (SELECT COALESCE(SUM(i.quantity), 0) FROM order_items i WHERE i.order_id = o.id),
+    o.created_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSummary(row scanner, extra ...interface{}) (*model.Summary, error) {
+	var s model.Summary
+	dest := append([]interface{}{&s.ID, &s.UserID, &s.Email, &s.Username, &s.Status, &s.TotalPrice, &s.Currency,
+		&s.Items, &s.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SearchOrders lists the orders matching f, newest first, and counts every
+// match so the caller can page through them.
+func (r *Repository) SearchOrders(ctx context.Context, f model.Filter) (*model.Page, error) {
+	const op = "repository.adminorder.SearchOrders"
+
+	var from, until sql.NullTime
+	if !f.From.IsZero() {
+		from = sql.NullTime{Time: f.From, Valid: true}
+	}
+	if !f.To.IsZero() {
+		until = sql.NullTime{Time: f.Until(), Valid: true}
+	}
+
+	var minTotal interface{}
+	if !f.MinTotal.IsZero() {
+		minTotal = f.MinTotal
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+summaryColumns+`, COUNT(*) OVER ()
+        FROM orders o
+        JOIN users u ON u.id = o.user_id
+        WHERE (($1 = '' AND o.status <> 'draft') OR o.status = $1)
+            AND ($2::timestamp IS NULL OR o.created_at >= $2)
+            AND ($3::timestamp IS NULL OR o.created_at < $3)
+            AND ($4 = '' OR POSITION(LOWER($4) IN LOWER(u.email)) > 0)
+            AND ($5::numeric IS NULL OR o.total_price >= $5)
+        ORDER BY o.created_at DESC, o.id
+        LIMIT $6 OFFSET $7`,
+		string(f.Status), from, until, f.Email, minTotal, f.Limit, f.Offset)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	page := &model.Page{Orders: []model.Summary{}, Limit: f.Limit, Offset: f.Offset}
+	for rows.Next() {
+		s, err := scanSummary(rows, &page.Total)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		page.Orders = append(page.Orders, *s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+// GetSummary returns the list row of a single order, drafts included.
+func (r *Repository) GetSummary(ctx context.Context, orderID uuid.UUID) (*model.Summary, error) {
+	const op = "repository.adminorder.GetSummary"
+
+	row := r.DB.QueryRowContext(ctx, `
+        SELECT `+summaryColumns+`
+        FROM orders o
+        JOIN users u ON u.id = o.user_id
+        WHERE o.id = $1`, orderID)
+
+	s, err := scanSummary(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrOrderNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s, nil
+}
+
+func (r *Repository) AddNote(ctx context.Context, note model.Note) (*model.Note, error) {
+	const op = "repository.adminorder.AddNote"
+
+	err := r.DB.QueryRowContext(ctx, `
+        WITH added AS (
+            INSERT INTO order_notes (order_id, author_id, body)
+            VALUES ($1, $2, $3)
+            RETURNING id, created_at, author_id
+        )
+        SELECT a.id, a.created_at, COALESCE(u.username, '')
+        FROM added a
+        LEFT JOIN users u ON u.id = a.author_id`,
+		note.OrderID, note.AuthorID, note.Body).Scan(&note.ID, &note.CreatedAt, &note.Author)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &note, nil
+}
+
+// GetNotes returns the notes on an order, oldest first. Notes whose author
+// was deleted keep an empty author.
+func (r *Repository) GetNotes(ctx context.Context, orderID uuid.UUID) ([]model.Note, error) {
+	const op = "repository.adminorder.GetNotes"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT n.id, n.order_id, COALESCE(n.author_id, '00000000-0000-0000-0000-000000000000'),
+            COALESCE(u.username, ''), n.body, n.created_at
+        FROM order_notes n
+        LEFT JOIN users u ON u.id = n.author_id
+        WHERE n.order_id = $1
+        ORDER BY n.created_at, n.id`, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	notes := []model.Note{}
+	for rows.Next() {
+		var n model.Note
+		if err := rows.Scan(&n.ID, &n.OrderID, &n.AuthorID, &n.Author, &n.Body, &n.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		notes = append(notes, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return notes, nil
+}
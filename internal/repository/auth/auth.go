@@ -5,16 +5,19 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
-	"errors"
 	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/auth"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 	"time"
 )
 
 type Repository struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Events interfaces.EventRepository
 }
 
 func (r *Repository) Register(ctx context.Context, user model.Registration) (uuid.UUID, error) {
@@ -43,7 +46,7 @@ func (r *Repository) Login(ctx context.Context, user model.Login) (uuid.UUID, in
 	var userID uuid.UUID
 	var role int
 
-	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, password, role FROM users where email = $1")
+	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, password, role FROM users WHERE email = $1 AND NOT is_guest")
 	if err != nil {
 		return uuid.Nil, 0, err
 	}
@@ -65,3 +68,57 @@ func (r *Repository) Login(ctx context.Context, user model.Login) (uuid.UUID, in
 
 	return userID, role, nil
 }
+
+// ChangePassword replaces the user's password hash if current matches the
+// stored one, and records a user.password_changed event with it.
+func (r *Repository) ChangePassword(ctx context.Context, userID uuid.UUID, current, next string) error {
+	const op = "repository.auth.ChangePassword"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var storedPassword string
+	err = tx.QueryRowContext(ctx, "SELECT password FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&storedPassword)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(repository.ErrUserNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if storedPassword != current {
+		err = repository.ErrWrongPassword
+		return errors.Wrap(err, op)
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, "UPDATE users SET password = $2 WHERE id = $1", userID, next)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var e event.Event
+	e, err = event.New(event.TypeUserPasswordChanged, event.AggregateUser, userID, event.PasswordChanged{
+		UserID:    userID,
+		ChangedAt: now,
+	})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err = r.Events.Append(ctx, tx, e); err != nil {
+		return errors.Wrap(err, op+": failed to record event")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
@@ -3,20 +3,27 @@ package books
 import (
 	"context"
 	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"time"
 )
 
 type Repository struct {
-	DB *sql.DB
+	DB        *sql.DB
+	Inventory interfaces.InventoryRepository
+	Events    interfaces.EventRepository
 }
 
 func (r *Repository) GetAllBooks(ctx context.Context) (*[]model.Book, error) {
 	const op = "repository.books.GetAllBooks"
 
-	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, title, author, price, stock FROM books")
+	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, title, author, category, tax_class, price, stock, weight_grams, rating_average, rating_count FROM books")
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
@@ -32,7 +39,7 @@ func (r *Repository) GetAllBooks(ctx context.Context) (*[]model.Book, error) {
 	for rows.Next() {
 		var book model.Book
 
-		err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.Price, &book.Stock)
+		err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.Category, &book.TaxClass, &book.Price, &book.Stock, &book.WeightGrams, &book.RatingAverage, &book.RatingCount)
 		if err != nil {
 			return nil, errors.Wrap(err, op)
 		}
@@ -46,7 +53,7 @@ func (r *Repository) GetAllBooks(ctx context.Context) (*[]model.Book, error) {
 func (r *Repository) GetBookById(ctx context.Context, bookId uuid.UUID) (*model.Book, error) {
 	const op = "repository.books.GetBookById"
 
-	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, title, author, price, stock FROM books WHERE id = $1")
+	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, title, author, category, tax_class, price, stock, weight_grams, rating_average, rating_count FROM books WHERE id = $1")
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
@@ -59,7 +66,7 @@ func (r *Repository) GetBookById(ctx context.Context, bookId uuid.UUID) (*model.
 		return nil, errors.Wrap(err, op)
 	}
 
-	err = row.Scan(&book.ID, &book.Title, &book.Author, &book.Price, &book.Stock)
+	err = row.Scan(&book.ID, &book.Title, &book.Author, &book.Category, &book.TaxClass, &book.Price, &book.Stock, &book.WeightGrams, &book.RatingAverage, &book.RatingCount)
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
@@ -70,18 +77,39 @@ func (r *Repository) GetBookById(ctx context.Context, bookId uuid.UUID) (*model.
 func (r *Repository) CreateBook(ctx context.Context, book model.Book) (uuid.UUID, error) {
 	const op = "repository.books.CreateBook"
 
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
 	var bookID uuid.UUID
-	err := r.DB.QueryRowContext(ctx, `
-		INSERT INTO books (title, author, price, stock, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO books (title, author, category, tax_class, price, stock, weight_grams, created_at)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'standard'), $5, 0, $6, $7)
 		RETURNING id`,
-		book.Title, book.Author, book.Price, book.Stock, time.Now(),
+		book.Title, book.Author, book.Category, book.TaxClass, book.Price, book.WeightGrams, time.Now(),
 	).Scan(&bookID)
-
 	if err != nil {
 		return uuid.Nil, errors.Wrap(err, op)
 	}
 
+	if book.Stock != 0 {
+		restock := inventory.NewMovement(bookID, inventory.ReasonRestock, book.Stock)
+		restock.Note = "initial stock"
+		if err = r.Inventory.RecordMovement(ctx, tx, restock); err != nil {
+			return uuid.Nil, errors.Wrap(err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
 	return bookID, nil
 }
 
@@ -102,26 +130,77 @@ func (r *Repository) DeleteBookById(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// UpdateBookById never writes books.stock directly: a differing stock value is
+// booked as an adjustment so the ledger can still explain the new number. A
+// new price is announced with a book.price_changed event.
 func (r *Repository) UpdateBookById(ctx context.Context, book model.Book, bookId uuid.UUID) (uuid.UUID, error) {
 	const op = "repository.books.UpdateBook"
 
-	stmt, err := r.DB.PrepareContext(ctx, `
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var oldPrice money.Money
+	err = tx.QueryRowContext(ctx, "SELECT price FROM books WHERE id = $1 FOR UPDATE", bookId).Scan(&oldPrice)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrBookNotFound
+		}
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	var currentStock int
+	err = tx.QueryRowContext(ctx, `
 		UPDATE books 
 		SET 
 			title = $2, 
 			author = $3,
-			price = $4,
-			stock = $5
+			category = $4,
+			tax_class = COALESCE(NULLIF($5, ''), 'standard'),
+			price = $6,
+			weight_grams = $7
 		WHERE id = $1
-	`)
+		RETURNING stock
+	`, bookId, book.Title, book.Author, book.Category, book.TaxClass, book.Price, book.WeightGrams).Scan(&currentStock)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrBookNotFound
+		}
 		return uuid.Nil, errors.Wrap(err, op)
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, bookId, book.Title, book.Author, book.Price, book.Stock)
-	if err != nil {
-		return uuid.Nil, errors.Wrap(err, op)
+	if delta := book.Stock - currentStock; delta != 0 {
+		adjustment := inventory.NewMovement(bookId, inventory.ReasonAdjustment, delta)
+		adjustment.Note = "stock set via book update"
+		if err = r.Inventory.RecordMovement(ctx, tx, adjustment); err != nil {
+			return uuid.Nil, errors.Wrap(err, op)
+		}
+	}
+
+	if !oldPrice.Equal(book.Price) {
+		var e event.Event
+		e, err = event.New(event.TypeBookPriceChanged, event.AggregateBook, bookId, event.PriceChanged{
+			BookID:   bookId,
+			Title:    book.Title,
+			OldPrice: oldPrice,
+			NewPrice: book.Price,
+		})
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, op)
+		}
+		if err = r.Events.Append(ctx, tx, e); err != nil {
+			return uuid.Nil, errors.Wrap(err, op+": failed to record event")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to commit transaction")
 	}
 
 	return bookId, nil
@@ -1,5 +1,28 @@
 package repository
 
+import "errors"
+
 var (
-	ErrUserNotFound = "user not found"
+	ErrUserNotFound         = errors.New("user not found")
+	ErrWrongPassword        = errors.New("wrong password")
+	ErrBookNotFound         = errors.New("book not found")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrPromotionNotFound    = errors.New("promotion not found")
+	ErrAddressNotFound      = errors.New("address not found")
+	ErrShipmentNotFound     = errors.New("shipment not found")
+	ErrReturnNotFound       = errors.New("return not found")
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrEventNotFound        = errors.New("event not found")
+	ErrWebhookNotFound      = errors.New("webhook endpoint not found")
+	ErrAlertNotFound        = errors.New("stock alert not found")
+	ErrWishlistNotFound     = errors.New("wishlist not found")
+	ErrWishlistItemNotFound = errors.New("book is not on the wishlist")
+	ErrReviewNotFound       = errors.New("review not found")
+	ErrCartItemNotFound     = errors.New("book is not in the cart")
+	ErrWebhookEventBusy     = errors.New("webhook event is being processed")
+	ErrPaymentPending       = errors.New("a payment for this order is still being processed")
+	ErrRefundNotFound       = errors.New("refund not found")
+	ErrRefundIssued         = errors.New("refund has already been issued")
 )
@@ -0,0 +1,191 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"sort"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const eventColumns = `id, type, aggregate_type, aggregate_id, payload, status, attempts, last_error, handled_by,
+    next_attempt_at, created_at, delivered_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEvent(row scanner) (*model.Event, error) {
+	var e model.Event
+	var payload []byte
+	var deliveredAt sql.NullTime
+	err := row.Scan(&e.ID, &e.Type, &e.AggregateType, &e.AggregateID, &payload, &e.Status, &e.Attempts,
+		&e.LastError, pq.Array(&e.HandledBy), &e.NextAttemptAt, &e.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	e.Payload = payload
+	if deliveredAt.Valid {
+		e.DeliveredAt = &deliveredAt.Time
+	}
+	return &e, nil
+}
+
+// Append writes events to the outbox inside the caller's transaction, so they
+// are published only if the change that raised them commits.
+func (r *Repository) Append(ctx context.Context, tx *sql.Tx, events ...model.Event) error {
+	const op = "repository.event.Append"
+
+	for _, e := range events {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO domain_events (type, aggregate_type, aggregate_id, payload)
+            VALUES ($1, $2, $3, $4)`,
+			e.Type, e.AggregateType, e.AggregateID, []byte(e.Payload))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue takes up to limit pending events that are due and pushes their
+// next attempt lease into the future, so no other dispatcher picks them up
+// meanwhile. An event whose dispatcher dies before marking it is claimed
+// again once the lease runs out. Events come back oldest first.
+func (r *Repository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Event, error) {
+	const op = "repository.event.ClaimDue"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        UPDATE domain_events
+        SET next_attempt_at = $1
+        WHERE id IN (
+            SELECT id
+            FROM domain_events
+            WHERE status = 'pending' AND next_attempt_at <= $2
+            ORDER BY created_at
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+eventColumns, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	return events, nil
+}
+
+func (r *Repository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.event.MarkDelivered"
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE domain_events
+        SET status = 'delivered', last_error = '', delivered_at = $1
+        WHERE id = $2`, time.Now(), id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, failure model.Failure) error {
+	const op = "repository.event.MarkFailed"
+
+	status := model.StatusPending
+	if failure.Dead {
+		status = model.StatusDead
+	}
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE domain_events
+        SET status = $1, attempts = $2, last_error = $3, handled_by = $4, next_attempt_at = $5
+        WHERE id = $6`,
+		status, failure.Attempts, failure.Error, pq.Array(failure.HandledBy), failure.NextAttemptAt, id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// GetStuck lists dead-lettered events and events still pending that were
+// raised before createdBefore, oldest first.
+func (r *Repository) GetStuck(ctx context.Context, createdBefore time.Time, limit int) ([]model.Event, error) {
+	const op = "repository.event.GetStuck"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+eventColumns+`
+        FROM domain_events
+        WHERE status = 'dead' OR (status = 'pending' AND created_at < $1)
+        ORDER BY created_at
+        LIMIT $2`, createdBefore, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return events, nil
+}
+
+// Requeue makes an undelivered event due now with a fresh set of attempts.
+// Subscribers that already handled it are not sent it again.
+func (r *Repository) Requeue(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+	const op = "repository.event.Requeue"
+
+	row := r.DB.QueryRowContext(ctx, `
+        UPDATE domain_events
+        SET status = 'pending', attempts = 0, next_attempt_at = $1
+        WHERE id = $2 AND status <> 'delivered'
+        RETURNING `+eventColumns, time.Now(), id)
+
+	e, err := scanEvent(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrEventNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return e, nil
+}
+
+func scanEvents(rows *sql.Rows) ([]model.Event, error) {
+	defer rows.Close()
+
+	var events []model.Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
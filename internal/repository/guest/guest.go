@@ -0,0 +1,104 @@
+package guest
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// CreateGuest adds a guest user to hang an anonymous cart on. Guests cannot
+// log in: they have no password and are left out of email lookups.
+func (r *Repository) CreateGuest(ctx context.Context) (uuid.UUID, error) {
+	const op = "repository.guest.CreateGuest"
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	_, err = r.DB.ExecContext(ctx, `
+        INSERT INTO users (id, username, email, password, is_guest, created_at)
+        VALUES ($1, 'guest', '', '', TRUE, $2)`, id, time.Now())
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	return id, nil
+}
+
+// SetEmail records where a guest's order confirmations go. Only guests are
+// changed; anyone else is not found.
+func (r *Repository) SetEmail(ctx context.Context, guestID uuid.UUID, email string) error {
+	const op = "repository.guest.SetEmail"
+
+	res, err := r.DB.ExecContext(ctx, "UPDATE users SET email = $2 WHERE id = $1 AND is_guest", guestID, email)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(repository.ErrUserNotFound, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteGuest(ctx context.Context, guestID uuid.UUID) error {
+	const op = "repository.guest.DeleteGuest"
+
+	_, err := r.DB.ExecContext(ctx, `
+        DELETE FROM users u
+        WHERE u.id = $1 AND u.is_guest
+          AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id)`, guestID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// PurgeAbandoned deletes guests whose cart has not changed since the cutoff
+// and who never checked out, carts included, and returns how many it deleted.
+// A guest without a cart counts from when it was created. Guests whose cart
+// still holds stock are left for the reservation sweeper to release first.
+func (r *Repository) PurgeAbandoned(ctx context.Context, before time.Time, limit int) (int, error) {
+	const op = "repository.guest.PurgeAbandoned"
+
+	res, err := r.DB.ExecContext(ctx, `
+        DELETE FROM users
+        WHERE id IN (
+            SELECT u.id FROM users u
+            LEFT JOIN orders d ON d.user_id = u.id AND d.status = 'draft'
+            WHERE u.is_guest AND COALESCE(d.updated_at, u.created_at) < $1
+              AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id AND o.status <> 'draft')
+              AND NOT EXISTS (
+                  SELECT 1 FROM orders o
+                  JOIN order_items oi ON oi.order_id = o.id
+                  WHERE o.user_id = u.id AND oi.reserved_until IS NOT NULL
+              )
+            ORDER BY COALESCE(d.updated_at, u.created_at)
+            LIMIT $2
+            FOR UPDATE OF u SKIP LOCKED
+        )`, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return int(purged), nil
+}
@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/idempotency"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// Acquire claims a key for a new request. When the key is already taken it
+// returns the stored record instead and reports false. A key whose window
+// has passed counts as free.
+func (r *Repository) Acquire(ctx context.Context, record *model.Record) (*model.Record, bool, error) {
+	const op = "repository.idempotency.Acquire"
+
+	_, err := r.DB.ExecContext(ctx, `
+        DELETE FROM idempotency_keys 
+        WHERE scope = $1 AND key = $2 AND expires_at < $3`, record.Scope, record.Key, time.Now())
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	res, err := r.DB.ExecContext(ctx, `
+        INSERT INTO idempotency_keys (scope, key, fingerprint, status, expires_at) 
+        VALUES ($1, $2, $3, $4, $5) 
+        ON CONFLICT (scope, key) DO NOTHING`,
+		record.Scope, record.Key, record.Fingerprint, model.StatusInFlight, record.ExpiresAt)
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+	if inserted == 1 {
+		record.Status = model.StatusInFlight
+		return record, true, nil
+	}
+
+	existing := model.Record{Scope: record.Scope, Key: record.Key}
+	var responseStatus sql.NullInt64
+	var headers []byte
+	err = r.DB.QueryRowContext(ctx, `
+        SELECT fingerprint, status, response_status, response_headers, response_body, expires_at 
+        FROM idempotency_keys 
+        WHERE scope = $1 AND key = $2`, record.Scope, record.Key).
+		Scan(&existing.Fingerprint, &existing.Status, &responseStatus, &headers, &existing.ResponseBody, &existing.ExpiresAt)
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	existing.ResponseStatus = int(responseStatus.Int64)
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &existing.ResponseHeaders); err != nil {
+			return nil, false, errors.Wrap(err, op)
+		}
+	}
+
+	return &existing, false, nil
+}
+
+func (r *Repository) Complete(ctx context.Context, record *model.Record) error {
+	const op = "repository.idempotency.Complete"
+
+	headers, err := json.Marshal(record.ResponseHeaders)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = r.DB.ExecContext(ctx, `
+        UPDATE idempotency_keys 
+        SET status = $1, response_status = $2, response_headers = $3, response_body = $4 
+        WHERE scope = $5 AND key = $6`,
+		model.StatusCompleted, record.ResponseStatus, string(headers), record.ResponseBody, record.Scope, record.Key)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Release frees a key whose request did not produce a response worth
+// replaying, so the client can retry with it.
+func (r *Repository) Release(ctx context.Context, scope, key string) error {
+	const op = "repository.idempotency.Release"
+
+	_, err := r.DB.ExecContext(ctx, `
+        DELETE FROM idempotency_keys 
+        WHERE scope = $1 AND key = $2 AND status = $3`, scope, key, model.StatusInFlight)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	const op = "repository.idempotency.DeleteExpired"
+
+	res, err := r.DB.ExecContext(ctx, `
+        DELETE FROM idempotency_keys 
+        WHERE (scope, key) IN (
+            SELECT scope, key 
+            FROM idempotency_keys 
+            WHERE expires_at < $1 
+            LIMIT $2
+        )`, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return int(deleted), nil
+}
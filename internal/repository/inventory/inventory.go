@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Repository struct {
+	DB     *sql.DB
+	Events interfaces.EventRepository
+}
+
+// RecordMovement appends a movement to the ledger and keeps books.stock in step
+// with it. It runs inside the caller's transaction so the movement commits or
+// rolls back together with the change that caused it, and so does the event
+// raised when the book sells out or comes back.
+func (r *Repository) RecordMovement(ctx context.Context, tx *sql.Tx, movement model.Movement) error {
+	const op = "repository.inventory.RecordMovement"
+
+	var available int
+	err := tx.QueryRowContext(ctx, `
+        UPDATE books 
+        SET stock = stock + $1 
+        WHERE id = $2 
+        RETURNING stock`, movement.AvailableDelta(), movement.BookID).Scan(&available)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(repository.ErrBookNotFound, op)
+		}
+		return errors.Wrap(err, op+": failed to update book stock")
+	}
+
+	if available < 0 {
+		return errors.Wrap(repository.ErrInsufficientStock, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO stock_movements (book_id, order_id, actor_id, reason, on_hand_delta, reserved_delta, note) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		movement.BookID, movement.OrderID, movement.ActorID, movement.Reason,
+		movement.OnHandDelta, movement.ReservedDelta, movement.Note)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to insert movement")
+	}
+
+	e, ok, err := event.StockEvent(movement.BookID, movement.AvailableDelta(), available)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if ok {
+		if err = r.Events.Append(ctx, tx, e); err != nil {
+			return errors.Wrap(err, op+": failed to record stock event")
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) AdjustStock(ctx context.Context, movement model.Movement) error {
+	const op = "repository.inventory.AdjustStock"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = r.RecordMovement(ctx, tx, movement); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetMovementsByBookID(ctx context.Context, bookID uuid.UUID) ([]model.Movement, error) {
+	const op = "repository.inventory.GetMovementsByBookID"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT id, book_id, order_id, actor_id, reason, on_hand_delta, reserved_delta, note, created_at 
+        FROM stock_movements 
+        WHERE book_id = $1 
+        ORDER BY created_at DESC`, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var movements []model.Movement
+	for rows.Next() {
+		var m model.Movement
+		err := rows.Scan(&m.ID, &m.BookID, &m.OrderID, &m.ActorID, &m.Reason,
+			&m.OnHandDelta, &m.ReservedDelta, &m.Note, &m.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		movements = append(movements, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return movements, nil
+}
+
+func (r *Repository) GetLevelByBookID(ctx context.Context, bookID uuid.UUID) (*model.Level, error) {
+	const op = "repository.inventory.GetLevelByBookID"
+
+	var level model.Level
+	err := r.DB.QueryRowContext(ctx, `
+        SELECT book_id, on_hand, reserved, available 
+        FROM book_inventory 
+        WHERE book_id = $1`, bookID).Scan(&level.BookID, &level.OnHand, &level.Reserved, &level.Available)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrBookNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &level, nil
+}
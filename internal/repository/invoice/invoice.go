@@ -0,0 +1,220 @@
+package invoice
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const invoiceColumns = `id, number, order_id, user_id, issued_at, details, pdf`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvoice(row scanner) (*model.Invoice, error) {
+	var inv model.Invoice
+	err := row.Scan(&inv.ID, &inv.Number, &inv.OrderID, &inv.UserID, &inv.IssuedAt, &inv.Details, &inv.PDF)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// IssueInvoice gives a paid order its invoice inside the transaction that
+// marks it paid; the caller holds the lock on the order row. Numbers come from
+// a single counter row, so a payment that rolls back hands its number back and
+// there are no gaps. An order that already has an invoice keeps it.
+func (r *Repository) IssueInvoice(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*model.Invoice, error) {
+	const op = "repository.invoice.IssueInvoice"
+
+	existing, err := scanInvoice(tx.QueryRowContext(ctx, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1", orderID))
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var order orderModel.Model
+	err = tx.QueryRowContext(ctx, `
+        SELECT id, user_id, subtotal, discount_total, tax_total, tax_included, shipping_total, total_price,
+            currency, exchange_rate, shipping_address, billing_address, delivery_method
+        FROM orders
+        WHERE id = $1`, orderID).
+		Scan(&order.ID, &order.UserID, &order.Subtotal, &order.DiscountTotal, &order.TaxTotal, &order.TaxIncluded,
+			&order.ShippingTotal, &order.TotalPrice, &order.Currency, &order.ExchangeRate, &order.ShippingAddress,
+			&order.BillingAddress, &order.DeliveryMethod)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrOrderNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	lines, err := lines(ctx, tx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to load order lines")
+	}
+	taxes, err := taxes(ctx, tx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to load order taxes")
+	}
+
+	inv := &model.Invoice{
+		OrderID:  orderID,
+		UserID:   order.UserID,
+		IssuedAt: time.Now().UTC(),
+		Details:  model.Snapshot(&order, lines, taxes),
+	}
+
+	err = tx.QueryRowContext(ctx, "UPDATE invoice_sequence SET last_number = last_number + 1 WHERE id RETURNING last_number").
+		Scan(&inv.Number)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to take an invoice number")
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO invoices (number, order_id, user_id, issued_at, details)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id`, inv.Number, inv.OrderID, inv.UserID, inv.IssuedAt, inv.Details).Scan(&inv.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return inv, nil
+}
+
+// IssueMissingInvoice issues the invoice of an order that was paid before
+// invoices were, numbered at the end of the sequence.
+func (r *Repository) IssueMissingInvoice(ctx context.Context, orderID uuid.UUID) (*model.Invoice, error) {
+	const op = "repository.invoice.IssueMissingInvoice"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var status orderModel.Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrOrderNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	if !model.IsInvoiced(status) {
+		err = model.ErrNotInvoiced
+		return nil, errors.Wrapf(err, "%s: order is %s", op, status)
+	}
+
+	inv, err := r.IssueInvoice(ctx, tx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return inv, nil
+}
+
+func (r *Repository) GetInvoiceByOrderID(ctx context.Context, orderID uuid.UUID) (*model.Invoice, error) {
+	const op = "repository.invoice.GetInvoiceByOrderID"
+
+	inv, err := scanInvoice(r.DB.QueryRowContext(ctx, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1", orderID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrInvoiceNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return inv, nil
+}
+
+// SavePDF stores the rendered invoice unless one was stored already, and
+// returns whichever copy is kept. The first rendering is the invoice from then
+// on.
+func (r *Repository) SavePDF(ctx context.Context, id uuid.UUID, pdf []byte) ([]byte, error) {
+	const op = "repository.invoice.SavePDF"
+
+	var stored []byte
+	err := r.DB.QueryRowContext(ctx, `
+        UPDATE invoices SET pdf = COALESCE(pdf, $1), rendered_at = COALESCE(rendered_at, $2)
+        WHERE id = $3
+        RETURNING pdf`, pdf, time.Now(), id).Scan(&stored)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrInvoiceNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return stored, nil
+}
+
+// lines lists an order's lines in the order they are printed.
+func lines(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) ([]model.Line, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT oi.id, b.title, oi.quantity, oi.price
+        FROM order_items oi
+        JOIN books b ON b.id = oi.book_id
+        WHERE oi.order_id = $1
+        ORDER BY b.title, oi.id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []model.Line
+	for rows.Next() {
+		var line model.Line
+		if err := rows.Scan(&line.ItemID, &line.Title, &line.Quantity, &line.UnitPrice); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, rows.Err()
+}
+
+func taxes(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) ([]tax.LineTax, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT t.order_item_id, oi.book_id, t.name, t.jurisdiction, t.tax_class, t.rate, t.taxable, t.amount, t.inclusive
+        FROM order_item_taxes t
+        JOIN order_items oi ON oi.id = t.order_item_id
+        WHERE oi.order_id = $1
+        ORDER BY t.order_item_id, t.name`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var taxes []tax.LineTax
+	for rows.Next() {
+		var t tax.LineTax
+		if err := rows.Scan(&t.ItemID, &t.BookID, &t.Name, &t.Jurisdiction, &t.Class, &t.Rate, &t.Taxable, &t.Amount, &t.Inclusive); err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, t)
+	}
+
+	return taxes, rows.Err()
+}
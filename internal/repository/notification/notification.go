@@ -0,0 +1,189 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const messageColumns = `id, user_id, event_id, kind, recipient, subject, body_text, body_html, status, attempts,
+    last_error, next_attempt_at, created_at, sent_at`
+
+func (r *Repository) GetRecipient(ctx context.Context, userID uuid.UUID) (*model.Recipient, error) {
+	const op = "repository.notification.GetRecipient"
+
+	rcpt := model.Recipient{UserID: userID}
+	err := r.DB.QueryRowContext(ctx, "SELECT username, email FROM users WHERE id = $1", userID).
+		Scan(&rcpt.Username, &rcpt.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrUserNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &rcpt, nil
+}
+
+func (r *Repository) GetOptOuts(ctx context.Context, userID uuid.UUID) ([]model.Kind, error) {
+	const op = "repository.notification.GetOptOuts"
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT kind FROM notification_opt_outs WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var kinds []model.Kind
+	for rows.Next() {
+		var k model.Kind
+		if err := rows.Scan(&k); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		kinds = append(kinds, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return kinds, nil
+}
+
+func (r *Repository) SetPreferences(ctx context.Context, userID uuid.UUID, req model.PreferencesRequest) error {
+	const op = "repository.notification.SetPreferences"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for kind, enabled := range req {
+		if enabled {
+			_, err = tx.ExecContext(ctx, "DELETE FROM notification_opt_outs WHERE user_id = $1 AND kind = $2", userID, kind)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+                INSERT INTO notification_opt_outs (user_id, kind) VALUES ($1, $2)
+                ON CONFLICT (user_id, kind) DO NOTHING`, userID, kind)
+		}
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (r *Repository) Enqueue(ctx context.Context, msg model.Message) (bool, error) {
+	const op = "repository.notification.Enqueue"
+
+	res, err := r.DB.ExecContext(ctx, `
+        INSERT INTO email_outbox (user_id, event_id, kind, recipient, subject, body_text, body_html)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (event_id, kind) DO NOTHING`,
+		msg.UserID, msg.EventID, msg.Kind, msg.To, msg.Subject, msg.Text, msg.HTML)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	queued, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	return queued > 0, nil
+}
+
+// ClaimDue takes up to limit pending emails that are due and hides them from
+// other senders until the lease runs out.
+func (r *Repository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Message, error) {
+	const op = "repository.notification.ClaimDue"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        UPDATE email_outbox
+        SET next_attempt_at = $1
+        WHERE id IN (
+            SELECT id
+            FROM email_outbox
+            WHERE status = 'pending' AND next_attempt_at <= $2
+            ORDER BY created_at
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+messageColumns, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var messages []model.Message
+	for rows.Next() {
+		var m model.Message
+		var sentAt sql.NullTime
+		err := rows.Scan(&m.ID, &m.UserID, &m.EventID, &m.Kind, &m.To, &m.Subject, &m.Text, &m.HTML, &m.Status,
+			&m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt, &sentAt)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if sentAt.Valid {
+			m.SentAt = &sentAt.Time
+		}
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return messages, nil
+}
+
+func (r *Repository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.notification.MarkSent"
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE email_outbox
+        SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = $2
+        WHERE id = $1`, id, time.Now())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, failure model.Failure) error {
+	const op = "repository.notification.MarkFailed"
+
+	status := model.StatusPending
+	if failure.Final {
+		status = model.StatusFailed
+	}
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE email_outbox
+        SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5
+        WHERE id = $1`, id, status, failure.Attempts, failure.Error, failure.NextAttemptAt)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
@@ -1,65 +1,81 @@
 package order
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
 	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"slices"
+	"time"
 )
 
+const orderColumns = `id, user_id, status, subtotal, discount_total, tax_total, tax_included, shipping_total,
+    total_price, refunded_total, currency, exchange_rate, shipping_address, billing_address, delivery_method`
+
 type Repository struct {
-	DB *sql.DB
+	DB             *sql.DB
+	Inventory      interfaces.InventoryRepository
+	Invoices       interfaces.InvoiceRepository
+	Events         interfaces.EventRepository
+	Refunds        interfaces.RefundRepository
+	ReservationTTL time.Duration
 }
 
 func (r *Repository) GetUsersOrder(ctx context.Context, userId string) (*orderModel.Model, error) {
 	const op = "repository.order.GetUsersOrder"
 
-	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, user_id, status, total_price FROM orders WHERE user_id = $1 and status = 'draft'")
+	stmt, err := r.DB.PrepareContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE user_id = $1 and status = 'draft'")
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 	defer stmt.Close()
 
-	var order orderModel.Model
-
 	row := stmt.QueryRowContext(ctx, userId)
 	if row.Err() != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
-	err = row.Scan(&order.ID, &order.UserID, &order.Status, &order.TotalPrice)
+	order, err := scanOrder(row)
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
-	return &order, nil
+	return order, nil
 }
 
 func (r *Repository) GetUserOrderByUserID(ctx context.Context, orderId string) (*orderModel.Model, error) {
 	const op = "repository.order.GetUsersOrder"
 
-	stmt, err := r.DB.PrepareContext(ctx, "SELECT id, user_id, status, total_price FROM orders WHERE user_id = $1")
+	stmt, err := r.DB.PrepareContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE user_id = $1")
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 	defer stmt.Close()
 
-	var order orderModel.Model
-
 	row := stmt.QueryRowContext(ctx, orderId)
 	if row.Err() != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
-	err = row.Scan(&order.ID, &order.UserID, &order.Status, &order.TotalPrice)
+	order, err := scanOrder(row)
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
-	return &order, nil
+	return order, nil
 }
 
 func (r *Repository) CreateUserOrder(ctx context.Context, userID string) error {
@@ -100,23 +116,6 @@ func (r *Repository) CheckOrderExists(ctx context.Context, userID string) (bool,
 	return exists, nil
 }
 
-func (r *Repository) AlterUserOrder(ctx context.Context, userID string) error {
-	const op = "repository.order.AlterUserOrder"
-
-	stmt, err := r.DB.PrepareContext(ctx, "UPDATE orders SET status = 'paid' WHERE user_id = ?")
-	if err != nil {
-		return errors.Wrap(err, op)
-	}
-	defer stmt.Close()
-
-	_, err = stmt.ExecContext(ctx, userID)
-	if err != nil {
-		return errors.Wrap(err, op)
-	}
-
-	return nil
-}
-
 func (r *Repository) AddOrderItemIntoOrder(ctx context.Context, userID string, items *[]orderModels.OrderItem) error {
 	const op = "repository.order.AddOrderItemIntoOrder"
 
@@ -141,10 +140,11 @@ func (r *Repository) AddOrderItemIntoOrder(ctx context.Context, userID string, i
 	}
 
 	if orderStatus != "draft" {
-		return errors.Wrap(errors.New("order is not in draft status"), op)
+		err = errors.Wrap(errors.New("order is not in draft status"), op)
+		return err
 	}
 
-	stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, stmtUpdateStock, stmtUpdateTotal, err := r.prepareStatements(ctx, tx)
+	stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, err := r.prepareStatements(ctx, tx)
 	if err != nil {
 		return err
 	}
@@ -152,49 +152,65 @@ func (r *Repository) AddOrderItemIntoOrder(ctx context.Context, userID string, i
 	defer stmtCheckExisting.Close()
 	defer stmtUpdateItem.Close()
 	defer stmtInsertItem.Close()
-	defer stmtUpdateStock.Close()
-	defer stmtUpdateTotal.Close()
 
-	for _, item := range *items {
-		if err := r.processItem(ctx, tx, item, orderID, stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, stmtUpdateStock, stmtUpdateTotal); err != nil {
+	// Books are locked in id order, as everywhere else that takes several of
+	// them, so two carts adding the same books cannot deadlock.
+	lines := slices.Clone(*items)
+	slices.SortFunc(lines, func(a, b orderModels.OrderItem) int {
+		return bytes.Compare(a.BookID.Bytes(), b.BookID.Bytes())
+	})
+
+	for _, item := range lines {
+		if err = r.processItem(ctx, tx, item, orderID, stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem); err != nil {
 			return err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err = r.recalculateTotal(ctx, tx, orderID); err != nil {
+		return err
+	}
+
+	added := event.ItemsAdded{OrderID: orderID, UserID: userID}
+	for _, item := range *items {
+		added.Items = append(added.Items, event.ItemLine{BookID: item.BookID, Quantity: item.Quantity})
+	}
+	e, err := event.New(event.TypeOrderItemsAdded, event.AggregateOrder, orderID, added)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err = r.Events.Append(ctx, tx, e); err != nil {
+		return errors.Wrap(err, op+": failed to record event")
+	}
+
+	if err = tx.Commit(); err != nil {
 		return errors.Wrap(err, op+": failed to commit transaction")
 	}
 
 	return nil
 }
 
+// getOrCreateOrder finds the user's draft order inside tx, locking it, or
+// creates one, so the lines added next land on an order the same transaction
+// holds.
 func (r *Repository) getOrCreateOrder(ctx context.Context, tx *sql.Tx, userID string) (uuid.UUID, error) {
 	const op = "repository.order.getOrCreateOrder"
 
-	exists, err := r.CheckOrderExists(ctx, userID)
-	if err != nil {
-		return uuid.Nil, errors.Wrap(err, op+": failed to check existing order")
+	var orderID uuid.UUID
+	err := tx.QueryRowContext(ctx, "SELECT id FROM orders WHERE user_id = $1 AND status = 'draft' FOR UPDATE", userID).
+		Scan(&orderID)
+	if err == nil {
+		return orderID, nil
 	}
-
-	if exists {
-		order, err := r.GetUsersOrder(ctx, userID)
-		if err != nil {
-			return uuid.Nil, errors.Wrap(err, op+": failed to get existing order")
-		}
-		return order.ID, nil
+	if !errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, errors.Wrap(err, op+": failed to get existing order")
 	}
 
-	err = r.CreateUserOrder(ctx, userID)
+	err = tx.QueryRowContext(ctx, "INSERT INTO orders(user_id) VALUES ($1) RETURNING id", userID).Scan(&orderID)
 	if err != nil {
 		return uuid.Nil, errors.Wrap(err, op+": failed to create new order")
 	}
 
-	order, err := r.GetUsersOrder(ctx, userID)
-	if err != nil {
-		return uuid.Nil, errors.Wrap(err, op+": failed to get newly created order")
-	}
-
-	return order.ID, nil
+	return orderID, nil
 }
 
 func (r *Repository) checkOrderStatus(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (string, error) {
@@ -208,78 +224,117 @@ func (r *Repository) checkOrderStatus(ctx context.Context, tx *sql.Tx, orderID u
 	return status, nil
 }
 
-func (r *Repository) prepareStatements(ctx context.Context, tx *sql.Tx) (*sql.Stmt, *sql.Stmt, *sql.Stmt, *sql.Stmt, *sql.Stmt, *sql.Stmt, error) {
+func (r *Repository) prepareStatements(ctx context.Context, tx *sql.Tx) (*sql.Stmt, *sql.Stmt, *sql.Stmt, *sql.Stmt, error) {
 	stmtBookPrice, err := tx.PrepareContext(ctx, "SELECT price, stock FROM books WHERE id = $1 FOR UPDATE")
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare book price statement")
-	}
-
-	stmtCheckExisting, err := tx.PrepareContext(ctx, "SELECT id, quantity FROM order_items WHERE order_id = $1 AND book_id = $2 FOR UPDATE")
-	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare check existing item statement")
-	}
-
-	stmtUpdateItem, err := tx.PrepareContext(ctx, "UPDATE order_items SET quantity = quantity + $1 WHERE id = $2")
-	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare update item statement")
+		return nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare book price statement")
 	}
 
-	stmtInsertItem, err := tx.PrepareContext(ctx, "INSERT INTO order_items (order_id, book_id, quantity, price) VALUES ($1, $2, $3, $4)")
+	stmtCheckExisting, err := tx.PrepareContext(ctx, "SELECT id, quantity, reserved_until FROM order_items WHERE order_id = $1 AND book_id = $2 FOR UPDATE")
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare insert item statement")
+		return nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare check existing item statement")
 	}
 
-	stmtUpdateStock, err := tx.PrepareContext(ctx, "UPDATE books SET stock = stock - $1 WHERE id = $2")
+	stmtUpdateItem, err := tx.PrepareContext(ctx, "UPDATE order_items SET quantity = quantity + $1, reserved_until = $3 WHERE id = $2")
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare update stock statement")
+		return nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare update item statement")
 	}
 
-	stmtUpdateTotal, err := tx.PrepareContext(ctx, "UPDATE orders SET total_price = total_price + $1 WHERE id = $2")
+	stmtInsertItem, err := tx.PrepareContext(ctx, "INSERT INTO order_items (order_id, book_id, quantity, price, reserved_until) VALUES ($1, $2, $3, $4, $5)")
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare update total statement")
+		return nil, nil, nil, nil, errors.Wrap(err, ": failed to prepare insert item statement")
 	}
 
-	return stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, stmtUpdateStock, stmtUpdateTotal, nil
+	return stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, nil
 }
 
-func (r *Repository) processItem(ctx context.Context, tx *sql.Tx, item orderModels.OrderItem, orderID uuid.UUID, stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, stmtUpdateStock, stmtUpdateTotal *sql.Stmt) error {
+func (r *Repository) processItem(ctx context.Context, tx *sql.Tx, item orderModels.OrderItem, orderID uuid.UUID, stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem *sql.Stmt) error {
 	const op = "repository.order.processItem"
 
-	var bookPrice float64
+	var bookPrice money.Money
 	var currentStock int
 	err := stmtBookPrice.QueryRowContext(ctx, item.BookID).Scan(&bookPrice, &currentStock)
 	if err != nil {
 		return errors.Wrap(err, op+": failed to get book price and stock")
 	}
 
-	if currentStock < item.Quantity {
-		return errors.Wrap(errors.New("insufficient stock"), op)
-	}
-
 	var existingItemID uuid.UUID
 	var existingQuantity int
-	err = stmtCheckExisting.QueryRowContext(ctx, orderID, item.BookID).Scan(&existingItemID, &existingQuantity)
+	var reservedUntil sql.NullTime
+	err = stmtCheckExisting.QueryRowContext(ctx, orderID, item.BookID).Scan(&existingItemID, &existingQuantity, &reservedUntil)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.Wrap(err, op+": failed to check existing item")
+	}
+
+	// A line whose reservation was swept holds no stock any more, so topping
+	// it up has to reserve the whole line again.
+	toReserve := item.Quantity
+	if err == nil && !reservedUntil.Valid {
+		toReserve += existingQuantity
+	}
+
+	if currentStock < toReserve {
+		return errors.Wrap(repository.ErrInsufficientStock, op)
+	}
+
+	expiresAt := time.Now().Add(r.ReservationTTL)
 
 	if err == sql.ErrNoRows {
-		_, err = stmtInsertItem.ExecContext(ctx, orderID, item.BookID, item.Quantity, bookPrice)
+		_, err = stmtInsertItem.ExecContext(ctx, orderID, item.BookID, item.Quantity, bookPrice, expiresAt)
 		if err != nil {
 			return errors.Wrap(err, op+": failed to add order item")
 		}
-	} else if err == nil {
-		_, err = stmtUpdateItem.ExecContext(ctx, item.Quantity, existingItemID)
+	} else {
+		_, err = stmtUpdateItem.ExecContext(ctx, item.Quantity, existingItemID, expiresAt)
 		if err != nil {
 			return errors.Wrap(err, op+": failed to update order item quantity")
 		}
-	} else {
-		return errors.Wrap(err, op+": failed to check existing item")
 	}
 
-	_, err = stmtUpdateStock.ExecContext(ctx, item.Quantity, item.BookID)
+	reservation := inventory.NewMovement(item.BookID, inventory.ReasonReservation, toReserve)
+	reservation.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+	if err = r.Inventory.RecordMovement(ctx, tx, reservation); err != nil {
+		return errors.Wrap(err, op+": failed to reserve stock")
+	}
+
+	return nil
+}
+
+// recalculateTotal sets the order's subtotal to the sum of its lines and
+// derives the total from it with the discount, tax and shipping last worked
+// out for the order. Totals are always derived this way, never nudged up and
+// down as lines change, so they cannot drift from what the lines add up to.
+// The order service prices the order again right after and stores the new
+// discount and tax with SavePricing.
+func (r *Repository) recalculateTotal(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	const op = "repository.order.recalculateTotal"
+
+	rows, err := tx.QueryContext(ctx, "SELECT quantity, price FROM order_items WHERE order_id = $1", orderID)
 	if err != nil {
-		return errors.Wrap(err, op+": failed to update book stock")
+		return errors.Wrap(err, op+": failed to get order lines")
+	}
+
+	var lines []orderModels.OrderItemFull
+	for rows.Next() {
+		var line orderModels.OrderItemFull
+		if err = rows.Scan(&line.Quantity, &line.Price); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op+": failed to scan order line")
+		}
+		lines = append(lines, line)
 	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return errors.Wrap(err, op+": rows iteration error")
+	}
+	rows.Close()
 
-	_, err = stmtUpdateTotal.ExecContext(ctx, bookPrice*float64(item.Quantity), orderID)
+	_, err = tx.ExecContext(ctx, `
+        UPDATE orders 
+        SET subtotal = $1, 
+            total_price = GREATEST($1 - discount_total, 0) + tax_total - tax_included + shipping_total, 
+            updated_at = $3 
+        WHERE id = $2`, orderModels.Total(lines), orderID, time.Now())
 	if err != nil {
 		return errors.Wrap(err, op+": failed to update order total price")
 	}
@@ -300,7 +355,7 @@ func (r *Repository) CreateUserOrderWithStatus(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-func (r *Repository) InsertOrderItem(ctx context.Context, tx *sql.Tx, orderID, bookID uuid.UUID, quantity int, price float64) error {
+func (r *Repository) InsertOrderItem(ctx context.Context, tx *sql.Tx, orderID, bookID uuid.UUID, quantity int, price money.Money) error {
 	const op = "repository.order.InsertOrderItem"
 
 	_, err := tx.ExecContext(ctx, `
@@ -327,7 +382,8 @@ func (r *Repository) GetOrderItemsFromOrderID(ctx context.Context, orderID strin
             oi.book_id, 
             b.title AS book_title, 
             oi.quantity, 
-            oi.price 
+            oi.price, 
+            oi.reserved_until 
         FROM 
             order_items oi 
         JOIN 
@@ -357,6 +413,7 @@ func (r *Repository) GetOrderItemsFromOrderID(ctx context.Context, orderID strin
 			&orderItem.Name,
 			&orderItem.Quantity,
 			&orderItem.Price,
+			&orderItem.ReservedUntil,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, op)
@@ -385,16 +442,17 @@ func (r *Repository) RemoveCartItem(ctx context.Context, userID string, bookID u
 
 	var orderID uuid.UUID
 	var quantity int
-	var price float64
+	var reserved bool
 	err = tx.QueryRowContext(ctx, `
-        SELECT o.id, oi.quantity, oi.price
+        SELECT o.id, oi.quantity, oi.reserved_until IS NOT NULL
         FROM orders o
         JOIN order_items oi ON o.id = oi.order_id
         WHERE o.user_id = $1 AND oi.book_id = $2 AND o.status = 'draft'
-    `, userID, bookID).Scan(&orderID, &quantity, &price)
+        FOR UPDATE OF oi
+    `, userID, bookID).Scan(&orderID, &quantity, &reserved)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return errors.Wrap(err, op+": no matching order or item found")
+			return errors.Wrap(repository.ErrCartItemNotFound, op)
 		}
 		return errors.Wrap(err, op+": failed to get order details")
 	}
@@ -405,16 +463,16 @@ func (r *Repository) RemoveCartItem(ctx context.Context, userID string, bookID u
 		return errors.Wrap(err, op+": failed to check book existence")
 	}
 	if !bookExists {
-		return errors.New("book not found")
+		err = errors.New("book not found")
+		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `
-        UPDATE books 
-        SET stock = stock + $1 
-        WHERE id = $2
-    `, quantity, bookID)
-	if err != nil {
-		return errors.Wrap(err, op+": failed to update book stock")
+	if reserved {
+		release := inventory.NewMovement(bookID, inventory.ReasonRelease, quantity)
+		release.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		if err = r.Inventory.RecordMovement(ctx, tx, release); err != nil {
+			return errors.Wrap(err, op+": failed to release stock")
+		}
 	}
 
 	res, err := tx.ExecContext(ctx, `
@@ -430,16 +488,116 @@ func (r *Repository) RemoveCartItem(ctx context.Context, userID string, bookID u
 		return errors.Wrap(err, op+": failed to check rows affected")
 	}
 	if rowsAffected == 0 {
-		return errors.New("no rows affected during deletion")
+		err = errors.New("no rows affected during deletion")
+		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `
-        UPDATE orders 
-        SET total_price = total_price - $1 
-        WHERE id = $2
-    `, price*float64(quantity), orderID)
+	if err = r.recalculateTotal(ctx, tx, orderID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+// SetCartItemQuantity sets how many copies of a book the user's draft order
+// holds, adding the line if it is not there yet. Only the difference to what
+// the line already holds is reserved or released; a line whose reservation
+// was swept reserves its whole new quantity again.
+func (r *Repository) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	const op = "repository.order.SetCartItemQuantity"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	orderID, err := r.getOrCreateOrder(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	var price money.Money
+	var stock int
+	err = tx.QueryRowContext(ctx, "SELECT price, stock FROM books WHERE id = $1 FOR UPDATE", bookID).Scan(&price, &stock)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(repository.ErrBookNotFound, op)
+		}
+		return errors.Wrap(err, op+": failed to get book price and stock")
+	}
+
+	var lineID uuid.UUID
+	var current int
+	var reservedUntil sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+        SELECT id, quantity, reserved_until FROM order_items
+        WHERE order_id = $1 AND book_id = $2
+        FOR UPDATE`, orderID, bookID).Scan(&lineID, &current, &reservedUntil)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, op+": failed to get cart line")
+	}
+
+	held := 0
+	if exists && reservedUntil.Valid {
+		held = current
+	}
+	delta := quantity - held
+
+	if delta > stock {
+		err = errors.Wrap(repository.ErrInsufficientStock, op)
+		return err
+	}
+
+	expiresAt := time.Now().Add(r.ReservationTTL)
+	if exists {
+		_, err = tx.ExecContext(ctx, "UPDATE order_items SET quantity = $1, reserved_until = $2 WHERE id = $3", quantity, expiresAt, lineID)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO order_items (order_id, book_id, quantity, price, reserved_until)
+            VALUES ($1, $2, $3, $4, $5)`, orderID, bookID, quantity, price, expiresAt)
+	}
 	if err != nil {
-		return errors.Wrap(err, op+": failed to update order total")
+		return errors.Wrap(err, op+": failed to save cart line")
+	}
+
+	if delta != 0 {
+		movement := inventory.NewMovement(bookID, inventory.ReasonReservation, delta)
+		if delta < 0 {
+			movement = inventory.NewMovement(bookID, inventory.ReasonRelease, -delta)
+		}
+		movement.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		if err = r.Inventory.RecordMovement(ctx, tx, movement); err != nil {
+			return errors.Wrap(err, op+": failed to move stock")
+		}
+	}
+
+	if err = r.recalculateTotal(ctx, tx, orderID); err != nil {
+		return err
+	}
+
+	if added := quantity - current; added > 0 {
+		var e event.Event
+		e, err = event.New(event.TypeOrderItemsAdded, event.AggregateOrder, orderID, event.ItemsAdded{
+			OrderID: orderID,
+			UserID:  userID,
+			Items:   []event.ItemLine{{BookID: bookID, Quantity: added}},
+		})
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if err = r.Events.Append(ctx, tx, e); err != nil {
+			return errors.Wrap(err, op+": failed to record event")
+		}
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -449,22 +607,30 @@ func (r *Repository) RemoveCartItem(ctx context.Context, userID string, bookID u
 	return nil
 }
 
-func (r *Repository) AlterUserOrderByID(ctx context.Context, userID, orderID uuid.UUID) error {
-	const op = "repository.order.AlterUserOrderByID"
+func (r *Repository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*orderModel.Model, error) {
+	const op = "repository.order.GetOrderByID"
 
-	stmt, err := r.DB.PrepareContext(ctx, `
-		UPDATE orders
-		SET status = 'paid'
-		WHERE user_id = $1
-		  AND id = $2
-		  AND status = 'draft'
-	`)
+	order, err := scanOrder(r.DB.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = $1", orderID))
 	if err != nil {
-		return errors.Wrap(err, op)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrOrderNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
 	}
-	defer stmt.Close()
 
-	res, err := stmt.ExecContext(ctx, userID, orderID)
+	return order, nil
+}
+
+// SetCheckoutCurrency records the currency a draft order is being checked out
+// in and the rate it was quoted at. Orders past draft keep what they were
+// charged in.
+func (r *Repository) SetCheckoutCurrency(ctx context.Context, orderID uuid.UUID, currency money.Currency, rate money.Rate) error {
+	const op = "repository.order.SetCheckoutCurrency"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE orders 
+        SET currency = $1, exchange_rate = $2, updated_at = $3 
+        WHERE id = $4 AND status = 'draft'`, currency, rate, time.Now(), orderID)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -474,89 +640,976 @@ func (r *Repository) AlterUserOrderByID(ctx context.Context, userID, orderID uui
 		return errors.Wrap(err, op)
 	}
 	if rowsAffected == 0 {
-		return errors.Wrap(errors.New("no draft order found for this user and order ID"), op)
+		return errors.Wrap(repository.ErrOrderNotFound, op)
 	}
 
 	return nil
 }
 
-func (r *Repository) ChangeStatusOfCart(ctx context.Context, userId, orderId string) error {
-	const op = "repository.order.ChangeStatusOfCart"
+// SetShipping records where a draft order goes, who is billed and how it is
+// delivered. Orders past draft keep what they were sent with.
+func (r *Repository) SetShipping(ctx context.Context, orderID uuid.UUID, shipping, billing *address.Snapshot, method string) error {
+	const op = "repository.order.SetShipping"
 
-	stmt, err := r.DB.PrepareContext(ctx, "UPDATE orders SET status = 'paid' WHERE user_id = $1 AND id = $2")
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE orders 
+        SET shipping_address = $1, billing_address = $2, delivery_method = $3, updated_at = $4 
+        WHERE id = $5 AND status = 'draft'`, shipping, billing, method, time.Now(), orderID)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, userId, orderId)
+	rowsAffected, err := res.RowsAffected()
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	if rowsAffected == 0 {
+		return errors.Wrap(repository.ErrOrderNotFound, op)
+	}
 
 	return nil
 }
 
-func (r *Repository) GetOrdersByUserID(ctx context.Context, userID string) ([]orderModels.HistoryOrderItem, error) {
-	const op = "repository.order.GetOrdersByUserID"
+// GetPricingLines returns an order's lines with what promotions are scoped by,
+// how each is taxed and what it weighs.
+func (r *Repository) GetPricingLines(ctx context.Context, orderID uuid.UUID) ([]promotion.Line, error) {
+	const op = "repository.order.GetPricingLines"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT oi.id, oi.book_id, COALESCE(b.author, ''), b.category, b.tax_class, oi.price, oi.quantity, b.weight_grams 
+        FROM order_items oi 
+        JOIN books b ON b.id = oi.book_id 
+        WHERE oi.order_id = $1 
+        ORDER BY oi.id`, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
 
-	query := `
-    WITH order_details AS (
-        SELECT 
-            o.id, 
-            o.total_price,
-            o.status,
-            o.created_at,
-            b.title as name, 
-            oi.book_id, 
-            oi.quantity, 
-            oi.price
-        FROM orders o
-        JOIN order_items oi ON o.id = oi.order_id
-        JOIN books b ON oi.book_id = b.id
-        WHERE o.user_id = $1
-    )
-    SELECT 
-        id, 
-        total_price, 
-        status, 
-        created_at,
-        json_agg(json_build_object(
-            'book_id', book_id,
-            'name', name,
-            'quantity', quantity,
-            'price', price
-        )) as items
-    FROM order_details
-    GROUP BY id, total_price, status, created_at
-    ORDER BY created_at DESC
-    `
+	var lines []promotion.Line
+	for rows.Next() {
+		var line promotion.Line
+		if err := rows.Scan(&line.ItemID, &line.BookID, &line.Author, &line.Category, &line.TaxClass, &line.Price, &line.Quantity, &line.WeightGrams); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		lines = append(lines, line)
+	}
 
-	var orders []orderModels.HistoryOrderItem
-	var itemsJSON []byte
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
 
-	rows, err := r.DB.QueryContext(ctx, query, userID)
+	return lines, nil
+}
+
+// SavePricing stores a draft order's priced breakdown: its subtotal, what
+// each applied promotion takes off, the tax on each line and where it was
+// taxed, and the total that will be charged.
+func (r *Repository) SavePricing(ctx context.Context, orderID uuid.UUID, breakdown promotion.Breakdown) error {
+	const op = "repository.order.SavePricing"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, op+": failed to execute query")
+		return errors.Wrap(err, op+": failed to begin transaction")
 	}
-	defer rows.Close()
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
-	for rows.Next() {
-		var order orderModels.HistoryOrderItem
-		if err := rows.Scan(&order.ID, &order.TotalPrice, &order.Status, &order.CreatedAt, &itemsJSON); err != nil {
-			return nil, errors.Wrap(err, op+": failed to scan row")
+	res, err := tx.ExecContext(ctx, `
+        UPDATE orders 
+        SET subtotal = $1, discount_total = $2, tax_total = $3, tax_included = $4, shipping_total = $5, 
+            total_price = $6, tax_country = $7, tax_region = $8, updated_at = $9 
+        WHERE id = $10 AND status = 'draft'`,
+		breakdown.Subtotal, breakdown.DiscountTotal, breakdown.TaxTotal, breakdown.TaxIncluded, breakdown.ShippingTotal,
+		breakdown.Total, breakdown.Jurisdiction.Country, breakdown.Jurisdiction.Region, time.Now(), orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if rowsAffected == 0 {
+		err = repository.ErrOrderNotFound
+		return errors.Wrap(err, op)
+	}
+
+	for _, discount := range breakdown.Discounts {
+		_, err = tx.ExecContext(ctx, "UPDATE order_promotions SET discount = $1 WHERE order_id = $2 AND promotion_id = $3",
+			discount.Amount, orderID, discount.PromotionID)
+		if err != nil {
+			return errors.Wrap(err, op)
 		}
+	}
 
-		if err := json.Unmarshal(itemsJSON, &order.Items); err != nil {
-			return nil, errors.Wrap(err, op+": failed to unmarshal items")
+	_, err = tx.ExecContext(ctx, `
+        DELETE FROM order_item_taxes 
+        WHERE order_item_id IN (SELECT id FROM order_items WHERE order_id = $1)`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to clear tax lines")
+	}
+
+	for _, line := range breakdown.Taxes {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO order_item_taxes (order_item_id, name, jurisdiction, tax_class, rate, taxable, amount, inclusive) 
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			line.ItemID, line.Name, line.Jurisdiction, line.Class, line.Rate, line.Taxable, line.Amount, line.Inclusive)
+		if err != nil {
+			return errors.Wrap(err, op+": failed to insert tax line")
 		}
+	}
 
-		orders = append(orders, order)
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, errors.Wrap(err, op+": rows iteration error")
+	return nil
+}
+
+// UpdateStatus moves an order in a transaction of its own through
+// ChangeStatus. It returns the status the order moved from.
+func (r *Repository) UpdateStatus(ctx context.Context, change orderModel.StatusChange) (orderModel.Status, error) {
+	const op = "repository.order.UpdateStatus"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(err, op+": failed to begin transaction")
 	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
-	return orders, nil
+	from, err := r.ChangeStatus(ctx, tx, change)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return from, nil
+}
+
+// ChangeStatus is the only place an order's status is written. It locks the
+// order, checks the move against the state machine, records it in
+// order_status_history and applies the side effects of the new status, stock
+// movements and the invoice of a payment, all in the caller's transaction
+// together with the event announcing the new status. It returns the status the
+// order moved from.
+func (r *Repository) ChangeStatus(ctx context.Context, tx *sql.Tx, change orderModel.StatusChange) (orderModel.Status, error) {
+	const op = "repository.order.ChangeStatus"
+
+	var from orderModel.Status
+	err := tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", change.OrderID).Scan(&from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrOrderNotFound
+		}
+		return "", errors.Wrap(err, op)
+	}
+
+	if err = orderModel.ValidateTransition(from, change.To); err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	if len(change.AllowedFrom) > 0 && !slices.Contains(change.AllowedFrom, from) {
+		err = &orderModel.TransitionError{From: from, To: change.To}
+		return "", errors.Wrap(err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3", change.To, time.Now(), change.OrderID)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO order_status_history (order_id, from_status, to_status, actor_id, actor_kind, reason) 
+        VALUES ($1, $2, $3, $4, $5, $6)`,
+		change.OrderID, from, change.To, change.Actor.ID, change.Actor.Kind, change.Reason)
+	if err != nil {
+		return "", errors.Wrap(err, op+": failed to record status history")
+	}
+
+	e, err := event.New(event.OrderStatusType(change.To), event.AggregateOrder, change.OrderID, event.StatusChanged{
+		OrderID:   change.OrderID,
+		From:      from,
+		To:        change.To,
+		ActorID:   change.Actor.ID,
+		ActorKind: change.Actor.Kind,
+		Reason:    change.Reason,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	if err = r.Events.Append(ctx, tx, e); err != nil {
+		return "", errors.Wrap(err, op+": failed to record event")
+	}
+
+	switch change.To {
+	case orderModel.StatusPendingPayment:
+		err = r.holdReservations(ctx, tx, change.OrderID)
+		if err == nil {
+			err = r.redeemPromotions(ctx, tx, change.OrderID)
+		}
+	case orderModel.StatusDraft:
+		_, err = tx.ExecContext(ctx, "UPDATE order_items SET reserved_until = $1 WHERE order_id = $2",
+			time.Now().Add(r.ReservationTTL), change.OrderID)
+		if err == nil {
+			err = r.releasePromotions(ctx, tx, change.OrderID)
+		}
+	case orderModel.StatusPaid:
+		// A fulfilled order unpacked back to paid has sold its stock and been
+		// invoiced already.
+		if from == orderModel.StatusPendingPayment {
+			err = r.recordSales(ctx, tx, change.OrderID)
+			if err == nil {
+				_, err = r.Invoices.IssueInvoice(ctx, tx, change.OrderID)
+			}
+		}
+	case orderModel.StatusCancelled:
+		err = r.cancelShipments(ctx, tx, change.OrderID, from)
+		if err == nil {
+			err = r.restoreStock(ctx, tx, change.OrderID, from.IsSettled())
+		}
+		if err == nil {
+			err = r.releasePromotions(ctx, tx, change.OrderID)
+		}
+		if err == nil && from.IsSettled() {
+			err = r.oweRefund(ctx, tx, change.OrderID, change.Reason)
+		}
+	}
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return from, nil
+}
+
+// oweRefund records what was charged for a paid order being cancelled as
+// owed back to the customer, so the refund outlives a provider that is down
+// when the cancellation commits.
+func (r *Repository) oweRefund(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, reason string) error {
+	const op = "repository.order.oweRefund"
+
+	order, err := scanOrder(tx.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = $1", orderID))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	amount := order.ChargeTotal()
+	if !amount.IsPositive() {
+		return nil
+	}
+
+	if reason == "" {
+		reason = "order cancelled"
+	}
+	err = r.Refunds.CreateRefund(ctx, tx, &refundModel.Refund{
+		OrderID: orderID,
+		Amount:  amount,
+		Reason:  reason,
+	})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// cancelShipments calls back the parcels of an order being cancelled. Once a
+// parcel is with the carrier the order can no longer be cancelled.
+func (r *Repository) cancelShipments(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, from orderModel.Status) error {
+	const op = "repository.order.cancelShipments"
+
+	var sent int
+	err := tx.QueryRowContext(ctx, `
+        SELECT COUNT(*) 
+        FROM shipments 
+        WHERE order_id = $1 AND status IN ('shipped', 'delivered')`, orderID).Scan(&sent)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if sent > 0 {
+		return errors.Wrap(&orderModel.TransitionError{From: from, To: orderModel.StatusCancelled}, op+": parcels already shipped")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE shipments 
+        SET status = 'cancelled', updated_at = $1 
+        WHERE order_id = $2 AND status = 'pending'`, time.Now(), orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]orderModel.StatusHistoryEntry, error) {
+	const op = "repository.order.GetStatusHistory"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT id, order_id, from_status, to_status, actor_id, actor_kind, reason, created_at 
+        FROM order_status_history 
+        WHERE order_id = $1 
+        ORDER BY created_at`, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var history []orderModel.StatusHistoryEntry
+	for rows.Next() {
+		var entry orderModel.StatusHistoryEntry
+		err := rows.Scan(&entry.ID, &entry.OrderID, &entry.FromStatus, &entry.ToStatus,
+			&entry.ActorID, &entry.ActorKind, &entry.Reason, &entry.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return history, nil
+}
+
+// holdReservations makes sure every line of an order leaving draft holds its
+// stock, reserving again what the sweeper released, so a checkout fails
+// before any money is taken if the books are gone. Orders outside draft are
+// never swept, so the reservation lasts until the order is paid or returns to
+// the cart. All lines are locked before deciding which to reserve, so the
+// sweeper cannot release one in between, and only the locked lines are
+// stamped as held.
+func (r *Repository) holdReservations(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	const op = "repository.order.holdReservations"
+
+	// Books before lines, the order every cart write locks them in.
+	_, err := tx.ExecContext(ctx, `
+        SELECT 1 
+        FROM books 
+        WHERE id IN (SELECT book_id FROM order_items WHERE order_id = $1) 
+        ORDER BY id 
+        FOR UPDATE`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to lock books")
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, book_id, quantity, reserved_until IS NOT NULL 
+        FROM order_items 
+        WHERE order_id = $1 
+        ORDER BY id 
+        FOR UPDATE`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var lineIDs []string
+	var movements []inventory.Movement
+	for rows.Next() {
+		var lineID, bookID uuid.UUID
+		var quantity int
+		var reserved bool
+		if err := rows.Scan(&lineID, &bookID, &quantity, &reserved); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op)
+		}
+		lineIDs = append(lineIDs, lineID.String())
+		if !reserved {
+			movements = append(movements, inventory.NewMovement(bookID, inventory.ReasonReservation, quantity))
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, movement := range movements {
+		movement.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		if err := r.Inventory.RecordMovement(ctx, tx, movement); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	if len(lineIDs) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE order_items SET reserved_until = $1 WHERE id = ANY($2::uuid[])",
+		time.Now().Add(r.ReservationTTL), pq.Array(lineIDs))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// redeemPromotions counts the promotions that take something off an order
+// against their usage limits as the order goes to payment. The promotion rows
+// are locked, in id order, so two checkouts cannot both take the last use.
+func (r *Repository) redeemPromotions(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	const op = "repository.order.redeemPromotions"
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT p.id, p.per_user_limit, o.user_id 
+        FROM order_promotions op 
+        JOIN promotions p ON p.id = op.promotion_id 
+        JOIN orders o ON o.id = op.order_id 
+        WHERE op.order_id = $1 AND op.discount > 0 
+        ORDER BY p.id 
+        FOR UPDATE OF p`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	type redemption struct {
+		promotionID  uuid.UUID
+		perUserLimit int
+		userID       uuid.UUID
+	}
+	var redemptions []redemption
+	for rows.Next() {
+		var rd redemption
+		if err := rows.Scan(&rd.promotionID, &rd.perUserLimit, &rd.userID); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op)
+		}
+		redemptions = append(redemptions, rd)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, rd := range redemptions {
+		if rd.perUserLimit > 0 {
+			var used int
+			err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2",
+				rd.promotionID, rd.userID).Scan(&used)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if used >= rd.perUserLimit {
+				return errors.Wrap(promotion.ErrUsageLimit, op)
+			}
+		}
+
+		res, err := tx.ExecContext(ctx, `
+            UPDATE promotions 
+            SET times_used = times_used + 1 
+            WHERE id = $1 AND (usage_limit = 0 OR times_used < usage_limit)`, rd.promotionID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if rowsAffected == 0 {
+			return errors.Wrap(promotion.ErrUsageLimit, op)
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO promotion_redemptions (promotion_id, order_id, user_id) VALUES ($1, $2, $3)",
+			rd.promotionID, orderID, rd.userID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
+
+// releasePromotions gives back the uses an order took when it returns to the
+// cart or is cancelled.
+func (r *Repository) releasePromotions(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	const op = "repository.order.releasePromotions"
+
+	_, err := tx.ExecContext(ctx, `
+        WITH released AS (
+            DELETE FROM promotion_redemptions WHERE order_id = $1 RETURNING promotion_id
+        )
+        UPDATE promotions p 
+        SET times_used = p.times_used - 1 
+        FROM released 
+        WHERE p.id = released.promotion_id`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// recordSales turns the reservations held by an order's lines into sales.
+// Lines whose reservation was already released by the sweeper are reserved
+// again first, so checkout fails if the stock has been sold in the meantime.
+func (r *Repository) recordSales(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	const op = "repository.order.recordSales"
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT book_id, quantity, reserved_until IS NOT NULL 
+        FROM order_items 
+        WHERE order_id = $1 
+        FOR UPDATE`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var movements []inventory.Movement
+	for rows.Next() {
+		var bookID uuid.UUID
+		var quantity int
+		var reserved bool
+		if err := rows.Scan(&bookID, &quantity, &reserved); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op)
+		}
+		if !reserved {
+			movements = append(movements, inventory.NewMovement(bookID, inventory.ReasonReservation, quantity))
+		}
+		movements = append(movements, inventory.NewMovement(bookID, inventory.ReasonSale, quantity))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, movement := range movements {
+		movement.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		if err := r.Inventory.RecordMovement(ctx, tx, movement); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE order_items SET reserved_until = NULL WHERE order_id = $1", orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// restoreStock undoes the stock effects of a cancelled order. Books that were
+// sold go back on hand; lines that were only reserved give their reservation
+// back, unless the sweeper already did.
+func (r *Repository) restoreStock(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, sold bool) error {
+	const op = "repository.order.restoreStock"
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT book_id, quantity, reserved_until IS NOT NULL 
+        FROM order_items 
+        WHERE order_id = $1 
+        FOR UPDATE`, orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var movements []inventory.Movement
+	for rows.Next() {
+		var bookID uuid.UUID
+		var quantity int
+		var reserved bool
+		if err := rows.Scan(&bookID, &quantity, &reserved); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op)
+		}
+		switch {
+		case sold:
+			movements = append(movements, inventory.NewMovement(bookID, inventory.ReasonReturn, quantity))
+		case reserved:
+			movements = append(movements, inventory.NewMovement(bookID, inventory.ReasonRelease, quantity))
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, movement := range movements {
+		movement.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		movement.Note = "order cancelled"
+		if err := r.Inventory.RecordMovement(ctx, tx, movement); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE order_items SET reserved_until = NULL WHERE order_id = $1", orderID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// ReleaseExpiredReservations gives the stock of draft lines whose reservation
+// has lapsed back to the available pool and returns how many lines it freed.
+// Like the cart writes it locks the books of the lines before the lines
+// themselves. Lines are claimed with SKIP LOCKED, so several instances can
+// sweep at once and a line that is being checked out is left alone.
+func (r *Repository) ReleaseExpiredReservations(ctx context.Context, limit int) (int, error) {
+	const op = "repository.order.ReleaseExpiredReservations"
+
+	now := time.Now()
+	expired, err := r.DB.QueryContext(ctx, `
+        SELECT oi.id, oi.book_id
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE o.status = 'draft' AND oi.reserved_until < $1
+        ORDER BY oi.reserved_until
+        LIMIT $2
+    `, now, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var candidates, bookIDs []string
+	for expired.Next() {
+		var lineID, bookID string
+		if err = expired.Scan(&lineID, &bookID); err != nil {
+			expired.Close()
+			return 0, errors.Wrap(err, op)
+		}
+		candidates = append(candidates, lineID)
+		bookIDs = append(bookIDs, bookID)
+	}
+	expired.Close()
+	if err = expired.Err(); err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "SELECT 1 FROM books WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE", pq.Array(bookIDs))
+	if err != nil {
+		return 0, errors.Wrap(err, op+": failed to lock books")
+	}
+
+	// The lines are read again under lock: one may have been renewed or
+	// checked out while the books were awaited.
+	rows, err := tx.QueryContext(ctx, `
+        SELECT oi.id, oi.order_id, oi.book_id, oi.quantity
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE oi.id = ANY($1::uuid[]) AND o.status = 'draft' AND oi.reserved_until < $2
+        FOR UPDATE OF oi SKIP LOCKED
+    `, pq.Array(candidates), now)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var lineIDs []uuid.UUID
+	var releases []inventory.Movement
+	for rows.Next() {
+		var lineID, orderID, bookID uuid.UUID
+		var quantity int
+		if err = rows.Scan(&lineID, &orderID, &bookID, &quantity); err != nil {
+			rows.Close()
+			return 0, errors.Wrap(err, op)
+		}
+		release := inventory.NewMovement(bookID, inventory.ReasonRelease, quantity)
+		release.OrderID = uuid.NullUUID{UUID: orderID, Valid: true}
+		release.Note = "cart reservation expired"
+		lineIDs = append(lineIDs, lineID)
+		releases = append(releases, release)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	for i, release := range releases {
+		if err = r.Inventory.RecordMovement(ctx, tx, release); err != nil {
+			return 0, errors.Wrap(err, op)
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE order_items SET reserved_until = NULL WHERE id = $1", lineIDs[i])
+		if err != nil {
+			return 0, errors.Wrap(err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return len(lineIDs), nil
+}
+
+// MergeCart moves the lines of one user's draft order into another's, adding
+// up the quantities of books both carts hold. The source lines give their
+// reserved stock back first and the merged lines reserve it again, so a line
+// only grows as far as the stock allows and what does not fit is dropped.
+// The source draft is deleted; a user without a draft has nothing to merge.
+func (r *Repository) MergeCart(ctx context.Context, fromUserID, toUserID string) error {
+	const op = "repository.order.MergeCart"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var fromOrderID uuid.UUID
+	err = tx.QueryRowContext(ctx, "SELECT id FROM orders WHERE user_id = $1 AND status = 'draft' FOR UPDATE", fromUserID).
+		Scan(&fromOrderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, op+": failed to get source order")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        SELECT 1
+        FROM books
+        WHERE id IN (SELECT book_id FROM order_items WHERE order_id = $1)
+        ORDER BY id
+        FOR UPDATE
+    `, fromOrderID)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to lock books")
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT book_id, quantity, reserved_until IS NOT NULL
+        FROM order_items
+        WHERE order_id = $1
+        ORDER BY book_id
+        FOR UPDATE
+    `, fromOrderID)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to get source lines")
+	}
+
+	var items []orderModels.OrderItem
+	var releases []inventory.Movement
+	for rows.Next() {
+		var item orderModels.OrderItem
+		var reserved bool
+		if err = rows.Scan(&item.BookID, &item.Quantity, &reserved); err != nil {
+			rows.Close()
+			return errors.Wrap(err, op+": failed to scan source line")
+		}
+		items = append(items, item)
+		if reserved {
+			release := inventory.NewMovement(item.BookID, inventory.ReasonRelease, item.Quantity)
+			release.OrderID = uuid.NullUUID{UUID: fromOrderID, Valid: true}
+			release.Note = "cart merged"
+			releases = append(releases, release)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, op+": rows iteration error")
+	}
+
+	for _, release := range releases {
+		if err = r.Inventory.RecordMovement(ctx, tx, release); err != nil {
+			return errors.Wrap(err, op+": failed to release stock")
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM orders WHERE id = $1", fromOrderID); err != nil {
+		return errors.Wrap(err, op+": failed to delete source order")
+	}
+
+	if len(items) > 0 {
+		if err = r.mergeItems(ctx, tx, toUserID, items); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+// mergeItems adds lines to a user's draft order, each capped at the stock
+// still available for it. Lines with nothing left to add are skipped.
+func (r *Repository) mergeItems(ctx context.Context, tx *sql.Tx, userID string, items []orderModels.OrderItem) error {
+	const op = "repository.order.mergeItems"
+
+	orderID, err := r.getOrCreateOrder(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem, err := r.prepareStatements(ctx, tx)
+	if err != nil {
+		return err
+	}
+	defer stmtBookPrice.Close()
+	defer stmtCheckExisting.Close()
+	defer stmtUpdateItem.Close()
+	defer stmtInsertItem.Close()
+
+	added := event.ItemsAdded{OrderID: orderID, UserID: userID}
+	for _, item := range items {
+		var stock, unreserved int
+		err = tx.QueryRowContext(ctx, "SELECT stock FROM books WHERE id = $1 FOR UPDATE", item.BookID).Scan(&stock)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return errors.Wrap(err, op+": failed to get book stock")
+		}
+		// A target line whose reservation was swept has to reserve its own
+		// quantity again before the merged copies fit.
+		err = tx.QueryRowContext(ctx, `
+            SELECT COALESCE(SUM(quantity), 0) FROM order_items
+            WHERE order_id = $1 AND book_id = $2 AND reserved_until IS NULL
+        `, orderID, item.BookID).Scan(&unreserved)
+		if err != nil {
+			return errors.Wrap(err, op+": failed to get target line")
+		}
+
+		item.Quantity = min(item.Quantity, stock-unreserved)
+		if item.Quantity <= 0 {
+			continue
+		}
+
+		if err = r.processItem(ctx, tx, item, orderID, stmtBookPrice, stmtCheckExisting, stmtUpdateItem, stmtInsertItem); err != nil {
+			return err
+		}
+		added.Items = append(added.Items, event.ItemLine{BookID: item.BookID, Quantity: item.Quantity})
+	}
+
+	if err = r.recalculateTotal(ctx, tx, orderID); err != nil {
+		return err
+	}
+
+	if len(added.Items) == 0 {
+		return nil
+	}
+
+	e, err := event.New(event.TypeOrderItemsAdded, event.AggregateOrder, orderID, added)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err = r.Events.Append(ctx, tx, e); err != nil {
+		return errors.Wrap(err, op+": failed to record event")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetOrdersByUserID(ctx context.Context, userID string) ([]orderModels.HistoryOrderItem, error) {
+	const op = "repository.order.GetOrdersByUserID"
+
+	query := `
+    WITH order_details AS (
+        SELECT 
+            o.id, 
+            o.subtotal,
+            o.discount_total,
+            o.tax_total,
+            o.tax_included,
+            o.shipping_total,
+            o.total_price,
+            o.refunded_total,
+            o.currency,
+            o.exchange_rate,
+            o.status,
+            o.created_at,
+            b.title as name, 
+            oi.book_id, 
+            oi.quantity, 
+            oi.price,
+            COALESCE((SELECT SUM(t.amount) FROM order_item_taxes t WHERE t.order_item_id = oi.id), 0) as tax
+        FROM orders o
+        JOIN order_items oi ON o.id = oi.order_id
+        JOIN books b ON oi.book_id = b.id
+        WHERE o.user_id = $1
+    )
+    SELECT 
+        id, 
+        subtotal, 
+        discount_total, 
+        tax_total, 
+        tax_included, 
+        shipping_total, 
+        total_price, 
+        refunded_total, 
+        currency, 
+        exchange_rate, 
+        status, 
+        created_at,
+        json_agg(json_build_object(
+            'book_id', book_id,
+            'name', name,
+            'quantity', quantity,
+            'price', price,
+            'tax', tax
+        )) as items
+    FROM order_details
+    GROUP BY id, subtotal, discount_total, tax_total, tax_included, shipping_total, total_price, refunded_total, currency, exchange_rate, status, created_at
+    ORDER BY created_at DESC
+    `
+
+	var orders []orderModels.HistoryOrderItem
+	var itemsJSON []byte
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to execute query")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order orderModels.HistoryOrderItem
+		if err := rows.Scan(&order.ID, &order.Subtotal, &order.DiscountTotal, &order.TaxTotal, &order.TaxIncluded, &order.ShippingTotal, &order.TotalPrice, &order.RefundedTotal, &order.Currency, &order.ExchangeRate, &order.Status, &order.CreatedAt, &itemsJSON); err != nil {
+			return nil, errors.Wrap(err, op+": failed to scan row")
+		}
+
+		if err := json.Unmarshal(itemsJSON, &order.Items); err != nil {
+			return nil, errors.Wrap(err, op+": failed to unmarshal items")
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op+": rows iteration error")
+	}
+
+	return orders, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrder(row scanner) (*orderModel.Model, error) {
+	var order orderModel.Model
+	err := row.Scan(&order.ID, &order.UserID, &order.Status, &order.Subtotal, &order.DiscountTotal, &order.TaxTotal,
+		&order.TaxIncluded, &order.ShippingTotal, &order.TotalPrice, &order.RefundedTotal, &order.Currency, &order.ExchangeRate,
+		&order.ShippingAddress, &order.BillingAddress, &order.DeliveryMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
 }
@@ -0,0 +1,341 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB      *sql.DB
+	Orders  interfaces.OrderRepository
+	Refunds interfaces.RefundRepository
+}
+
+const paymentColumns = `id, order_id, provider, COALESCE(provider_ref, ''), currency, amount, refunded_amount, 
+        status, failure_reason, created_at, updated_at`
+
+// CreatePayment records a new attempt to pay for an order. An order with an
+// attempt still pending gets no second one: ErrPaymentPending.
+func (r *Repository) CreatePayment(ctx context.Context, payment *model.Payment) error {
+	const op = "repository.payment.CreatePayment"
+
+	err := r.DB.QueryRowContext(ctx, `
+        INSERT INTO payments (id, order_id, provider, currency, amount, status) 
+        VALUES ($1, $2, $3, $4, $5, $6) 
+        RETURNING created_at, updated_at`,
+		payment.ID, payment.OrderID, payment.Provider, payment.Amount.Currency(), payment.Amount, payment.Status).
+		Scan(&payment.CreatedAt, &payment.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_payments_one_pending" {
+		return errors.Wrap(repository.ErrPaymentPending, op)
+	}
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetPayment(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	const op = "repository.payment.GetPayment"
+
+	payment, err := scanPayment(r.DB.QueryRowContext(ctx, "SELECT "+paymentColumns+" FROM payments WHERE id = $1", id))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return payment, nil
+}
+
+func (r *Repository) GetPaymentByProviderRef(ctx context.Context, provider, providerRef string) (*model.Payment, error) {
+	const op = "repository.payment.GetPaymentByProviderRef"
+
+	row := r.DB.QueryRowContext(ctx, `
+        SELECT `+paymentColumns+` 
+        FROM payments 
+        WHERE provider = $1 AND provider_ref = $2`, provider, providerRef)
+
+	payment, err := scanPayment(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return payment, nil
+}
+
+// GetSettledPaymentByOrderID returns the most recent payment that actually
+// took money for the order, refunded or not.
+func (r *Repository) GetSettledPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*model.Payment, error) {
+	const op = "repository.payment.GetSettledPaymentByOrderID"
+
+	row := r.DB.QueryRowContext(ctx, `
+        SELECT `+paymentColumns+` 
+        FROM payments 
+        WHERE order_id = $1 AND status IN ('succeeded', 'refunded') 
+        ORDER BY created_at DESC 
+        LIMIT 1`, orderID)
+
+	payment, err := scanPayment(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return payment, nil
+}
+
+// GetPaymentsByOrderID returns every attempt to pay for the order, failed
+// ones included, oldest first.
+func (r *Repository) GetPaymentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]model.Payment, error) {
+	const op = "repository.payment.GetPaymentsByOrderID"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+paymentColumns+`
+        FROM payments
+        WHERE order_id = $1
+        ORDER BY created_at`, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	payments := []model.Payment{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		payments = append(payments, *payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return payments, nil
+}
+
+func (r *Repository) SetProviderRef(ctx context.Context, paymentID uuid.UUID, providerRef string) error {
+	const op = "repository.payment.SetProviderRef"
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE payments 
+        SET provider_ref = $1, updated_at = $2 
+        WHERE id = $3`, providerRef, time.Now(), paymentID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// SettlePayment settles a pending payment and moves its order as change
+// says, in one transaction, so a payment is never left succeeded while its
+// order still awaits it. It reports whether the payment was settled by this
+// call and the status the order was in. A payment that has already left
+// pending is not touched, so a late or repeated notification cannot flip a
+// failed payment to succeeded or the other way round. An order that no longer
+// awaits payment is left where it is, and the payment is settled all the same;
+// money it captured for such an order is recorded as owed back.
+func (r *Repository) SettlePayment(ctx context.Context, paymentID uuid.UUID, status model.Status, failureReason string, change orderModel.StatusChange) (bool, orderModel.Status, error) {
+	const op = "repository.payment.SettlePayment"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, "", errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var currency money.Currency
+	var amount string
+	err = tx.QueryRowContext(ctx, `
+        UPDATE payments 
+        SET status = $1, failure_reason = $2, updated_at = $3 
+        WHERE id = $4 AND status = 'pending' 
+        RETURNING currency, amount`, status, failureReason, time.Now(), paymentID).Scan(&currency, &amount)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", errors.Wrap(err, op)
+	}
+
+	from, err := r.Orders.ChangeStatus(ctx, tx, change)
+	var transitionErr *orderModel.TransitionError
+	if errors.As(err, &transitionErr) && transitionErr.From != orderModel.StatusPendingPayment {
+		from, err = transitionErr.From, nil
+		if status == model.StatusSucceeded {
+			err = r.owePayment(ctx, tx, paymentID, change.OrderID, currency, amount, from)
+		}
+	}
+	if err != nil {
+		return false, "", errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, "", errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return true, from, nil
+}
+
+// owePayment records a payment captured for an order that had stopped
+// awaiting it, cancelled or already paid by another attempt, as owed back in
+// full.
+func (r *Repository) owePayment(ctx context.Context, tx *sql.Tx, paymentID, orderID uuid.UUID, currency money.Currency, amount string, status orderModel.Status) error {
+	captured, err := money.Parse(amount, currency)
+	if err != nil {
+		return err
+	}
+
+	return r.Refunds.CreateRefund(ctx, tx, &refund.Refund{
+		OrderID:   orderID,
+		PaymentID: uuid.NullUUID{UUID: paymentID, Valid: true},
+		Amount:    captured,
+		Reason:    "payment captured for an order that is " + string(status),
+	})
+}
+
+// AddRefund records money returned on a payment. The refunded total never
+// exceeds the captured amount, and a fully refunded payment is marked so.
+func (r *Repository) AddRefund(ctx context.Context, paymentID uuid.UUID, amount money.Money) error {
+	const op = "repository.payment.AddRefund"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE payments 
+        SET refunded_amount = LEAST(amount, refunded_amount + $1), 
+            status = CASE WHEN refunded_amount + $1 >= amount THEN 'refunded' ELSE status END, 
+            updated_at = $2 
+        WHERE id = $3 AND status IN ('succeeded', 'refunded')`, amount, time.Now(), paymentID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(repository.ErrPaymentNotFound, op)
+	}
+
+	return nil
+}
+
+// webhookClaim is how long a delivery holds its event. A worker that dies
+// mid-way frees the event for the provider's next redelivery once it lapses.
+const webhookClaim = time.Minute
+
+// RecordWebhookEvent stores a delivery and claims its event for processing,
+// reporting whether this delivery has to apply it. A redelivery of an event
+// that was already handled returns false. The claim is taken in the same
+// statement that stores the event, so of two concurrent deliveries only one
+// gets it; the other fails with ErrWebhookEventBusy and is retried by the
+// provider.
+func (r *Repository) RecordWebhookEvent(ctx context.Context, provider string, event *model.WebhookEvent, payload []byte) (bool, error) {
+	const op = "repository.payment.RecordWebhookEvent"
+
+	now := time.Now()
+
+	var claimed bool
+	err := r.DB.QueryRowContext(ctx, `
+        INSERT INTO payment_webhook_events (provider, event_id, event_type, payload, claimed_until) 
+        VALUES ($1, $2, $3, $4, $5) 
+        ON CONFLICT (provider, event_id) DO UPDATE SET claimed_until = EXCLUDED.claimed_until 
+        WHERE payment_webhook_events.processed_at IS NULL 
+          AND (payment_webhook_events.claimed_until IS NULL OR payment_webhook_events.claimed_until < $6) 
+        RETURNING true`, provider, event.ID, event.Type, string(payload), now.Add(webhookClaim), now).Scan(&claimed)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, errors.Wrap(err, op)
+	}
+
+	var processed bool
+	err = r.DB.QueryRowContext(ctx, `
+        SELECT processed_at IS NOT NULL FROM payment_webhook_events 
+        WHERE provider = $1 AND event_id = $2`, provider, event.ID).Scan(&processed)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	if !processed {
+		return false, errors.Wrap(repository.ErrWebhookEventBusy, op)
+	}
+
+	return false, nil
+}
+
+// ReleaseWebhookEvent gives up the claim on an event that failed to apply, so
+// the provider's redelivery can try again straight away.
+func (r *Repository) ReleaseWebhookEvent(ctx context.Context, provider, eventID string) error {
+	const op = "repository.payment.ReleaseWebhookEvent"
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE payment_webhook_events 
+        SET claimed_until = NULL 
+        WHERE provider = $1 AND event_id = $2 AND processed_at IS NULL`, provider, eventID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkWebhookEventProcessed(ctx context.Context, provider, eventID string) error {
+	const op = "repository.payment.MarkWebhookEventProcessed"
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE payment_webhook_events 
+        SET processed_at = $1 
+        WHERE provider = $2 AND event_id = $3`, time.Now(), provider, eventID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row scanner) (*model.Payment, error) {
+	var payment model.Payment
+	var currency money.Currency
+	var amount, refunded string
+	err := row.Scan(&payment.ID, &payment.OrderID, &payment.Provider, &payment.ProviderRef,
+		&currency, &amount, &refunded, &payment.Status, &payment.FailureReason,
+		&payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	// Amounts are read in the payment's own currency, which decides how many
+	// decimal places they carry.
+	if payment.Amount, err = money.Parse(amount, currency); err != nil {
+		return nil, err
+	}
+	if payment.RefundedAmount, err = money.Parse(refunded, currency); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
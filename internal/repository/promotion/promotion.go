@@ -0,0 +1,251 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const promotionColumns = `
+        p.id, p.code, p.description, p.kind, p.percent, p.amount, p.buy_quantity, p.get_quantity,
+        p.scope, p.scope_value, p.min_order_value, p.usage_limit, p.per_user_limit, p.times_used,
+        p.starts_at, p.ends_at, p.stackable, p.active, p.created_at, p.updated_at`
+
+type Repository struct {
+	DB *sql.DB
+}
+
+func (r *Repository) CreatePromotion(ctx context.Context, p model.Promotion) (uuid.UUID, error) {
+	const op = "repository.promotion.CreatePromotion"
+
+	var id uuid.UUID
+	err := r.DB.QueryRowContext(ctx, `
+        INSERT INTO promotions (code, description, kind, percent, amount, buy_quantity, get_quantity,
+            scope, scope_value, min_order_value, usage_limit, per_user_limit, starts_at, ends_at, stackable, active)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+        RETURNING id`,
+		p.Code, p.Description, p.Kind, p.Percent, p.Amount, p.BuyQuantity, p.GetQuantity,
+		p.Scope, p.ScopeValue, p.MinOrderValue, p.UsageLimit, p.PerUserLimit, p.StartsAt, p.EndsAt, p.Stackable, p.Active,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	return id, nil
+}
+
+// UpdatePromotion rewrites a promotion's terms. How often it has been used is
+// kept; that is counted by redemptions only.
+func (r *Repository) UpdatePromotion(ctx context.Context, p model.Promotion) error {
+	const op = "repository.promotion.UpdatePromotion"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE promotions
+        SET code = $2, description = $3, kind = $4, percent = $5, amount = $6, buy_quantity = $7,
+            get_quantity = $8, scope = $9, scope_value = $10, min_order_value = $11, usage_limit = $12,
+            per_user_limit = $13, starts_at = $14, ends_at = $15, stackable = $16, active = $17, updated_at = $18
+        WHERE id = $1`,
+		p.ID, p.Code, p.Description, p.Kind, p.Percent, p.Amount, p.BuyQuantity,
+		p.GetQuantity, p.Scope, p.ScopeValue, p.MinOrderValue, p.UsageLimit,
+		p.PerUserLimit, p.StartsAt, p.EndsAt, p.Stackable, p.Active, time.Now())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return expectOne(res, op)
+}
+
+// DeactivatePromotion switches a promotion off rather than deleting it, so
+// orders that used it keep their history.
+func (r *Repository) DeactivatePromotion(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.promotion.DeactivatePromotion"
+
+	res, err := r.DB.ExecContext(ctx, "UPDATE promotions SET active = FALSE, updated_at = $2 WHERE id = $1", id, time.Now())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return expectOne(res, op)
+}
+
+func (r *Repository) GetPromotions(ctx context.Context) ([]model.Promotion, error) {
+	const op = "repository.promotion.GetPromotions"
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+promotionColumns+" FROM promotions p ORDER BY p.created_at DESC")
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	promotions, err := scanPromotions(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return promotions, nil
+}
+
+func (r *Repository) GetPromotionByID(ctx context.Context, id uuid.UUID) (*model.Promotion, error) {
+	const op = "repository.promotion.GetPromotionByID"
+
+	p, err := scanPromotion(r.DB.QueryRowContext(ctx, "SELECT "+promotionColumns+" FROM promotions p WHERE p.id = $1", id))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return p, nil
+}
+
+func (r *Repository) GetPromotionByCode(ctx context.Context, code string) (*model.Promotion, error) {
+	const op = "repository.promotion.GetPromotionByCode"
+
+	p, err := scanPromotion(r.DB.QueryRowContext(ctx, "SELECT "+promotionColumns+" FROM promotions p WHERE UPPER(p.code) = UPPER($1)", code))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return p, nil
+}
+
+// GetOrderPromotions lists the promotions applied to an order in the order
+// they were applied, which is the order stacking is decided in.
+func (r *Repository) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]model.Promotion, error) {
+	const op = "repository.promotion.GetOrderPromotions"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+promotionColumns+`
+        FROM order_promotions op
+        JOIN promotions p ON p.id = op.promotion_id
+        WHERE op.order_id = $1
+        ORDER BY op.applied_at`, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	promotions, err := scanPromotions(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return promotions, nil
+}
+
+// AttachToOrder applies a promotion to a draft order. Applying it twice is a
+// no-op.
+func (r *Repository) AttachToOrder(ctx context.Context, orderID, promotionID uuid.UUID) error {
+	const op = "repository.promotion.AttachToOrder"
+
+	res, err := r.DB.ExecContext(ctx, `
+        INSERT INTO order_promotions (order_id, promotion_id)
+        SELECT id, $2 FROM orders WHERE id = $1 AND status = 'draft'
+        ON CONFLICT (order_id, promotion_id) DO NOTHING`, orderID, promotionID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		err = r.DB.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM order_promotions WHERE order_id = $1 AND promotion_id = $2)",
+			orderID, promotionID).Scan(&exists)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if !exists {
+			return errors.Wrap(repository.ErrOrderNotFound, op)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) DetachFromOrder(ctx context.Context, orderID, promotionID uuid.UUID) error {
+	const op = "repository.promotion.DetachFromOrder"
+
+	res, err := r.DB.ExecContext(ctx, `
+        DELETE FROM order_promotions op
+        USING orders o
+        WHERE o.id = op.order_id AND o.status = 'draft' AND op.order_id = $1 AND op.promotion_id = $2`,
+		orderID, promotionID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(repository.ErrPromotionNotFound, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) CountUserRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error) {
+	const op = "repository.promotion.CountUserRedemptions"
+
+	var count int
+	err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2",
+		promotionID, userID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return count, nil
+}
+
+func expectOne(res sql.Result, op string) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(repository.ErrPromotionNotFound, op)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromotion(row scanner) (*model.Promotion, error) {
+	var p model.Promotion
+	err := row.Scan(&p.ID, &p.Code, &p.Description, &p.Kind, &p.Percent, &p.Amount, &p.BuyQuantity, &p.GetQuantity,
+		&p.Scope, &p.ScopeValue, &p.MinOrderValue, &p.UsageLimit, &p.PerUserLimit, &p.TimesUsed,
+		&p.StartsAt, &p.EndsAt, &p.Stackable, &p.Active, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func scanPromotions(rows *sql.Rows) ([]model.Promotion, error) {
+	var promotions []model.Promotion
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
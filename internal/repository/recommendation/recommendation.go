@@ -0,0 +1,185 @@
+package recommendation
+
+import (
+	"context"
+	"database/sql"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// bookColumns are read from books as b.
+const bookColumns = `b.id, b.title, COALESCE(b.author, ''), COALESCE(b.category, ''), b.tax_class, b.price, COALESCE(b.stock, 0),
+    b.weight_grams, b.rating_average, b.rating_count`
+
+// Rebuild recomputes the similar books of every book from the paid orders
+// and keeps the perBook best of each. Readers see the old list until the new
+// one is committed.
+func (r *Repository) Rebuild(ctx context.Context, perBook int) (int64, error) {
+	const op = "repository.recommendation.Rebuild"
+
+	settled := orderModel.SettledStatuses()
+	statuses := make([]string, len(settled))
+	for i, s := range settled {
+		statuses[i] = string(s)
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM book_recommendations")
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	// Two books are as similar as the share of their orders they have in
+	// common: co / sqrt(orders of a * orders of b).
+	var res sql.Result
+	res, err = tx.ExecContext(ctx, `
+        WITH items AS (
+            SELECT DISTINCT oi.order_id, oi.book_id
+            FROM order_items oi
+            JOIN orders o ON o.id = oi.order_id
+            WHERE o.status = ANY($1)
+        ),
+        counts AS (
+            SELECT book_id, COUNT(*) AS orders
+            FROM items
+            GROUP BY book_id
+        ),
+        pairs AS (
+            SELECT a.book_id, b.book_id AS recommended_id, COUNT(*) AS co
+            FROM items a
+            JOIN items b ON b.order_id = a.order_id AND b.book_id <> a.book_id
+            GROUP BY a.book_id, b.book_id
+        ),
+        ranked AS (
+            SELECT p.book_id, p.recommended_id, p.co,
+                   p.co / SQRT(ca.orders::DOUBLE PRECISION * cb.orders) AS score
+            FROM pairs p
+            JOIN counts ca ON ca.book_id = p.book_id
+            JOIN counts cb ON cb.book_id = p.recommended_id
+        ),
+        numbered AS (
+            SELECT *, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY score DESC, co DESC, recommended_id) AS rank
+            FROM ranked
+        )
+        INSERT INTO book_recommendations (book_id, recommended_id, score, co_purchases, computed_at)
+        SELECT book_id, recommended_id, score, co, $3
+        FROM numbered
+        WHERE rank <= $2`, pq.Array(statuses), perBook, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var stored int64
+	stored, err = res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return stored, nil
+}
+
+// GetAlsoBought returns the books in stock most often bought together with
+// any of bookIDs, summing the scores of books similar to several of them.
+// The books themselves are left out.
+func (r *Repository) GetAlsoBought(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]model.Recommendation, error) {
+	const op = "repository.recommendation.GetAlsoBought"
+
+	recs, err := r.query(ctx, `
+        SELECT `+bookColumns+`, 'also_bought', SUM(r.score) AS score
+        FROM book_recommendations r
+        JOIN books b ON b.id = r.recommended_id
+        WHERE r.book_id = ANY($1::uuid[]) AND r.recommended_id <> ALL($1::uuid[]) AND b.stock > 0
+        GROUP BY b.id
+        ORDER BY score DESC, b.title
+        LIMIT $2`, pq.Array(idStrings(bookIDs)), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return recs, nil
+}
+
+// GetSimilar returns books in stock by the same author as any of bookIDs,
+// then books in the same category, best rated first. The books themselves
+// are left out.
+func (r *Repository) GetSimilar(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]model.Recommendation, error) {
+	const op = "repository.recommendation.GetSimilar"
+
+	recs, err := r.query(ctx, `
+        WITH seeds AS (
+            SELECT NULLIF(author, '') AS author, NULLIF(category, '') AS category
+            FROM books
+            WHERE id = ANY($1::uuid[])
+        ),
+        candidates AS (
+            SELECT b.*, COALESCE(b.author IN (SELECT author FROM seeds WHERE author IS NOT NULL), FALSE) AS same_author
+            FROM books b
+            WHERE b.id <> ALL($1::uuid[]) AND b.stock > 0
+              AND (b.author IN (SELECT author FROM seeds WHERE author IS NOT NULL)
+                OR b.category IN (SELECT category FROM seeds WHERE category IS NOT NULL))
+        )
+        SELECT `+bookColumns+`,
+               CASE WHEN b.same_author THEN 'same_author' ELSE 'same_category' END, 0
+        FROM candidates b
+        ORDER BY b.same_author DESC, b.rating_average DESC, b.rating_count DESC, b.title
+        LIMIT $2`, pq.Array(idStrings(bookIDs)), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return recs, nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) ([]model.Recommendation, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recs := []model.Recommendation{}
+	for rows.Next() {
+		var rec model.Recommendation
+		err := rows.Scan(&rec.ID, &rec.Title, &rec.Author, &rec.Category, &rec.TaxClass, &rec.Price, &rec.Stock,
+			&rec.WeightGrams, &rec.RatingAverage, &rec.RatingCount, &rec.Reason, &rec.Score)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recs, nil
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return s
+}
@@ -0,0 +1,295 @@
+package refund
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const refundColumns = `id, order_id, payment_id, return_id, currency, amount, reason, status, attempts, last_error, next_attempt_at,
+        issued_by, created_at, updated_at, issued_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRefund(row scanner) (*model.Refund, error) {
+	var refund model.Refund
+	var currency money.Currency
+	var amount string
+	var issuedAt sql.NullTime
+	err := row.Scan(&refund.ID, &refund.OrderID, &refund.PaymentID, &refund.ReturnID, &currency, &amount, &refund.Reason, &refund.Status,
+		&refund.Attempts, &refund.LastError, &refund.NextAttemptAt, &refund.IssuedBy, &refund.CreatedAt,
+		&refund.UpdatedAt, &issuedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRefundNotFound
+		}
+		return nil, err
+	}
+
+	if refund.Amount, err = money.Parse(amount, currency); err != nil {
+		return nil, err
+	}
+	if issuedAt.Valid {
+		refund.IssuedAt = &issuedAt.Time
+	}
+
+	return &refund, nil
+}
+
+// CreateRefund records a refund as owed inside the transaction that makes it
+// owed, so it cannot be lost between that change committing and the money
+// going out. It is due at once.
+func (r *Repository) CreateRefund(ctx context.Context, tx *sql.Tx, refund *model.Refund) error {
+	const op = "repository.refund.CreateRefund"
+
+	created, err := scanRefund(tx.QueryRowContext(ctx, `
+        INSERT INTO refunds (order_id, payment_id, return_id, currency, amount, reason) 
+        VALUES ($1, $2, $3, $4, $5, $6) 
+        RETURNING `+refundColumns,
+		refund.OrderID, refund.PaymentID, refund.ReturnID, refund.Amount.Currency(), refund.Amount, refund.Reason))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	*refund = *created
+
+	return nil
+}
+
+func (r *Repository) GetRefund(ctx context.Context, id uuid.UUID) (*model.Refund, error) {
+	const op = "repository.refund.GetRefund"
+
+	refund, err := scanRefund(r.DB.QueryRowContext(ctx, "SELECT "+refundColumns+" FROM refunds WHERE id = $1", id))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refund, nil
+}
+
+// GetRefunds lists the refunds in the given statuses, oldest first, so the
+// ones that have waited longest come up first.
+func (r *Repository) GetRefunds(ctx context.Context, statuses []model.Status) ([]model.Refund, error) {
+	const op = "repository.refund.GetRefunds"
+
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	refunds, err := r.query(ctx, `
+        SELECT `+refundColumns+` 
+        FROM refunds 
+        WHERE status = ANY($1) 
+        ORDER BY created_at`, pq.Array(names))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refunds, nil
+}
+
+// ClaimDue hands out owed refunds whose next attempt is due and hides them
+// from other claims for lease, so each is sent by one worker at a time.
+func (r *Repository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Refund, error) {
+	const op = "repository.refund.ClaimDue"
+
+	refunds, err := r.query(ctx, `
+        UPDATE refunds 
+        SET next_attempt_at = $1 
+        WHERE id IN (
+            SELECT id 
+            FROM refunds 
+            WHERE status = 'owed' AND next_attempt_at <= $2 
+            ORDER BY next_attempt_at 
+            LIMIT $3 
+            FOR UPDATE SKIP LOCKED
+        ) 
+        RETURNING `+refundColumns, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refunds, nil
+}
+
+// ClaimOrderRefunds is ClaimDue for the refunds of one order, for sending them
+// right after the change that made them owed instead of waiting for the
+// next sweep.
+func (r *Repository) ClaimOrderRefunds(ctx context.Context, orderID uuid.UUID, now time.Time, lease time.Duration) ([]model.Refund, error) {
+	const op = "repository.refund.ClaimOrderRefunds"
+
+	refunds, err := r.query(ctx, `
+        UPDATE refunds 
+        SET next_attempt_at = $1 
+        WHERE id IN (
+            SELECT id 
+            FROM refunds 
+            WHERE order_id = $2 AND status = 'owed' AND next_attempt_at <= $3 
+            FOR UPDATE SKIP LOCKED
+        ) 
+        RETURNING `+refundColumns, now.Add(lease), orderID, now)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refunds, nil
+}
+
+// MarkIssued records a refund the provider took, and on its return that the
+// money went out. A refund the provider settled at once is added to its
+// payment in the same transaction, so what is left of the payment is never
+// counted twice.
+func (r *Repository) MarkIssued(ctx context.Context, id uuid.UUID, issue model.Issue) error {
+	const op = "repository.refund.MarkIssued"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `
+        UPDATE refunds 
+        SET status = $1, payment_id = $2, amount = $3, attempts = attempts + 1, last_error = '', 
+            issued_at = $4, updated_at = $4 
+        WHERE id = $5`, model.StatusIssued, issue.PaymentID, issue.Amount, now, id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err = markReturnRefunded(ctx, tx, id, now); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if issue.Settled {
+		_, err = tx.ExecContext(ctx, `
+            UPDATE payments 
+            SET refunded_amount = LEAST(amount, refunded_amount + $1), 
+                status = CASE WHEN refunded_amount + $1 >= amount THEN 'refunded' ELSE status END, 
+                updated_at = $2 
+            WHERE id = $3`, issue.Amount, now, issue.PaymentID)
+		if err != nil {
+			return errors.Wrap(err, op+": failed to add refund to payment")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+// MarkFailed records an attempt the provider did not take and when to try
+// again, or hands the refund to staff.
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, failure model.Failure) error {
+	const op = "repository.refund.MarkFailed"
+
+	status := model.StatusOwed
+	if failure.Manual {
+		status = model.StatusManual
+	}
+
+	_, err := r.DB.ExecContext(ctx, `
+        UPDATE refunds 
+        SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, updated_at = $5 
+        WHERE id = $6`, status, failure.Attempts, failure.Error, failure.NextAttemptAt, time.Now(), id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// MarkIssuedByHand records that staff issued a refund outside the provider,
+// and on its return that the money went out. A refund that already went out
+// is left alone.
+func (r *Repository) MarkIssuedByHand(ctx context.Context, id uuid.UUID, actor uuid.UUID) (*model.Refund, error) {
+	const op = "repository.refund.MarkIssuedByHand"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	refund, err := scanRefund(tx.QueryRowContext(ctx, `
+        UPDATE refunds 
+        SET status = $1, issued_by = $2, issued_at = $3, updated_at = $3 
+        WHERE id = $4 AND status <> $1 
+        RETURNING `+refundColumns, model.StatusIssued, actor, now, id))
+	if errors.Is(err, repository.ErrRefundNotFound) {
+		if _, err = r.GetRefund(ctx, id); err == nil {
+			err = repository.ErrRefundIssued
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = markReturnRefunded(ctx, tx, id, now); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return refund, nil
+}
+
+// markReturnRefunded stamps refund_issued_at on the return a refund pays for.
+// Refunds that are not for a return touch nothing.
+func markReturnRefunded(ctx context.Context, tx *sql.Tx, id uuid.UUID, now time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE returns 
+        SET refund_issued_at = $1, updated_at = $1 
+        FROM refunds f 
+        WHERE f.id = $2 AND returns.id = f.return_id`, now, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to stamp return refund")
+	}
+
+	return nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) ([]model.Refund, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []model.Refund{}
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, *refund)
+	}
+
+	return refunds, rows.Err()
+}
@@ -0,0 +1,198 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// paidOrders is the orders first paid between $1 and $2, with when. An order
+// refunded or cancelled since still counts on the day it was paid; what was
+// given back shows in refunded_total.
+const paidOrders = `
+        WITH paid AS (
+            SELECT o.id, o.total_price, o.refunded_total, h.paid_at
+            FROM orders o
+            JOIN (
+                SELECT order_id, MIN(created_at) AS paid_at
+                FROM order_status_history
+                WHERE to_status = 'paid'
+                GROUP BY order_id
+            ) h ON h.order_id = o.id
+            WHERE h.paid_at >= $1 AND h.paid_at < $2
+        )`
+
+func (r *Repository) GetSummary(ctx context.Context, rng model.Range) (*model.Summary, error) {
+	const op = "repository.report.GetSummary"
+
+	summary := model.Summary{
+		Range:    rng,
+		Revenue:  money.Zero(money.DefaultCurrency),
+		Refunded: money.Zero(money.DefaultCurrency),
+	}
+
+	err := r.DB.QueryRowContext(ctx, paidOrders+`
+        SELECT COUNT(*), COALESCE(SUM(total_price), 0), COALESCE(SUM(refunded_total), 0),
+               COALESCE((SELECT SUM(oi.quantity) FROM order_items oi JOIN paid p ON p.id = oi.order_id), 0)
+        FROM paid`, rng.From, rng.Until()).Scan(&summary.Orders, &summary.Revenue, &summary.Refunded, &summary.UnitsSold)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	err = r.DB.QueryRowContext(ctx, `
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE EXISTS (
+            SELECT 1 FROM order_status_history h WHERE h.order_id = o.id AND h.to_status = 'paid'
+        ))
+        FROM orders o
+        WHERE o.created_at >= $1 AND o.created_at < $2`, rng.From, rng.Until()).Scan(&summary.Carts, &summary.Converted)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	summary.Finish()
+	return &summary, nil
+}
+
+func (r *Repository) GetRevenue(ctx context.Context, rng model.Range, interval model.Interval) (*model.Revenue, error) {
+	const op = "repository.report.GetRevenue"
+
+	rows, err := r.DB.QueryContext(ctx, paidOrders+`
+        SELECT s.period, COUNT(p.id), COALESCE(SUM(p.total_price), 0), COALESCE(SUM(p.refunded_total), 0)
+        FROM generate_series(
+            DATE_TRUNC($3, $1::TIMESTAMP),
+            $2::TIMESTAMP - INTERVAL '1 second',
+            ('1 ' || $3)::INTERVAL
+        ) AS s(period)
+        LEFT JOIN paid p ON DATE_TRUNC($3, p.paid_at) = s.period
+        GROUP BY s.period
+        ORDER BY s.period`, rng.From, rng.Until(), string(interval))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	revenue := model.Revenue{Range: rng, Interval: interval, Points: []model.Point{}}
+	for rows.Next() {
+		point := model.Point{
+			Revenue:  money.Zero(money.DefaultCurrency),
+			Refunded: money.Zero(money.DefaultCurrency),
+		}
+		if err := rows.Scan(&point.Period, &point.Orders, &point.Revenue, &point.Refunded); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		revenue.Points = append(revenue.Points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	revenue.Finish()
+	return &revenue, nil
+}
+
+// GetTopSellers lists the books that sold the most copies in paid orders.
+func (r *Repository) GetTopSellers(ctx context.Context, rng model.Range, limit int) (*model.TopSellers, error) {
+	const op = "repository.report.GetTopSellers"
+
+	rows, err := r.DB.QueryContext(ctx, paidOrders+`
+        SELECT b.id, b.title, COALESCE(b.author, ''), SUM(oi.quantity), SUM(oi.price * oi.quantity)
+        FROM paid p
+        JOIN order_items oi ON oi.order_id = p.id
+        JOIN books b ON b.id = oi.book_id
+        GROUP BY b.id
+        ORDER BY SUM(oi.quantity) DESC, SUM(oi.price * oi.quantity) DESC, b.title
+        LIMIT $3`, rng.From, rng.Until(), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	sellers := model.TopSellers{Range: rng, Books: []model.TopSeller{}}
+	for rows.Next() {
+		seller := model.TopSeller{Revenue: money.Zero(money.DefaultCurrency)}
+		if err := rows.Scan(&seller.BookID, &seller.Title, &seller.Author, &seller.Units, &seller.Revenue); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		sellers.Books = append(sellers.Books, seller)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &sellers, nil
+}
+
+// GetTurnover lists books by the share of their copies that sold, fastest
+// first. Books that neither sold nor are in stock come last.
+func (r *Repository) GetTurnover(ctx context.Context, rng model.Range, limit int) (*model.StockReport, error) {
+	const op = "repository.report.GetTurnover"
+
+	books, err := r.stock(ctx, rng, "TRUE", `
+        COALESCE(s.units, 0)::DOUBLE PRECISION / NULLIF(COALESCE(s.units, 0) + GREATEST(COALESCE(b.stock, 0), 0), 0) DESC NULLS LAST,
+        COALESCE(s.units, 0) DESC, b.title`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &model.StockReport{Range: rng, Books: books}, nil
+}
+
+// GetLowStock lists books with threshold copies or fewer left, the ones
+// that will run out first at the top.
+func (r *Repository) GetLowStock(ctx context.Context, rng model.Range, threshold, limit int) (*model.StockReport, error) {
+	const op = "repository.report.GetLowStock"
+
+	books, err := r.stock(ctx, rng, "COALESCE(b.stock, 0) <= $4", `
+        COALESCE(b.stock, 0), COALESCE(s.units, 0) DESC, b.title`, limit, threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &model.StockReport{Range: rng, Threshold: threshold, Books: books}, nil
+}
+
+// stock lists the books matching where with the copies they sold in the
+// range. where and order are SQL over books as b and the sales as s.
+func (r *Repository) stock(ctx context.Context, rng model.Range, where, order string, limit int, args ...interface{}) ([]model.Stock, error) {
+	rows, err := r.DB.QueryContext(ctx, paidOrders+`,
+        sold AS (
+            SELECT oi.book_id, SUM(oi.quantity) AS units
+            FROM paid p
+            JOIN order_items oi ON oi.order_id = p.id
+            GROUP BY oi.book_id
+        )
+        SELECT b.id, b.title, COALESCE(b.author, ''), COALESCE(b.stock, 0), COALESCE(s.units, 0)
+        FROM books b
+        LEFT JOIN sold s ON s.book_id = b.id
+        WHERE `+where+`
+        ORDER BY `+order+`
+        LIMIT $3`, append([]interface{}{rng.From, rng.Until(), limit}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []model.Stock{}
+	for rows.Next() {
+		var s model.Stock
+		if err := rows.Scan(&s.BookID, &s.Title, &s.Author, &s.Stock, &s.UnitsSold); err != nil {
+			return nil, err
+		}
+		s.Finish(rng.Days())
+		books = append(books, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
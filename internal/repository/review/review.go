@@ -0,0 +1,408 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// reviewColumns are read from reviews as r joined with books as b and users
+// as u.
+const reviewColumns = `r.id, r.book_id, b.title, r.user_id, u.username, r.rating, r.title, r.body, r.status,
+    r.moderation_note, r.helpful_count, r.created_at, r.updated_at, r.moderated_at`
+
+const reviewFrom = `
+        FROM reviews r
+        JOIN books b ON b.id = r.book_id
+        JOIN users u ON u.id = r.user_id`
+
+// sortOrders maps every sort to its ORDER BY clause.
+var sortOrders = map[model.Sort]string{
+	model.SortHelpful: "r.helpful_count DESC, r.created_at DESC",
+	model.SortRecent:  "r.created_at DESC",
+	model.SortHighest: "r.rating DESC, r.helpful_count DESC, r.created_at DESC",
+	model.SortLowest:  "r.rating ASC, r.helpful_count DESC, r.created_at DESC",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row scanner) (*model.Review, error) {
+	var rv model.Review
+	var moderatedAt sql.NullTime
+	err := row.Scan(&rv.ID, &rv.BookID, &rv.BookTitle, &rv.UserID, &rv.Author, &rv.Rating, &rv.Title, &rv.Body, &rv.Status,
+		&rv.ModerationNote, &rv.HelpfulCount, &rv.CreatedAt, &rv.UpdatedAt, &moderatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if moderatedAt.Valid {
+		rv.ModeratedAt = &moderatedAt.Time
+	}
+	return &rv, nil
+}
+
+func (r *Repository) scanReviews(ctx context.Context, op, query string, args ...interface{}) ([]model.Review, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	reviews := []model.Review{}
+	for rows.Next() {
+		rv, err := scanReview(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		reviews = append(reviews, *rv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return reviews, nil
+}
+
+// HasPurchased reports whether the user paid for an order with the book in
+// it, which makes their review a verified purchase.
+func (r *Repository) HasPurchased(ctx context.Context, userID, bookID uuid.UUID) (bool, error) {
+	const op = "repository.review.HasPurchased"
+
+	settled := orderModel.SettledStatuses()
+	statuses := make([]string, len(settled))
+	for i, s := range settled {
+		statuses[i] = string(s)
+	}
+
+	var purchased bool
+	err := r.DB.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1
+            FROM order_items oi
+            JOIN orders o ON o.id = oi.order_id
+            WHERE o.user_id = $1 AND oi.book_id = $2 AND o.status = ANY($3)
+        )`, userID, bookID, pq.Array(statuses)).Scan(&purchased)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	return purchased, nil
+}
+
+// SaveReview writes the user's review of the book, replacing the one they
+// wrote before. A rewritten review goes back to moderation and stops counting
+// towards the book's rating until approved again.
+func (r *Repository) SaveReview(ctx context.Context, userID, bookID uuid.UUID, req model.Request) (*model.Review, error) {
+	const op = "repository.review.SaveReview"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = lockBook(ctx, tx, bookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO reviews (book_id, user_id, rating, title, body)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (book_id, user_id) DO UPDATE
+        SET rating = EXCLUDED.rating,
+            title = EXCLUDED.title,
+            body = EXCLUDED.body,
+            status = 'pending',
+            moderation_note = '',
+            moderated_by = NULL,
+            moderated_at = NULL,
+            updated_at = $6
+        RETURNING id`,
+		bookID, userID, req.Rating, req.Title, req.Body, time.Now()).Scan(&id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = refreshRating(ctx, tx, bookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return r.GetReview(ctx, id)
+}
+
+func (r *Repository) GetReview(ctx context.Context, id uuid.UUID) (*model.Review, error) {
+	const op = "repository.review.GetReview"
+
+	row := r.DB.QueryRowContext(ctx, `SELECT `+reviewColumns+reviewFrom+` WHERE r.id = $1`, id)
+
+	rv, err := scanReview(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrReviewNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return rv, nil
+}
+
+func (r *Repository) GetUserReview(ctx context.Context, userID, bookID uuid.UUID) (*model.Review, error) {
+	const op = "repository.review.GetUserReview"
+
+	row := r.DB.QueryRowContext(ctx, `SELECT `+reviewColumns+reviewFrom+` WHERE r.user_id = $1 AND r.book_id = $2`,
+		userID, bookID)
+
+	rv, err := scanReview(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrReviewNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return rv, nil
+}
+
+// GetReviews lists the approved reviews of a book.
+func (r *Repository) GetReviews(ctx context.Context, bookID uuid.UUID, sort model.Sort) ([]model.Review, error) {
+	const op = "repository.review.GetReviews"
+
+	order, ok := sortOrders[sort]
+	if !ok {
+		order = sortOrders[model.SortHelpful]
+	}
+
+	return r.scanReviews(ctx, op, `
+        SELECT `+reviewColumns+reviewFrom+`
+        WHERE r.book_id = $1 AND r.status = 'approved'
+        ORDER BY `+order, bookID)
+}
+
+// GetDistribution counts the approved reviews of a book per number of stars.
+func (r *Repository) GetDistribution(ctx context.Context, bookID uuid.UUID) (map[int]int, error) {
+	const op = "repository.review.GetDistribution"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT rating, COUNT(*)
+        FROM reviews
+        WHERE book_id = $1 AND status = 'approved'
+        GROUP BY rating`, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	distribution := make(map[int]int)
+	for rows.Next() {
+		var stars, count int
+		if err := rows.Scan(&stars, &count); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		distribution[stars] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return distribution, nil
+}
+
+func (r *Repository) DeleteReview(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repository.review.DeleteReview"
+
+	var bookID uuid.UUID
+	err := r.DB.QueryRowContext(ctx, "SELECT book_id FROM reviews WHERE id = $1 AND user_id = $2", id, userID).Scan(&bookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(repository.ErrReviewNotFound, op)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = lockBook(ctx, tx, bookID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var res sql.Result
+	res, err = tx.ExecContext(ctx, "DELETE FROM reviews WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var deleted int64
+	deleted, err = res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if deleted == 0 {
+		err = repository.ErrReviewNotFound
+		return errors.Wrap(err, op)
+	}
+
+	if err = refreshRating(ctx, tx, bookID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+// Vote counts the user as finding the review helpful. It reports false if
+// they had voted for it already.
+func (r *Repository) Vote(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	const op = "repository.review.Vote"
+
+	res, err := r.DB.ExecContext(ctx, `
+        WITH v AS (
+            INSERT INTO review_votes (review_id, user_id)
+            VALUES ($1, $2)
+            ON CONFLICT DO NOTHING
+            RETURNING review_id
+        )
+        UPDATE reviews
+        SET helpful_count = helpful_count + 1
+        WHERE id IN (SELECT review_id FROM v)`, id, userID)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	counted, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	return counted > 0, nil
+}
+
+// GetQueue lists reviews in a status across all books, oldest first.
+func (r *Repository) GetQueue(ctx context.Context, status model.Status, limit int) ([]model.Review, error) {
+	const op = "repository.review.GetQueue"
+
+	return r.scanReviews(ctx, op, `
+        SELECT `+reviewColumns+reviewFrom+`
+        WHERE r.status = $1
+        ORDER BY r.created_at
+        LIMIT $2`, status, limit)
+}
+
+// Moderate moves a review to the status staff decided on and updates the
+// book's rating with it.
+func (r *Repository) Moderate(ctx context.Context, change model.StatusChange) (*model.Review, error) {
+	const op = "repository.review.Moderate"
+
+	var bookID uuid.UUID
+	err := r.DB.QueryRowContext(ctx, "SELECT book_id FROM reviews WHERE id = $1", change.ReviewID).Scan(&bookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrReviewNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = lockBook(ctx, tx, bookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var from model.Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM reviews WHERE id = $1 FOR UPDATE", change.ReviewID).Scan(&from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrReviewNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !from.CanTransitionTo(change.To) {
+		err = &model.TransitionError{From: from, To: change.To}
+		return nil, errors.Wrap(err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE reviews
+        SET status = $2, moderation_note = $3, moderated_by = $4, moderated_at = $5
+        WHERE id = $1`, change.ReviewID, change.To, change.Note, change.Actor, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = refreshRating(ctx, tx, bookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return r.GetReview(ctx, change.ReviewID)
+}
+
+// lockBook serialises the changes to a book's reviews, so refreshRating
+// always sees the ones committed before it.
+func lockBook(ctx context.Context, tx *sql.Tx, bookID uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.QueryRowContext(ctx, "SELECT id FROM books WHERE id = $1 FOR UPDATE", bookID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrBookNotFound
+	}
+	return err
+}
+
+// refreshRating recomputes the rating kept on the book from its approved
+// reviews.
+func refreshRating(ctx context.Context, tx *sql.Tx, bookID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE books b
+        SET rating_average = s.average, rating_count = s.count
+        FROM (
+            SELECT COALESCE(ROUND(AVG(rating), 2), 0) AS average, COUNT(*) AS count
+            FROM reviews
+            WHERE book_id = $1 AND status = 'approved'
+        ) s
+        WHERE b.id = $1`, bookID)
+	return err
+}
@@ -0,0 +1,450 @@
+package rma
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB        *sql.DB
+	Inventory interfaces.InventoryRepository
+	Orders    interfaces.OrderRepository
+	Refunds   interfaces.RefundRepository
+}
+
+const returnColumns = `r.id, r.order_id, r.user_id, r.status, r.note, r.resolution_note, r.refund_amount, r.resolved_by,
+    r.created_at, r.updated_at, r.received_at, r.refund_issued_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReturn(row scanner) (*model.Return, error) {
+	var ret model.Return
+	var receivedAt, refundIssuedAt sql.NullTime
+	err := row.Scan(&ret.ID, &ret.OrderID, &ret.UserID, &ret.Status, &ret.Note, &ret.ResolutionNote, &ret.RefundAmount,
+		&ret.ResolvedBy, &ret.CreatedAt, &ret.UpdatedAt, &receivedAt, &refundIssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if receivedAt.Valid {
+		ret.ReceivedAt = &receivedAt.Time
+	}
+	if refundIssuedAt.Valid {
+		ret.RefundIssuedAt = &refundIssuedAt.Time
+	}
+	return &ret, nil
+}
+
+// CreateReturn records a customer's request to send books back. The order row
+// is locked while what is left to return is worked out, so two requests
+// cannot claim the same copies.
+func (r *Repository) CreateReturn(ctx context.Context, orderID, userID uuid.UUID, note string, items []model.ItemRequest) (uuid.UUID, error) {
+	const op = "repository.rma.CreateReturn"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var status orderModel.Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = $1 AND user_id = $2 FOR UPDATE", orderID, userID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrOrderNotFound
+		}
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+	if !model.IsReturnable(status) {
+		err = model.ErrNotReturnable
+		return uuid.Nil, errors.Wrapf(err, "%s: order is %s", op, status)
+	}
+
+	lines, err := r.lines(ctx, tx, orderID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+	returns, err := r.returns(ctx, tx, "r.order_id = $1", orderID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	allocated, err := model.Allocate(lines, returns, items)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO returns (order_id, user_id, note)
+        VALUES ($1, $2, $3)
+        RETURNING id`, orderID, userID, note).Scan(&id)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to insert return")
+	}
+
+	for _, item := range allocated {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO return_items (return_id, book_id, quantity, reason)
+            VALUES ($1, $2, $3, $4)`, id, item.BookID, item.Quantity, item.Reason)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, op+": failed to insert return item")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetReturn(ctx context.Context, id uuid.UUID) (*model.Return, error) {
+	const op = "repository.rma.GetReturn"
+
+	returns, err := r.returns(ctx, r.DB, "r.id = $1", id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(returns) == 0 {
+		return nil, errors.Wrap(repository.ErrReturnNotFound, op)
+	}
+
+	return &returns[0], nil
+}
+
+func (r *Repository) GetReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]model.Return, error) {
+	const op = "repository.rma.GetReturnsByOrderID"
+
+	returns, err := r.returns(ctx, r.DB, "r.order_id = $1", orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return returns, nil
+}
+
+func (r *Repository) GetReturnsByUserID(ctx context.Context, userID uuid.UUID) ([]model.Return, error) {
+	const op = "repository.rma.GetReturnsByUserID"
+
+	returns, err := r.returns(ctx, r.DB, "r.user_id = $1", userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return returns, nil
+}
+
+// GetReturnsByStatus lists the returns in a status, or every return when the
+// status is empty.
+func (r *Repository) GetReturnsByStatus(ctx context.Context, status model.Status) ([]model.Return, error) {
+	const op = "repository.rma.GetReturnsByStatus"
+
+	returns, err := r.returns(ctx, r.DB, "($1 = '' OR r.status = $1)", status)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return returns, nil
+}
+
+// UpdateStatus approves or rejects a return, recording who decided and what
+// they told the customer. Receiving books goes through ReceiveReturn.
+func (r *Repository) UpdateStatus(ctx context.Context, change model.StatusChange) (*model.Return, error) {
+	const op = "repository.rma.UpdateStatus"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	from, err := r.lock(ctx, tx, change.ReturnID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if change.To == model.StatusReceived || !from.CanTransitionTo(change.To) {
+		err = &model.TransitionError{From: from, To: change.To}
+		return nil, errors.Wrap(err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE returns
+        SET status = $1, resolution_note = $2, resolved_by = $3, updated_at = $4
+        WHERE id = $5`, change.To, change.Note, change.Actor, time.Now(), change.ReturnID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	ret, err := r.GetReturn(ctx, change.ReturnID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ret, nil
+}
+
+// ReceiveReturn takes the books of an approved return back into stock, adds
+// what they are worth to the order's refunded total, marks the order
+// partially or fully refunded and records the refund as owed, all in one
+// transaction. Sending the refund is left to the payments, which stamp the
+// return's refund_issued_at once the money went out.
+func (r *Repository) ReceiveReturn(ctx context.Context, id uuid.UUID, actor orderModel.Actor) (*model.Receipt, error) {
+	const op = "repository.rma.ReceiveReturn"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	from, err := r.lock(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !from.CanTransitionTo(model.StatusReceived) {
+		err = &model.TransitionError{From: from, To: model.StatusReceived}
+		return nil, errors.Wrap(err, op)
+	}
+
+	returns, err := r.returns(ctx, tx, "r.id = $1", id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	ret := &returns[0]
+
+	order, err := r.lockOrder(ctx, tx, ret.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	lines, err := r.lines(ctx, tx, ret.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	refund := model.Refund(order, lines, ret.Items)
+
+	for _, item := range ret.Items {
+		movement := inventory.NewMovement(item.BookID, inventory.ReasonReturn, item.Quantity)
+		movement.OrderID = uuid.NullUUID{UUID: ret.OrderID, Valid: true}
+		movement.ActorID = actor.ID
+		movement.Note = "return " + ret.ID.String() + ": " + string(item.Reason)
+		if err = r.Inventory.RecordMovement(ctx, tx, movement); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `
+        UPDATE returns
+        SET status = $1, refund_amount = $2, resolved_by = COALESCE($3, resolved_by), received_at = $4, updated_at = $4
+        WHERE id = $5`, model.StatusReceived, refund, actor.ID, now, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        UPDATE orders
+        SET refunded_total = refunded_total + $1, updated_at = $2
+        WHERE id = $3
+        RETURNING refunded_total`, refund, now, ret.OrderID).Scan(&order.RefundedTotal)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to update refunded total")
+	}
+
+	returns, err = r.returns(ctx, tx, "r.order_id = $1", ret.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	status := model.OrderStatus(lines, returns)
+	if status != order.Status {
+		_, err = r.Orders.ChangeStatus(ctx, tx, orderModel.StatusChange{
+			OrderID:     order.ID,
+			To:          status,
+			Actor:       actor,
+			Reason:      "return " + id.String() + " received",
+			AllowedFrom: []orderModel.Status{orderModel.StatusDelivered, orderModel.StatusPartiallyRefunded},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		order.Status = status
+	}
+
+	if refund.IsPositive() {
+		err = r.Refunds.CreateRefund(ctx, tx, &refundModel.Refund{
+			OrderID:  order.ID,
+			ReturnID: uuid.NullUUID{UUID: id, Valid: true},
+			Amount:   order.Convert(refund),
+			Reason:   "return " + id.String() + " received",
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	for i := range returns {
+		if returns[i].ID == id {
+			ret = &returns[i]
+		}
+	}
+
+	return &model.Receipt{
+		Return: ret,
+		Order:  order,
+		Refund: refund,
+	}, nil
+}
+
+func (r *Repository) lock(ctx context.Context, tx *sql.Tx, id uuid.UUID) (model.Status, error) {
+	var status model.Status
+	err := tx.QueryRowContext(ctx, "SELECT status FROM returns WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", repository.ErrReturnNotFound
+	}
+	return status, err
+}
+
+// lockOrder loads the totals a refund is worked out from and holds the order
+// so two receipts cannot both spend what is left of it.
+func (r *Repository) lockOrder(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*orderModel.Model, error) {
+	order := orderModel.Model{ID: orderID}
+	err := tx.QueryRowContext(ctx, `
+        SELECT user_id, status, subtotal, discount_total, shipping_total, total_price, refunded_total, currency, exchange_rate
+        FROM orders
+        WHERE id = $1
+        FOR UPDATE`, orderID).Scan(&order.UserID, &order.Status, &order.Subtotal, &order.DiscountTotal,
+		&order.ShippingTotal, &order.TotalPrice, &order.RefundedTotal, &order.Currency, &order.ExchangeRate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// querier is what the read helpers need, so they run the same inside and
+// outside a transaction.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// lines sums up what an order bought of each book and the tax added on it;
+// tax already in the prices is part of the line total.
+func (r *Repository) lines(ctx context.Context, q querier, orderID uuid.UUID) ([]model.Line, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT oi.book_id, b.title, SUM(oi.quantity), SUM(oi.price * oi.quantity), COALESCE(SUM(t.added), 0)
+        FROM order_items oi
+        JOIN books b ON b.id = oi.book_id
+        LEFT JOIN (
+            SELECT order_item_id, SUM(amount) AS added
+            FROM order_item_taxes
+            WHERE NOT inclusive
+            GROUP BY order_item_id
+        ) t ON t.order_item_id = oi.id
+        WHERE oi.order_id = $1
+        GROUP BY oi.book_id, b.title
+        ORDER BY b.title`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []model.Line
+	for rows.Next() {
+		var line model.Line
+		if err := rows.Scan(&line.BookID, &line.Title, &line.Quantity, &line.Total, &line.Tax); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, rows.Err()
+}
+
+// returns loads the returns matching a filter on returns r, with their items,
+// newest first.
+func (r *Repository) returns(ctx context.Context, q querier, filter string, arg interface{}) ([]model.Return, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT `+returnColumns+`
+        FROM returns r
+        WHERE `+filter+`
+        ORDER BY r.created_at DESC`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var returns []model.Return
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		ret, err := scanReturn(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[ret.ID] = len(returns)
+		returns = append(returns, *ret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	itemRows, err := q.QueryContext(ctx, `
+        SELECT ri.return_id, ri.book_id, b.title, ri.quantity, ri.reason
+        FROM return_items ri
+        JOIN returns r ON r.id = ri.return_id
+        JOIN books b ON b.id = ri.book_id
+        WHERE `+filter+`
+        ORDER BY b.title`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var returnID uuid.UUID
+		var item model.Item
+		if err := itemRows.Scan(&returnID, &item.BookID, &item.Title, &item.Quantity, &item.Reason); err != nil {
+			return nil, err
+		}
+		if i, ok := index[returnID]; ok {
+			returns[i].Items = append(returns[i].Items, item)
+		}
+	}
+
+	return returns, itemRows.Err()
+}
@@ -0,0 +1,329 @@
+package shipment
+
+import (
+	"context"
+	"database/sql"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const shipmentColumns = `id, order_id, status, carrier, tracking_number, created_by, created_at, shipped_at, delivered_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanShipment(row scanner) (*model.Shipment, error) {
+	var s model.Shipment
+	var shippedAt, deliveredAt sql.NullTime
+	err := row.Scan(&s.ID, &s.OrderID, &s.Status, &s.Carrier, &s.TrackingNumber,
+		&s.CreatedBy, &s.CreatedAt, &shippedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	if shippedAt.Valid {
+		s.ShippedAt = &shippedAt.Time
+	}
+	if deliveredAt.Valid {
+		s.DeliveredAt = &deliveredAt.Time
+	}
+	s.TrackingURL = model.TrackingURL(s.Carrier, s.TrackingNumber)
+	return &s, nil
+}
+
+// CreateShipment makes up a parcel for a paid order. The order row is locked
+// while what is left to pack is worked out, so two parcels cannot take the
+// same books.
+func (r *Repository) CreateShipment(ctx context.Context, orderID uuid.UUID, createdBy uuid.NullUUID, items []model.ItemRequest) (uuid.UUID, error) {
+	const op = "repository.shipment.CreateShipment"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var status orderModel.Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrOrderNotFound
+		}
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+	if !model.IsShippable(status) {
+		err = model.ErrNotShippable
+		return uuid.Nil, errors.Wrapf(err, "%s: order is %s", op, status)
+	}
+
+	ordered, err := r.orderedItems(ctx, tx, orderID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+	shipments, err := r.shipments(ctx, tx, "s.order_id = $1", orderID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	allocated, err := model.Allocate(ordered, shipments, items)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO shipments (order_id, created_by)
+        VALUES ($1, $2)
+        RETURNING id`, orderID, createdBy).Scan(&id)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to insert shipment")
+	}
+
+	for _, item := range allocated {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO shipment_items (shipment_id, book_id, quantity)
+            VALUES ($1, $2, $3)`, id, item.BookID, item.Quantity)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, op+": failed to insert shipment item")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetShipment(ctx context.Context, id uuid.UUID) (*model.Shipment, error) {
+	const op = "repository.shipment.GetShipment"
+
+	s, err := scanShipment(r.DB.QueryRowContext(ctx, "SELECT "+shipmentColumns+" FROM shipments WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrShipmentNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	items, err := r.items(ctx, r.DB, `
+        SELECT si.shipment_id, si.book_id, b.title, si.quantity
+        FROM shipment_items si
+        JOIN books b ON b.id = si.book_id
+        WHERE si.shipment_id = $1
+        ORDER BY b.title`, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	s.Items = items[id]
+
+	return s, nil
+}
+
+func (r *Repository) GetShipmentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]model.Shipment, error) {
+	const op = "repository.shipment.GetShipmentsByOrderID"
+
+	shipments, err := r.shipments(ctx, r.DB, "s.order_id = $1", orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return shipments, nil
+}
+
+// GetShipmentsByUserID loads the parcels of every order a user placed.
+func (r *Repository) GetShipmentsByUserID(ctx context.Context, userID uuid.UUID) ([]model.Shipment, error) {
+	const op = "repository.shipment.GetShipmentsByUserID"
+
+	shipments, err := r.shipments(ctx, r.DB, "o.user_id = $1", userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return shipments, nil
+}
+
+// GetOrderedItems lists what an order has to ship, one entry per book.
+func (r *Repository) GetOrderedItems(ctx context.Context, orderID uuid.UUID) ([]model.Item, error) {
+	const op = "repository.shipment.GetOrderedItems"
+
+	items, err := r.orderedItems(ctx, r.DB, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return items, nil
+}
+
+// UpdateStatus moves a shipment on, stamping when it left or arrived.
+func (r *Repository) UpdateStatus(ctx context.Context, change model.StatusChange) (*model.Shipment, error) {
+	const op = "repository.shipment.UpdateStatus"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var from model.Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM shipments WHERE id = $1 FOR UPDATE", change.ShipmentID).Scan(&from)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrShipmentNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !from.CanTransitionTo(change.To) {
+		err = &model.TransitionError{From: from, To: change.To}
+		return nil, errors.Wrap(err, op)
+	}
+
+	now := time.Now()
+	switch change.To {
+	case model.StatusShipped:
+		_, err = tx.ExecContext(ctx, `
+            UPDATE shipments
+            SET status = $1, carrier = $2, tracking_number = $3, shipped_at = $4, updated_at = $4
+            WHERE id = $5`, change.To, change.Carrier, change.TrackingNumber, now, change.ShipmentID)
+	case model.StatusDelivered:
+		_, err = tx.ExecContext(ctx, `
+            UPDATE shipments
+            SET status = $1, delivered_at = $2, updated_at = $2
+            WHERE id = $3`, change.To, now, change.ShipmentID)
+	default:
+		_, err = tx.ExecContext(ctx, `
+            UPDATE shipments
+            SET status = $1, updated_at = $2
+            WHERE id = $3`, change.To, now, change.ShipmentID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	s, err := r.GetShipment(ctx, change.ShipmentID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s, nil
+}
+
+// querier is what the read helpers need, so they run the same inside and
+// outside a transaction.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *Repository) orderedItems(ctx context.Context, q querier, orderID uuid.UUID) ([]model.Item, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT oi.book_id, b.title, SUM(oi.quantity)
+        FROM order_items oi
+        JOIN books b ON b.id = oi.book_id
+        WHERE oi.order_id = $1
+        GROUP BY oi.book_id, b.title
+        ORDER BY b.title`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.Item
+	for rows.Next() {
+		var item model.Item
+		if err := rows.Scan(&item.BookID, &item.Title, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// shipments loads the parcels matching a filter on shipments s joined to
+// orders o, with their items, oldest first.
+func (r *Repository) shipments(ctx context.Context, q querier, filter string, arg interface{}) ([]model.Shipment, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT s.id, s.order_id, s.status, s.carrier, s.tracking_number, s.created_by, s.created_at, s.shipped_at, s.delivered_at
+        FROM shipments s
+        JOIN orders o ON o.id = s.order_id
+        WHERE `+filter+`
+        ORDER BY s.created_at`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shipments []model.Shipment
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		s, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[s.ID] = len(shipments)
+		shipments = append(shipments, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items, err := r.items(ctx, q, `
+        SELECT si.shipment_id, si.book_id, b.title, si.quantity
+        FROM shipment_items si
+        JOIN shipments s ON s.id = si.shipment_id
+        JOIN orders o ON o.id = s.order_id
+        JOIN books b ON b.id = si.book_id
+        WHERE `+filter+`
+        ORDER BY b.title`, arg)
+	if err != nil {
+		return nil, err
+	}
+	for id, list := range items {
+		shipments[index[id]].Items = list
+	}
+
+	return shipments, nil
+}
+
+// items runs a query selecting shipment_id, book_id, title and quantity and
+// groups the rows by shipment.
+func (r *Repository) items(ctx context.Context, q querier, query string, arg interface{}) (map[uuid.UUID][]model.Item, error) {
+	rows, err := q.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := map[uuid.UUID][]model.Item{}
+	for rows.Next() {
+		var shipmentID uuid.UUID
+		var item model.Item
+		if err := rows.Scan(&shipmentID, &item.BookID, &item.Title, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items[shipmentID] = append(items[shipmentID], item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,302 @@
+package stockalert
+
+import (
+	"context"
+	"database/sql"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+// alertColumns are read from stock_alerts as a joined with books as b.
+const alertColumns = `a.id, a.user_id, a.book_id, b.title, a.kind, a.threshold, a.pending, a.token,
+    a.triggered_at, a.notified_at, a.created_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAlert(row scanner, extra ...interface{}) (*model.Alert, error) {
+	var a model.Alert
+	var triggeredAt, notifiedAt sql.NullTime
+	dest := append([]interface{}{&a.ID, &a.UserID, &a.BookID, &a.Title, &a.Kind, &a.Threshold, &a.Pending, &a.Token,
+		&triggeredAt, &notifiedAt, &a.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if triggeredAt.Valid {
+		a.TriggeredAt = &triggeredAt.Time
+	}
+	if notifiedAt.Valid {
+		a.NotifiedAt = &notifiedAt.Time
+	}
+	return &a, nil
+}
+
+// Subscribe creates the alert, or updates the threshold of the one the user
+// already has for the book, keeping its unsubscribe token.
+func (r *Repository) Subscribe(ctx context.Context, userID uuid.UUID, req model.Request, token string) (*model.Alert, error) {
+	const op = "repository.stockalert.Subscribe"
+
+	row := r.DB.QueryRowContext(ctx, `
+        WITH a AS (
+            INSERT INTO stock_alerts (user_id, book_id, kind, threshold, token)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (user_id, book_id, kind) DO UPDATE
+            SET threshold = EXCLUDED.threshold, pending = FALSE
+            RETURNING *
+        )
+        SELECT `+alertColumns+`
+        FROM a
+        JOIN books b ON b.id = a.book_id`,
+		userID, req.BookID, req.Kind, req.Threshold, token)
+
+	a, err := scanAlert(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return a, nil
+}
+
+func (r *Repository) GetAlerts(ctx context.Context, userID uuid.UUID) ([]model.Alert, error) {
+	const op = "repository.stockalert.GetAlerts"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+alertColumns+`
+        FROM stock_alerts a
+        JOIN books b ON b.id = a.book_id
+        WHERE a.user_id = $1
+        ORDER BY a.created_at DESC`, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var alerts []model.Alert
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		alerts = append(alerts, *a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return alerts, nil
+}
+
+func (r *Repository) DeleteAlert(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repository.stockalert.DeleteAlert"
+
+	res, err := r.DB.ExecContext(ctx, "DELETE FROM stock_alerts WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if deleted == 0 {
+		return errors.Wrap(repository.ErrAlertNotFound, op)
+	}
+
+	return nil
+}
+
+// Unsubscribe deletes the alert with the token from an email link and
+// returns what it was.
+func (r *Repository) Unsubscribe(ctx context.Context, token string) (*model.Alert, error) {
+	const op = "repository.stockalert.Unsubscribe"
+
+	row := r.DB.QueryRowContext(ctx, `
+        WITH a AS (
+            DELETE FROM stock_alerts WHERE token = $1
+            RETURNING *
+        )
+        SELECT `+alertColumns+`
+        FROM a
+        JOIN books b ON b.id = a.book_id`, token)
+
+	a, err := scanAlert(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrAlertNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return a, nil
+}
+
+func (r *Repository) TriggerBackInStock(ctx context.Context, bookID uuid.UUID, at time.Time) (int, error) {
+	const op = "repository.stockalert.TriggerBackInStock"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE stock_alerts
+        SET pending = TRUE, triggered_at = $2
+        WHERE book_id = $1 AND kind = 'back_in_stock' AND NOT pending`, bookID, at)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	fired, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return int(fired), nil
+}
+
+// TriggerPriceDrop fires the price alerts whose threshold the price went
+// through: above it before, at or under it now.
+func (r *Repository) TriggerPriceDrop(ctx context.Context, bookID uuid.UUID, oldPrice, newPrice money.Money, at time.Time) (int, error) {
+	const op = "repository.stockalert.TriggerPriceDrop"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE stock_alerts
+        SET pending = TRUE, triggered_at = $4
+        WHERE book_id = $1 AND kind = 'price_below' AND NOT pending
+            AND threshold < $2 AND threshold >= $3`, bookID, oldPrice, newPrice, at)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	fired, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return int(fired), nil
+}
+
+// GetFired lists pending alerts with the book's current price and stock,
+// grouped by user.
+func (r *Repository) GetFired(ctx context.Context, limit int) ([]model.Fired, error) {
+	const op = "repository.stockalert.GetFired"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+alertColumns+`, b.price, b.stock
+        FROM stock_alerts a
+        JOIN books b ON b.id = a.book_id
+        WHERE a.pending
+        ORDER BY a.user_id, a.triggered_at
+        LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var fired []model.Fired
+	for rows.Next() {
+		var f model.Fired
+		a, err := scanAlert(rows, &f.Price, &f.Stock)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		f.Alert = *a
+		fired = append(fired, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return fired, nil
+}
+
+func (r *Repository) Notify(ctx context.Context, msg *notification.Message, alertIDs []uuid.UUID) error {
+	const op = "repository.stockalert.Notify"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	ids := make([]string, len(alertIDs))
+	for i, id := range alertIDs {
+		ids[i] = id.String()
+	}
+
+	var res sql.Result
+	res, err = tx.ExecContext(ctx, `
+        UPDATE stock_alerts
+        SET pending = FALSE, notified_at = $2
+        WHERE id = ANY($1::uuid[]) AND pending`, pq.Array(ids), time.Now())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	var cleared int64
+	cleared, err = res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if msg != nil && cleared > 0 {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO email_outbox (user_id, kind, recipient, subject, body_text, body_html)
+            VALUES ($1, $2, $3, $4, $5, $6)`,
+			msg.UserID, msg.Kind, msg.To, msg.Subject, msg.Text, msg.HTML)
+		if err != nil {
+			return errors.Wrap(err, op+": failed to queue email")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return nil
+}
+
+// GetDemand lists out-of-stock books by how many customers wait for them.
+func (r *Repository) GetDemand(ctx context.Context, limit int) ([]model.Demand, error) {
+	const op = "repository.stockalert.GetDemand"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT b.id, b.title, COALESCE(b.author, ''), b.stock, COUNT(*)
+        FROM stock_alerts a
+        JOIN books b ON b.id = a.book_id
+        WHERE a.kind = 'back_in_stock' AND b.stock <= 0
+        GROUP BY b.id, b.title, b.author, b.stock
+        ORDER BY COUNT(*) DESC, b.title
+        LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var demand []model.Demand
+	for rows.Next() {
+		var d model.Demand
+		if err := rows.Scan(&d.BookID, &d.Title, &d.Author, &d.Stock, &d.Subscribers); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		demand = append(demand, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return demand, nil
+}
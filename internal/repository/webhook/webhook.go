@@ -0,0 +1,385 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const endpointColumns = `id, url, secret, event_types, enabled, disabled_reason, consecutive_failures, created_at, updated_at`
+
+const deliveryColumns = `id, endpoint_id, event_id, event_type, payload, status, attempts, response_code, response_body,
+    last_error, next_attempt_at, created_at, delivered_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEndpoint(row scanner) (*model.Endpoint, error) {
+	var ep model.Endpoint
+	var types []string
+	err := row.Scan(&ep.ID, &ep.URL, &ep.Secret, pq.Array(&types), &ep.Enabled, &ep.DisabledReason,
+		&ep.ConsecutiveFailures, &ep.CreatedAt, &ep.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		ep.EventTypes = append(ep.EventTypes, event.Type(t))
+	}
+	return &ep, nil
+}
+
+func scanDelivery(row scanner) (*model.Delivery, error) {
+	var d model.Delivery
+	var payload []byte
+	var code sql.NullInt64
+	var deliveredAt sql.NullTime
+	err := row.Scan(&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts, &code,
+		&d.ResponseBody, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	if code.Valid {
+		c := int(code.Int64)
+		d.ResponseCode = &c
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
+
+func typeStrings(types []event.Type) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
+
+func (r *Repository) CreateEndpoint(ctx context.Context, endpoint model.Endpoint) (*model.Endpoint, error) {
+	const op = "repository.webhook.CreateEndpoint"
+
+	row := r.DB.QueryRowContext(ctx, `
+        INSERT INTO webhook_endpoints (url, secret, event_types, enabled)
+        VALUES ($1, $2, $3, $4)
+        RETURNING `+endpointColumns,
+		endpoint.URL, endpoint.Secret, pq.Array(typeStrings(endpoint.EventTypes)), endpoint.Enabled)
+
+	ep, err := scanEndpoint(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ep, nil
+}
+
+func (r *Repository) GetEndpoints(ctx context.Context) ([]model.Endpoint, error) {
+	const op = "repository.webhook.GetEndpoints"
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+endpointColumns+" FROM webhook_endpoints ORDER BY created_at DESC")
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var endpoints []model.Endpoint
+	for rows.Next() {
+		ep, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		endpoints = append(endpoints, *ep)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return endpoints, nil
+}
+
+func (r *Repository) GetEndpoint(ctx context.Context, id uuid.UUID) (*model.Endpoint, error) {
+	const op = "repository.webhook.GetEndpoint"
+
+	row := r.DB.QueryRowContext(ctx, "SELECT "+endpointColumns+" FROM webhook_endpoints WHERE id = $1", id)
+
+	ep, err := scanEndpoint(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrWebhookNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ep, nil
+}
+
+// UpdateEndpoint replaces the endpoint's settings. Switching a disabled
+// endpoint back on clears its failure count and the reason it was disabled.
+func (r *Repository) UpdateEndpoint(ctx context.Context, endpoint model.Endpoint) (*model.Endpoint, error) {
+	const op = "repository.webhook.UpdateEndpoint"
+
+	row := r.DB.QueryRowContext(ctx, `
+        UPDATE webhook_endpoints
+        SET url = $2,
+            secret = $3,
+            event_types = $4,
+            consecutive_failures = CASE WHEN $5 AND NOT enabled THEN 0 ELSE consecutive_failures END,
+            disabled_reason = CASE WHEN $5 THEN '' ELSE disabled_reason END,
+            enabled = $5,
+            updated_at = $6
+        WHERE id = $1
+        RETURNING `+endpointColumns,
+		endpoint.ID, endpoint.URL, endpoint.Secret, pq.Array(typeStrings(endpoint.EventTypes)), endpoint.Enabled, time.Now())
+
+	ep, err := scanEndpoint(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = repository.ErrWebhookNotFound
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ep, nil
+}
+
+func (r *Repository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	const op = "repository.webhook.DeleteEndpoint"
+
+	res, err := r.DB.ExecContext(ctx, "DELETE FROM webhook_endpoints WHERE id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if affected == 0 {
+		return errors.Wrap(repository.ErrWebhookNotFound, op)
+	}
+
+	return nil
+}
+
+// Enqueue queues a delivery of e for every enabled endpoint subscribed to its
+// type. An event handed over again is not queued twice.
+func (r *Repository) Enqueue(ctx context.Context, e event.Event) (int, error) {
+	const op = "repository.webhook.Enqueue"
+
+	res, err := r.DB.ExecContext(ctx, `
+        INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, created_at)
+        SELECT id, $1, $2, $3, $4
+        FROM webhook_endpoints
+        WHERE enabled AND $2 = ANY(event_types)
+        ON CONFLICT (endpoint_id, event_id) DO NOTHING`,
+		e.ID, e.Type, []byte(e.Payload), e.CreatedAt)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	queued, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	return int(queued), nil
+}
+
+// CreateDelivery logs a delivery outside the event flow, such as a test
+// event. It is not picked up by ClaimDue until it is due.
+func (r *Repository) CreateDelivery(ctx context.Context, endpointID uuid.UUID, eventType event.Type, payload json.RawMessage) (*model.Delivery, error) {
+	const op = "repository.webhook.CreateDelivery"
+
+	now := time.Now()
+	row := r.DB.QueryRowContext(ctx, `
+        INSERT INTO webhook_deliveries (endpoint_id, event_type, payload, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING `+deliveryColumns,
+		endpointID, eventType, []byte(payload), now.Add(time.Hour), now)
+
+	d, err := scanDelivery(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return d, nil
+}
+
+// ClaimDue takes up to limit due deliveries of enabled endpoints and leases
+// them like the event outbox does. Deliveries of a disabled endpoint wait
+// until it is switched back on.
+func (r *Repository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Outgoing, error) {
+	const op = "repository.webhook.ClaimDue"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        WITH claimed AS (
+            UPDATE webhook_deliveries
+            SET next_attempt_at = $1
+            WHERE id IN (
+                SELECT d.id
+                FROM webhook_deliveries d
+                JOIN webhook_endpoints e ON e.id = d.endpoint_id
+                WHERE d.status = 'pending' AND d.next_attempt_at <= $2 AND e.enabled
+                ORDER BY d.created_at
+                LIMIT $3
+                FOR UPDATE OF d SKIP LOCKED
+            )
+            RETURNING `+deliveryColumns+`
+        )
+        SELECT c.*, e.url, e.secret
+        FROM claimed c
+        JOIN webhook_endpoints e ON e.id = c.endpoint_id
+        ORDER BY c.created_at`, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var out []model.Outgoing
+	for rows.Next() {
+		var o model.Outgoing
+		d, err := scanDelivery(outgoingScanner{rows: rows, out: &o})
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		o.Delivery = *d
+		out = append(out, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return out, nil
+}
+
+// outgoingScanner reads the endpoint columns ClaimDue appends to a delivery.
+type outgoingScanner struct {
+	rows *sql.Rows
+	out  *model.Outgoing
+}
+
+func (s outgoingScanner) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, &s.out.URL, &s.out.Secret)...)
+}
+
+// RecordAttempt writes the outcome of a send to the delivery log. Unless it
+// was a test, it also keeps the endpoint's count of failures in a row and
+// disables the endpoint once that count reaches disableAfter.
+func (r *Repository) RecordAttempt(ctx context.Context, attempt model.Attempt, disableAfter int) (*model.Delivery, error) {
+	const op = "repository.webhook.RecordAttempt"
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	status := model.DeliveryPending
+	var deliveredAt sql.NullTime
+	switch {
+	case attempt.Result.Succeeded():
+		status = model.DeliverySucceeded
+		deliveredAt = sql.NullTime{Time: now, Valid: true}
+	case attempt.Final:
+		status = model.DeliveryFailed
+	}
+
+	var code sql.NullInt64
+	if attempt.Result.StatusCode != 0 {
+		code = sql.NullInt64{Int64: int64(attempt.Result.StatusCode), Valid: true}
+	}
+
+	row := tx.QueryRowContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = $2, attempts = $3, response_code = $4, response_body = $5, last_error = $6,
+            next_attempt_at = $7, delivered_at = $8
+        WHERE id = $1
+        RETURNING `+deliveryColumns,
+		attempt.DeliveryID, status, attempt.Attempts, code, attempt.Result.Body, attempt.Result.Error(),
+		attempt.NextAttemptAt, deliveredAt)
+
+	var d *model.Delivery
+	d, err = scanDelivery(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op+": failed to update delivery")
+	}
+
+	if !attempt.Test {
+		if attempt.Result.Succeeded() {
+			_, err = tx.ExecContext(ctx, `
+                UPDATE webhook_endpoints SET consecutive_failures = 0 WHERE id = $1`, attempt.EndpointID)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+                UPDATE webhook_endpoints
+                SET consecutive_failures = consecutive_failures + 1,
+                    enabled = enabled AND consecutive_failures + 1 < $2,
+                    disabled_reason = CASE
+                        WHEN enabled AND consecutive_failures + 1 >= $2 THEN $3
+                        ELSE disabled_reason
+                    END,
+                    updated_at = $4
+                WHERE id = $1`,
+				attempt.EndpointID, disableAfter, "disabled after repeated failures: "+attempt.Result.Error(), now)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, op+": failed to update endpoint health")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, op+": failed to commit transaction")
+	}
+
+	return d, nil
+}
+
+// GetDeliveries returns the latest deliveries to an endpoint, newest first.
+func (r *Repository) GetDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]model.Delivery, error) {
+	const op = "repository.webhook.GetDeliveries"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+deliveryColumns+`
+        FROM webhook_deliveries
+        WHERE endpoint_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2`, endpointID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var deliveries []model.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		deliveries = append(deliveries, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return deliveries, nil
+}
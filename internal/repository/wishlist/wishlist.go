@@ -0,0 +1,263 @@
+package wishlist
+
+import (
+	"context"
+	"database/sql"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+const wishlistColumns = "w.id, w.user_id, w.name, w.shared, w.share_token, w.created_at, w.updated_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWishlist(row scanner, extra ...interface{}) (*model.Wishlist, error) {
+	var w model.Wishlist
+	dest := append([]interface{}{&w.ID, &w.UserID, &w.Name, &w.Shared, &w.ShareToken, &w.CreatedAt, &w.UpdatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	w.Items = []model.Item{}
+	return &w, nil
+}
+
+func (r *Repository) CreateWishlist(ctx context.Context, w model.Wishlist) (*model.Wishlist, error) {
+	const op = "repository.wishlist.CreateWishlist"
+
+	row := r.DB.QueryRowContext(ctx, `
+        INSERT INTO wishlists AS w (user_id, name, shared, share_token)
+        VALUES ($1, $2, $3, $4)
+        RETURNING `+wishlistColumns,
+		w.UserID, w.Name, w.Shared, w.ShareToken)
+
+	created, err := scanWishlist(row)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetWishlists(ctx context.Context, userID uuid.UUID) ([]model.Wishlist, error) {
+	const op = "repository.wishlist.GetWishlists"
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT `+wishlistColumns+`
+        FROM wishlists w
+        WHERE w.user_id = $1
+        ORDER BY w.created_at`, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	var lists []model.Wishlist
+	for rows.Next() {
+		w, err := scanWishlist(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		lists = append(lists, *w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := r.loadItems(ctx, lists); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return lists, nil
+}
+
+func (r *Repository) GetWishlist(ctx context.Context, userID, id uuid.UUID) (*model.Wishlist, error) {
+	const op = "repository.wishlist.GetWishlist"
+
+	row := r.DB.QueryRowContext(ctx, `
+        SELECT `+wishlistColumns+`
+        FROM wishlists w
+        WHERE w.id = $1 AND w.user_id = $2`, id, userID)
+
+	w, err := scanWishlist(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrWishlistNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	lists := []model.Wishlist{*w}
+	if err := r.loadItems(ctx, lists); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &lists[0], nil
+}
+
+func (r *Repository) GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error) {
+	const op = "repository.wishlist.GetSharedWishlist"
+
+	var owner string
+	row := r.DB.QueryRowContext(ctx, `
+        SELECT `+wishlistColumns+`, u.username
+        FROM wishlists w
+        JOIN users u ON u.id = w.user_id
+        WHERE w.share_token = $1 AND w.shared`, token)
+
+	w, err := scanWishlist(row, &owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(repository.ErrWishlistNotFound, op)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	w.Owner = owner
+
+	lists := []model.Wishlist{*w}
+	if err := r.loadItems(ctx, lists); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &lists[0], nil
+}
+
+func (r *Repository) UpdateWishlist(ctx context.Context, userID, id uuid.UUID, req model.Request) error {
+	const op = "repository.wishlist.UpdateWishlist"
+
+	res, err := r.DB.ExecContext(ctx, `
+        UPDATE wishlists
+        SET name = $3, shared = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND user_id = $2`, id, userID, req.Name, req.Shared)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if updated == 0 {
+		return errors.Wrap(repository.ErrWishlistNotFound, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteWishlist(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repository.wishlist.DeleteWishlist"
+
+	res, err := r.DB.ExecContext(ctx, "DELETE FROM wishlists WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if deleted == 0 {
+		return errors.Wrap(repository.ErrWishlistNotFound, op)
+	}
+
+	return nil
+}
+
+func (r *Repository) AddItem(ctx context.Context, wishlistID, bookID uuid.UUID) (bool, error) {
+	const op = "repository.wishlist.AddItem"
+
+	res, err := r.DB.ExecContext(ctx, `
+        WITH added AS (
+            INSERT INTO wishlist_items (wishlist_id, book_id)
+            VALUES ($1, $2)
+            ON CONFLICT (wishlist_id, book_id) DO NOTHING
+            RETURNING book_id
+        )
+        UPDATE wishlists
+        SET updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND EXISTS (SELECT 1 FROM added)`, wishlistID, bookID)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	added, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+
+	return added > 0, nil
+}
+
+func (r *Repository) RemoveItem(ctx context.Context, wishlistID, bookID uuid.UUID) error {
+	const op = "repository.wishlist.RemoveItem"
+
+	res, err := r.DB.ExecContext(ctx, `
+        WITH removed AS (
+            DELETE FROM wishlist_items
+            WHERE wishlist_id = $1 AND book_id = $2
+            RETURNING book_id
+        )
+        UPDATE wishlists
+        SET updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND EXISTS (SELECT 1 FROM removed)`, wishlistID, bookID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if removed == 0 {
+		return errors.Wrap(repository.ErrWishlistItemNotFound, op)
+	}
+
+	return nil
+}
+
+// loadItems fills in the items of lists, newest first, in one query.
+func (r *Repository) loadItems(ctx context.Context, lists []model.Wishlist) error {
+	if len(lists) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(lists))
+	byID := make(map[uuid.UUID]*model.Wishlist, len(lists))
+	for i := range lists {
+		ids[i] = lists[i].ID.String()
+		byID[lists[i].ID] = &lists[i]
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+        SELECT i.wishlist_id, b.id, b.title, COALESCE(b.author, ''), b.price, COALESCE(b.stock, 0), i.added_at
+        FROM wishlist_items i
+        JOIN books b ON b.id = i.book_id
+        WHERE i.wishlist_id = ANY($1::uuid[])
+        ORDER BY i.added_at DESC`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wishlistID uuid.UUID
+		var item model.Item
+		if err := rows.Scan(&wishlistID, &item.BookID, &item.Title, &item.Author, &item.Price, &item.Stock, &item.AddedAt); err != nil {
+			return err
+		}
+		if w, ok := byID[wishlistID]; ok {
+			w.Items = append(w.Items, item)
+		}
+	}
+
+	return rows.Err()
+}
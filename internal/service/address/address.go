@@ -0,0 +1,137 @@
+package address
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// maxAddresses caps an address book so it stays usable as a picker.
+const maxAddresses = 20
+
+type Service struct {
+	AddressRepo interfaces.AddressRepository
+}
+
+func (s *Service) CreateAddress(ctx context.Context, userID string, req model.Request) (uuid.UUID, error) {
+	const op = "service.address.CreateAddress"
+
+	uID, err := parseUserID(userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req = req.Normalize()
+	if err = req.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w: %w", op, err, service.ErrValid)
+	}
+
+	existing, err := s.AddressRepo.GetAddresses(ctx, uID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+	if len(existing) >= maxAddresses {
+		return uuid.Nil, fmt.Errorf("%s: address book is full (%d addresses): %w", op, maxAddresses, service.ErrValid)
+	}
+
+	id, err := s.AddressRepo.CreateAddress(ctx, req.Address(uID))
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdateAddress(ctx context.Context, userID string, id uuid.UUID, req model.Request) error {
+	const op = "service.address.UpdateAddress"
+
+	uID, err := parseUserID(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req = req.Normalize()
+	if err = req.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %w", op, err, service.ErrValid)
+	}
+
+	a := req.Address(uID)
+	a.ID = id
+
+	err = s.AddressRepo.UpdateAddress(ctx, a)
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteAddress(ctx context.Context, userID string, id uuid.UUID) error {
+	const op = "service.address.DeleteAddress"
+
+	uID, err := parseUserID(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.AddressRepo.DeleteAddress(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) GetAddresses(ctx context.Context, userID string) ([]model.Address, error) {
+	const op = "service.address.GetAddresses"
+
+	uID, err := parseUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	addresses, err := s.AddressRepo.GetAddresses(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return addresses, nil
+}
+
+func (s *Service) GetAddress(ctx context.Context, userID string, id uuid.UUID) (*model.Address, error) {
+	const op = "service.address.GetAddress"
+
+	uID, err := parseUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	a, err := s.AddressRepo.GetAddress(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return a, nil
+}
+
+func parseUserID(userID string) (uuid.UUID, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+	return uID, nil
+}
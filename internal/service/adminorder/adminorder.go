@@ -0,0 +1,206 @@
+package adminorder
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"slices"
+)
+
+// Service lets staff find any customer's order and act on it. Status changes
+// go through the order service, so they obey the same state machine, stock
+// and refund rules as everywhere else.
+type Service struct {
+	AdminOrderRepo interfaces.AdminOrderRepository
+	OrderRepo      interfaces.OrderRepository
+	OrderSvc       interfaces.OrderService
+	PaymentRepo    interfaces.PaymentRepository
+	ShipmentRepo   interfaces.ShipmentRepository
+	Notifications  interfaces.NotificationService
+}
+
+func (s *Service) SearchOrders(ctx context.Context, filter model.Filter) (*model.Page, error) {
+	const op = "service.adminorder.SearchOrders"
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%s: unknown status %q: %w", op, filter.Status, service.ErrValid)
+	}
+	if filter.Limit <= 0 || filter.Limit > model.MaxLimit {
+		filter.Limit = model.DefaultLimit
+	}
+	filter.Offset = max(filter.Offset, 0)
+
+	page, err := s.AdminOrderRepo.SearchOrders(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return page, nil
+}
+
+// GetOrder gathers an order with its customer, lines, payments, parcels,
+// status history and internal notes.
+func (s *Service) GetOrder(ctx context.Context, orderID string) (*model.Detail, error) {
+	const op = "service.adminorder.GetOrder"
+
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	summary, err := s.AdminOrderRepo.GetSummary(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	detail := &model.Detail{
+		Order: order,
+		Customer: model.Customer{
+			ID:       summary.UserID,
+			Username: summary.Username,
+			Email:    summary.Email,
+		},
+		CreatedAt: summary.CreatedAt,
+		Items:     []orderItem.OrderItemFull{},
+	}
+
+	items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, order.ID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if items != nil {
+		detail.Items = *items
+	}
+
+	if detail.Payments, err = s.PaymentRepo.GetPaymentsByOrderID(ctx, order.ID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if detail.Shipments, err = s.ShipmentRepo.GetShipmentsByOrderID(ctx, order.ID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if detail.History, err = s.OrderRepo.GetStatusHistory(ctx, order.ID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if detail.Notes, err = s.AdminOrderRepo.GetNotes(ctx, order.ID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return detail, nil
+}
+
+// UpdateStatus moves an order to one of model.StatusTargets. Cancelling puts
+// the books back and refunds a paid order, just as the cancel endpoint does.
+func (s *Service) UpdateStatus(ctx context.Context, adminID, orderID string, req model.StatusRequest) (*model.Detail, error) {
+	const op = "service.adminorder.UpdateStatus"
+
+	aID, err := uuid.FromString(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid userID format: %w", op, service.ErrValid)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !slices.Contains(model.StatusTargets(order.Status), req.Status) {
+		return nil, fmt.Errorf("%s: %w", op, &orderModel.TransitionError{From: order.Status, To: req.Status})
+	}
+
+	if req.Status == orderModel.StatusCancelled {
+		err = s.OrderSvc.Cancel(ctx, adminID, order.ID.String(), true, req.Reason)
+	} else {
+		err = s.OrderSvc.Transition(ctx, order.ID, req.Status, orderModel.AdminActor(aID), req.Reason)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	detail, err := s.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return detail, nil
+}
+
+func (s *Service) AddNote(ctx context.Context, adminID, orderID string, req model.NoteRequest) (*model.Note, error) {
+	const op = "service.adminorder.AddNote"
+
+	aID, err := uuid.FromString(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid userID format: %w", op, service.ErrValid)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	note, err := s.AdminOrderRepo.AddNote(ctx, model.Note{
+		OrderID:  order.ID,
+		AuthorID: aID,
+		Body:     req.Body,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return note, nil
+}
+
+// ResendConfirmation emails the customer their order confirmation again.
+// Only orders that were paid have been confirmed.
+func (s *Service) ResendConfirmation(ctx context.Context, orderID string) error {
+	const op = "service.adminorder.ResendConfirmation"
+
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !order.Status.IsSettled() {
+		return fmt.Errorf("%s: a %s order has no confirmation to resend: %w", op, order.Status, service.ErrValid)
+	}
+
+	if err := s.Notifications.ResendOrderConfirmation(ctx, order.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) getOrder(ctx context.Context, orderID string) (*orderModel.Model, error) {
+	oID, err := uuid.FromString(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid orderID format: %w", service.ErrValid)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, oID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
@@ -2,11 +2,15 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/auth"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 )
 
 type Service struct {
@@ -59,3 +63,35 @@ func (s *Service) Login(ctx context.Context, user model.Login) (uuid.UUID, int,
 
 	return id, role, err
 }
+
+func (s *Service) ChangePassword(ctx context.Context, userID string, req model.PasswordChange) error {
+	const op = "service.auth.ChangePassword"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	err = s.AuthRepo.ChangePassword(ctx, uID, hashPassword(req.CurrentPassword), hashPassword(req.NewPassword))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWrongPassword):
+			return fmt.Errorf("%s: current password is wrong: %w", op, service.ErrValid)
+		case errors.Is(err, repository.ErrUserNotFound):
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// hashPassword hashes a password the way it is stored in users.password.
+func hashPassword(password string) string {
+	hashed := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(hashed[:])
+}
@@ -0,0 +1,52 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+	"slices"
+)
+
+// Service prices the catalogue, which is kept in money.DefaultCurrency, in
+// the currencies the storefront offers.
+type Service struct {
+	Rates               interfaces.ExchangeRateProvider
+	SupportedCurrencies []money.Currency
+}
+
+func (s *Service) Supported() []money.Currency {
+	return s.SupportedCurrencies
+}
+
+// Quote is the rate from the catalogue currency to the given one.
+func (s *Service) Quote(ctx context.Context, to money.Currency) (money.Rate, error) {
+	const op = "service.currency.Quote"
+
+	if to == money.DefaultCurrency {
+		return money.One, nil
+	}
+	if !slices.Contains(s.SupportedCurrencies, to) {
+		return money.Rate{}, fmt.Errorf("%s: currency %q is not offered: %w", op, to, service.ErrValid)
+	}
+
+	rate, err := s.Rates.Rate(ctx, money.DefaultCurrency, to)
+	if err != nil {
+		return money.Rate{}, errors.Wrap(err, op)
+	}
+
+	return rate, nil
+}
+
+func (s *Service) Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error) {
+	const op = "service.currency.Convert"
+
+	rate, err := s.Quote(ctx, to)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return amount.Convert(to, rate), nil
+}
@@ -6,4 +6,6 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrNotFound          = errors.New("not found")
 	ErrValid             = errors.New("invalid data")
+	ErrPaymentDeclined   = errors.New("payment declined")
+	ErrPaymentPending    = errors.New("a payment for this order is still being processed")
 )
@@ -0,0 +1,105 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/pkg/errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Subscribers is everything the dispatcher hands events to. The list is put
+// together in the event provider set.
+type Subscribers []interfaces.EventSubscriber
+
+// Dispatcher delivers events from the outbox to the subscribers in-process.
+// An event is marked delivered once every subscriber that wants it has handled
+// it; otherwise it is tried again later, skipping the subscribers that already
+// took it, until the retry policy gives up and dead-letters it.
+type Dispatcher struct {
+	EventRepo   interfaces.EventRepository
+	Subscribers Subscribers
+	Retry       model.RetryPolicy
+	Lease       time.Duration
+	Every       time.Duration
+	BatchSize   int
+	Log         *slog.Logger
+}
+
+func (d *Dispatcher) Name() string {
+	return "domain-event-dispatcher"
+}
+
+func (d *Dispatcher) Interval() time.Duration {
+	return d.Every
+}
+
+func (d *Dispatcher) Run(ctx context.Context) error {
+	const op = "service.event.Dispatcher.Run"
+
+	for {
+		events, err := d.EventRepo.ClaimDue(ctx, time.Now(), d.Lease, d.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		for _, e := range events {
+			if err := d.deliver(ctx, e); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+
+		if len(events) < d.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, e model.Event) error {
+	handledBy := slices.Clone(e.HandledBy)
+	var failures []string
+
+	for _, sub := range d.Subscribers {
+		if !wants(sub, e.Type) || slices.Contains(handledBy, sub.Name()) {
+			continue
+		}
+		if err := sub.Handle(ctx, e); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", sub.Name(), err))
+			continue
+		}
+		handledBy = append(handledBy, sub.Name())
+	}
+
+	if len(failures) == 0 {
+		return d.EventRepo.MarkDelivered(ctx, e.ID)
+	}
+
+	failure := d.Retry.Fail(e, handledBy, errors.New(strings.Join(failures, "; ")), time.Now())
+	if failure.Dead {
+		d.Log.Error("domain event dead-lettered",
+			slog.String("event_id", e.ID.String()),
+			slog.String("type", string(e.Type)),
+			slog.Int("attempts", failure.Attempts),
+			slog.String("error", failure.Error),
+		)
+	} else {
+		d.Log.Warn("domain event delivery failed, will retry",
+			slog.String("event_id", e.ID.String()),
+			slog.String("type", string(e.Type)),
+			slog.Int("attempts", failure.Attempts),
+			slog.Time("next_attempt_at", failure.NextAttemptAt),
+			slog.String("error", failure.Error),
+		)
+	}
+
+	return d.EventRepo.MarkFailed(ctx, e.ID, failure)
+}
+
+func wants(sub interfaces.EventSubscriber, typ model.Type) bool {
+	topics := sub.Topics()
+	return len(topics) == 0 || slices.Contains(topics, typ)
+}
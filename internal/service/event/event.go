@@ -0,0 +1,49 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// stuckLimit caps how many stuck events the admin view lists at once.
+const stuckLimit = 200
+
+type Service struct {
+	EventRepo  interfaces.EventRepository
+	StuckAfter time.Duration
+}
+
+// GetStuckEvents lists events that were dead-lettered, or that are still
+// undelivered StuckAfter after they were raised.
+func (s *Service) GetStuckEvents(ctx context.Context) ([]model.Event, error) {
+	const op = "service.event.GetStuckEvents"
+
+	events, err := s.EventRepo.GetStuck(ctx, time.Now().Add(-s.StuckAfter), stuckLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return events, nil
+}
+
+// RetryEvent sends an undelivered event out again on the next dispatch.
+func (s *Service) RetryEvent(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+	const op = "service.event.RetryEvent"
+
+	e, err := s.EventRepo.Requeue(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"log/slog"
+)
+
+// LogSubscriber writes every event to the application log, leaving a trail of
+// what happened to orders and stock in the order it was published.
+type LogSubscriber struct {
+	Log *slog.Logger
+}
+
+func (s *LogSubscriber) Name() string {
+	return "log"
+}
+
+func (s *LogSubscriber) Topics() []model.Type {
+	return nil
+}
+
+func (s *LogSubscriber) Handle(ctx context.Context, e model.Event) error {
+	s.Log.Info("domain event",
+		slog.String("event_id", e.ID.String()),
+		slog.String("type", string(e.Type)),
+		slog.String("aggregate_type", e.AggregateType),
+		slog.String("aggregate_id", e.AggregateID.String()),
+		slog.String("payload", string(e.Payload)),
+	)
+	return nil
+}
@@ -8,10 +8,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/adminorder"
 	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/auth"
 	modelB "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/books"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/mainPageParams"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"html/template"
@@ -22,11 +33,21 @@ import (
 )
 
 type Service struct {
-	UserRepo  interfaces.UserRepository
-	AuthRepo  interfaces.AuthRepository
-	BookRepo  interfaces.BookRepository
-	OrderRepo interfaces.OrderRepository
-	Templates map[string]*template.Template
+	UserRepo          interfaces.UserRepository
+	AuthRepo          interfaces.AuthRepository
+	BookRepo          interfaces.BookRepository
+	OrderRepo         interfaces.OrderRepository
+	OrderSvc          interfaces.OrderService
+	ShipmentRepo      interfaces.ShipmentRepository
+	ShipmentSvc       interfaces.ShipmentService
+	ReturnRepo        interfaces.ReturnRepository
+	CurrencySvc       interfaces.CurrencyService
+	WishlistSvc       interfaces.WishlistService
+	ReviewSvc         interfaces.ReviewService
+	RecommendationSvc interfaces.RecommendationService
+	ReportSvc         interfaces.ReportService
+	AdminOrderSvc     interfaces.AdminOrderService
+	Templates         map[string]*template.Template
 }
 
 func (s *Service) MainPage(ctx context.Context, params mainPageParams.Model) (string, error) {
@@ -53,6 +74,11 @@ func (s *Service) MainPage(ctx context.Context, params mainPageParams.Model) (st
 		filteredBooks = *allBooks
 	}
 
+	currency, rate := s.quote(ctx, params.Currency)
+	for i := range filteredBooks {
+		filteredBooks[i].Price = filteredBooks[i].Price.Convert(currency, rate)
+	}
+
 	switch params.SortBy {
 	case "name":
 		sort.Slice(filteredBooks, func(i, j int) bool {
@@ -60,12 +86,19 @@ func (s *Service) MainPage(ctx context.Context, params mainPageParams.Model) (st
 		})
 	case "price":
 		sort.Slice(filteredBooks, func(i, j int) bool {
-			return filteredBooks[i].Price < filteredBooks[j].Price
+			return filteredBooks[i].Price.LessThan(filteredBooks[j].Price)
 		})
 	case "stock":
 		sort.Slice(filteredBooks, func(i, j int) bool {
 			return filteredBooks[i].Stock < filteredBooks[j].Stock
 		})
+	case "rating":
+		sort.SliceStable(filteredBooks, func(i, j int) bool {
+			if filteredBooks[i].RatingAverage != filteredBooks[j].RatingAverage {
+				return filteredBooks[i].RatingAverage > filteredBooks[j].RatingAverage
+			}
+			return filteredBooks[i].RatingCount > filteredBooks[j].RatingCount
+		})
 	}
 
 	var buf bytes.Buffer
@@ -77,6 +110,8 @@ func (s *Service) MainPage(ctx context.Context, params mainPageParams.Model) (st
 		"SearchQuery": params.SearchQuery,
 		"UserName":    params.UserName,
 		"Role":        params.Role,
+		"Currency":    currency,
+		"Currencies":  s.CurrencySvc.Supported(),
 	})
 	if err != nil {
 		return "", errors.Wrap(err, op)
@@ -106,14 +141,17 @@ func (s *Service) LoginPage(ctx context.Context, page, errorMessage, successMess
 	return buf.String(), nil
 }
 
-func (s *Service) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) error {
+// ProcessLogin logs the user in through the API and hands its cookies to the
+// browser. The guest cart cookie is kept back: the storefront merges that cart
+// itself once it knows who logged in.
+func (s *Service) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, form url.Values) (uuid.UUID, error) {
 	const op = "service.front.ProcessLogin"
 
 	email := form.Get("email")
 	password := form.Get("password")
 
 	if email == "" || password == "" {
-		return errors.New("empty_fields")
+		return uuid.Nil, errors.New("empty_fields")
 	}
 
 	loginData := map[string]string{
@@ -123,15 +161,18 @@ func (s *Service) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *ht
 
 	jsonData, err := json.Marshal(loginData)
 	if err != nil {
-		return errors.New("json_encoding_failed")
+		return uuid.Nil, errors.New("json_encoding_failed")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", "http://localhost:8080/api/v1/login", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return errors.New("request_failed")
+		return uuid.Nil, errors.New("request_failed")
 	}
 
 	for _, cookie := range r.Cookies() {
+		if cookie.Name == guest.CookieName {
+			continue
+		}
 		req.AddCookie(cookie)
 	}
 
@@ -140,23 +181,30 @@ func (s *Service) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *ht
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return errors.New("server_unavailable")
+		return uuid.Nil, errors.New("server_unavailable")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return errors.New("already_logged_in")
+		return uuid.Nil, errors.New("already_logged_in")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.New("invalid_credentials")
+		return uuid.Nil, errors.New("invalid_credentials")
+	}
+
+	var loggedIn struct {
+		Data uuid.UUID `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&loggedIn); err != nil {
+		return uuid.Nil, errors.New("invalid_response")
 	}
 
 	for _, cookie := range resp.Cookies() {
 		http.SetCookie(w, cookie)
 	}
 
-	return nil
+	return loggedIn.Data, nil
 }
 
 func (s *Service) RegistrationPage(ctx context.Context, page, errorMessage, successMessage string) (string, error) {
@@ -180,7 +228,7 @@ func (s *Service) RegistrationPage(ctx context.Context, page, errorMessage, succ
 	return buf.String(), nil
 }
 
-func (s *Service) ProcessRegistration(ctx context.Context, form url.Values) error {
+func (s *Service) ProcessRegistration(ctx context.Context, form url.Values) (uuid.UUID, error) {
 	const op = "service.front.ProcessRegistration"
 
 	username := form.Get("username")
@@ -188,11 +236,11 @@ func (s *Service) ProcessRegistration(ctx context.Context, form url.Values) erro
 	password := form.Get("password")
 
 	if email == "" || password == "" {
-		return errors.New("empty_fields")
+		return uuid.Nil, errors.New("empty_fields")
 	}
 
 	if len(strings.TrimSpace(password)) < 3 {
-		return errors.New("password is too short")
+		return uuid.Nil, errors.New("password is too short")
 	}
 
 	user := model.Registration{
@@ -204,12 +252,12 @@ func (s *Service) ProcessRegistration(ctx context.Context, form url.Values) erro
 	hashedPassword := sha256.Sum256([]byte(user.Password))
 	user.Password = hex.EncodeToString(hashedPassword[:])
 
-	_, err := s.AuthRepo.Register(ctx, user)
+	userID, err := s.AuthRepo.Register(ctx, user)
 	if err != nil {
-		return errors.New("user by that mail already exists")
+		return uuid.Nil, errors.New("user by that mail already exists")
 	}
 
-	return nil
+	return userID, nil
 }
 
 func (s *Service) AdminPage(ctx context.Context, params mainPageParams.Model) (string, error) {
@@ -243,7 +291,7 @@ func (s *Service) AdminPage(ctx context.Context, params mainPageParams.Model) (s
 		})
 	case "price":
 		sort.Slice(filteredBooks, func(i, j int) bool {
-			return filteredBooks[i].Price < filteredBooks[j].Price
+			return filteredBooks[i].Price.LessThan(filteredBooks[j].Price)
 		})
 	case "stock":
 		sort.Slice(filteredBooks, func(i, j int) bool {
@@ -251,10 +299,16 @@ func (s *Service) AdminPage(ctx context.Context, params mainPageParams.Model) (s
 		})
 	}
 
+	reviews, err := s.ReviewSvc.GetQueue(ctx, review.StatusPending)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, map[string]interface{}{
 		"Title":       "Admin Panel",
 		"Books":       filteredBooks,
+		"Reviews":     reviews,
 		"Error":       params.ErrorMessage,
 		"Success":     params.SuccessMessage,
 		"SearchQuery": params.SearchQuery,
@@ -316,39 +370,22 @@ func (s *Service) DeleteBook(ctx context.Context, bookID string) error {
 	return nil
 }
 
-func (s *Service) GetCartItems(ctx context.Context, userId string) (*[]orderModels.OrderItemFull, error) {
+// GetCartItems lists the user's cart with prices in the given currency.
+func (s *Service) GetCartItems(ctx context.Context, userId string, currency money.Currency) (*[]orderModels.OrderItemFull, error) {
 	const op = "service.front.GetCartItems"
 
-	exists, err := s.OrderRepo.CheckOrderExists(ctx, userId)
-	if err != nil {
-		return nil, errors.Wrap(err, op)
-	}
-	if !exists {
-		err = s.OrderRepo.CreateUserOrder(ctx, userId)
-		if err != nil {
-			return nil, errors.Wrap(err, op)
-		}
-	}
-
-	userOrder, err := s.OrderRepo.GetUsersOrder(ctx, userId)
+	c, err := s.OrderSvc.GetCart(ctx, userId, currency)
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
 
-	orderID := userOrder.ID.String()
-
-	orderItems, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, orderID)
-	if err != nil {
-		return nil, errors.Wrap(err, op)
-	}
-
-	return orderItems, nil
+	return &c.Items, nil
 }
 
 func (s *Service) AddCartItems(ctx context.Context, userID string, items *[]orderModels.OrderItem) error {
 	const op = "service.front.AddCartItems"
 
-	err := s.OrderRepo.AddOrderItemIntoOrder(ctx, userID, items)
+	err := s.OrderSvc.AddOrderItemIntoOrder(ctx, userID, items)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -359,7 +396,7 @@ func (s *Service) AddCartItems(ctx context.Context, userID string, items *[]orde
 func (s *Service) RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error {
 	const op = "service.front.RemoveCartItem"
 
-	err := s.OrderRepo.RemoveCartItem(ctx, userID, bookID)
+	err := s.OrderSvc.RemoveCartItem(ctx, userID, bookID)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -367,15 +404,86 @@ func (s *Service) RemoveCartItem(ctx context.Context, userID string, bookID uuid
 	return nil
 }
 
-func (s *Service) CartCheckout(ctx context.Context, userID string) error {
-	const op = "service.front.CartCheckout"
+// SetCartItemQuantity sets how many copies of a book the user's cart holds.
+func (s *Service) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	const op = "service.front.SetCartItemQuantity"
 
-	orderModel, err := s.OrderRepo.GetUserOrderByUserID(ctx, userID)
+	err := s.OrderSvc.SetCartItemQuantity(ctx, userID, bookID, quantity)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
 
-	err = s.OrderRepo.ChangeStatusOfCart(ctx, userID, orderModel.ID.String())
+	return nil
+}
+
+// CartSummary prices the user's cart, promotions included, in the given
+// currency. A user without a cart gets an empty one.
+func (s *Service) CartSummary(ctx context.Context, userID string, currency money.Currency) (*promotion.Breakdown, error) {
+	const op = "service.front.CartSummary"
+
+	c, err := s.OrderSvc.GetCart(ctx, userID, currency)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &c.Summary, nil
+}
+
+func (s *Service) ApplyCartPromotion(ctx context.Context, userID, code string, currency money.Currency) (*promotion.Breakdown, error) {
+	const op = "service.front.ApplyCartPromotion"
+
+	breakdown, err := s.OrderSvc.ApplyPromotion(ctx, userID, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.convertBreakdown(ctx, breakdown, currency), nil
+}
+
+func (s *Service) RemoveCartPromotion(ctx context.Context, userID, code string, currency money.Currency) (*promotion.Breakdown, error) {
+	const op = "service.front.RemoveCartPromotion"
+
+	breakdown, err := s.OrderSvc.RemovePromotion(ctx, userID, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.convertBreakdown(ctx, breakdown, currency), nil
+}
+
+// CartShippingOptions quotes delivery of the cart to one of the user's
+// addresses, in the currency the customer browses in.
+func (s *Service) CartShippingOptions(ctx context.Context, userID string, addressID uuid.UUID, currency money.Currency) ([]shipping.Quote, error) {
+	const op = "service.front.CartShippingOptions"
+
+	quotes, err := s.OrderSvc.GetShippingOptions(ctx, userID, addressID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	currency, rate := s.quote(ctx, currency)
+	for i := range quotes {
+		quotes[i].Price = quotes[i].Price.Convert(currency, rate)
+	}
+
+	return quotes, nil
+}
+
+func (s *Service) SetCartShipping(ctx context.Context, userID string, req orderModel.ShippingRequest, currency money.Currency) (*promotion.Breakdown, error) {
+	const op = "service.front.SetCartShipping"
+
+	breakdown, err := s.OrderSvc.SetShipping(ctx, userID, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.convertBreakdown(ctx, breakdown, currency), nil
+}
+
+func (s *Service) CartCheckout(ctx context.Context, userID string, currency money.Currency) error {
+	const op = "service.front.CartCheckout"
+
+	err := s.OrderSvc.Checkout(ctx, userID, currency)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -391,6 +499,34 @@ func (s *Service) HistoryPage(ctx context.Context, userID string) (string, error
 		return "", errors.Wrap(err, op)
 	}
 
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return "", errors.Wrap(err, op+": invalid userID format")
+	}
+
+	shipments, err := s.ShipmentRepo.GetShipmentsByUserID(ctx, uID)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	returns, err := s.ReturnRepo.GetReturnsByUserID(ctx, uID)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	byOrder := make(map[uuid.UUID][]shipment.Shipment)
+	for _, sh := range shipments {
+		byOrder[sh.OrderID] = append(byOrder[sh.OrderID], sh)
+	}
+	returnsByOrder := make(map[uuid.UUID][]rma.Return)
+	for _, ret := range returns {
+		returnsByOrder[ret.OrderID] = append(returnsByOrder[ret.OrderID], ret)
+	}
+	for i := range orders {
+		orders[i].Shipments = byOrder[orders[i].ID]
+		orders[i].Returns = returnsByOrder[orders[i].ID]
+	}
+
 	var tmpl, ok = s.Templates["history"]
 	if !ok {
 		return "", errors.Wrap(errors.New("couldn't load template"), op)
@@ -407,3 +543,261 @@ func (s *Service) HistoryPage(ctx context.Context, userID string) (string, error
 
 	return buf.String(), nil
 }
+
+// AddressesPage renders the address book; the page manages the addresses
+// through the JSON API.
+func (s *Service) AddressesPage(ctx context.Context) (string, error) {
+	const op = "service.front.AddressesPage"
+
+	var tmpl, ok = s.Templates["addresses"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]interface{}{
+		"Title": "My Addresses",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
+
+// PickListPage renders the printable pick list of a shipment.
+func (s *Service) PickListPage(ctx context.Context, shipmentID uuid.UUID) (string, error) {
+	const op = "service.front.PickListPage"
+
+	pickList, err := s.ShipmentSvc.GetPickList(ctx, shipmentID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tmpl, ok = s.Templates["picklist"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Title":    "Pick List",
+		"PickList": pickList,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
+
+// ReportsPage renders the sales and inventory dashboard for a date range.
+func (s *Service) ReportsPage(ctx context.Context, rng report.Range, interval report.Interval) (string, error) {
+	const op = "service.front.ReportsPage"
+
+	summary, err := s.ReportSvc.GetSummary(ctx, rng)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	revenue, err := s.ReportSvc.GetRevenue(ctx, rng, interval)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	topSellers, err := s.ReportSvc.GetTopSellers(ctx, rng, report.DefaultLimit)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	turnover, err := s.ReportSvc.GetTurnover(ctx, rng, report.DefaultLimit)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	lowStock, err := s.ReportSvc.GetLowStock(ctx, rng, report.DefaultThreshold, report.DefaultLimit)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tmpl, ok = s.Templates["reports"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Title":      "Sales Reports",
+		"From":       rng.From.Format(report.DateLayout),
+		"To":         rng.To.Format(report.DateLayout),
+		"Interval":   interval,
+		"Summary":    summary,
+		"Revenue":    revenue,
+		"TopSellers": topSellers,
+		"Turnover":   turnover,
+		"LowStock":   lowStock,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
+
+// WishlistPage renders a shared wishlist for friends to buy from, priced in
+// the visitor's currency.
+func (s *Service) WishlistPage(ctx context.Context, token string, loggedIn bool, currency money.Currency) (string, error) {
+	const op = "service.front.WishlistPage"
+
+	list, err := s.WishlistSvc.GetSharedWishlist(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	currency, rate := s.quote(ctx, currency)
+	for i := range list.Items {
+		list.Items[i].Price = list.Items[i].Price.Convert(currency, rate)
+	}
+
+	var tmpl, ok = s.Templates["wishlist"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Title":    list.Name,
+		"Wishlist": list,
+		"LoggedIn": loggedIn,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
+
+// BookPage renders a book with its rating, approved reviews and the books
+// bought with it, priced in the visitor's currency. Logged-in customers also
+// see their own review.
+func (s *Service) BookPage(ctx context.Context, userID string, bookID uuid.UUID, sort review.Sort, currency money.Currency) (string, error) {
+	const op = "service.front.BookPage"
+
+	reviews, err := s.ReviewSvc.GetReviews(ctx, userID, bookID, sort)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	book, err := s.BookRepo.GetBookById(ctx, bookID)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	// Suggestions are an extra; the page is still worth showing without them.
+	recs, err := s.RecommendationSvc.GetRecommendations(ctx, bookID, recommendation.DefaultLimit)
+	if err != nil {
+		recs = nil
+	}
+
+	currency, rate := s.quote(ctx, currency)
+	book.Price = book.Price.Convert(currency, rate)
+	for i := range recs {
+		recs[i].Price = recs[i].Price.Convert(currency, rate)
+	}
+
+	var tmpl, ok = s.Templates["book"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Title":           book.Title,
+		"Book":            book,
+		"Reviews":         reviews,
+		"Recommendations": recs,
+		"Sort":            sort,
+		"Stars":           []int{5, 4, 3, 2, 1},
+		"LoggedIn":        userID != "",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
+
+func (s *Service) convertBreakdown(ctx context.Context, breakdown *promotion.Breakdown, currency money.Currency) *promotion.Breakdown {
+	currency, rate := s.quote(ctx, currency)
+	converted := breakdown.Convert(currency, rate)
+	return &converted
+}
+
+// quote finds the rate for showing prices in currency. Browsing should not
+// break because rates are unavailable, so it falls back to the catalogue
+// currency; checkout quotes again and does fail.
+func (s *Service) quote(ctx context.Context, currency money.Currency) (money.Currency, money.Rate) {
+	if currency == "" || currency == money.DefaultCurrency {
+		return money.DefaultCurrency, money.One
+	}
+
+	rate, err := s.CurrencySvc.Quote(ctx, currency)
+	if err != nil {
+		return money.DefaultCurrency, money.One
+	}
+
+	return currency, rate
+}
+
+// OrdersPage renders the admin order console: the orders matching filter, or
+// one order with everything staff can do to it when orderID is set.
+func (s *Service) OrdersPage(ctx context.Context, filter adminorder.Filter, orderID string) (string, error) {
+	const op = "service.front.OrdersPage"
+
+	data := map[string]interface{}{
+		"Title": "Orders",
+	}
+
+	if orderID != "" {
+		detail, err := s.AdminOrderSvc.GetOrder(ctx, orderID)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		data["Detail"] = detail
+	} else {
+		page, err := s.AdminOrderSvc.SearchOrders(ctx, filter)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		data["Page"] = page
+		data["Status"] = filter.Status
+		data["Email"] = filter.Email
+		data["From"], data["To"], data["MinTotal"] = "", "", ""
+		if !filter.From.IsZero() {
+			data["From"] = filter.From.Format(adminorder.DateLayout)
+		}
+		if !filter.To.IsZero() {
+			data["To"] = filter.To.Format(adminorder.DateLayout)
+		}
+		if !filter.MinTotal.IsZero() {
+			data["MinTotal"] = filter.MinTotal.String()
+		}
+		data["HasPrev"] = page.Offset > 0
+		data["PrevOffset"] = max(page.Offset-page.Limit, 0)
+		data["HasNext"] = page.Offset+len(page.Orders) < page.Total
+		data["NextOffset"] = page.Offset + page.Limit
+	}
+
+	var tmpl, ok = s.Templates["orders"]
+	if !ok {
+		return "", errors.Wrap(errors.New("couldn't load template"), op)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return buf.String(), nil
+}
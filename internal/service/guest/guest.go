@@ -0,0 +1,116 @@
+package guest
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/guest"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	GuestRepo interfaces.GuestRepository
+	OrderRepo interfaces.OrderRepository
+	OrderSvc  interfaces.OrderService
+	Addresses interfaces.AddressService
+}
+
+// Checkout pays for a guest's cart without an account: the email is kept on
+// the guest for the order emails, the address is saved to the guest's
+// address book and the order is shipped there and paid for.
+func (s *Service) Checkout(ctx context.Context, guestID string, req model.CheckoutRequest, currency money.Currency) (*orderModel.Model, error) {
+	const op = "service.guest.Checkout"
+
+	gID, err := uuid.FromString(guestID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid guest ID: %w", op, service.ErrValid)
+	}
+
+	req = req.Normalize()
+	if err = req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, err, service.ErrValid)
+	}
+
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, guestID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: cart is empty: %w", op, service.ErrNotFound)
+	}
+
+	err = s.GuestRepo.SetEmail(ctx, gID, req.Email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, fmt.Errorf("%s: customers with an account check out from their cart: %w", op, service.ErrValid)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	addressID, err := s.Addresses.CreateAddress(ctx, guestID, req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	method := req.DeliveryMethod
+	if method == "" {
+		quotes, err := s.OrderSvc.GetShippingOptions(ctx, guestID, addressID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(quotes) == 0 {
+			return nil, fmt.Errorf("%s: nothing delivers to this address: %w", op, shipping.ErrUnavailable)
+		}
+		method = quotes[0].Method
+	}
+
+	_, err = s.OrderSvc.SetShipping(ctx, guestID, orderModel.ShippingRequest{
+		ShippingAddressID: addressID,
+		DeliveryMethod:    method,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	draft, err := s.OrderRepo.GetUsersOrder(ctx, guestID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = s.OrderSvc.Checkout(ctx, guestID, currency); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, draft.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return order, nil
+}
+
+// MergeCart moves a guest's cart into the account the visitor logged in or
+// registered with, then deletes the guest unless it has orders of its own.
+func (s *Service) MergeCart(ctx context.Context, guestID, userID uuid.UUID) error {
+	const op = "service.guest.MergeCart"
+
+	if guestID == userID {
+		return nil
+	}
+
+	if err := s.OrderSvc.MergeCart(ctx, guestID.String(), userID.String()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.GuestRepo.DeleteGuest(ctx, guestID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package guest
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// CartPurger deletes guests whose cart was abandoned: left unchanged for TTL,
+// which is also when their cookie stops working, and never checked out.
+type CartPurger struct {
+	GuestRepo interfaces.GuestRepository
+	TTL       time.Duration
+	Every     time.Duration
+	BatchSize int
+}
+
+func (p *CartPurger) Name() string {
+	return "guest-cart-purger"
+}
+
+func (p *CartPurger) Interval() time.Duration {
+	return p.Every
+}
+
+func (p *CartPurger) Run(ctx context.Context) error {
+	const op = "service.guest.CartPurger.Run"
+
+	before := time.Now().Add(-p.TTL)
+	for {
+		purged, err := p.GuestRepo.PurgeAbandoned(ctx, before, p.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if purged < p.BatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// KeySweeper deletes idempotency keys whose window has passed. Expired keys
+// are already ignored by Acquire; this only keeps the table small.
+type KeySweeper struct {
+	Store     interfaces.IdempotencyRepository
+	Every     time.Duration
+	BatchSize int
+}
+
+func (s *KeySweeper) Name() string {
+	return "idempotency-key-sweeper"
+}
+
+func (s *KeySweeper) Interval() time.Duration {
+	return s.Every
+}
+
+func (s *KeySweeper) Run(ctx context.Context) error {
+	const op = "service.idempotency.KeySweeper.Run"
+
+	for {
+		deleted, err := s.Store.DeleteExpired(ctx, time.Now(), s.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if deleted < s.BatchSize {
+			return nil
+		}
+	}
+}
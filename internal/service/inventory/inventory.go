@@ -0,0 +1,73 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type Service struct {
+	InventoryRepo interfaces.InventoryRepository
+}
+
+func (s *Service) GetMovements(ctx context.Context, bookID uuid.UUID) ([]model.Movement, error) {
+	const op = "service.inventory.GetMovements"
+
+	movements, err := s.InventoryRepo.GetMovementsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return movements, nil
+}
+
+func (s *Service) GetLevel(ctx context.Context, bookID uuid.UUID) (*model.Level, error) {
+	const op = "service.inventory.GetLevel"
+
+	level, err := s.InventoryRepo.GetLevelByBookID(ctx, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return level, nil
+}
+
+func (s *Service) AdjustStock(ctx context.Context, bookID uuid.UUID, actorID string, req model.AdjustmentRequest) error {
+	const op = "service.inventory.AdjustStock"
+
+	if !req.Reason.IsManual() {
+		return fmt.Errorf("%s: reason %q cannot be posted manually: %w", op, req.Reason, service.ErrValid)
+	}
+	if req.Quantity == 0 {
+		return fmt.Errorf("%s: quantity must not be zero: %w", op, service.ErrValid)
+	}
+	if req.Reason != model.ReasonAdjustment && req.Quantity < 0 {
+		return fmt.Errorf("%s: %s quantity must be positive: %w", op, req.Reason, service.ErrValid)
+	}
+	if req.Reason == model.ReasonAdjustment && strings.TrimSpace(req.Note) == "" {
+		return fmt.Errorf("%s: adjustments require a note: %w", op, service.ErrValid)
+	}
+
+	movement := model.NewMovement(bookID, req.Reason, req.Quantity)
+	movement.Note = strings.TrimSpace(req.Note)
+
+	if actorID != "" {
+		actor, err := uuid.FromString(actorID)
+		if err != nil {
+			return errors.Wrap(err, op+": invalid actor ID format")
+		}
+		movement.ActorID = uuid.NullUUID{UUID: actor, Valid: true}
+	}
+
+	err := s.InventoryRepo.AdjustStock(ctx, movement)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
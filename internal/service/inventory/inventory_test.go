@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/inventory"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestService_AdjustStock(t *testing.T) {
+	bookID := uuid.Must(uuid.NewV4())
+	actorID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		req          model.AdjustmentRequest
+		wantOnHand   int
+		wantNote     string
+		wantErrValid bool
+	}{
+		{name: "restock adds to what is on hand", req: model.AdjustmentRequest{Reason: model.ReasonRestock, Quantity: 5}, wantOnHand: 5},
+		{
+			name:       "adjustment can take stock away",
+			req:        model.AdjustmentRequest{Reason: model.ReasonAdjustment, Quantity: -2, Note: " stocktake "},
+			wantOnHand: -2,
+			wantNote:   "stocktake",
+		},
+		{name: "sales are not posted by hand", req: model.AdjustmentRequest{Reason: model.ReasonSale, Quantity: 1}, wantErrValid: true},
+		{name: "zero moves nothing", req: model.AdjustmentRequest{Reason: model.ReasonRestock}, wantErrValid: true},
+		{name: "restock cannot be negative", req: model.AdjustmentRequest{Reason: model.ReasonRestock, Quantity: -1}, wantErrValid: true},
+		{name: "adjustment needs a note", req: model.AdjustmentRequest{Reason: model.ReasonAdjustment, Quantity: 1, Note: " "}, wantErrValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewInventoryRepository(t)
+			svc := &Service{InventoryRepo: repo}
+			if !tt.wantErrValid {
+				repo.On("AdjustStock", mock.Anything, mock.MatchedBy(func(m model.Movement) bool {
+					return m.BookID == bookID && m.Reason == tt.req.Reason && m.OnHandDelta == tt.wantOnHand &&
+						m.ReservedDelta == 0 && m.ActorID.UUID == actorID && m.Note == tt.wantNote
+				})).Return(nil).Once()
+			}
+
+			err := svc.AdjustStock(context.Background(), bookID, actorID.String(), tt.req)
+
+			if tt.wantErrValid {
+				assert.ErrorIs(t, err, service.ErrValid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package invoice
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	InvoiceRepo interfaces.InvoiceRepository
+	OrderRepo   interfaces.OrderRepository
+	Seller      model.Seller
+	Prefix      string
+}
+
+// GetInvoicePDF returns the invoice of a paid order to its owner, or to an
+// admin. The PDF is rendered the first time it is asked for and the stored
+// copy is served from then on. Orders paid before invoices existed get theirs
+// here.
+func (s *Service) GetInvoicePDF(ctx context.Context, userID, orderID string, asAdmin bool) (*model.File, error) {
+	const op = "service.invoice.GetInvoicePDF"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+	oID, err := uuid.FromString(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid orderID format: %w", service.ErrValid)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, oID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	if !asAdmin && order.UserID != uID {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+	}
+
+	inv, err := s.InvoiceRepo.GetInvoiceByOrderID(ctx, oID)
+	if errors.Is(err, repository.ErrInvoiceNotFound) {
+		inv, err = s.InvoiceRepo.IssueMissingInvoice(ctx, oID)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	reference := inv.Reference(s.Prefix)
+	content := inv.PDF
+	if content == nil {
+		rendered, err := model.Render(inv, reference, s.Seller)
+		if err != nil {
+			return nil, errors.Wrap(err, op+": failed to render invoice")
+		}
+		if content, err = s.InvoiceRepo.SavePDF(ctx, inv.ID, rendered); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return &model.File{Reference: reference, Content: content}, nil
+}
@@ -0,0 +1,66 @@
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/invoice"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestService_GetInvoicePDF(t *testing.T) {
+	owner := uuid.Must(uuid.NewV4())
+	order := &orderModel.Model{ID: uuid.Must(uuid.NewV4()), UserID: owner, Status: orderModel.StatusPaid}
+	issued := func(pdf []byte) *model.Invoice {
+		return &model.Invoice{ID: uuid.Must(uuid.NewV4()), Number: 42, OrderID: order.ID, UserID: owner, IssuedAt: time.Now(), PDF: pdf}
+	}
+
+	t.Run("stored copy is served as it is", func(t *testing.T) {
+		invoices, orders := mocks.NewInvoiceRepository(t), mocks.NewOrderRepository(t)
+		svc := &Service{InvoiceRepo: invoices, OrderRepo: orders, Prefix: "INV-"}
+		orders.On("GetOrderByID", mock.Anything, order.ID).Return(order, nil).Once()
+		invoices.On("GetInvoiceByOrderID", mock.Anything, order.ID).Return(issued([]byte("%PDF-stored")), nil).Once()
+
+		file, err := svc.GetInvoicePDF(context.Background(), owner.String(), order.ID.String(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-000042", file.Reference)
+		assert.Equal(t, []byte("%PDF-stored"), file.Content)
+	})
+
+	t.Run("order paid before invoices existed is numbered and rendered once", func(t *testing.T) {
+		invoices, orders := mocks.NewInvoiceRepository(t), mocks.NewOrderRepository(t)
+		svc := &Service{InvoiceRepo: invoices, OrderRepo: orders, Prefix: "INV-"}
+		inv := issued(nil)
+		orders.On("GetOrderByID", mock.Anything, order.ID).Return(order, nil).Once()
+		invoices.On("GetInvoiceByOrderID", mock.Anything, order.ID).Return(nil, errors.Wrap(repository.ErrInvoiceNotFound, "test")).Once()
+		invoices.On("IssueMissingInvoice", mock.Anything, order.ID).Return(inv, nil).Once()
+		invoices.On("SavePDF", mock.Anything, inv.ID, mock.MatchedBy(func(pdf []byte) bool {
+			return bytes.HasPrefix(pdf, []byte("%PDF-"))
+		})).Return(func(_ context.Context, _ uuid.UUID, pdf []byte) []byte { return pdf }, nil).Once()
+
+		file, err := svc.GetInvoicePDF(context.Background(), owner.String(), order.ID.String(), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-000042", file.Reference)
+		assert.True(t, bytes.HasPrefix(file.Content, []byte("%PDF-")))
+	})
+
+	t.Run("someone else's invoice is not found", func(t *testing.T) {
+		invoices, orders := mocks.NewInvoiceRepository(t), mocks.NewOrderRepository(t)
+		svc := &Service{InvoiceRepo: invoices, OrderRepo: orders, Prefix: "INV-"}
+		orders.On("GetOrderByID", mock.Anything, order.ID).Return(order, nil).Once()
+
+		_, err := svc.GetInvoicePDF(context.Background(), uuid.Must(uuid.NewV4()).String(), order.ID.String(), false)
+
+		assert.ErrorIs(t, err, service.ErrNotFound)
+	})
+}
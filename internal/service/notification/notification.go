@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/mail/templates"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	NotificationRepo interfaces.NotificationRepository
+	OrderRepo        interfaces.OrderRepository
+}
+
+func (s *Service) GetPreferences(ctx context.Context, userID string) ([]model.Preference, error) {
+	const op = "service.notification.GetPreferences"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	optedOut, err := s.NotificationRepo.GetOptOuts(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return model.Preferences(optedOut), nil
+}
+
+func (s *Service) UpdatePreferences(ctx context.Context, userID string, req model.PreferencesRequest) ([]model.Preference, error) {
+	const op = "service.notification.UpdatePreferences"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := s.NotificationRepo.SetPreferences(ctx, uID, req); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	optedOut, err := s.NotificationRepo.GetOptOuts(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return model.Preferences(optedOut), nil
+}
+
+// ResendOrderConfirmation queues the confirmation of an order again, for a
+// customer who lost the first one. The copy belongs to no event, so it is
+// never taken for a duplicate, and staff asked for it, so opt-outs do not
+// apply.
+func (s *Service) ResendOrderConfirmation(ctx context.Context, orderID uuid.UUID) error {
+	const op = "service.notification.ResendOrderConfirmation"
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	rcpt, err := s.NotificationRepo.GetRecipient(ctx, order.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return fmt.Errorf("%s: the customer's account is gone: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, order.ID.String())
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	email, err := templates.Render(model.KindOrderConfirmation, model.Data{
+		Recipient: *rcpt,
+		Order:     order,
+		Items:     *items,
+	})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = s.NotificationRepo.Enqueue(ctx, model.Message{
+		UserID:  order.UserID,
+		Kind:    model.KindOrderConfirmation,
+		To:      rcpt.Email,
+		Subject: email.Subject,
+		Text:    email.Text,
+		HTML:    email.HTML,
+	})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
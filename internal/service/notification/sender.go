@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	"github.com/pkg/errors"
+	"log/slog"
+	"time"
+)
+
+// Sender hands queued emails to the Mailer, retrying failures with
+// exponential backoff until Retry.MaxAttempts.
+type Sender struct {
+	NotificationRepo interfaces.NotificationRepository
+	Mailer           interfaces.Mailer
+	From             string
+	Retry            event.RetryPolicy
+	Lease            time.Duration
+	Every            time.Duration
+	BatchSize        int
+	Log              *slog.Logger
+}
+
+func (s *Sender) Name() string {
+	return "email-sender"
+}
+
+func (s *Sender) Interval() time.Duration {
+	return s.Every
+}
+
+func (s *Sender) Run(ctx context.Context) error {
+	const op = "service.notification.Sender.Run"
+
+	for {
+		due, err := s.NotificationRepo.ClaimDue(ctx, time.Now(), s.Lease, s.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		for _, msg := range due {
+			if err := s.send(ctx, msg); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+
+		if len(due) < s.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Sender) send(ctx context.Context, msg model.Message) error {
+	err := s.Mailer.Send(ctx, msg.Email(s.From))
+	if err == nil {
+		return s.NotificationRepo.MarkSent(ctx, msg.ID)
+	}
+
+	attempts := msg.Attempts + 1
+	failure := model.Failure{
+		Attempts:      attempts,
+		Error:         err.Error(),
+		NextAttemptAt: time.Now().Add(s.Retry.Backoff(attempts)),
+		Final:         attempts >= s.Retry.MaxAttempts,
+	}
+
+	s.Log.Warn("email not sent",
+		slog.String("message_id", msg.ID.String()),
+		slog.String("kind", string(msg.Kind)),
+		slog.Int("attempts", attempts),
+		slog.Bool("final", failure.Final),
+		slog.String("error", failure.Error),
+	)
+
+	return s.NotificationRepo.MarkFailed(ctx, msg.ID, failure)
+}
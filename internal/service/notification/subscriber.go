@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/mail/templates"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"slices"
+)
+
+// kinds maps the events customers are emailed about to the email they get.
+var kinds = map[event.Type]model.Kind{
+	event.OrderStatusType(orderModel.StatusPaid):              model.KindOrderConfirmation,
+	event.OrderStatusType(orderModel.StatusShipped):           model.KindOrderShipped,
+	event.OrderStatusType(orderModel.StatusCancelled):         model.KindOrderCancelled,
+	event.OrderStatusType(orderModel.StatusPartiallyRefunded): model.KindOrderRefunded,
+	event.OrderStatusType(orderModel.StatusRefunded):          model.KindOrderRefunded,
+	event.TypeUserPasswordChanged:                             model.KindPasswordChanged,
+}
+
+// Subscriber renders the email for an event and queues it for the Sender.
+// Emails the customer opted out of are skipped.
+type Subscriber struct {
+	NotificationRepo interfaces.NotificationRepository
+	OrderRepo        interfaces.OrderRepository
+	ShipmentRepo     interfaces.ShipmentRepository
+}
+
+func (s *Subscriber) Name() string {
+	return "email"
+}
+
+func (s *Subscriber) Topics() []event.Type {
+	topics := make([]event.Type, 0, len(kinds))
+	for t := range kinds {
+		topics = append(topics, t)
+	}
+	slices.Sort(topics)
+	return topics
+}
+
+func (s *Subscriber) Handle(ctx context.Context, e event.Event) error {
+	const op = "service.notification.Subscriber.Handle"
+
+	kind, ok := kinds[e.Type]
+	if !ok {
+		return nil
+	}
+
+	var data model.Data
+	var userID uuid.UUID
+
+	if kind == model.KindPasswordChanged {
+		var changed event.PasswordChanged
+		if err := e.Decode(&changed); err != nil {
+			return errors.Wrap(err, op)
+		}
+		userID, data.ChangedAt = changed.UserID, changed.ChangedAt
+	} else {
+		var changed event.StatusChanged
+		if err := e.Decode(&changed); err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		order, err := s.OrderRepo.GetOrderByID(ctx, changed.OrderID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		userID, data.Order, data.Reason = order.UserID, order, changed.Reason
+	}
+
+	if !kind.Required() {
+		optedOut, err := s.NotificationRepo.GetOptOuts(ctx, userID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if slices.Contains(optedOut, kind) {
+			return nil
+		}
+	}
+
+	rcpt, err := s.NotificationRepo.GetRecipient(ctx, userID)
+	if err != nil {
+		// The account is gone; there is no one left to tell.
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, op)
+	}
+	data.Recipient = *rcpt
+
+	switch kind {
+	case model.KindOrderConfirmation:
+		items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, data.Order.ID.String())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		data.Items = *items
+	case model.KindOrderShipped:
+		data.Shipments, err = s.ShipmentRepo.GetShipmentsByOrderID(ctx, data.Order.ID)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	email, err := templates.Render(kind, data)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	_, err = s.NotificationRepo.Enqueue(ctx, model.Message{
+		UserID:  userID,
+		EventID: uuid.NullUUID{UUID: e.ID, Valid: true},
+		Kind:    kind,
+		To:      rcpt.Email,
+		Subject: email.Subject,
+		Text:    email.Text,
+		HTML:    email.HTML,
+	})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
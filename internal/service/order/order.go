@@ -2,15 +2,36 @@ package order
 
 import (
 	"context"
+	"fmt"
 	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/address"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/cart"
 	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
 	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	"log/slog"
+	"slices"
+	"time"
 )
 
 type Service struct {
-	OrderRepo interfaces.OrderRepository
+	OrderRepo  interfaces.OrderRepository
+	Promotions interfaces.PromotionRepository
+	Payments   interfaces.PaymentService
+	Currency   interfaces.CurrencyService
+	Tax        interfaces.TaxCalculator
+	Addresses  interfaces.AddressRepository
+	Shipping   interfaces.ShippingRateProvider
+	// TaxJurisdiction is where orders without a shipping address are taxed.
+	TaxJurisdiction tax.Jurisdiction
+	Log             *slog.Logger
 }
 
 func (s *Service) GetUsersOrder(ctx context.Context, userId string) (*orderModel.Model, error) {
@@ -54,7 +75,7 @@ func (s *Service) CreateUserOrder(ctx context.Context, userID string) error {
 	return nil
 }
 
-func (s *Service) AlterUserOrder(ctx context.Context, userID string) error {
+func (s *Service) AlterUserOrder(ctx context.Context, userID string, currency money.Currency) error {
 	const op = "service.order.AlterUserOrder"
 
 	exists, err := s.OrderRepo.CheckOrderExists(ctx, userID)
@@ -66,7 +87,7 @@ func (s *Service) AlterUserOrder(ctx context.Context, userID string) error {
 		return errors.Wrap(errors.New("order does not exists"), op)
 	}
 
-	err = s.OrderRepo.AlterUserOrder(ctx, userID)
+	err = s.Checkout(ctx, userID, currency)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -82,28 +103,681 @@ func (s *Service) AddOrderItemIntoOrder(ctx context.Context, userID string, item
 		return errors.Wrap(err, op)
 	}
 
+	if err = s.repriceDraft(ctx, userID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveCartItem(ctx context.Context, userID string, bookID uuid.UUID) error {
+	const op = "service.order.RemoveCartItem"
+
+	err := s.OrderRepo.RemoveCartItem(ctx, userID, bookID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCartItemNotFound) {
+			return fmt.Errorf("%s: %w: %w", op, repository.ErrCartItemNotFound, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if err = s.repriceDraft(ctx, userID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
 	return nil
 }
 
-func (s *Service) AlterUserOrderByID(ctx context.Context, userID, orderID string) error {
+// SetCartItemQuantity sets how many copies of a book the user's cart holds,
+// adding the book if it is not in the cart yet. Zero takes it out.
+func (s *Service) SetCartItemQuantity(ctx context.Context, userID string, bookID uuid.UUID, quantity int) error {
+	const op = "service.order.SetCartItemQuantity"
+
+	if err := (cart.QuantityRequest{Quantity: quantity}).Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %w", op, err, service.ErrValid)
+	}
+
+	if quantity == 0 {
+		if err := s.RemoveCartItem(ctx, userID, bookID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	}
+
+	err := s.OrderRepo.SetCartItemQuantity(ctx, userID, bookID, quantity)
+	if err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			return fmt.Errorf("%s: %w: %w", op, repository.ErrBookNotFound, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	if err = s.repriceDraft(ctx, userID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// GetCart lists the user's cart with its price breakdown, shown in currency.
+// A user without a draft order gets an empty cart. Browsing should not break
+// because rates are unavailable, so it falls back to the catalogue currency.
+func (s *Service) GetCart(ctx context.Context, userID string, currency money.Currency) (*cart.Cart, error) {
+	const op = "service.order.GetCart"
+
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !exists {
+		empty := cart.Empty()
+		return &empty, nil
+	}
+
+	order, err := s.OrderRepo.GetUsersOrder(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, order.ID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	breakdown, err := s.price(ctx, order)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	c := cart.New(order.ID, *items, breakdown)
+	if currency != "" && currency != money.DefaultCurrency {
+		if rate, err := s.Currency.Quote(ctx, currency); err == nil {
+			c = c.Convert(currency, rate)
+		}
+	}
+
+	return &c, nil
+}
+
+// ApplyPromotion applies a promotion code to the user's draft order and
+// returns the order's new price breakdown. A code that does not apply is
+// reported with the reason, wrapping promotion.ErrIneligible.
+func (s *Service) ApplyPromotion(ctx context.Context, userID, code string) (*promotion.Breakdown, error) {
+	const op = "service.order.ApplyPromotion"
+
+	order, err := s.draftOrder(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := s.promotionByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	applied, err := s.Promotions.GetOrderPromotions(ctx, order.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if !slices.ContainsFunc(applied, func(a promotion.Promotion) bool { return a.ID == p.ID }) {
+		lines, err := s.OrderRepo.GetPricingLines(ctx, order.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+
+		if err = s.checkPromotion(ctx, order, *p, lines, applied); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, p.Code, err)
+		}
+
+		if err = s.Promotions.AttachToOrder(ctx, order.ID, p.ID); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	breakdown, err := s.reprice(ctx, order)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return breakdown, nil
+}
+
+func (s *Service) RemovePromotion(ctx context.Context, userID, code string) (*promotion.Breakdown, error) {
+	const op = "service.order.RemovePromotion"
+
+	order, err := s.draftOrder(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := s.promotionByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Promotions.DetachFromOrder(ctx, order.ID, p.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	breakdown, err := s.reprice(ctx, order)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return breakdown, nil
+}
+
+// GetPriceBreakdown prices the user's draft order as it stands, showing what
+// each applied promotion takes off and why one that no longer applies does not.
+func (s *Service) GetPriceBreakdown(ctx context.Context, userID string) (*promotion.Breakdown, error) {
+	const op = "service.order.GetPriceBreakdown"
+
+	order, err := s.draftOrder(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	breakdown, err := s.price(ctx, order)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &breakdown, nil
+}
+
+// GetShippingOptions quotes every delivery method that takes the user's draft
+// order to one of their addresses, cheapest first.
+func (s *Service) GetShippingOptions(ctx context.Context, userID string, addressID uuid.UUID) ([]shipping.Quote, error) {
+	const op = "service.order.GetShippingOptions"
+
+	order, err := s.draftOrder(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	to, err := s.ownedAddress(ctx, order.UserID, addressID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	quotes, err := s.quote(ctx, order.ID, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quotes, nil
+}
+
+// SetShipping copies the chosen addresses onto the user's draft order, picks
+// its delivery method and prices the order again. A method that does not go
+// to the address, or not with this much weight, wraps shipping.ErrUnavailable.
+func (s *Service) SetShipping(ctx context.Context, userID string, req orderModel.ShippingRequest) (*promotion.Breakdown, error) {
+	const op = "service.order.SetShipping"
+
+	if req.DeliveryMethod == "" {
+		return nil, fmt.Errorf("%s: delivery method is required: %w", op, service.ErrValid)
+	}
+
+	order, err := s.draftOrder(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	to, err := s.ownedAddress(ctx, order.UserID, req.ShippingAddressID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: shipping address: %w", op, err)
+	}
+
+	billTo := to
+	if req.BillingAddressID != uuid.Nil && req.BillingAddressID != req.ShippingAddressID {
+		billTo, err = s.ownedAddress(ctx, order.UserID, req.BillingAddressID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: billing address: %w", op, err)
+		}
+	}
+
+	quotes, err := s.quote(ctx, order.ID, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, ok := shipping.Find(quotes, req.DeliveryMethod); !ok {
+		return nil, fmt.Errorf("%s: %q does not deliver to %s: %w", op, req.DeliveryMethod, to.Destination(), shipping.ErrUnavailable)
+	}
+
+	err = s.OrderRepo.SetShipping(ctx, order.ID, to, billTo, req.DeliveryMethod)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	order.ShippingAddress, order.BillingAddress, order.DeliveryMethod = to, billTo, req.DeliveryMethod
+
+	breakdown, err := s.reprice(ctx, order)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return breakdown, nil
+}
+
+func (s *Service) AlterUserOrderByID(ctx context.Context, userID, orderID string, currency money.Currency) error {
 	const op = "service.order.AlterUserOrderByID"
 
-	// Парсим userID и orderID в uuid.UUID
-	uID, err := uuid.FromString(userID)
+	order, err := s.getOwnedOrder(ctx, userID, orderID)
 	if err != nil {
-		return errors.Wrap(err, op+": invalid userID format")
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	oID, err := uuid.FromString(orderID)
+	err = s.pay(ctx, order, orderModel.CustomerActor(order.UserID), currency)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Checkout pays for the user's draft order in the given currency.
+func (s *Service) Checkout(ctx context.Context, userID string, currency money.Currency) error {
+	const op = "service.order.Checkout"
+
+	order, err := s.OrderRepo.GetUsersOrder(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	err = s.pay(ctx, order, orderModel.CustomerActor(order.UserID), currency)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Transition is the single entry point for changing an order's status. The
+// move is checked against the state machine by the repository while the
+// order row is locked, so an illegal move surfaces as *orderModel.TransitionError.
+func (s *Service) Transition(ctx context.Context, orderID uuid.UUID, to orderModel.Status, actor orderModel.Actor, reason string) error {
+	const op = "service.order.Transition"
+
+	_, err := s.transition(ctx, orderModel.StatusChange{
+		OrderID: orderID,
+		To:      to,
+		Actor:   actor,
+		Reason:  reason,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Cancel cancels an order on behalf of its owner or an admin. Which statuses
+// may still be cancelled depends on who asks; stock is restored and the refund
+// of a paid order recorded as owed by the same transaction that flips the
+// status, and the refund is sent right after.
+func (s *Service) Cancel(ctx context.Context, userID, orderID string, asAdmin bool, reason string) error {
+	const op = "service.order.Cancel"
+
+	var order *orderModel.Model
+	var actor orderModel.Actor
+	var err error
+
+	if asAdmin {
+		order, err = s.getOrder(ctx, orderID)
+		if err == nil {
+			actor, err = s.adminActor(userID)
+		}
+	} else {
+		order, err = s.getOwnedOrder(ctx, userID, orderID)
+		if err == nil {
+			actor = orderModel.CustomerActor(order.UserID)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	from, err := s.transition(ctx, orderModel.StatusChange{
+		OrderID:     order.ID,
+		To:          orderModel.StatusCancelled,
+		Actor:       actor,
+		Reason:      reason,
+		AllowedFrom: orderModel.CancellableStatuses(actor.Kind),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// A refund the provider does not take now is retried by the
+	// RefundRetrier, so the cancellation stands either way.
+	if from.IsSettled() {
+		if err = s.Payments.IssueRefunds(ctx, order.ID); err != nil {
+			s.Log.Error("order cancelled but refund failed, left to the retrier",
+				slog.String("op", op),
+				slog.String("order_id", order.ID.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) transition(ctx context.Context, change orderModel.StatusChange) (orderModel.Status, error) {
+	if !change.To.IsValid() {
+		return "", fmt.Errorf("unknown status %q: %w", change.To, service.ErrValid)
+	}
+
+	from, err := s.OrderRepo.UpdateStatus(ctx, change)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return "", service.ErrNotFound
+		}
+		return "", err
+	}
+
+	return from, nil
+}
+
+func (s *Service) GetStatusHistory(ctx context.Context, userID, orderID string) ([]orderModel.StatusHistoryEntry, error) {
+	const op = "service.order.GetStatusHistory"
+
+	order, err := s.getOwnedOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	history, err := s.OrderRepo.GetStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return history, nil
+}
+
+// MergeCart moves one user's cart into another's, as when a guest logs in,
+// and prices the merged cart again.
+func (s *Service) MergeCart(ctx context.Context, fromUserID, toUserID string) error {
+	const op = "service.order.MergeCart"
+
+	err := s.OrderRepo.MergeCart(ctx, fromUserID, toUserID)
 	if err != nil {
-		return errors.Wrap(err, op+": invalid orderID format")
+		return errors.Wrap(err, op)
 	}
 
-	// Вызываем метод репозитория, который действительно меняет статус на paid
-	err = s.OrderRepo.AlterUserOrderByID(ctx, uID, oID)
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, toUserID)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	if !exists {
+		return nil
+	}
+
+	if err = s.repriceDraft(ctx, toUserID); err != nil {
+		return errors.Wrap(err, op)
+	}
 
 	return nil
 }
+
+// pay fixes the currency and exchange rate of a draft order, moves it to
+// pending_payment, which secures its stock, and charges it. The payment layer
+// moves the order on to paid, or back to draft when the charge is declined.
+// An order already awaiting payment is charged again in the currency it was
+// quoted in, unless its last charge has not settled yet: that is turned away
+// with service.ErrPaymentPending rather than taking the money twice.
+func (s *Service) pay(ctx context.Context, order *orderModel.Model, actor orderModel.Actor, currency money.Currency) error {
+	if order.Status == orderModel.StatusDraft {
+		breakdown, err := s.reprice(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		if order.ShippingAddress == nil {
+			return fmt.Errorf("choose a shipping address before checking out: %w", service.ErrValid)
+		}
+		if breakdown.DeliveryMethod == "" {
+			return fmt.Errorf("choose a delivery method that goes to %s: %w", order.ShippingAddress.Destination(), service.ErrValid)
+		}
+
+		rate, err := s.Currency.Quote(ctx, currency)
+		if err != nil {
+			return err
+		}
+
+		err = s.OrderRepo.SetCheckoutCurrency(ctx, order.ID, currency, rate)
+		if err != nil {
+			return err
+		}
+		order.Currency, order.ExchangeRate = currency, rate
+
+		err = s.Transition(ctx, order.ID, orderModel.StatusPendingPayment, actor, "checkout")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Promotions can take an order down to nothing; there is nothing to charge
+	// then.
+	if order.TotalPrice.IsZero() && order.DiscountTotal.IsPositive() {
+		return s.Transition(ctx, order.ID, orderModel.StatusPaid, actor, "fully discounted")
+	}
+
+	_, err := s.Payments.Charge(ctx, order, actor)
+	return err
+}
+
+// price works out an order's discounts from its lines and the promotions
+// applied to it, adds delivery to its shipping address, then taxes what the
+// lines cost after those discounts where the order is shipped. Delivery is
+// not taxed.
+func (s *Service) price(ctx context.Context, order *orderModel.Model) (promotion.Breakdown, error) {
+	lines, err := s.OrderRepo.GetPricingLines(ctx, order.ID)
+	if err != nil {
+		return promotion.Breakdown{}, err
+	}
+
+	applied, err := s.Promotions.GetOrderPromotions(ctx, order.ID)
+	if err != nil {
+		return promotion.Breakdown{}, err
+	}
+
+	breakdown := promotion.Price(lines, applied, time.Now())
+
+	jurisdiction := s.TaxJurisdiction
+	if order.ShippingAddress != nil {
+		jurisdiction = order.ShippingAddress.Jurisdiction()
+
+		breakdown, err = s.withShipping(ctx, breakdown, order, lines)
+		if err != nil {
+			return promotion.Breakdown{}, err
+		}
+	}
+
+	taxes, err := s.Tax.Calculate(ctx, jurisdiction, breakdown.TaxLines(lines))
+	if err != nil {
+		return promotion.Breakdown{}, err
+	}
+
+	return breakdown.WithTax(taxes), nil
+}
+
+// reprice prices a draft order again and stores its new totals. It runs
+// whenever the lines or the promotions change and once more at checkout, so
+// an expired promotion is never charged at its old discount and the tax is
+// worked out at the rates in force when the customer pays.
+func (s *Service) reprice(ctx context.Context, order *orderModel.Model) (*promotion.Breakdown, error) {
+	breakdown, err := s.price(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OrderRepo.SavePricing(ctx, order.ID, breakdown)
+	if err != nil {
+		return nil, err
+	}
+	order.Subtotal, order.DiscountTotal, order.TotalPrice = breakdown.Subtotal, breakdown.DiscountTotal, breakdown.Total
+	order.TaxTotal, order.TaxIncluded, order.ShippingTotal = breakdown.TaxTotal, breakdown.TaxIncluded, breakdown.ShippingTotal
+
+	return &breakdown, nil
+}
+
+// withShipping prices the order's delivery method for what its lines weigh
+// now. When the cart has outgrown the method, or no method goes to the address
+// any more, the order is left without one and has to pick again at checkout.
+func (s *Service) withShipping(ctx context.Context, breakdown promotion.Breakdown, order *orderModel.Model, lines []promotion.Line) (promotion.Breakdown, error) {
+	quotes, err := s.Shipping.Quotes(ctx, order.ShippingAddress.Destination(), promotion.Weight(lines))
+	if err != nil {
+		if errors.Is(err, shipping.ErrUnavailable) {
+			return breakdown, nil
+		}
+		return promotion.Breakdown{}, err
+	}
+
+	chosen, ok := shipping.Find(quotes, order.DeliveryMethod)
+	if !ok {
+		return breakdown, nil
+	}
+
+	return breakdown.WithShipping(chosen.Method, chosen.Price), nil
+}
+
+// quote prices delivering an order's lines to an address.
+func (s *Service) quote(ctx context.Context, orderID uuid.UUID, to *address.Snapshot) ([]shipping.Quote, error) {
+	lines, err := s.OrderRepo.GetPricingLines(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Shipping.Quotes(ctx, to.Destination(), promotion.Weight(lines))
+}
+
+// ownedAddress snapshots one of a user's addresses; anyone else's is not found.
+func (s *Service) ownedAddress(ctx context.Context, userID, addressID uuid.UUID) (*address.Snapshot, error) {
+	a, err := s.Addresses.GetAddress(ctx, userID, addressID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return a.Snapshot(), nil
+}
+
+func (s *Service) repriceDraft(ctx context.Context, userID string) error {
+	order, err := s.OrderRepo.GetUsersOrder(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.reprice(ctx, order)
+	return err
+}
+
+// checkPromotion decides whether a promotion may join an order: it has to be
+// live and eligible for the lines, have uses left overall and for the
+// customer, and stack with what is already applied. Uses are only taken at
+// checkout, where the limits are enforced again under lock.
+func (s *Service) checkPromotion(ctx context.Context, order *orderModel.Model, p promotion.Promotion, lines []promotion.Line, applied []promotion.Promotion) error {
+	if err := p.Eligible(lines, time.Now()); err != nil {
+		return err
+	}
+
+	if p.Exhausted() {
+		return promotion.ErrUsageLimit
+	}
+
+	if p.PerUserLimit > 0 {
+		used, err := s.Promotions.CountUserRedemptions(ctx, p.ID, order.UserID)
+		if err != nil {
+			return err
+		}
+		if used >= p.PerUserLimit {
+			return promotion.ErrUsageLimit
+		}
+	}
+
+	return promotion.CanStack(applied, p)
+}
+
+func (s *Service) draftOrder(ctx context.Context, userID string) (*orderModel.Model, error) {
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, service.ErrNotFound
+	}
+
+	return s.OrderRepo.GetUsersOrder(ctx, userID)
+}
+
+func (s *Service) promotionByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	code = promotion.NormalizeCode(code)
+	if code == "" {
+		return nil, fmt.Errorf("promotion code is required: %w", service.ErrValid)
+	}
+
+	p, err := s.Promotions.GetPromotionByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// getOwnedOrder loads an order and hides it from anyone but its owner.
+func (s *Service) getOwnedOrder(ctx context.Context, userID, orderID string) (*orderModel.Model, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != uID {
+		return nil, service.ErrNotFound
+	}
+
+	return order, nil
+}
+
+func (s *Service) getOrder(ctx context.Context, orderID string) (*orderModel.Model, error) {
+	oID, err := uuid.FromString(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid orderID format: %w", service.ErrValid)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, oID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *Service) adminActor(userID string) (orderModel.Actor, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return orderModel.Actor{}, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	return orderModel.AdminActor(uID), nil
+}
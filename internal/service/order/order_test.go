@@ -0,0 +1,148 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	paymentModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type fixture struct {
+	svc      *Service
+	orders   *mocks.OrderRepository
+	payments *mocks.PaymentService
+}
+
+func newFixture(t *testing.T) fixture {
+	f := fixture{
+		orders:   mocks.NewOrderRepository(t),
+		payments: mocks.NewPaymentService(t),
+	}
+	f.svc = &Service{
+		OrderRepo: f.orders,
+		Payments:  f.payments,
+		Log:       logger.New(logger.EnvLocal),
+	}
+	return f
+}
+
+func TestService_Cancel(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	order := &orderModel.Model{ID: uuid.Must(uuid.NewV4()), UserID: userID}
+	cancelledBy := func(kind orderModel.ActorKind) interface{} {
+		return mock.MatchedBy(func(change orderModel.StatusChange) bool {
+			return change.OrderID == order.ID && change.To == orderModel.StatusCancelled && change.Actor.Kind == kind
+		})
+	}
+
+	tests := []struct {
+		name       string
+		from       orderModel.Status
+		changeErr  error
+		issueErr   error
+		wantRefund bool
+		wantErr    bool
+	}{
+		{name: "awaiting payment has nothing to refund", from: orderModel.StatusPendingPayment},
+		{name: "paid order is refunded", from: orderModel.StatusPaid, wantRefund: true},
+		{name: "fulfilled order is refunded", from: orderModel.StatusFulfilled, wantRefund: true},
+		{name: "refund failure leaves the cancellation standing", from: orderModel.StatusPaid, issueErr: errors.New("provider down"), wantRefund: true},
+		{
+			name:      "shipped order cannot be cancelled",
+			changeErr: &orderModel.TransitionError{From: orderModel.StatusShipped, To: orderModel.StatusCancelled},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.orders.On("GetOrderByID", mock.Anything, order.ID).Return(order, nil).Once()
+			f.orders.On("UpdateStatus", mock.Anything, cancelledBy(orderModel.ActorCustomer)).Return(tt.from, tt.changeErr).Once()
+			if tt.wantRefund {
+				f.payments.On("IssueRefunds", mock.Anything, order.ID).Return(tt.issueErr).Once()
+			}
+
+			err := f.svc.Cancel(context.Background(), userID.String(), order.ID.String(), false, "changed my mind")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_AddOrderItemIntoOrder_outOfStock(t *testing.T) {
+	f := newFixture(t)
+	items := &[]orderModels.OrderItem{{BookID: uuid.Must(uuid.NewV4()), Quantity: 3}}
+	f.orders.On("AddOrderItemIntoOrder", mock.Anything, "123", items).
+		Return(fmt.Errorf("repository.order.processItem: %w", repository.ErrInsufficientStock)).Once()
+
+	err := f.svc.AddOrderItemIntoOrder(context.Background(), "123", items)
+
+	assert.ErrorIs(t, err, repository.ErrInsufficientStock)
+}
+
+// An order already awaiting payment is charged as it stands, without being
+// priced or moved again.
+func TestService_AlterUserOrderByID_awaitingPayment(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	usd := func(s string) money.Money { return money.MustParse(s, money.DefaultCurrency) }
+
+	tests := []struct {
+		name      string
+		total     string
+		discount  string
+		chargeErr error
+		wantPaid  bool
+		wantErr   error
+	}{
+		{name: "charged again", total: "20.00", discount: "0.00"},
+		{name: "earlier charge still in flight", total: "20.00", discount: "0.00", chargeErr: service.ErrPaymentPending, wantErr: service.ErrPaymentPending},
+		{name: "declined", total: "20.00", discount: "0.00", chargeErr: service.ErrPaymentDeclined, wantErr: service.ErrPaymentDeclined},
+		{name: "fully discounted is paid without a charge", total: "0.00", discount: "20.00", wantPaid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			order := &orderModel.Model{
+				ID:            uuid.Must(uuid.NewV4()),
+				UserID:        userID,
+				Status:        orderModel.StatusPendingPayment,
+				TotalPrice:    usd(tt.total),
+				DiscountTotal: usd(tt.discount),
+			}
+			f.orders.On("GetOrderByID", mock.Anything, order.ID).Return(order, nil).Once()
+			if tt.wantPaid {
+				f.orders.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(change orderModel.StatusChange) bool {
+					return change.OrderID == order.ID && change.To == orderModel.StatusPaid
+				})).Return(orderModel.StatusPendingPayment, nil).Once()
+			} else {
+				f.payments.On("Charge", mock.Anything, order, orderModel.CustomerActor(userID)).
+					Return(&paymentModel.Payment{OrderID: order.ID}, tt.chargeErr).Once()
+			}
+
+			err := f.svc.AlterUserOrderByID(context.Background(), userID.String(), order.ID.String(), money.DefaultCurrency)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
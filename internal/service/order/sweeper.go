@@ -0,0 +1,38 @@
+package order
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// ReservationSweeper periodically releases cart reservations that outlived
+// their TTL so abandoned drafts stop holding stock.
+type ReservationSweeper struct {
+	OrderRepo interfaces.OrderRepository
+	Every     time.Duration
+	BatchSize int
+}
+
+func (s *ReservationSweeper) Name() string {
+	return "cart-reservation-sweeper"
+}
+
+func (s *ReservationSweeper) Interval() time.Duration {
+	return s.Every
+}
+
+func (s *ReservationSweeper) Run(ctx context.Context) error {
+	const op = "service.order.ReservationSweeper.Run"
+
+	for {
+		released, err := s.OrderRepo.ReleaseExpiredReservations(ctx, s.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if released < s.BatchSize {
+			return nil
+		}
+	}
+}
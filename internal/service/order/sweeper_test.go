@@ -0,0 +1,29 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestReservationSweeper_Run(t *testing.T) {
+	t.Run("sweeps batches until one comes back short", func(t *testing.T) {
+		orders := mocks.NewOrderRepository(t)
+		s := &ReservationSweeper{OrderRepo: orders, BatchSize: 10}
+		orders.On("ReleaseExpiredReservations", mock.Anything, 10).Return(10, nil).Twice()
+		orders.On("ReleaseExpiredReservations", mock.Anything, 10).Return(3, nil).Once()
+
+		assert.NoError(t, s.Run(context.Background()))
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		orders := mocks.NewOrderRepository(t)
+		s := &ReservationSweeper{OrderRepo: orders, BatchSize: 10}
+		orders.On("ReleaseExpiredReservations", mock.Anything, 10).Return(0, errors.New("db down")).Once()
+
+		assert.Error(t, s.Run(context.Background()))
+	})
+}
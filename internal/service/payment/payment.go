@@ -0,0 +1,376 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"time"
+)
+
+// Service takes and returns money through the configured PaymentProvider and
+// drives the order state machine from the outcome, whether that outcome comes
+// back synchronously or later through a webhook.
+type Service struct {
+	Provider    interfaces.PaymentProvider
+	PaymentRepo interfaces.PaymentRepository
+	RefundRepo  interfaces.RefundRepository
+	// Retry spaces out the attempts at a refund the provider turned down.
+	Retry event.RetryPolicy
+	// RefundLease hides a refund being sent from other senders.
+	RefundLease time.Duration
+	Log         *slog.Logger
+}
+
+// Charge takes payment for an order that is awaiting payment. A declined
+// charge sends the order back to draft and returns service.ErrPaymentDeclined;
+// a charge the provider confirms asynchronously leaves the order pending, and
+// charging it again before that charge settles returns
+// service.ErrPaymentPending.
+func (s *Service) Charge(ctx context.Context, order *orderModel.Model, actor orderModel.Actor) (*model.Payment, error) {
+	const op = "service.payment.Charge"
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	payment := &model.Payment{
+		ID:       id,
+		OrderID:  order.ID,
+		Provider: s.Provider.Name(),
+		Amount:   order.ChargeTotal(),
+		Status:   model.StatusPending,
+	}
+	if err = s.PaymentRepo.CreatePayment(ctx, payment); err != nil {
+		if errors.Is(err, repository.ErrPaymentPending) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrPaymentPending)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	result, err := s.Provider.CreateIntent(ctx, model.IntentRequest{
+		PaymentID: payment.ID,
+		OrderID:   order.ID,
+		Amount:    payment.Amount,
+	})
+	if err == nil {
+		payment.ProviderRef = result.ProviderRef
+		err = s.PaymentRepo.SetProviderRef(ctx, payment.ID, result.ProviderRef)
+	}
+	if err == nil && result.Status == model.StatusPending {
+		result, err = s.Provider.Capture(ctx, payment.ProviderRef)
+	}
+	if err != nil {
+		result = &model.Result{Status: model.StatusFailed, FailureReason: err.Error()}
+	}
+
+	if settleErr := s.settle(ctx, payment, result.Status, result.FailureReason, actor); settleErr != nil {
+		return nil, errors.Wrap(settleErr, op)
+	}
+
+	if payment.Status == model.StatusFailed {
+		if err != nil {
+			return payment, errors.Wrap(err, op)
+		}
+		return payment, fmt.Errorf("%s: %s: %w", op, payment.FailureReason, service.ErrPaymentDeclined)
+	}
+
+	return payment, nil
+}
+
+// IssueRefunds sends the owed refunds of an order to the provider straight
+// away instead of leaving them to the RefundRetrier. A refund the provider
+// turns down stays owed and is retried later, so only failing to reach the
+// database is an error.
+func (s *Service) IssueRefunds(ctx context.Context, orderID uuid.UUID) error {
+	const op = "service.payment.IssueRefunds"
+
+	refunds, err := s.RefundRepo.ClaimOrderRefunds(ctx, orderID, time.Now(), s.RefundLease)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	for _, refund := range refunds {
+		if err = s.issueRefund(ctx, refund); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
+
+// GetRefunds lists refunds for staff by status. Without a status it lists
+// every refund that has not gone out yet.
+func (s *Service) GetRefunds(ctx context.Context, status string) ([]refundModel.Refund, error) {
+	const op = "service.payment.GetRefunds"
+
+	statuses, err := refundModel.ParseStatus(status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, err, service.ErrValid)
+	}
+
+	refunds, err := s.RefundRepo.GetRefunds(ctx, statuses)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refunds, nil
+}
+
+// ResolveRefund records that staff issued a refund by hand, typically one
+// left manual because the provider could not send it.
+func (s *Service) ResolveRefund(ctx context.Context, actorID string, id uuid.UUID) (*refundModel.Refund, error) {
+	const op = "service.payment.ResolveRefund"
+
+	actor, err := uuid.FromString(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid userID format: %w", op, service.ErrValid)
+	}
+
+	refund, err := s.RefundRepo.MarkIssuedByHand(ctx, id, actor)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefundNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return refund, nil
+}
+
+// issueRefund sends one claimed refund to the provider and records how it
+// went. A refund with nothing to go against is handed to staff at once.
+func (s *Service) issueRefund(ctx context.Context, refund refundModel.Refund) error {
+	const op = "service.payment.issueRefund"
+
+	var payment *model.Payment
+	var err error
+	if refund.PaymentID.Valid {
+		payment, err = s.PaymentRepo.GetPayment(ctx, refund.PaymentID.UUID)
+	} else {
+		payment, err = s.PaymentRepo.GetSettledPaymentByOrderID(ctx, refund.OrderID)
+	}
+	if errors.Is(err, repository.ErrPaymentNotFound) {
+		return s.failRefund(ctx, refund, "no captured payment to refund", true)
+	}
+	if err != nil {
+		return err
+	}
+
+	left := payment.Amount.Sub(payment.RefundedAmount)
+	if left.Currency() != refund.Amount.Currency() {
+		return s.failRefund(ctx, refund, "payment was taken in "+string(left.Currency()), true)
+	}
+	amount := refund.Amount.Min(left)
+	if !amount.IsPositive() {
+		return s.failRefund(ctx, refund, "nothing left of the payment to refund", true)
+	}
+
+	result, err := s.Provider.Refund(ctx, payment.ProviderRef, amount)
+	if err != nil {
+		return s.failRefund(ctx, refund, err.Error(), false)
+	}
+
+	// Providers that refund asynchronously confirm through refund.succeeded.
+	err = s.RefundRepo.MarkIssued(ctx, refund.ID, refundModel.Issue{
+		PaymentID: payment.ID,
+		Amount:    amount,
+		Settled:   result.Status == model.StatusRefunded,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Log.Info("refund issued",
+		slog.String("op", op),
+		slog.String("refund_id", refund.ID.String()),
+		slog.String("order_id", refund.OrderID.String()),
+		slog.String("payment_id", payment.ID.String()),
+		slog.String("amount", amount.String()),
+		slog.String("reason", refund.Reason),
+	)
+
+	return nil
+}
+
+// failRefund records an attempt that did not go out. Once the attempts run
+// out, or when manual is set, the refund is left to staff.
+func (s *Service) failRefund(ctx context.Context, refund refundModel.Refund, reason string, manual bool) error {
+	const op = "service.payment.failRefund"
+
+	attempts := refund.Attempts + 1
+	failure := refundModel.Failure{
+		Attempts:      attempts,
+		Error:         reason,
+		NextAttemptAt: time.Now().Add(s.Retry.Backoff(attempts)),
+		Manual:        manual || attempts >= s.Retry.MaxAttempts,
+	}
+
+	s.Log.Warn("refund not issued",
+		slog.String("op", op),
+		slog.String("refund_id", refund.ID.String()),
+		slog.String("order_id", refund.OrderID.String()),
+		slog.String("amount", refund.Amount.String()),
+		slog.Int("attempts", attempts),
+		slog.Bool("manual", failure.Manual),
+		slog.String("error", reason),
+	)
+
+	return s.RefundRepo.MarkFailed(ctx, refund.ID, failure)
+}
+
+// HandleWebhook verifies and applies a provider notification. Every event is
+// stored and claimed by its provider id first, so a redelivery of an event
+// that was already applied is acknowledged without doing anything, and one
+// that arrives while another delivery applies it is turned away. An event that
+// fails to apply is released for the provider to redeliver.
+func (s *Service) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	const op = "service.payment.HandleWebhook"
+
+	event, err := s.Provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	provider := s.Provider.Name()
+
+	pending, err := s.PaymentRepo.RecordWebhookEvent(ctx, provider, event, payload)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if !pending {
+		s.Log.Info("duplicate webhook delivery ignored",
+			slog.String("op", op),
+			slog.String("event_id", event.ID),
+		)
+		return nil
+	}
+
+	if err = s.applyWebhook(ctx, provider, event); err != nil {
+		if releaseErr := s.PaymentRepo.ReleaseWebhookEvent(ctx, provider, event.ID); releaseErr != nil {
+			s.Log.Error("failed to release webhook event",
+				slog.String("op", op),
+				slog.String("event_id", event.ID),
+				slog.String("error", releaseErr.Error()),
+			)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	// Once applied the event is not released again: if marking it fails the
+	// claim is left to lapse rather than inviting an immediate reapply.
+	if err = s.PaymentRepo.MarkWebhookEventProcessed(ctx, provider, event.ID); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// applyWebhook applies a claimed event to its payment.
+func (s *Service) applyWebhook(ctx context.Context, provider string, event *model.WebhookEvent) error {
+	const op = "service.payment.applyWebhook"
+
+	payment, err := s.PaymentRepo.GetPaymentByProviderRef(ctx, provider, event.ProviderRef)
+	if err != nil {
+		if !errors.Is(err, repository.ErrPaymentNotFound) {
+			return errors.Wrap(err, op)
+		}
+		s.Log.Warn("webhook for unknown payment ignored",
+			slog.String("op", op),
+			slog.String("event_id", event.ID),
+			slog.String("provider_ref", event.ProviderRef),
+		)
+	} else {
+		switch event.Type {
+		case model.EventPaymentSucceeded:
+			err = s.settle(ctx, payment, model.StatusSucceeded, "", orderModel.SystemActor())
+		case model.EventPaymentFailed:
+			err = s.settle(ctx, payment, model.StatusFailed, event.FailureReason, orderModel.SystemActor())
+		case model.EventRefundSucceeded:
+			err = s.PaymentRepo.AddRefund(ctx, payment.ID, event.Amount)
+		default:
+			s.Log.Info("unhandled webhook event type",
+				slog.String("op", op),
+				slog.String("event_type", string(event.Type)),
+			)
+		}
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
+
+// settle records the outcome of a payment and moves its order accordingly:
+// paid on success, back to draft on failure, both in one transaction. A
+// successful payment for an order that stopped awaiting it is refunded. Only the
+// call that settles the payment moves the order: a late or repeated outcome
+// for an attempt that was already settled must not touch an order that has
+// since moved on, for example to a retry awaiting payment.
+func (s *Service) settle(ctx context.Context, payment *model.Payment, status model.Status, failureReason string, actor orderModel.Actor) error {
+	var to orderModel.Status
+	switch status {
+	case model.StatusSucceeded:
+		to = orderModel.StatusPaid
+	case model.StatusFailed:
+		to = orderModel.StatusDraft
+	default:
+		return nil
+	}
+
+	reason := "payment " + string(status)
+	if failureReason != "" {
+		reason += ": " + failureReason
+	}
+
+	settled, from, err := s.PaymentRepo.SettlePayment(ctx, payment.ID, status, failureReason, orderModel.StatusChange{
+		OrderID:     payment.OrderID,
+		To:          to,
+		Actor:       actor,
+		Reason:      reason,
+		AllowedFrom: []orderModel.Status{orderModel.StatusPendingPayment},
+	})
+	if err != nil {
+		return err
+	}
+	if !settled {
+		s.Log.Info("payment already settled, order left unchanged",
+			slog.String("payment_id", payment.ID.String()),
+			slog.String("order_id", payment.OrderID.String()),
+			slog.String("payment_status", string(status)),
+		)
+		return nil
+	}
+	payment.Status = status
+	payment.FailureReason = failureReason
+
+	if from != orderModel.StatusPendingPayment {
+		s.Log.Info("order no longer awaits payment, status left unchanged",
+			slog.String("order_id", payment.OrderID.String()),
+			slog.String("status", string(from)),
+			slog.String("payment_status", string(status)),
+		)
+		// The money it captured was recorded as owed back with the payment.
+		if status == model.StatusSucceeded {
+			if err = s.IssueRefunds(ctx, payment.OrderID); err != nil {
+				s.Log.Error("failed to refund payment for an order no longer awaiting it, left to the retrier",
+					slog.String("order_id", payment.OrderID.String()),
+					slog.String("payment_id", payment.ID.String()),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type fixture struct {
+	svc      *Service
+	provider *mocks.PaymentProvider
+	payments *mocks.PaymentRepository
+	refunds  *mocks.RefundRepository
+}
+
+func newFixture(t *testing.T) fixture {
+	f := fixture{
+		provider: mocks.NewPaymentProvider(t),
+		payments: mocks.NewPaymentRepository(t),
+		refunds:  mocks.NewRefundRepository(t),
+	}
+	f.svc = &Service{
+		Provider:    f.provider,
+		PaymentRepo: f.payments,
+		RefundRepo:  f.refunds,
+		Retry:       event.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour},
+		RefundLease: time.Minute,
+		Log:         logger.New(logger.EnvLocal),
+	}
+	return f
+}
+
+func usd(s string) money.Money {
+	return money.MustParse(s, money.DefaultCurrency)
+}
+
+func TestService_settle(t *testing.T) {
+	orderID := uuid.Must(uuid.NewV4())
+	payment := func() *model.Payment {
+		return &model.Payment{ID: uuid.Must(uuid.NewV4()), OrderID: orderID, Amount: usd("20.00"), Status: model.StatusPending}
+	}
+	settlesAs := func(to orderModel.Status) interface{} {
+		return mock.MatchedBy(func(change orderModel.StatusChange) bool {
+			return change.OrderID == orderID && change.To == to &&
+				len(change.AllowedFrom) == 1 && change.AllowedFrom[0] == orderModel.StatusPendingPayment
+		})
+	}
+
+	t.Run("success moves the awaiting order to paid", func(t *testing.T) {
+		f := newFixture(t)
+		p := payment()
+		f.payments.On("SettlePayment", mock.Anything, p.ID, model.StatusSucceeded, "", settlesAs(orderModel.StatusPaid)).
+			Return(true, orderModel.StatusPendingPayment, nil).Once()
+
+		err := f.svc.settle(context.Background(), p, model.StatusSucceeded, "", orderModel.SystemActor())
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusSucceeded, p.Status)
+		f.refunds.AssertNotCalled(t, "ClaimOrderRefunds", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure sends the order back to draft", func(t *testing.T) {
+		f := newFixture(t)
+		p := payment()
+		f.payments.On("SettlePayment", mock.Anything, p.ID, model.StatusFailed, "card declined", settlesAs(orderModel.StatusDraft)).
+			Return(true, orderModel.StatusPendingPayment, nil).Once()
+
+		err := f.svc.settle(context.Background(), p, model.StatusFailed, "card declined", orderModel.SystemActor())
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusFailed, p.Status)
+		assert.Equal(t, "card declined", p.FailureReason)
+	})
+
+	t.Run("an already settled payment changes nothing", func(t *testing.T) {
+		f := newFixture(t)
+		p := payment()
+		f.payments.On("SettlePayment", mock.Anything, p.ID, model.StatusSucceeded, "", mock.Anything).
+			Return(false, orderModel.Status(""), nil).Once()
+
+		err := f.svc.settle(context.Background(), p, model.StatusSucceeded, "", orderModel.SystemActor())
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StatusPending, p.Status)
+	})
+
+	t.Run("money captured for a cancelled order is refunded", func(t *testing.T) {
+		f := newFixture(t)
+		p := payment()
+		p.ProviderRef = "pi_1"
+		owed := refundModel.Refund{ID: uuid.Must(uuid.NewV4()), OrderID: orderID, PaymentID: uuid.NullUUID{UUID: p.ID, Valid: true}, Amount: usd("20.00")}
+
+		f.payments.On("SettlePayment", mock.Anything, p.ID, model.StatusSucceeded, "", mock.Anything).
+			Return(true, orderModel.StatusCancelled, nil).Once()
+		f.refunds.On("ClaimOrderRefunds", mock.Anything, orderID, mock.Anything, time.Minute).Return([]refundModel.Refund{owed}, nil).Once()
+		f.payments.On("GetPayment", mock.Anything, p.ID).Return(&model.Payment{ID: p.ID, ProviderRef: "pi_1", Amount: usd("20.00"), RefundedAmount: usd("0")}, nil).Once()
+		f.provider.On("Refund", mock.Anything, "pi_1", usd("20.00")).Return(&model.Result{Status: model.StatusRefunded}, nil).Once()
+		f.refunds.On("MarkIssued", mock.Anything, owed.ID, refundModel.Issue{PaymentID: p.ID, Amount: usd("20.00"), Settled: true}).Return(nil).Once()
+
+		err := f.svc.settle(context.Background(), p, model.StatusSucceeded, "", orderModel.SystemActor())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("a settlement that fails is reported", func(t *testing.T) {
+		f := newFixture(t)
+		p := payment()
+		f.payments.On("SettlePayment", mock.Anything, p.ID, model.StatusSucceeded, "", mock.Anything).
+			Return(false, orderModel.Status(""), errors.New("invoice numbering failed")).Once()
+
+		err := f.svc.settle(context.Background(), p, model.StatusSucceeded, "", orderModel.SystemActor())
+
+		assert.Error(t, err)
+		assert.Equal(t, model.StatusPending, p.Status)
+	})
+}
+
+func TestService_issueRefund(t *testing.T) {
+	orderID := uuid.Must(uuid.NewV4())
+	captured := &model.Payment{ID: uuid.Must(uuid.NewV4()), OrderID: orderID, ProviderRef: "pi_1", Amount: usd("30.00"), RefundedAmount: usd("25.00")}
+
+	tests := []struct {
+		name        string
+		attempts    int
+		payment     *model.Payment
+		paymentErr  error
+		providerErr error
+		wantIssued  money.Money
+		wantManual  bool
+	}{
+		{name: "never more than is left of the payment", payment: captured, wantIssued: usd("5.00")},
+		{name: "provider failure is retried", payment: captured, providerErr: errors.New("provider down")},
+		{name: "last attempt leaves it to staff", attempts: 2, payment: captured, providerErr: errors.New("provider down"), wantManual: true},
+		{name: "no captured payment leaves it to staff", paymentErr: repository.ErrPaymentNotFound, wantManual: true},
+		{name: "nothing left to refund leaves it to staff", payment: &model.Payment{ID: captured.ID, Amount: usd("30.00"), RefundedAmount: usd("30.00")}, wantManual: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			refund := refundModel.Refund{ID: uuid.Must(uuid.NewV4()), OrderID: orderID, Amount: usd("10.00"), Attempts: tt.attempts}
+
+			f.payments.On("GetSettledPaymentByOrderID", mock.Anything, orderID).Return(tt.payment, tt.paymentErr).Once()
+			if tt.payment != nil && tt.payment.ProviderRef != "" {
+				var result *model.Result
+				if tt.providerErr == nil {
+					result = &model.Result{Status: model.StatusPending}
+				}
+				f.provider.On("Refund", mock.Anything, "pi_1", usd("5.00")).Return(result, tt.providerErr).Once()
+			}
+			if tt.wantIssued.IsPositive() {
+				f.refunds.On("MarkIssued", mock.Anything, refund.ID, refundModel.Issue{PaymentID: captured.ID, Amount: tt.wantIssued}).Return(nil).Once()
+			} else {
+				f.refunds.On("MarkFailed", mock.Anything, refund.ID, mock.MatchedBy(func(failure refundModel.Failure) bool {
+					return failure.Attempts == tt.attempts+1 && failure.Manual == tt.wantManual && failure.Error != ""
+				})).Return(nil).Once()
+			}
+
+			err := f.svc.issueRefund(context.Background(), refund)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Charge_paymentInFlight(t *testing.T) {
+	f := newFixture(t)
+	order := &orderModel.Model{ID: uuid.Must(uuid.NewV4()), TotalPrice: usd("20.00")}
+	f.provider.On("Name").Return("fake")
+	f.payments.On("CreatePayment", mock.Anything, mock.Anything).
+		Return(errors.Join(errors.New("repository.payment.CreatePayment"), repository.ErrPaymentPending)).Once()
+
+	payment, err := f.svc.Charge(context.Background(), order, orderModel.SystemActor())
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, service.ErrPaymentPending)
+	f.provider.AssertNotCalled(t, "CreateIntent", mock.Anything, mock.Anything)
+	f.payments.AssertNotCalled(t, "SettlePayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,45 @@
+package payment
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// RefundRetrier sends owed refunds whose next attempt is due: ones the
+// provider turned down before, and ones nothing sent right after they were
+// recorded.
+type RefundRetrier struct {
+	Payments  *Service
+	Every     time.Duration
+	BatchSize int
+}
+
+func (r *RefundRetrier) Name() string {
+	return "refund-retrier"
+}
+
+func (r *RefundRetrier) Interval() time.Duration {
+	return r.Every
+}
+
+func (r *RefundRetrier) Run(ctx context.Context) error {
+	const op = "service.payment.RefundRetrier.Run"
+
+	for {
+		due, err := r.Payments.RefundRepo.ClaimDue(ctx, time.Now(), r.Payments.RefundLease, r.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		for _, refund := range due {
+			if err = r.Payments.issueRefund(ctx, refund); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+
+		if len(due) < r.BatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package payment
+
+import (
+	"context"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/payment"
+	refundModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/refund"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRefundRetrier_Run(t *testing.T) {
+	f := newFixture(t)
+	r := &RefundRetrier{Payments: f.svc, BatchSize: 1}
+
+	payment := &model.Payment{ID: uuid.Must(uuid.NewV4()), ProviderRef: "pi_1", Amount: usd("30.00"), RefundedAmount: usd("0.00")}
+	refund := refundModel.Refund{
+		ID:        uuid.Must(uuid.NewV4()),
+		OrderID:   uuid.Must(uuid.NewV4()),
+		PaymentID: uuid.NullUUID{UUID: payment.ID, Valid: true},
+		Amount:    usd("12.00"),
+	}
+
+	f.refunds.On("ClaimDue", mock.Anything, mock.Anything, f.svc.RefundLease, 1).Return([]refundModel.Refund{refund}, nil).Once()
+	f.refunds.On("ClaimDue", mock.Anything, mock.Anything, f.svc.RefundLease, 1).Return([]refundModel.Refund{}, nil).Once()
+	f.payments.On("GetPayment", mock.Anything, payment.ID).Return(payment, nil).Once()
+	f.provider.On("Refund", mock.Anything, "pi_1", usd("12.00")).Return(&model.Result{Status: model.StatusRefunded}, nil).Once()
+	f.refunds.On("MarkIssued", mock.Anything, refund.ID, refundModel.Issue{PaymentID: payment.ID, Amount: usd("12.00"), Settled: true}).
+		Return(nil).Once()
+
+	assert.NoError(t, r.Run(context.Background()))
+}
@@ -0,0 +1,117 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/promotion"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	PromotionRepo interfaces.PromotionRepository
+}
+
+func (s *Service) CreatePromotion(ctx context.Context, req model.Request) (uuid.UUID, error) {
+	const op = "service.promotion.CreatePromotion"
+
+	p := req.Promotion()
+	if err := p.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := s.checkCodeFree(ctx, p.Code, uuid.Nil); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.PromotionRepo.CreatePromotion(ctx, p)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, op)
+	}
+
+	return id, nil
+}
+
+func (s *Service) UpdatePromotion(ctx context.Context, id uuid.UUID, req model.Request) error {
+	const op = "service.promotion.UpdatePromotion"
+
+	p := req.Promotion()
+	p.ID = id
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := s.checkCodeFree(ctx, p.Code, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.PromotionRepo.UpdatePromotion(ctx, p)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) DeactivatePromotion(ctx context.Context, id uuid.UUID) error {
+	const op = "service.promotion.DeactivatePromotion"
+
+	err := s.PromotionRepo.DeactivatePromotion(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) GetPromotions(ctx context.Context) ([]model.Promotion, error) {
+	const op = "service.promotion.GetPromotions"
+
+	promotions, err := s.PromotionRepo.GetPromotions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return promotions, nil
+}
+
+func (s *Service) GetPromotionByID(ctx context.Context, id uuid.UUID) (*model.Promotion, error) {
+	const op = "service.promotion.GetPromotionByID"
+
+	p, err := s.PromotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return p, nil
+}
+
+// checkCodeFree makes sure no other promotion answers to the code; codes are
+// compared without regard to case.
+func (s *Service) checkCodeFree(ctx context.Context, code string, self uuid.UUID) error {
+	existing, err := s.PromotionRepo.GetPromotionByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != self {
+		return fmt.Errorf("code %q is already in use: %w", code, service.ErrValid)
+	}
+
+	return nil
+}
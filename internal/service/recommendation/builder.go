@@ -0,0 +1,39 @@
+package recommendation
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/pkg/errors"
+	"log/slog"
+	"time"
+)
+
+// Builder periodically recomputes which books are bought together, so
+// serving a recommendation is a single indexed read.
+type Builder struct {
+	RecommendationRepo interfaces.RecommendationRepository
+	Log                *slog.Logger
+	Every              time.Duration
+	// PerBook is how many similar books are kept for each book.
+	PerBook int
+}
+
+func (b *Builder) Name() string {
+	return "recommendation-builder"
+}
+
+func (b *Builder) Interval() time.Duration {
+	return b.Every
+}
+
+func (b *Builder) Run(ctx context.Context) error {
+	const op = "service.recommendation.Builder.Run"
+
+	stored, err := b.RecommendationRepo.Rebuild(ctx, b.PerBook)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	b.Log.Info("recommendations rebuilt", slog.Int64("pairs", stored))
+	return nil
+}
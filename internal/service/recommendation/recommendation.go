@@ -0,0 +1,99 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/recommendation"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	RecommendationRepo interfaces.RecommendationRepository
+	BookRepo           interfaces.BookRepository
+	OrderRepo          interfaces.OrderRepository
+}
+
+// GetRecommendations suggests books customers bought together with the book.
+// Until enough of them have, the list is topped up with books by the same
+// author or in the same category.
+func (s *Service) GetRecommendations(ctx context.Context, bookID uuid.UUID, limit int) ([]model.Recommendation, error) {
+	const op = "service.recommendation.GetRecommendations"
+
+	exists, err := s.BookRepo.IfBookExists(ctx, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: book: %w", op, service.ErrNotFound)
+	}
+
+	recs, err := s.recommend(ctx, []uuid.UUID{bookID}, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return recs, nil
+}
+
+func (s *Service) GetCartRecommendations(ctx context.Context, userID string, limit int) ([]model.Recommendation, error) {
+	const op = "service.recommendation.GetCartRecommendations"
+
+	bookIDs, err := s.cartBooks(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(bookIDs) == 0 {
+		return []model.Recommendation{}, nil
+	}
+
+	recs, err := s.recommend(ctx, bookIDs, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return recs, nil
+}
+
+func (s *Service) recommend(ctx context.Context, bookIDs []uuid.UUID, limit int) ([]model.Recommendation, error) {
+	recs, err := s.RecommendationRepo.GetAlsoBought(ctx, bookIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) >= limit {
+		return recs, nil
+	}
+
+	similar, err := s.RecommendationRepo.GetSimilar(ctx, bookIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.Merge(recs, similar, bookIDs, limit), nil
+}
+
+// cartBooks lists the books in the user's cart, none when they have no cart.
+func (s *Service) cartBooks(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, userID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	order, err := s.OrderRepo.GetUsersOrder(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, order.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	bookIDs := make([]uuid.UUID, 0, len(*items))
+	for _, item := range *items {
+		bookIDs = append(bookIDs, item.BookID)
+	}
+	return bookIDs, nil
+}
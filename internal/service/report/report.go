@@ -0,0 +1,85 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/report"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	ReportRepo interfaces.ReportRepository
+}
+
+func (s *Service) GetSummary(ctx context.Context, rng model.Range) (*model.Summary, error) {
+	const op = "service.report.GetSummary"
+
+	summary, err := s.ReportRepo.GetSummary(ctx, rng)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return summary, nil
+}
+
+func (s *Service) GetRevenue(ctx context.Context, rng model.Range, interval model.Interval) (*model.Revenue, error) {
+	const op = "service.report.GetRevenue"
+
+	if _, err := model.ParseInterval(string(interval)); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	revenue, err := s.ReportRepo.GetRevenue(ctx, rng, interval)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return revenue, nil
+}
+
+func (s *Service) GetTopSellers(ctx context.Context, rng model.Range, limit int) (*model.TopSellers, error) {
+	const op = "service.report.GetTopSellers"
+
+	sellers, err := s.ReportRepo.GetTopSellers(ctx, rng, clamp(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return sellers, nil
+}
+
+func (s *Service) GetTurnover(ctx context.Context, rng model.Range, limit int) (*model.StockReport, error) {
+	const op = "service.report.GetTurnover"
+
+	turnover, err := s.ReportRepo.GetTurnover(ctx, rng, clamp(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return turnover, nil
+}
+
+func (s *Service) GetLowStock(ctx context.Context, rng model.Range, threshold, limit int) (*model.StockReport, error) {
+	const op = "service.report.GetLowStock"
+
+	if threshold < 0 {
+		return nil, fmt.Errorf("%s: threshold must not be negative: %w", op, service.ErrValid)
+	}
+
+	lowStock, err := s.ReportRepo.GetLowStock(ctx, rng, threshold, clamp(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return lowStock, nil
+}
+
+// clamp keeps a list report between one row and model.MaxLimit.
+func clamp(limit int) int {
+	if limit <= 0 {
+		return model.DefaultLimit
+	}
+	return min(limit, model.MaxLimit)
+}
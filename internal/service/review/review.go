@@ -0,0 +1,258 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/review"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// queueLimit caps how many reviews the moderation queue lists at once.
+const queueLimit = 100
+
+type Service struct {
+	ReviewRepo interfaces.ReviewRepository
+	BookRepo   interfaces.BookRepository
+}
+
+func (s *Service) GetReviews(ctx context.Context, userID string, bookID uuid.UUID, sort model.Sort) (*model.Reviews, error) {
+	const op = "service.review.GetReviews"
+
+	if err := s.bookExists(ctx, bookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	distribution, err := s.ReviewRepo.GetDistribution(ctx, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	reviews, err := s.ReviewRepo.GetReviews(ctx, bookID, sort)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	result := &model.Reviews{
+		BookID:  bookID,
+		Rating:  model.NewRating(distribution),
+		Reviews: reviews,
+	}
+
+	if userID == "" {
+		return result, nil
+	}
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	mine, err := s.ReviewRepo.GetUserReview(ctx, uID, bookID)
+	if err != nil && !errors.Is(err, repository.ErrReviewNotFound) {
+		return nil, errors.Wrap(err, op)
+	}
+	result.Mine = mine
+
+	return result, nil
+}
+
+// SaveReview writes the customer's review of a book they paid for. It waits
+// for moderation before it is shown or counted.
+func (s *Service) SaveReview(ctx context.Context, userID string, bookID uuid.UUID, req model.Request) (*model.Review, error) {
+	const op = "service.review.SaveReview"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := s.bookExists(ctx, bookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	purchased, err := s.ReviewRepo.HasPurchased(ctx, uID, bookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !purchased {
+		return nil, fmt.Errorf("%s: %w", op, model.ErrNotVerified)
+	}
+
+	rv, err := s.ReviewRepo.SaveReview(ctx, uID, bookID, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			return nil, fmt.Errorf("%s: book: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return rv, nil
+}
+
+func (s *Service) DeleteReview(ctx context.Context, userID string, bookID, id uuid.UUID) error {
+	const op = "service.review.DeleteReview"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	rv, err := s.getReview(ctx, bookID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rv.UserID != uID {
+		return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+	}
+
+	err = s.ReviewRepo.DeleteReview(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrReviewNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Vote marks an approved review as helpful. Voting twice counts once.
+func (s *Service) Vote(ctx context.Context, userID string, bookID, id uuid.UUID) (*model.Review, error) {
+	const op = "service.review.Vote"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	rv, err := s.getReview(ctx, bookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if rv.Status != model.StatusApproved {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+	}
+	if rv.UserID == uID {
+		return nil, fmt.Errorf("%s: %w", op, model.ErrOwnReview)
+	}
+
+	counted, err := s.ReviewRepo.Vote(ctx, uID, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if counted {
+		rv.HelpfulCount++
+	}
+
+	return rv, nil
+}
+
+// GetQueue lists reviews in a status for moderation, oldest first.
+func (s *Service) GetQueue(ctx context.Context, status model.Status) ([]model.Review, error) {
+	const op = "service.review.GetQueue"
+
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%s: unknown status %q: %w", op, status, service.ErrValid)
+	}
+
+	reviews, err := s.ReviewRepo.GetQueue(ctx, status, queueLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return reviews, nil
+}
+
+// ApproveReview publishes a review and counts it towards the book's rating.
+func (s *Service) ApproveReview(ctx context.Context, actorID string, id uuid.UUID, req model.ModerateRequest) (*model.Review, error) {
+	const op = "service.review.ApproveReview"
+
+	rv, err := s.moderate(ctx, actorID, id, model.StatusApproved, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rv, nil
+}
+
+// RejectReview keeps a review off the book page, or takes an approved one
+// down. The note tells the author why.
+func (s *Service) RejectReview(ctx context.Context, actorID string, id uuid.UUID, req model.ModerateRequest) (*model.Review, error) {
+	const op = "service.review.RejectReview"
+
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, fmt.Errorf("%s: a note is required to reject a review: %w", op, service.ErrValid)
+	}
+
+	rv, err := s.moderate(ctx, actorID, id, model.StatusRejected, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rv, nil
+}
+
+func (s *Service) moderate(ctx context.Context, actorID string, id uuid.UUID, to model.Status, req model.ModerateRequest) (*model.Review, error) {
+	actor, err := uuid.FromString(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+	if err = req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, service.ErrValid)
+	}
+
+	rv, err := s.ReviewRepo.Moderate(ctx, model.StatusChange{
+		ReviewID: id,
+		To:       to,
+		Actor:    uuid.NullUUID{UUID: actor, Valid: true},
+		Note:     req.Note,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrReviewNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return rv, nil
+}
+
+// getReview finds a review of the book, treating one of another book as not
+// found.
+func (s *Service) getReview(ctx context.Context, bookID, id uuid.UUID) (*model.Review, error) {
+	rv, err := s.ReviewRepo.GetReview(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrReviewNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+	if rv.BookID != bookID {
+		return nil, service.ErrNotFound
+	}
+
+	return rv, nil
+}
+
+func (s *Service) bookExists(ctx context.Context, bookID uuid.UUID) error {
+	exists, err := s.BookRepo.IfBookExists(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("book: %w", service.ErrNotFound)
+	}
+	return nil
+}
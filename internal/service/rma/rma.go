@@ -0,0 +1,231 @@
+package rma
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"log/slog"
+	"strings"
+)
+
+type Service struct {
+	ReturnRepo interfaces.ReturnRepository
+	Payments   interfaces.PaymentService
+	Log        *slog.Logger
+}
+
+// RequestReturn opens a return for books of a delivered order. It waits for
+// staff to approve it before the customer sends anything.
+func (s *Service) RequestReturn(ctx context.Context, userID string, req model.CreateRequest) (*model.Return, error) {
+	const op = "service.rma.RequestReturn"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	note := strings.TrimSpace(req.Note)
+	switch {
+	case req.OrderID == uuid.Nil:
+		return nil, fmt.Errorf("%s: order_id is required: %w", op, service.ErrValid)
+	case len(req.Items) == 0:
+		return nil, fmt.Errorf("%s: at least one item is required: %w", op, service.ErrValid)
+	}
+	if err = model.ValidateNote(note); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	id, err := s.ReturnRepo.CreateReturn(ctx, req.OrderID, uID, note, req.Items)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ret, err := s.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ret, nil
+}
+
+func (s *Service) GetCustomerReturns(ctx context.Context, userID string) ([]model.Return, error) {
+	const op = "service.rma.GetCustomerReturns"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	returns, err := s.ReturnRepo.GetReturnsByUserID(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return returns, nil
+}
+
+// GetCustomerReturn shows a return to the customer who asked for it and hides
+// it from everyone else.
+func (s *Service) GetCustomerReturn(ctx context.Context, userID string, id uuid.UUID) (*model.Return, error) {
+	const op = "service.rma.GetCustomerReturn"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	ret, err := s.GetReturn(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if ret.UserID != uID {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+	}
+
+	return ret, nil
+}
+
+func (s *Service) GetReturns(ctx context.Context, status model.Status) ([]model.Return, error) {
+	const op = "service.rma.GetReturns"
+
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("%s: unknown status %q: %w", op, status, service.ErrValid)
+	}
+
+	returns, err := s.ReturnRepo.GetReturnsByStatus(ctx, status)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return returns, nil
+}
+
+func (s *Service) GetReturn(ctx context.Context, id uuid.UUID) (*model.Return, error) {
+	const op = "service.rma.GetReturn"
+
+	ret, err := s.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ret, nil
+}
+
+// ApproveReturn lets the customer send the books back.
+func (s *Service) ApproveReturn(ctx context.Context, actorID string, id uuid.UUID, req model.ResolveRequest) (*model.Return, error) {
+	const op = "service.rma.ApproveReturn"
+
+	ret, err := s.resolve(ctx, actorID, id, model.StatusApproved, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ret, nil
+}
+
+// RejectReturn turns a return down, either before anything was sent or when
+// the books that arrived do not qualify. The note tells the customer why.
+func (s *Service) RejectReturn(ctx context.Context, actorID string, id uuid.UUID, req model.ResolveRequest) (*model.Return, error) {
+	const op = "service.rma.RejectReturn"
+
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, fmt.Errorf("%s: a note is required to reject a return: %w", op, service.ErrValid)
+	}
+
+	ret, err := s.resolve(ctx, actorID, id, model.StatusRejected, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ret, nil
+}
+
+// ReceiveReturn books the returned copies back into stock, marks the order
+// partially or fully refunded and sends the refund for what the books cost.
+func (s *Service) ReceiveReturn(ctx context.Context, actorID string, id uuid.UUID) (*model.Return, error) {
+	const op = "service.rma.ReceiveReturn"
+
+	actor, err := adminActor(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	receipt, err := s.ReturnRepo.ReceiveReturn(ctx, id, actor)
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !receipt.Refund.IsPositive() {
+		return receipt.Return, nil
+	}
+
+	// The refund is owed from the moment the books are back; one the
+	// provider does not take now is retried by the RefundRetrier.
+	if err = s.Payments.IssueRefunds(ctx, receipt.Order.ID); err != nil {
+		s.Log.Error("failed to issue refund for received return, left to the retrier",
+			slog.String("op", op),
+			slog.String("return_id", id.String()),
+			slog.String("order_id", receipt.Order.ID.String()),
+			slog.String("amount", receipt.Refund.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	ret, err := s.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ret, nil
+}
+
+func (s *Service) resolve(ctx context.Context, actorID string, id uuid.UUID, to model.Status, req model.ResolveRequest) (*model.Return, error) {
+	actor, err := adminActor(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if err = model.ValidateNote(note); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, service.ErrValid)
+	}
+
+	ret, err := s.ReturnRepo.UpdateStatus(ctx, model.StatusChange{
+		ReturnID: id,
+		To:       to,
+		Actor:    actor.ID,
+		Note:     note,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func adminActor(userID string) (orderModel.Actor, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return orderModel.Actor{}, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	return orderModel.AdminActor(uID), nil
+}
@@ -0,0 +1,56 @@
+package rma
+
+import (
+	"context"
+	"errors"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/rma"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestService_ReceiveReturn(t *testing.T) {
+	actorID := uuid.Must(uuid.NewV4())
+	id := uuid.Must(uuid.NewV4())
+	order := &orderModel.Model{ID: uuid.Must(uuid.NewV4())}
+	received := &model.Return{ID: id, OrderID: order.ID, Status: model.StatusReceived}
+
+	tests := []struct {
+		name      string
+		refund    string
+		issueErr  error
+		wantIssue bool
+	}{
+		{name: "refund is sent at once", refund: "12.99", wantIssue: true},
+		{name: "refund the provider does not take is left to the retrier", refund: "12.99", issueErr: errors.New("db down"), wantIssue: true},
+		{name: "nothing owed sends nothing", refund: "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returns := mocks.NewReturnRepository(t)
+			payments := mocks.NewPaymentService(t)
+			svc := &Service{ReturnRepo: returns, Payments: payments, Log: logger.New(logger.EnvLocal)}
+
+			returns.On("ReceiveReturn", mock.Anything, id, orderModel.AdminActor(actorID)).Return(&model.Receipt{
+				Return: received,
+				Order:  order,
+				Refund: money.MustParse(tt.refund, money.DefaultCurrency),
+			}, nil).Once()
+			if tt.wantIssue {
+				payments.On("IssueRefunds", mock.Anything, order.ID).Return(tt.issueErr).Once()
+				returns.On("GetReturn", mock.Anything, id).Return(received, nil).Once()
+			}
+
+			ret, err := svc.ReceiveReturn(context.Background(), actorID.String(), id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, received, ret)
+		})
+	}
+}
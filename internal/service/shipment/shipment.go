@@ -0,0 +1,246 @@
+package shipment
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModel "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/order"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipment"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type Service struct {
+	ShipmentRepo interfaces.ShipmentRepository
+	OrderRepo    interfaces.OrderRepository
+}
+
+// CreateShipment makes up a parcel for a paid order and, once nothing is left
+// to pack, marks the order fulfilled.
+func (s *Service) CreateShipment(ctx context.Context, actorID string, req model.CreateRequest) (*model.Shipment, error) {
+	const op = "service.shipment.CreateShipment"
+
+	actor, err := adminActor(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.OrderID == uuid.Nil {
+		return nil, fmt.Errorf("%s: order_id is required: %w", op, service.ErrValid)
+	}
+
+	id, err := s.ShipmentRepo.CreateShipment(ctx, req.OrderID, actor.ID, req.Items)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shipment, err := s.ShipmentRepo.GetShipment(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err = s.advance(ctx, req.OrderID, actor, "shipment "+id.String()+" packed"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shipment, nil
+}
+
+func (s *Service) GetShipment(ctx context.Context, id uuid.UUID) (*model.Shipment, error) {
+	const op = "service.shipment.GetShipment"
+
+	shipment, err := s.ShipmentRepo.GetShipment(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrShipmentNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return shipment, nil
+}
+
+func (s *Service) GetOrderShipments(ctx context.Context, orderID uuid.UUID) ([]model.Shipment, error) {
+	const op = "service.shipment.GetOrderShipments"
+
+	shipments, err := s.ShipmentRepo.GetShipmentsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return shipments, nil
+}
+
+// GetCustomerShipments lists the parcels of an order to its owner and hides
+// the order from everyone else.
+func (s *Service) GetCustomerShipments(ctx context.Context, userID, orderID string) ([]model.Shipment, error) {
+	const op = "service.shipment.GetCustomerShipments"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+	oID, err := uuid.FromString(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid orderID format: %w", service.ErrValid)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, oID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	if order.UserID != uID {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+	}
+
+	shipments, err := s.ShipmentRepo.GetShipmentsByOrderID(ctx, oID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return shipments, nil
+}
+
+func (s *Service) GetPickList(ctx context.Context, id uuid.UUID) (*model.PickList, error) {
+	const op = "service.shipment.GetPickList"
+
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := s.OrderRepo.GetOrderByID(ctx, shipment.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return model.NewPickList(*shipment, order), nil
+}
+
+// ShipShipment hands a parcel to a carrier. The order is marked shipped once
+// every parcel has left.
+func (s *Service) ShipShipment(ctx context.Context, actorID string, id uuid.UUID, req model.ShipRequest) (*model.Shipment, error) {
+	const op = "service.shipment.ShipShipment"
+
+	carrier := strings.ToLower(strings.TrimSpace(req.Carrier))
+	tracking := strings.TrimSpace(req.TrackingNumber)
+	switch {
+	case carrier == "" || len(carrier) > 50:
+		return nil, fmt.Errorf("%s: carrier must be 1 to 50 characters: %w", op, service.ErrValid)
+	case tracking == "" || len(tracking) > 100:
+		return nil, fmt.Errorf("%s: tracking number must be 1 to 100 characters: %w", op, service.ErrValid)
+	}
+
+	shipment, err := s.update(ctx, actorID, model.StatusChange{
+		ShipmentID:     id,
+		To:             model.StatusShipped,
+		Carrier:        carrier,
+		TrackingNumber: tracking,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shipment, nil
+}
+
+// DeliverShipment records that a parcel arrived. The order is marked
+// delivered once every parcel has.
+func (s *Service) DeliverShipment(ctx context.Context, actorID string, id uuid.UUID) (*model.Shipment, error) {
+	const op = "service.shipment.DeliverShipment"
+
+	shipment, err := s.update(ctx, actorID, model.StatusChange{ShipmentID: id, To: model.StatusDelivered})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shipment, nil
+}
+
+// CancelShipment calls a parcel back before it leaves, putting its books back
+// in the pool still to pack.
+func (s *Service) CancelShipment(ctx context.Context, actorID string, id uuid.UUID) (*model.Shipment, error) {
+	const op = "service.shipment.CancelShipment"
+
+	shipment, err := s.update(ctx, actorID, model.StatusChange{ShipmentID: id, To: model.StatusCancelled})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shipment, nil
+}
+
+func (s *Service) update(ctx context.Context, actorID string, change model.StatusChange) (*model.Shipment, error) {
+	actor, err := adminActor(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := s.ShipmentRepo.UpdateStatus(ctx, change)
+	if err != nil {
+		if errors.Is(err, repository.ErrShipmentNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+
+	err = s.advance(ctx, shipment.OrderID, actor, fmt.Sprintf("shipment %s %s", shipment.ID, shipment.Status))
+	if err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// advance moves an order along the fulfillment chain to wherever its
+// shipments now put it, one recorded step at a time.
+func (s *Service) advance(ctx context.Context, orderID uuid.UUID, actor orderModel.Actor, reason string) error {
+	order, err := s.OrderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load order")
+	}
+
+	ordered, err := s.ShipmentRepo.GetOrderedItems(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	shipments, err := s.ShipmentRepo.GetShipmentsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	from := order.Status
+	for _, to := range model.OrderPath(from, model.OrderStatus(ordered, shipments)) {
+		_, err = s.OrderRepo.UpdateStatus(ctx, orderModel.StatusChange{
+			OrderID:     orderID,
+			To:          to,
+			Actor:       actor,
+			Reason:      reason,
+			AllowedFrom: []orderModel.Status{from},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to move order to %s", to)
+		}
+		from = to
+	}
+
+	return nil
+}
+
+func adminActor(userID string) (orderModel.Actor, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return orderModel.Actor{}, fmt.Errorf("invalid userID format: %w", service.ErrValid)
+	}
+
+	return orderModel.AdminActor(uID), nil
+}
@@ -0,0 +1,136 @@
+package stockalert
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/notification"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/mail/templates"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Digest emails each customer one message listing every alert of theirs
+// that fired since the last run. Alerts whose condition stopped holding in the
+// meantime are cleared without an email.
+type Digest struct {
+	StockAlertRepo   interfaces.StockAlertRepository
+	NotificationRepo interfaces.NotificationRepository
+	// BaseURL is where the API is reachable from a customer's mail client,
+	// used for unsubscribe links.
+	BaseURL   string
+	Every     time.Duration
+	BatchSize int
+}
+
+func (d *Digest) Name() string {
+	return "stock-alert-digest"
+}
+
+func (d *Digest) Interval() time.Duration {
+	return d.Every
+}
+
+func (d *Digest) Run(ctx context.Context) error {
+	const op = "service.stockalert.Digest.Run"
+
+	for {
+		fired, err := d.StockAlertRepo.GetFired(ctx, d.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		// GetFired orders by user, so a batch cut at BatchSize can split only
+		// the last user's alerts; the rest are picked up on the next loop.
+		for start := 0; start < len(fired); {
+			end := start + 1
+			for end < len(fired) && fired[end].UserID == fired[start].UserID {
+				end++
+			}
+			if err := d.notify(ctx, fired[start:end]); err != nil {
+				return errors.Wrap(err, op)
+			}
+			start = end
+		}
+
+		if len(fired) < d.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (d *Digest) notify(ctx context.Context, fired []model.Fired) error {
+	userID := fired[0].UserID
+
+	ids := make([]uuid.UUID, 0, len(fired))
+	var notices []model.Notice
+	for _, f := range fired {
+		ids = append(ids, f.ID)
+		if f.StillMet() {
+			notices = append(notices, model.Notice{
+				Title:          f.Title,
+				Kind:           f.Kind,
+				Price:          f.Price,
+				Threshold:      f.Threshold,
+				UnsubscribeURL: d.unsubscribeURL(f.Token),
+			})
+		}
+	}
+
+	msg, err := d.message(ctx, userID, notices)
+	if err != nil {
+		return err
+	}
+
+	return d.StockAlertRepo.Notify(ctx, msg, ids)
+}
+
+// message renders the email for notices, or returns nil when there is no
+// one to send it to or nothing worth sending.
+func (d *Digest) message(ctx context.Context, userID uuid.UUID, notices []model.Notice) (*notification.Message, error) {
+	if len(notices) == 0 {
+		return nil, nil
+	}
+
+	optedOut, err := d.NotificationRepo.GetOptOuts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(optedOut, notification.KindStockAlert) {
+		return nil, nil
+	}
+
+	rcpt, err := d.NotificationRepo.GetRecipient(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	email, err := templates.Render(notification.KindStockAlert, notification.Data{
+		Recipient: *rcpt,
+		Alerts:    notices,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.Message{
+		UserID:  userID,
+		Kind:    notification.KindStockAlert,
+		To:      rcpt.Email,
+		Subject: email.Subject,
+		Text:    email.Text,
+		HTML:    email.HTML,
+	}, nil
+}
+
+func (d *Digest) unsubscribeURL(token string) string {
+	return strings.TrimRight(d.BaseURL, "/") + "/api/v1/alerts/unsubscribe?token=" + url.QueryEscape(token)
+}
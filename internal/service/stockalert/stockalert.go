@@ -0,0 +1,140 @@
+package stockalert
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/stockalert"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// demandLimit caps how many titles the demand view lists.
+const demandLimit = 100
+
+type Service struct {
+	StockAlertRepo interfaces.StockAlertRepository
+	BookRepo       interfaces.BookRepository
+}
+
+// Subscribe sets up an alert, or changes the threshold of the customer's
+// existing one for the book. Alerts that would fire right away are refused.
+func (s *Service) Subscribe(ctx context.Context, userID string, req model.Request) (*model.Alert, error) {
+	const op = "service.stockalert.Subscribe"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	exists, err := s.BookRepo.IfBookExists(ctx, req.BookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: book: %w", op, service.ErrNotFound)
+	}
+
+	book, err := s.BookRepo.GetBookById(ctx, req.BookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := req.AlreadyMet(book.Price, book.Stock); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	alert, err := s.StockAlertRepo.Subscribe(ctx, uID, req, token)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return alert, nil
+}
+
+func (s *Service) GetAlerts(ctx context.Context, userID string) ([]model.Alert, error) {
+	const op = "service.stockalert.GetAlerts"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	alerts, err := s.StockAlertRepo.GetAlerts(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return alerts, nil
+}
+
+func (s *Service) DeleteAlert(ctx context.Context, userID string, id uuid.UUID) error {
+	const op = "service.stockalert.DeleteAlert"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	err = s.StockAlertRepo.DeleteAlert(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAlertNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the alert an email link points at. It needs no login:
+// the token is the proof.
+func (s *Service) Unsubscribe(ctx context.Context, token string) (*model.Alert, error) {
+	const op = "service.stockalert.Unsubscribe"
+
+	if token == "" {
+		return nil, fmt.Errorf("%s: token is required: %w", op, service.ErrValid)
+	}
+
+	alert, err := s.StockAlertRepo.Unsubscribe(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrAlertNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return alert, nil
+}
+
+func (s *Service) GetDemand(ctx context.Context) ([]model.Demand, error) {
+	const op = "service.stockalert.GetDemand"
+
+	demand, err := s.StockAlertRepo.GetDemand(ctx, demandLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return demand, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
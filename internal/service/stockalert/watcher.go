@@ -0,0 +1,51 @@
+package stockalert
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/pkg/errors"
+)
+
+// Watcher marks alerts as fired when a book comes back in stock or its price
+// drops. Customers are emailed later, in batches, by the Digest. Firing only
+// alerts that are not pending already makes redelivered events harmless.
+type Watcher struct {
+	StockAlertRepo interfaces.StockAlertRepository
+}
+
+func (w *Watcher) Name() string {
+	return "stock-alerts"
+}
+
+func (w *Watcher) Topics() []event.Type {
+	return []event.Type{event.TypeBookBackInStock, event.TypeBookPriceChanged}
+}
+
+func (w *Watcher) Handle(ctx context.Context, e event.Event) error {
+	const op = "service.stockalert.Watcher.Handle"
+
+	switch e.Type {
+	case event.TypeBookBackInStock:
+		var level event.StockLevel
+		if err := e.Decode(&level); err != nil {
+			return errors.Wrap(err, op)
+		}
+		if _, err := w.StockAlertRepo.TriggerBackInStock(ctx, level.BookID, e.CreatedAt); err != nil {
+			return errors.Wrap(err, op)
+		}
+	case event.TypeBookPriceChanged:
+		var changed event.PriceChanged
+		if err := e.Decode(&changed); err != nil {
+			return errors.Wrap(err, op)
+		}
+		if !changed.OldPrice.GreaterThan(changed.NewPrice) {
+			return nil
+		}
+		if _, err := w.StockAlertRepo.TriggerPriceDrop(ctx, changed.BookID, changed.OldPrice, changed.NewPrice, e.CreatedAt); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/pkg/errors"
+	"log/slog"
+	"time"
+)
+
+// Subscriber turns domain events into deliveries for the endpoints subscribed
+// to them. Sending is left to the Dispatcher so a slow endpoint never holds up
+// the event bus.
+type Subscriber struct {
+	WebhookRepo interfaces.WebhookRepository
+}
+
+func (s *Subscriber) Name() string {
+	return "webhooks"
+}
+
+// Topics is empty because which events are wanted is up to the endpoints.
+func (s *Subscriber) Topics() []event.Type {
+	return nil
+}
+
+func (s *Subscriber) Handle(ctx context.Context, e event.Event) error {
+	const op = "service.webhook.Subscriber.Handle"
+
+	if _, err := s.WebhookRepo.Enqueue(ctx, e); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// Dispatcher sends due deliveries, retrying failures with exponential backoff
+// until Retry.MaxAttempts, and disables endpoints that failed DisableAfter
+// times in a row.
+type Dispatcher struct {
+	WebhookRepo  interfaces.WebhookRepository
+	Sender       interfaces.WebhookSender
+	Retry        event.RetryPolicy
+	Lease        time.Duration
+	Every        time.Duration
+	BatchSize    int
+	DisableAfter int
+	Log          *slog.Logger
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhook-dispatcher"
+}
+
+func (d *Dispatcher) Interval() time.Duration {
+	return d.Every
+}
+
+func (d *Dispatcher) Run(ctx context.Context) error {
+	const op = "service.webhook.Dispatcher.Run"
+
+	for {
+		due, err := d.WebhookRepo.ClaimDue(ctx, time.Now(), d.Lease, d.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		for _, out := range due {
+			if err := d.send(ctx, out); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+
+		if len(due) < d.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, out model.Outgoing) error {
+	res := d.Sender.Send(ctx, out)
+
+	attempts := out.Delivery.Attempts + 1
+	attempt := model.Attempt{
+		DeliveryID:    out.Delivery.ID,
+		EndpointID:    out.Delivery.EndpointID,
+		Result:        res,
+		Attempts:      attempts,
+		NextAttemptAt: time.Now().Add(d.Retry.Backoff(attempts)),
+		Final:         attempts >= d.Retry.MaxAttempts,
+	}
+
+	if !res.Succeeded() {
+		d.Log.Warn("webhook delivery failed",
+			slog.String("delivery_id", out.Delivery.ID.String()),
+			slog.String("endpoint_id", out.Delivery.EndpointID.String()),
+			slog.Int("attempts", attempts),
+			slog.Bool("final", attempt.Final),
+			slog.String("error", res.Error()),
+		)
+	}
+
+	_, err := d.WebhookRepo.RecordAttempt(ctx, attempt, d.DisableAfter)
+	return err
+}
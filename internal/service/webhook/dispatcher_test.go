@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces/mocks"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/webhook/client"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/logger"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const secret = "whsec_test"
+
+// receiver is an endpoint that answers every delivery with status and counts
+// how many it got.
+func receiver(t *testing.T, status int) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		assert.NotEmpty(t, r.Header.Get(model.SignatureHeader))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func newDispatcher(repo *mocks.WebhookRepository) *Dispatcher {
+	return &Dispatcher{
+		WebhookRepo:  repo,
+		Sender:       &client.Sender{},
+		Retry:        event.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour},
+		Lease:        time.Minute,
+		BatchSize:    10,
+		DisableAfter: 3,
+		Log:          logger.New(logger.EnvLocal),
+	}
+}
+
+func outgoing(url string, attempts int) model.Outgoing {
+	return model.Outgoing{
+		Delivery: model.Delivery{
+			ID:         uuid.Must(uuid.NewV4()),
+			EndpointID: uuid.Must(uuid.NewV4()),
+			EventType:  event.TypeOrderItemsAdded,
+			Payload:    []byte(`{}`),
+			Attempts:   attempts,
+		},
+		URL:    url,
+		Secret: secret,
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		attempts      int
+		wantSucceeded bool
+		wantFinal     bool
+		wantBackoff   time.Duration
+	}{
+		{name: "2xx delivers", status: http.StatusNoContent, wantSucceeded: true, wantBackoff: time.Minute},
+		{name: "5xx is retried", status: http.StatusServiceUnavailable, wantBackoff: time.Minute},
+		{name: "backoff doubles with each attempt", status: http.StatusInternalServerError, attempts: 1, wantBackoff: 2 * time.Minute},
+		{name: "last attempt is final", status: http.StatusBadGateway, attempts: 2, wantFinal: true, wantBackoff: 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := receiver(t, tt.status)
+			repo := mocks.NewWebhookRepository(t)
+			d := newDispatcher(repo)
+			out := outgoing(srv.URL, tt.attempts)
+
+			repo.On("ClaimDue", mock.Anything, mock.Anything, d.Lease, d.BatchSize).Return([]model.Outgoing{out}, nil).Once()
+
+			var got model.Attempt
+			repo.On("RecordAttempt", mock.Anything, mock.Anything, d.DisableAfter).
+				Run(func(args mock.Arguments) { got = args.Get(1).(model.Attempt) }).
+				Return(&out.Delivery, nil).Once()
+
+			assert.NoError(t, d.Run(context.Background()))
+
+			assert.EqualValues(t, 1, atomic.LoadInt32(hits))
+			assert.Equal(t, out.Delivery.ID, got.DeliveryID)
+			assert.Equal(t, tt.status, got.Result.StatusCode)
+			assert.Equal(t, tt.wantSucceeded, got.Result.Succeeded())
+			assert.Equal(t, tt.attempts+1, got.Attempts)
+			assert.Equal(t, tt.wantFinal, got.Final)
+			assert.WithinDuration(t, time.Now().Add(tt.wantBackoff), got.NextAttemptAt, 5*time.Second)
+		})
+	}
+}
+
+func TestDispatcher_Run_unreachableEndpoint(t *testing.T) {
+	srv, _ := receiver(t, http.StatusOK)
+	srv.Close()
+
+	repo := mocks.NewWebhookRepository(t)
+	d := newDispatcher(repo)
+	out := outgoing(srv.URL, 0)
+
+	repo.On("ClaimDue", mock.Anything, mock.Anything, d.Lease, d.BatchSize).Return([]model.Outgoing{out}, nil).Once()
+	repo.On("RecordAttempt", mock.Anything, mock.MatchedBy(func(a model.Attempt) bool {
+		return a.Result.Err != nil && !a.Final && a.Attempts == 1
+	}), d.DisableAfter).Return(&out.Delivery, nil).Once()
+
+	assert.NoError(t, d.Run(context.Background()))
+}
+
+// An endpoint answering 5xx is sent its deliveries until it failed
+// DisableAfter times in a row; once disabled, nothing is claimed for it.
+func TestDispatcher_Run_disablesFailingEndpoint(t *testing.T) {
+	srv, hits := receiver(t, http.StatusInternalServerError)
+	repo := mocks.NewWebhookRepository(t)
+	d := newDispatcher(repo)
+
+	enabled, failures := true, 0
+	repo.On("ClaimDue", mock.Anything, mock.Anything, d.Lease, d.BatchSize).
+		Return(func(context.Context, time.Time, time.Duration, int) []model.Outgoing {
+			if !enabled {
+				return nil
+			}
+			return []model.Outgoing{outgoing(srv.URL, 0)}
+		}, nil)
+	repo.On("RecordAttempt", mock.Anything, mock.Anything, d.DisableAfter).
+		Run(func(args mock.Arguments) {
+			failures++
+			enabled = failures < args.Int(2)
+		}).
+		Return(&model.Delivery{}, nil)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, d.Run(context.Background()))
+	}
+
+	assert.False(t, enabled)
+	assert.EqualValues(t, d.DisableAfter, atomic.LoadInt32(hits))
+	repo.AssertNumberOfCalls(t, "RecordAttempt", d.DisableAfter)
+}
@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// deliveryLogLimit caps how many deliveries of an endpoint are listed.
+const deliveryLogLimit = 100
+
+type Service struct {
+	WebhookRepo interfaces.WebhookRepository
+	Sender      interfaces.WebhookSender
+}
+
+// CreateEndpoint registers an endpoint. The response is the only place the
+// generated secret is shown.
+func (s *Service) CreateEndpoint(ctx context.Context, req model.EndpointRequest) (*model.Endpoint, error) {
+	const op = "service.webhook.CreateEndpoint"
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newSecret(); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	ep, err := s.WebhookRepo.CreateEndpoint(ctx, model.Endpoint{
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: req.EventTypes,
+		Enabled:    req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ep, nil
+}
+
+func (s *Service) GetEndpoints(ctx context.Context) ([]model.Endpoint, error) {
+	const op = "service.webhook.GetEndpoints"
+
+	endpoints, err := s.WebhookRepo.GetEndpoints(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+
+	return endpoints, nil
+}
+
+func (s *Service) GetEndpoint(ctx context.Context, id uuid.UUID) (*model.Endpoint, error) {
+	const op = "service.webhook.GetEndpoint"
+
+	ep, err := s.getEndpoint(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ep.Secret = ""
+	return ep, nil
+}
+
+func (s *Service) UpdateEndpoint(ctx context.Context, id uuid.UUID, req model.EndpointRequest) (*model.Endpoint, error) {
+	const op = "service.webhook.UpdateEndpoint"
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	current, err := s.getEndpoint(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	next := *current
+	next.URL = req.URL
+	next.EventTypes = req.EventTypes
+	if req.Secret != "" {
+		next.Secret = req.Secret
+	}
+	if req.Enabled != nil {
+		next.Enabled = *req.Enabled
+	}
+
+	ep, err := s.WebhookRepo.UpdateEndpoint(ctx, next)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	ep.Secret = ""
+	return ep, nil
+}
+
+// DeleteEndpoint removes an endpoint together with its delivery log.
+func (s *Service) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	const op = "service.webhook.DeleteEndpoint"
+
+	err := s.WebhookRepo.DeleteEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, endpointID uuid.UUID) ([]model.Delivery, error) {
+	const op = "service.webhook.GetDeliveries"
+
+	if _, err := s.getEndpoint(ctx, endpointID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries, err := s.WebhookRepo.GetDeliveries(ctx, endpointID, deliveryLogLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return deliveries, nil
+}
+
+// SendTestEvent sends a webhook.test delivery to the endpoint right away and
+// returns how it went. It is tried once, works on disabled endpoints too and
+// does not count towards disabling the endpoint.
+func (s *Service) SendTestEvent(ctx context.Context, endpointID uuid.UUID) (*model.Delivery, error) {
+	const op = "service.webhook.SendTestEvent"
+
+	ep, err := s.getEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"message": "This is a test delivery from the bookstore.",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	d, err := s.WebhookRepo.CreateDelivery(ctx, ep.ID, model.TestEventType, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	res := s.Sender.Send(ctx, model.Outgoing{Delivery: *d, URL: ep.URL, Secret: ep.Secret})
+
+	d, err = s.WebhookRepo.RecordAttempt(ctx, model.Attempt{
+		DeliveryID:    d.ID,
+		EndpointID:    ep.ID,
+		Result:        res,
+		Attempts:      1,
+		NextAttemptAt: time.Now(),
+		Final:         true,
+		Test:          true,
+	}, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return d, nil
+}
+
+func (s *Service) getEndpoint(ctx context.Context, id uuid.UUID) (*model.Endpoint, error) {
+	ep, err := s.WebhookRepo.GetEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+	return ep, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
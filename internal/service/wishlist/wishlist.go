@@ -0,0 +1,321 @@
+package wishlist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/interfaces"
+	orderModels "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/orderItem"
+	model "github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/wishlist"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/repository"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/service"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	WishlistRepo interfaces.WishlistRepository
+	BookRepo     interfaces.BookRepository
+	OrderRepo    interfaces.OrderRepository
+	OrderSvc     interfaces.OrderService
+}
+
+func (s *Service) CreateWishlist(ctx context.Context, userID string, req model.Request) (*model.Wishlist, error) {
+	const op = "service.wishlist.CreateWishlist"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	w, err := s.WishlistRepo.CreateWishlist(ctx, model.Wishlist{
+		UserID:     uID,
+		Name:       req.Name,
+		Shared:     req.Shared,
+		ShareToken: token,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return ownerView(w), nil
+}
+
+func (s *Service) GetWishlists(ctx context.Context, userID string) ([]model.Wishlist, error) {
+	const op = "service.wishlist.GetWishlists"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	lists, err := s.WishlistRepo.GetWishlists(ctx, uID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for i := range lists {
+		lists[i] = *ownerView(&lists[i])
+	}
+
+	return lists, nil
+}
+
+func (s *Service) GetWishlist(ctx context.Context, userID string, id uuid.UUID) (*model.Wishlist, error) {
+	const op = "service.wishlist.GetWishlist"
+
+	w, err := s.getWishlist(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ownerView(w), nil
+}
+
+// UpdateWishlist renames the wishlist or shares it. Sharing again after
+// making it private brings the old link back to life.
+func (s *Service) UpdateWishlist(ctx context.Context, userID string, id uuid.UUID, req model.Request) (*model.Wishlist, error) {
+	const op = "service.wishlist.UpdateWishlist"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	err = s.WishlistRepo.UpdateWishlist(ctx, uID, id, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s.GetWishlist(ctx, userID, id)
+}
+
+func (s *Service) DeleteWishlist(ctx context.Context, userID string, id uuid.UUID) error {
+	const op = "service.wishlist.DeleteWishlist"
+
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	err = s.WishlistRepo.DeleteWishlist(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistNotFound) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// AddItem puts a book on the wishlist. Adding a book that is already there
+// changes nothing.
+func (s *Service) AddItem(ctx context.Context, userID string, id uuid.UUID, req model.ItemRequest) (*model.Wishlist, error) {
+	const op = "service.wishlist.AddItem"
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	w, err := s.getWishlist(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	exists, err := s.BookRepo.IfBookExists(ctx, req.BookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: book: %w", op, service.ErrNotFound)
+	}
+
+	if _, err := s.WishlistRepo.AddItem(ctx, w.ID, req.BookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s.GetWishlist(ctx, userID, id)
+}
+
+func (s *Service) RemoveItem(ctx context.Context, userID string, id, bookID uuid.UUID) (*model.Wishlist, error) {
+	const op = "service.wishlist.RemoveItem"
+
+	w, err := s.getWishlist(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.WishlistRepo.RemoveItem(ctx, w.ID, bookID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistItemNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s.GetWishlist(ctx, userID, id)
+}
+
+// MoveToCart puts copies of a wishlist book into the cart, reserving them,
+// and takes the book off the wishlist. The book is only taken off once it is
+// in the cart, so a failure never loses it.
+func (s *Service) MoveToCart(ctx context.Context, userID string, id, bookID uuid.UUID, req model.MoveToCartRequest) (*model.Wishlist, error) {
+	const op = "service.wishlist.MoveToCart"
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	w, err := s.getWishlist(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !w.Contains(bookID) {
+		return nil, fmt.Errorf("%s: %s: %w", op, repository.ErrWishlistItemNotFound, service.ErrNotFound)
+	}
+
+	items := []orderModels.OrderItem{{BookID: bookID, Quantity: req.Quantity}}
+	if err := s.OrderSvc.AddOrderItemIntoOrder(ctx, userID, &items); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	err = s.WishlistRepo.RemoveItem(ctx, w.ID, bookID)
+	if err != nil && !errors.Is(err, repository.ErrWishlistItemNotFound) {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s.GetWishlist(ctx, userID, id)
+}
+
+// SaveForLater moves a book out of the cart, releasing its reservation, and
+// onto the wishlist. The book is only taken out of the cart once it is on the
+// wishlist.
+func (s *Service) SaveForLater(ctx context.Context, userID string, id uuid.UUID, req model.ItemRequest) (*model.Wishlist, error) {
+	const op = "service.wishlist.SaveForLater"
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, err, service.ErrValid)
+	}
+
+	w, err := s.getWishlist(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	inCart, err := s.inCart(ctx, userID, req.BookID)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if !inCart {
+		return nil, fmt.Errorf("%s: book is not in the cart: %w", op, service.ErrNotFound)
+	}
+
+	if _, err := s.WishlistRepo.AddItem(ctx, w.ID, req.BookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if err := s.OrderSvc.RemoveCartItem(ctx, userID, req.BookID); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return s.GetWishlist(ctx, userID, id)
+}
+
+// GetSharedWishlist is what a friend with the share link sees.
+func (s *Service) GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error) {
+	const op = "service.wishlist.GetSharedWishlist"
+
+	if token == "" {
+		return nil, fmt.Errorf("%s: token is required: %w", op, service.ErrValid)
+	}
+
+	w, err := s.WishlistRepo.GetSharedWishlist(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFound)
+		}
+		return nil, errors.Wrap(err, op)
+	}
+
+	w.ShareToken = ""
+	return w, nil
+}
+
+func (s *Service) getWishlist(ctx context.Context, userID string, id uuid.UUID) (*model.Wishlist, error) {
+	uID, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err, service.ErrValid)
+	}
+
+	w, err := s.WishlistRepo.GetWishlist(ctx, uID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistNotFound) {
+			return nil, service.ErrNotFound
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// inCart reports whether the book is in the user's draft order.
+func (s *Service) inCart(ctx context.Context, userID string, bookID uuid.UUID) (bool, error) {
+	exists, err := s.OrderRepo.CheckOrderExists(ctx, userID)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	order, err := s.OrderRepo.GetUsersOrder(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	items, err := s.OrderRepo.GetOrderItemsFromOrderID(ctx, order.ID.String())
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range *items {
+		if item.BookID == bookID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ownerView hides the share token of a private wishlist, so the owner is
+// not handed a link that does not work.
+func ownerView(w *model.Wishlist) *model.Wishlist {
+	if !w.Shared {
+		w.ShareToken = ""
+	}
+	return w
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,73 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/pkg/errors"
+	"os"
+)
+
+// Provider quotes shipping from a fixed table of rates by zone and weight,
+// typically loaded from a JSON file at startup.
+type Provider struct {
+	Table shipping.Table
+}
+
+// Load reads a shipping rates file in the shipping.Table format.
+func Load(path string) (*Provider, error) {
+	const op = "shipping.table.Load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var table shipping.Table
+	if err = json.Unmarshal(data, &table); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	methods := make(map[string]bool, len(table.Methods))
+	for i, method := range table.Methods {
+		if method.Code == "" {
+			return nil, errors.Wrap(errors.Errorf("method %d has no code", i), op)
+		}
+		methods[method.Code] = true
+	}
+
+	zones := make(map[string]bool, len(table.Zones))
+	for i, zone := range table.Zones {
+		if zone.Code == "" {
+			return nil, errors.Wrap(errors.Errorf("zone %d has no code", i), op)
+		}
+		zones[zone.Code] = true
+	}
+
+	for i, rate := range table.Rates {
+		if !zones[rate.Zone] {
+			return nil, errors.Wrap(errors.Errorf("rate %d has unknown zone %q", i, rate.Zone), op)
+		}
+		if !methods[rate.Method] {
+			return nil, errors.Wrap(errors.Errorf("rate %d has unknown method %q", i, rate.Method), op)
+		}
+		if rate.MaxGrams < 0 || rate.Price.IsNegative() {
+			return nil, errors.Wrap(errors.Errorf("rate %d has a negative weight or price", i), op)
+		}
+	}
+
+	return &Provider{Table: table}, nil
+}
+
+// Quotes prices a parcel of the given weight to a destination with every
+// delivery method that goes there.
+func (p *Provider) Quotes(ctx context.Context, to shipping.Destination, grams int) ([]shipping.Quote, error) {
+	const op = "shipping.table.Quotes"
+
+	quotes, err := p.Table.Quotes(to, grams)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return quotes, nil
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/shipping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type wantQuote struct {
+	method string
+	price  string
+}
+
+func TestQuotes_Zones(t *testing.T) {
+	provider, err := Load(filepath.Join("testdata", "rates.json"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		to    shipping.Destination
+		grams int
+		want  []wantQuote
+	}{
+		{
+			name:  "light domestic parcel, cheapest first",
+			to:    shipping.Destination{Country: "US", Region: "NY"},
+			grams: 400,
+			want:  []wantQuote{{"standard", "3.99"}, {"express", "14.99"}},
+		},
+		{
+			name:  "weight exactly on a bracket edge stays in it",
+			to:    shipping.Destination{Country: "US", Region: "NY"},
+			grams: 500,
+			want:  []wantQuote{{"standard", "3.99"}, {"express", "14.99"}},
+		},
+		{
+			name:  "heavy domestic parcel uses the open-ended bracket",
+			to:    shipping.Destination{Country: "US", Region: "CA"},
+			grams: 7500,
+			want:  []wantQuote{{"standard", "9.99"}, {"express", "24.99"}},
+		},
+		{
+			name:  "a region zone wins over its country",
+			to:    shipping.Destination{Country: "us", Region: "ak"},
+			grams: 400,
+			want:  []wantQuote{{"standard", "12.99"}},
+		},
+		{
+			name:  "express has a weight limit abroad",
+			to:    shipping.Destination{Country: "GB"},
+			grams: 3000,
+			want:  []wantQuote{{"standard", "34.99"}},
+		},
+		{
+			name:  "everywhere else falls in the catch-all zone",
+			to:    shipping.Destination{Country: "KZ"},
+			grams: 1000,
+			want:  []wantQuote{{"standard", "29.99"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotes, err := provider.Quotes(context.Background(), tt.to, tt.grams)
+			require.NoError(t, err)
+
+			require.Len(t, quotes, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.method, quotes[i].Method)
+				assert.Equal(t, want.price, quotes[i].Price.String())
+			}
+		})
+	}
+}
+
+func TestQuotes_Unavailable(t *testing.T) {
+	provider, err := Load(filepath.Join("testdata", "rates.json"))
+	require.NoError(t, err)
+
+	_, err = provider.Quotes(context.Background(), shipping.Destination{Country: "JP"}, 6000)
+	assert.ErrorIs(t, err, shipping.ErrUnavailable)
+
+	empty := &Provider{}
+	_, err = empty.Quotes(context.Background(), shipping.Destination{Country: "US"}, 100)
+	assert.ErrorIs(t, err, shipping.ErrUnavailable)
+}
+
+func TestLoadRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	bad := map[string]string{
+		"unknown zone":   `{"methods":[{"code":"standard"}],"zones":[],"rates":[{"zone":"eu","method":"standard","price":"1.00"}]}`,
+		"unknown method": `{"methods":[],"zones":[{"code":"eu","places":["DE"]}],"rates":[{"zone":"eu","method":"standard","price":"1.00"}]}`,
+		"negative price": `{"methods":[{"code":"standard"}],"zones":[{"code":"eu","places":["DE"]}],"rates":[{"zone":"eu","method":"standard","price":"-1.00"}]}`,
+		"method no code": `{"methods":[{"name":"Standard"}]}`,
+	}
+	for name, content := range bad {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "rates.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := Load(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestShippedRatesFileLoads(t *testing.T) {
+	_, err := Load(filepath.Join("..", "..", "..", "shipping_rates.json"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,92 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/pkg/errors"
+	"os"
+)
+
+// Calculator taxes orders from a fixed table of rules, typically loaded from
+// a JSON file at startup.
+type Calculator struct {
+	Table tax.Table
+}
+
+// Load reads a tax rules file in the tax.Table format.
+func Load(path string) (*Calculator, error) {
+	const op = "tax.table.Load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var table tax.Table
+	if err = json.Unmarshal(data, &table); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	for i, rule := range table.Rules {
+		if rule.Country == "" {
+			return nil, errors.Wrap(errors.Errorf("rule %d has no country", i), op)
+		}
+		if rule.Class != "" && !rule.Class.IsValid() {
+			return nil, errors.Wrap(errors.Errorf("rule %d has unknown tax class %q", i, rule.Class), op)
+		}
+	}
+
+	return &Calculator{Table: table}, nil
+}
+
+// Calculate taxes each line at the rule matching its class in the
+// jurisdiction, rounding per line. Lines with no matching rule are not taxed.
+func (c *Calculator) Calculate(ctx context.Context, jurisdiction tax.Jurisdiction, lines []tax.Line) (tax.Result, error) {
+	const op = "tax.table.Calculate"
+
+	jurisdiction = jurisdiction.Normalize()
+	if jurisdiction.Country == "" {
+		return tax.Result{}, errors.Wrap(tax.ErrNoJurisdiction, op)
+	}
+
+	currency := money.DefaultCurrency
+	if len(lines) > 0 {
+		currency = lines[0].Amount.Currency()
+	}
+
+	result := tax.Result{
+		Jurisdiction: jurisdiction,
+		Total:        money.Zero(currency),
+		Included:     money.Zero(currency),
+	}
+
+	for _, line := range lines {
+		rule, ok := c.Table.Match(jurisdiction, line.Class)
+		if !ok {
+			continue
+		}
+
+		amount := rule.Rate.Of(line.Amount)
+		if rule.Inclusive {
+			amount = rule.Rate.IncludedIn(line.Amount)
+			result.Included = result.Included.Add(amount)
+		}
+		result.Total = result.Total.Add(amount)
+
+		result.Lines = append(result.Lines, tax.LineTax{
+			ItemID:       line.ItemID,
+			BookID:       line.BookID,
+			Name:         rule.Name,
+			Jurisdiction: jurisdiction.String(),
+			Class:        line.Class,
+			Rate:         rule.Rate,
+			Taxable:      line.Amount,
+			Amount:       amount,
+			Inclusive:    rule.Inclusive,
+		})
+	}
+
+	return result, nil
+}
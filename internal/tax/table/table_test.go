@@ -0,0 +1,178 @@
+package table
+
+import (
+	"context"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/tax"
+	"github.com/TeslaMode1X/DockerWireAPI/packages/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// line is a catalogue-currency line of the given class costing amount.
+func line(class tax.Class, amount string) tax.Line {
+	return tax.Line{Class: class, Amount: money.MustParse(amount, money.USD)}
+}
+
+type wantLine struct {
+	name   string
+	rate   string
+	amount string
+}
+
+func TestCalculate_Jurisdictions(t *testing.T) {
+	calc, err := Load(filepath.Join("testdata", "rates.json"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		jurisdiction tax.Jurisdiction
+		lines        []tax.Line
+		wantLines    []wantLine
+		wantTotal    string
+		wantIncluded string
+	}{
+		{
+			name:         "new york adds sales tax on top",
+			jurisdiction: tax.Jurisdiction{Country: "US", Region: "NY"},
+			lines:        []tax.Line{line(tax.ClassStandard, "10.00"), line(tax.ClassReduced, "25.00")},
+			wantLines:    []wantLine{{"Sales tax", "8.875", "0.89"}, {"Sales tax", "8.875", "2.22"}},
+			wantTotal:    "3.11",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "lower case codes are normalized",
+			jurisdiction: tax.Jurisdiction{Country: "us", Region: " ca "},
+			lines:        []tax.Line{line(tax.ClassStandard, "19.99")},
+			wantLines:    []wantLine{{"Sales tax", "7.25", "1.45"}},
+			wantTotal:    "1.45",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "state without sales tax",
+			jurisdiction: tax.Jurisdiction{Country: "US", Region: "OR"},
+			lines:        []tax.Line{line(tax.ClassStandard, "19.99")},
+			wantTotal:    "0.00",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "uk vat is inside the price and books are zero rated",
+			jurisdiction: tax.Jurisdiction{Country: "GB"},
+			lines:        []tax.Line{line(tax.ClassStandard, "12.00"), line(tax.ClassZero, "8.99")},
+			wantLines:    []wantLine{{"VAT", "20", "2.00"}, {"VAT", "0", "0.00"}},
+			wantTotal:    "2.00",
+			wantIncluded: "2.00",
+		},
+		{
+			name:         "a region the table does not list falls back to the country",
+			jurisdiction: tax.Jurisdiction{Country: "GB", Region: "SCT"},
+			lines:        []tax.Line{line(tax.ClassReduced, "10.50")},
+			wantLines:    []wantLine{{"VAT", "5", "0.50"}},
+			wantTotal:    "0.50",
+			wantIncluded: "0.50",
+		},
+		{
+			name:         "german reduced rate",
+			jurisdiction: tax.Jurisdiction{Country: "DE"},
+			lines:        []tax.Line{line(tax.ClassReduced, "21.40"), line(tax.ClassStandard, "11.90")},
+			wantLines:    []wantLine{{"MwSt", "7", "1.40"}, {"MwSt", "19", "1.90"}},
+			wantTotal:    "3.30",
+			wantIncluded: "3.30",
+		},
+		{
+			name:         "french fractional reduced rate",
+			jurisdiction: tax.Jurisdiction{Country: "FR"},
+			lines:        []tax.Line{line(tax.ClassReduced, "10.55")},
+			wantLines:    []wantLine{{"TVA", "5.5", "0.55"}},
+			wantTotal:    "0.55",
+			wantIncluded: "0.55",
+		},
+		{
+			name:         "canadian province overrides the federal rate",
+			jurisdiction: tax.Jurisdiction{Country: "CA", Region: "ON"},
+			lines:        []tax.Line{line(tax.ClassStandard, "20.00"), line(tax.ClassReduced, "20.00")},
+			wantLines:    []wantLine{{"HST", "13", "2.60"}, {"GST", "5", "1.00"}},
+			wantTotal:    "3.60",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "canadian province without its own rule pays the federal rate",
+			jurisdiction: tax.Jurisdiction{Country: "CA", Region: "AB"},
+			lines:        []tax.Line{line(tax.ClassReduced, "20.00")},
+			wantLines:    []wantLine{{"GST", "5", "1.00"}},
+			wantTotal:    "1.00",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "country the store does not collect in",
+			jurisdiction: tax.Jurisdiction{Country: "BR"},
+			lines:        []tax.Line{line(tax.ClassStandard, "50.00")},
+			wantTotal:    "0.00",
+			wantIncluded: "0.00",
+		},
+		{
+			name:         "fully discounted line carries no tax",
+			jurisdiction: tax.Jurisdiction{Country: "US", Region: "NY"},
+			lines:        []tax.Line{line(tax.ClassStandard, "0.00")},
+			wantLines:    []wantLine{{"Sales tax", "8.875", "0.00"}},
+			wantTotal:    "0.00",
+			wantIncluded: "0.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Calculate(context.Background(), tt.jurisdiction, tt.lines)
+			require.NoError(t, err)
+
+			require.Len(t, result.Lines, len(tt.wantLines))
+			for i, want := range tt.wantLines {
+				got := result.Lines[i]
+				assert.Equal(t, want.name, got.Name)
+				assert.Equal(t, want.rate, got.Rate.String())
+				assert.Equal(t, want.amount, got.Amount.String())
+				assert.Equal(t, result.Jurisdiction.String(), got.Jurisdiction)
+			}
+
+			assert.Equal(t, tt.wantTotal, result.Total.String())
+			assert.Equal(t, tt.wantIncluded, result.Included.String())
+		})
+	}
+}
+
+func TestCalculate_NoJurisdiction(t *testing.T) {
+	calc := &Calculator{}
+
+	_, err := calc.Calculate(context.Background(), tax.Jurisdiction{}, []tax.Line{line(tax.ClassStandard, "1.00")})
+	assert.ErrorIs(t, err, tax.ErrNoJurisdiction)
+}
+
+func TestLoadRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	bad := map[string]string{
+		"no country":    `{"rules":[{"name":"VAT","rate":"20"}]}`,
+		"unknown class": `{"rules":[{"country":"GB","class":"luxury","name":"VAT","rate":"20"}]}`,
+		"rate over 100": `{"rules":[{"country":"GB","name":"VAT","rate":"120"}]}`,
+		"negative rate": `{"rules":[{"country":"GB","name":"VAT","rate":"-5"}]}`,
+	}
+	for name, content := range bad {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "rates.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := Load(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestShippedRatesFileLoads(t *testing.T) {
+	_, err := Load(filepath.Join("..", "..", "..", "tax_rates.json"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseBody caps how much of an endpoint's answer is kept in the
+// delivery log.
+const maxResponseBody = 1024
+
+// Sender posts deliveries as signed JSON envelopes over HTTP.
+type Sender struct {
+	Client *http.Client
+	// Now stamps the signature; it defaults to time.Now.
+	Now func() time.Time
+}
+
+func (s *Sender) Send(ctx context.Context, out webhook.Outgoing) webhook.Result {
+	body, err := json.Marshal(out.Delivery.Envelope())
+	if err != nil {
+		return webhook.Result{Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, out.URL, bytes.NewReader(body))
+	if err != nil {
+		return webhook.Result{Err: err}
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DockerWireAPI-Webhooks/1.0")
+	req.Header.Set(webhook.EventHeader, string(out.Delivery.EventType))
+	req.Header.Set(webhook.DeliveryHeader, out.Delivery.ID.String())
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(out.Secret, now(), body))
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return webhook.Result{Err: err}
+	}
+	defer resp.Body.Close()
+
+	answer, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+
+	return webhook.Result{
+		StatusCode: resp.StatusCode,
+		Body:       string(answer),
+	}
+}
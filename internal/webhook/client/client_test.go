@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/event"
+	"github.com/TeslaMode1X/DockerWireAPI/internal/domain/models/webhook"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const secret = "whsec_test"
+
+// receiver is an endpoint that checks signatures the way a subscriber should
+// and answers with status.
+func receiver(t *testing.T, status int, got chan<- webhook.Envelope) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if err := webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), 5*time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var env webhook.Envelope
+		require.NoError(t, json.Unmarshal(body, &env))
+		assert.Equal(t, string(env.Type), r.Header.Get(webhook.EventHeader))
+		assert.Equal(t, env.ID.String(), r.Header.Get(webhook.DeliveryHeader))
+		got <- env
+
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func outgoing(url, secret string) webhook.Outgoing {
+	id, _ := uuid.NewV4()
+	eventID, _ := uuid.NewV4()
+	return webhook.Outgoing{
+		Delivery: webhook.Delivery{
+			ID:        id,
+			EventID:   uuid.NullUUID{UUID: eventID, Valid: true},
+			EventType: event.Type("order.paid"),
+			Payload:   json.RawMessage(`{"order_id":"42"}`),
+			CreatedAt: time.Now(),
+		},
+		URL:    url,
+		Secret: secret,
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	got := make(chan webhook.Envelope, 1)
+	srv := receiver(t, http.StatusNoContent, got)
+	s := &Sender{Client: srv.Client()}
+
+	out := outgoing(srv.URL, secret)
+	res := s.Send(context.Background(), out)
+
+	require.NoError(t, res.Err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.True(t, res.Succeeded())
+
+	env := <-got
+	assert.Equal(t, out.Delivery.ID, env.ID)
+	assert.Equal(t, out.Delivery.EventID, env.EventID)
+	assert.JSONEq(t, `{"order_id":"42"}`, string(env.Data))
+}
+
+func TestSender_Send_WrongSecret(t *testing.T) {
+	got := make(chan webhook.Envelope, 1)
+	srv := receiver(t, http.StatusOK, got)
+	s := &Sender{Client: srv.Client()}
+
+	res := s.Send(context.Background(), outgoing(srv.URL, "whsec_other"))
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.False(t, res.Succeeded())
+	assert.Empty(t, got)
+}
+
+func TestSender_Send_RecordsErrorAnswers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(strings.Repeat("x", 4096)))
+	}))
+	t.Cleanup(srv.Close)
+	s := &Sender{Client: srv.Client()}
+
+	res := s.Send(context.Background(), outgoing(srv.URL, secret))
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Len(t, res.Body, maxResponseBody)
+	assert.Equal(t, "endpoint answered 500", res.Error())
+}
+
+func TestSender_Send_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	s := &Sender{Client: &http.Client{Timeout: time.Second}}
+	res := s.Send(context.Background(), outgoing(url, secret))
+
+	assert.Error(t, res.Err)
+	assert.Zero(t, res.StatusCode)
+	assert.False(t, res.Succeeded())
+}
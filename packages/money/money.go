@@ -0,0 +1,370 @@
+// Package money represents amounts of money exactly, as an integer number of
+// minor units (cents) of an ISO 4217 currency.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	KZT Currency = "KZT"
+	JPY Currency = "JPY"
+	CHF Currency = "CHF"
+)
+
+// DefaultCurrency is the currency the catalogue is priced in. It is also
+// assumed for amounts read from the database or JSON into a Money that has no
+// currency yet.
+const DefaultCurrency = USD
+
+type currencyInfo struct {
+	exponent int    // decimal places of the minor unit
+	step     int64  // converted amounts are rounded to a multiple of this many minor units
+	symbol   string // shown in front of amounts
+}
+
+var currencies = map[Currency]currencyInfo{
+	USD: {exponent: 2, step: 1, symbol: "$"},
+	EUR: {exponent: 2, step: 1, symbol: "€"},
+	GBP: {exponent: 2, step: 1, symbol: "£"},
+	// Tiyn are not in circulation, prices are quoted in whole tenge.
+	KZT: {exponent: 2, step: 100, symbol: "₸"},
+	JPY: {exponent: 0, step: 1, symbol: "¥"},
+	// Swiss cash prices are rounded to the nearest 5 centimes.
+	CHF: {exponent: 2, step: 5, symbol: "CHF "},
+}
+
+// ParseCurrency reads an ISO 4217 code in any case and rejects currencies
+// this package does not know.
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.IsValid() {
+		return "", fmt.Errorf("money: unknown currency %q", code)
+	}
+	return c, nil
+}
+
+func (c Currency) IsValid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Exponent is the number of decimal places of the currency's minor unit.
+func (c Currency) Exponent() int {
+	if info, ok := currencies[c]; ok {
+		return info.exponent
+	}
+	return 2
+}
+
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok {
+		return info.symbol
+	}
+	return string(c) + " "
+}
+
+func (c Currency) roundingStep() int64 {
+	if info, ok := currencies[c]; ok {
+		return info.step
+	}
+	return 1
+}
+
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+func New(minor int64, currency Currency) Money {
+	return Money{amount: minor, currency: currency}
+}
+
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Parse reads a decimal amount such as "12.30" or "-4". More decimal places
+// than the currency has are rejected rather than rounded.
+func Parse(s string, currency Currency) (Money, error) {
+	minor, err := parseMinor(strings.TrimSpace(s), currency.Exponent())
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minor, currency: currency}, nil
+}
+
+func MustParse(s string, currency Currency) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Amount is the value in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Add and Sub mix two amounts of the same currency. Mixing currencies is a
+// programming error and panics; convert first.
+func (m Money) Add(o Money) Money {
+	return Money{amount: m.amount + o.amount, currency: m.sameCurrency(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{amount: m.amount - o.amount, currency: m.sameCurrency(o)}
+}
+
+func (m Money) Mul(quantity int) Money {
+	return Money{amount: m.amount * int64(quantity), currency: m.currency}
+}
+
+// Percent is the given percentage of the amount, rounded half away from zero
+// to the minor unit.
+func (m Money) Percent(percent int) Money {
+	return m.Ratio(int64(percent), 100)
+}
+
+// Ratio is the amount times num/den, rounded half away from zero to the minor
+// unit. den must not be zero.
+func (m Money) Ratio(num, den int64) Money {
+	n := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(num))
+	return Money{amount: roundDiv(n, big.NewInt(den)).Int64(), currency: m.currency}
+}
+
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than o.
+func (m Money) Cmp(o Money) int {
+	m.sameCurrency(o)
+	switch {
+	case m.amount < o.amount:
+		return -1
+	case m.amount > o.amount:
+		return 1
+	}
+	return 0
+}
+
+// Equal compares amounts, treating a missing currency as the default one.
+func (m Money) Equal(o Money) bool {
+	return m.amount == o.amount && m.Currency() == o.Currency()
+}
+
+func (m Money) LessThan(o Money) bool {
+	return m.Cmp(o) < 0
+}
+
+func (m Money) GreaterThan(o Money) bool {
+	return m.Cmp(o) > 0
+}
+
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// Sum adds up amounts of one currency. The sum of nothing is zero.
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, amount := range amounts {
+		total = total.Add(amount)
+	}
+	return total
+}
+
+// Convert prices the amount in another currency at the given rate, rounded
+// half away from zero to the target currency's rounding step.
+func (m Money) Convert(to Currency, rate Rate) Money {
+	if to == m.Currency() {
+		return Money{amount: m.amount, currency: to}
+	}
+
+	num := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(rate.units))
+	num.Mul(num, pow10(to.Exponent()))
+	den := new(big.Int).Mul(big.NewInt(rateUnit), pow10(m.Currency().Exponent()))
+
+	step := big.NewInt(to.roundingStep())
+	den.Mul(den, step)
+	steps := roundDiv(num, den)
+
+	return Money{amount: steps.Mul(steps, step).Int64(), currency: to}
+}
+
+// String formats the amount as a plain decimal, "12.30", without the currency.
+func (m Money) String() string {
+	return m.fixed(m.Currency().Exponent())
+}
+
+func (m Money) fixed(exp int) string {
+	sign := ""
+	amount := m.amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// Format is String with the currency symbol in front, "€12.30".
+func (m Money) Format() string {
+	if m.amount < 0 {
+		return "-" + m.Currency().Symbol() + m.Neg().String()
+	}
+	return m.Currency().Symbol() + m.String()
+}
+
+// MarshalJSON encodes the amount as a string so clients never round it
+// through a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts the amount as a string or as a JSON number; the
+// number is read from its literal text, not through a float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	return m.set(text)
+}
+
+// Scan reads a NUMERIC column.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		m.amount = 0
+		return nil
+	case []byte:
+		return m.set(string(v))
+	case string:
+		return m.set(v)
+	case int64:
+		return m.set(strconv.FormatInt(v, 10))
+	case float64:
+		return m.set(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return fmt.Errorf("money: cannot scan %T", src)
+}
+
+// Value writes the amount as a decimal string, which NUMERIC takes exactly.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// set parses text in m's currency. A Money without a currency keeps none, so
+// decoding into a zero value compares equal to one built the same way.
+func (m *Money) set(text string) error {
+	minor, err := parseMinor(strings.TrimSpace(text), m.Currency().Exponent())
+	if err != nil {
+		return err
+	}
+	m.amount = minor
+	return nil
+}
+
+func (m Money) sameCurrency(o Money) Currency {
+	switch {
+	case m.currency == "":
+		return o.currency
+	case o.currency == "" || o.currency == m.currency:
+		return m.currency
+	}
+	panic(fmt.Sprintf("money: mixing %s and %s", m.currency, o.currency))
+}
+
+func parseMinor(s string, exp int) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("money: empty amount")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	// NUMERIC columns pad with zeros beyond the currency's precision.
+	fraction = strings.TrimRight(fraction, "0")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+	if len(fraction) > exp {
+		return 0, fmt.Errorf("money: amount %q has more than %d decimal places", s, exp)
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("money: invalid amount %q: %w", s, err)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return minor, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
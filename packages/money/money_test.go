@@ -0,0 +1,175 @@
+package money
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+// bounded keeps generated amounts far enough from the int64 limits that sums
+// and products in the properties below cannot overflow.
+func bounded(n int64) int64 {
+	return n % (math.MaxInt32)
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		cur  Currency
+		want int64
+	}{
+		{"12.30", USD, 1230},
+		{"12.3", USD, 1230},
+		{"12", USD, 1200},
+		{"-0.05", USD, -5},
+		{"19.990000", USD, 1999},
+		{"1500", JPY, 1500},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in, c.cur)
+		require.NoError(t, err, c.in)
+		assert.Equal(t, c.want, got.Amount(), c.in)
+	}
+
+	for _, bad := range []string{"", "1.234", "abc", "1.2.3", "-", "1e3"} {
+		_, err := Parse(bad, USD)
+		assert.Error(t, err, bad)
+	}
+	_, err := Parse("1.5", JPY)
+	assert.Error(t, err)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "12.30", New(1230, USD).String())
+	assert.Equal(t, "0.05", New(5, USD).String())
+	assert.Equal(t, "-0.05", New(-5, USD).String())
+	assert.Equal(t, "0.00", Zero(EUR).String())
+	assert.Equal(t, "1500", New(1500, JPY).String())
+}
+
+func TestMixingCurrenciesPanics(t *testing.T) {
+	assert.Panics(t, func() { New(1, USD).Add(New(1, EUR)) })
+	assert.NotPanics(t, func() { Money{}.Add(New(1, EUR)) })
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Price Money `json:"price"`
+	}{New(1999, USD)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"price":"19.99"}`, string(data))
+
+	var fromNumber struct {
+		Price Money `json:"price"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"price":0.1}`), &fromNumber))
+	assert.Equal(t, int64(10), fromNumber.Price.Amount())
+	assert.Equal(t, DefaultCurrency, fromNumber.Price.Currency())
+
+	yen := Zero(JPY)
+	require.NoError(t, json.Unmarshal([]byte(`"1500"`), &yen))
+	assert.True(t, yen.Equal(New(1500, JPY)))
+}
+
+func TestStringParseRoundTrip(t *testing.T) {
+	property := func(n int64) bool {
+		m := New(n, USD)
+		parsed, err := Parse(m.String(), USD)
+		return err == nil && parsed.Equal(m)
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	property := func(n int64) bool {
+		m := New(n, USD)
+		data, err := json.Marshal(m)
+		if err != nil {
+			return false
+		}
+		var decoded Money
+		return json.Unmarshal(data, &decoded) == nil && decoded.Equal(m)
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestScanValueRoundTrip(t *testing.T) {
+	property := func(n int64) bool {
+		m := New(n, USD)
+		v, err := m.Value()
+		if err != nil {
+			return false
+		}
+		// Postgres hands NUMERIC back as text, padded to the column scale.
+		var scanned Money
+		return scanned.Scan([]byte(v.(string)+"00")) == nil && scanned.Equal(m)
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestAddIsCommutativeAndSubInverts(t *testing.T) {
+	property := func(a, b int64) bool {
+		x, y := New(bounded(a), USD), New(bounded(b), USD)
+		return x.Add(y) == y.Add(x) && x.Add(y).Sub(y) == x
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestSumEqualsSumOfLines(t *testing.T) {
+	property := func(prices []int32, quantities []uint8) bool {
+		var lines []Money
+		var want int64
+		for i, price := range prices {
+			qty := 1
+			if i < len(quantities) {
+				qty = int(quantities[i])
+			}
+			lines = append(lines, New(int64(price), USD).Mul(qty))
+			want += int64(price) * int64(qty)
+		}
+		return Sum(lines...).Amount() == want
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestPercent(t *testing.T) {
+	cases := []struct {
+		in      Money
+		percent int
+		want    int64
+	}{
+		{New(1999, USD), 10, 200},
+		{New(1999, USD), 15, 300},
+		{New(1000, USD), 100, 1000},
+		{New(1000, USD), 0, 0},
+		{New(-1999, USD), 10, -200},
+		{New(1500, JPY), 33, 495},
+	}
+	for _, c := range cases {
+		got := c.in.Percent(c.percent)
+		assert.Equal(t, c.want, got.Amount(), "%s * %d%%", c.in, c.percent)
+		assert.Equal(t, c.in.Currency(), got.Currency())
+	}
+}
+
+func TestRatio(t *testing.T) {
+	cases := []struct {
+		in       Money
+		num, den int64
+		want     int64
+	}{
+		{New(1000, USD), 8875, 100000, 89},
+		{New(1200, EUR), 20, 120, 200},
+		{New(1199, EUR), 20, 120, 200},
+		{New(1000, USD), 0, 7, 0},
+		{New(-1000, USD), 1, 8, -125},
+	}
+	for _, c := range cases {
+		got := c.in.Ratio(c.num, c.den)
+		assert.Equal(t, c.want, got.Amount(), "%s * %d/%d", c.in, c.num, c.den)
+		assert.Equal(t, c.in.Currency(), got.Currency())
+	}
+}
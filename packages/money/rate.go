@@ -0,0 +1,132 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// rateScale is the number of decimal places exchange rates are kept to.
+const rateScale = 8
+
+const rateUnit = 100_000_000
+
+// Rate is an exchange rate, the number of units of one currency a single unit
+// of another buys, kept as a fixed-point decimal so conversions stay exact.
+type Rate struct {
+	units int64
+}
+
+// One is the rate between a currency and itself.
+var One = Rate{units: rateUnit}
+
+// ParseRate reads a positive decimal rate such as "0.92" or "476.5".
+func ParseRate(s string) (Rate, error) {
+	units, err := parseMinor(strings.TrimSpace(s), rateScale)
+	if err != nil {
+		return Rate{}, err
+	}
+	if units <= 0 {
+		return Rate{}, fmt.Errorf("money: exchange rate %q must be positive", s)
+	}
+	return Rate{units: units}, nil
+}
+
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (r Rate) IsZero() bool {
+	return r.units == 0
+}
+
+// Inverse is the rate for converting back the other way.
+func (r Rate) Inverse() Rate {
+	return One.Div(r)
+}
+
+// Div gives the rate between two currencies quoted against a common base:
+// with r as base→B and o as base→A, r.Div(o) is A→B.
+func (r Rate) Div(o Rate) Rate {
+	num := new(big.Int).Mul(big.NewInt(r.units), big.NewInt(rateUnit))
+	return Rate{units: roundDiv(num, big.NewInt(o.units)).Int64()}
+}
+
+func (r Rate) String() string {
+	text := Money{amount: r.units}.fixed(rateScale)
+	text = strings.TrimRight(text, "0")
+	return strings.TrimSuffix(text, ".")
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts the rate as a string or as a JSON number.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC column.
+func (r *Rate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return r.set(string(v))
+	case string:
+		return r.set(v)
+	case int64:
+		return r.set(strconv.FormatInt(v, 10))
+	case float64:
+		return r.set(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return fmt.Errorf("money: cannot scan %T into a rate", src)
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func (r *Rate) set(text string) error {
+	parsed, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundDiv divides, rounding half away from zero.
+func roundDiv(num, den *big.Int) *big.Int {
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	twice := new(big.Int).Lsh(new(big.Int).Abs(rem), 1)
+	if twice.Cmp(new(big.Int).Abs(den)) < 0 {
+		return q
+	}
+	if num.Sign()*den.Sign() < 0 {
+		return q.Sub(q, big.NewInt(1))
+	}
+	return q.Add(q, big.NewInt(1))
+}
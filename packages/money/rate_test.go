@@ -0,0 +1,92 @@
+package money
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseRate(t *testing.T) {
+	r, err := ParseRate("0.92")
+	require.NoError(t, err)
+	assert.Equal(t, "0.92", r.String())
+	assert.Equal(t, "1", One.String())
+	assert.Equal(t, "476.5", MustParseRate("476.500").String())
+
+	for _, bad := range []string{"", "0", "-1.2", "0.000000001", "x"} {
+		_, err := ParseRate(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestRateJSONAndScan(t *testing.T) {
+	var rates map[Currency]Rate
+	require.NoError(t, json.Unmarshal([]byte(`{"EUR":"0.92","JPY":151.2}`), &rates))
+	assert.Equal(t, MustParseRate("0.92"), rates[EUR])
+	assert.Equal(t, MustParseRate("151.2"), rates[JPY])
+
+	var scanned Rate
+	require.NoError(t, scanned.Scan([]byte("0.92000000")))
+	assert.Equal(t, MustParseRate("0.92"), scanned)
+}
+
+func TestRateDivAndInverse(t *testing.T) {
+	eur, gbp := MustParseRate("0.92"), MustParseRate("0.79")
+	assert.Equal(t, "0.85869565", gbp.Div(eur).String())
+	assert.Equal(t, "2", MustParseRate("0.5").Inverse().String())
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		name string
+		from Money
+		to   Currency
+		rate string
+		want string
+	}{
+		{"same currency ignores rate", MustParse("12.99", USD), USD, "3", "12.99"},
+		{"rounds half away from zero", MustParse("0.05", USD), EUR, "0.5", "0.03"},
+		{"negative amounts round symmetrically", MustParse("-0.05", USD), EUR, "0.5", "-0.03"},
+		{"to a currency without minor units", MustParse("12.99", USD), JPY, "151.2", "1964"},
+		{"from a currency without minor units", MustParse("1964", JPY), USD, "0.00661376", "12.99"},
+		{"tenge are rounded to whole units", MustParse("12.99", USD), KZT, "476.5", "6190.00"},
+		{"francs are rounded to 5 centimes", MustParse("12.99", USD), CHF, "0.88", "11.45"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.from.Convert(c.to, MustParseRate(c.rate))
+			assert.Equal(t, c.to, got.Currency())
+			assert.Equal(t, c.want, got.String())
+		})
+	}
+}
+
+func TestConvertRespectsRoundingStep(t *testing.T) {
+	property := func(cents int32, rateCents uint16) bool {
+		if rateCents == 0 {
+			return true
+		}
+		rate := Rate{units: int64(rateCents) * rateUnit / 100}
+		from := New(int64(cents), USD)
+		return from.Convert(KZT, rate).Amount()%100 == 0 &&
+			from.Convert(CHF, rate).Amount()%5 == 0
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "$12.30", New(1230, USD).Format())
+	assert.Equal(t, "-€0.05", New(-5, EUR).Format())
+	assert.Equal(t, "¥1500", New(1500, JPY).Format())
+}
+
+func TestParseCurrency(t *testing.T) {
+	c, err := ParseCurrency(" eur ")
+	require.NoError(t, err)
+	assert.Equal(t, EUR, c)
+
+	_, err = ParseCurrency("XXX")
+	assert.Error(t, err)
+}
@@ -0,0 +1,236 @@
+// Package pdf writes simple PDF documents: pages of text in the standard
+// Helvetica faces, lines and shaded boxes. It needs no fonts or images of its
+// own, every PDF reader ships the faces it uses, and the same input always
+// produces the same bytes.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = [...]string{
+	Helvetica:     "Helvetica",
+	HelveticaBold: "Helvetica-Bold",
+}
+
+type Document struct {
+	Title  string
+	Author string
+	pages  []*Page
+}
+
+// Page collects drawing operators. Coordinates are in points from the bottom
+// left corner of the page.
+type Page struct {
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text draws s with its baseline starting at x, y.
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, num(size), num(x), num(y), escape(encode(s)))
+}
+
+// TextRight draws s so that it ends at x.
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-Width(font, size, s), y, font, size, s)
+}
+
+// Line draws a straight line of the given width in black.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// FillRect paints a box in a shade of grey, 0 being black and 1 white. Text
+// drawn afterwards goes back to black.
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n", num(gray), num(x), num(y), num(w), num(h))
+}
+
+// Width is how long s runs when set in font at size.
+func Width(font Font, size float64, s string) float64 {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+
+	var units int
+	for _, b := range encode(s) {
+		if b >= 32 && b <= 126 {
+			units += widths[b-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo renders the document. Objects are numbered catalog, page tree,
+// info, the two fonts, then a page and its content stream for every page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &writer{}
+	out.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	out.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	out.object(3, fmt.Sprintf("<< /Title (%s) /Author (%s) /Producer (DockerWireAPI) >>",
+		escape(encode(d.Title)), escape(encode(d.Author))))
+	for font, name := range fontNames {
+		out.object(4+font, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+
+	for i, page := range d.pages {
+		pageID := firstPage + 2*i
+		out.object(pageID, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents %d 0 R >>",
+			num(A4Width), num(A4Height), pageID+1))
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		out.stream(pageID+1, stream.Bytes())
+	}
+
+	xref := out.buf.Len()
+	out.printf("xref\n0 %d\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		out.printf("%010d 00000 n \n", offset)
+	}
+	out.printf("trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(out.offsets)+1, xref)
+
+	n, err := w.Write(out.buf.Bytes())
+	return int64(n), err
+}
+
+// writer keeps the offset of every object for the cross-reference table.
+// Objects have to be written in id order.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.buf, format, args...)
+}
+
+func (w *writer) object(id int, body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	w.printf("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (w *writer) stream(id int, data []byte) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	w.printf("%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", id, len(data))
+	w.buf.Write(data)
+	w.printf("\nendstream\nendobj\n")
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// encode maps s onto WinAnsiEncoding, the code page the standard faces are
+// set in. Characters it does not have print as '?'.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case r == '€':
+			out = append(out, 0x80)
+		case r == '–':
+			out = append(out, 0x96)
+		case r == '—':
+			out = append(out, 0x97)
+		case r == '‘', r == '’':
+			out = append(out, '\'')
+		case r == '“', r == '”':
+			out = append(out, '"')
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// escape quotes the characters that end or escape a PDF string literal.
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch c {
+		case '(', ')', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// Advance widths of the printable ASCII characters, 32 to 126, in thousandths
+// of the font size, from the Adobe font metrics of the standard faces.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
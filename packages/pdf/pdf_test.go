@@ -0,0 +1,75 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, []byte("Caf\xe9 \x80 12"), encode("Café € 12"))
+	assert.Equal(t, []byte("? ?"), encode("₸ 日"))
+	assert.Equal(t, `\(a\) \\ b`, escape([]byte(`(a) \ b`)))
+}
+
+func TestWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, Width(Helvetica, 10, "0"), 1e-9)
+	assert.InDelta(t, 6.11, Width(HelveticaBold, 10, "b"), 1e-9)
+	assert.Greater(t, Width(Helvetica, 10, "WWW"), Width(Helvetica, 10, "iii"))
+}
+
+func TestDocument(t *testing.T) {
+	doc := New()
+	doc.Title = "Invoice (test)"
+	first := doc.AddPage()
+	first.Text(50, 800, HelveticaBold, 18, "Hello")
+	first.TextRight(545, 800, Helvetica, 9, "€12.30")
+	first.Line(50, 790, 545, 790, 0.5)
+	doc.AddPage().FillRect(50, 50, 100, 20, 0.9)
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `/Title (Invoice \(test\))`)
+
+	again, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, out, again, "rendering is deterministic")
+
+	t.Run("xref offsets point at their objects", func(t *testing.T) {
+		start := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+		require.NotNil(t, start)
+		xref, err := strconv.Atoi(string(start[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 10\n")))
+
+		entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+		require.Len(t, entries, 9)
+		for i, entry := range entries {
+			offset, err := strconv.Atoi(string(entry[1]))
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(out[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+		}
+	})
+
+	t.Run("content streams inflate to the drawing operators", func(t *testing.T) {
+		stream := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindSubmatch(out)
+		require.NotNil(t, stream)
+		zr, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(content), "BT /F2 18 Tf 50 800 Td (Hello) Tj ET")
+		assert.Contains(t, string(content), "(\x8012.30) Tj")
+		assert.Contains(t, string(content), "0.5 w 50 790 m 545 790 l S")
+	})
+}